@@ -0,0 +1,171 @@
+// Package assignment proposes reviewer assignments that balance workload across a reviewer pool
+package assignment
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Reviewer is a member of the reviewer pool
+type Reviewer struct {
+	Email     string
+	Name      string
+	Workplace string
+	Keywords  []string
+	Capacity  int // maximum number of assigned students, 0 for unlimited
+	Load      int // students already assigned
+}
+
+// Student is a thesis waiting for a reviewer
+type Student struct {
+	ID                  int
+	SupervisorEmail     string
+	SupervisorWorkplace string
+	Topic               string
+	// Excluded reviewers, e.g. previous reviewers or rejected conflict declarations
+	Excluded []string
+}
+
+// Proposal is a suggested reviewer for one student
+type Proposal struct {
+	StudentID       int
+	ReviewerEmail   string
+	ReviewerName    string
+	MatchedKeywords []string
+}
+
+// Eligible reports whether the reviewer may review the student under the conflict rules
+func Eligible(student Student, reviewer Reviewer) bool {
+	if strings.EqualFold(strings.TrimSpace(reviewer.Email), strings.TrimSpace(student.SupervisorEmail)) {
+		return false
+	}
+	if SameWorkplace(reviewer.Workplace, student.SupervisorWorkplace) {
+		return false
+	}
+	for _, excluded := range student.Excluded {
+		if strings.EqualFold(excluded, reviewer.Email) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchKeywords returns the reviewer keywords found in the topic text
+func MatchKeywords(topic string, keywords []string) []string {
+	text := " " + normalize(topic) + " "
+	var matched []string
+	for _, keyword := range keywords {
+		k := normalize(keyword)
+		if k == "" {
+			continue
+		}
+		// Prefix match so that inflected forms ("tinklai", "tinklų") match the stem "tinkl"
+		if strings.Contains(text, " "+k) {
+			matched = append(matched, strings.TrimSpace(keyword))
+		}
+	}
+	return matched
+}
+
+// Assign proposes a reviewer for every student it can. The most constrained students are
+// placed first; each gets the eligible reviewer with spare capacity that matches most topic
+// keywords, and among equal matches the one with the lowest relative load.
+// Students without an eligible reviewer are returned separately.
+func Assign(students []Student, reviewers []Reviewer) ([]Proposal, []int) {
+	pool := make([]Reviewer, len(reviewers))
+	copy(pool, reviewers)
+
+	eligible := make(map[int][]int, len(students))
+	for _, student := range students {
+		for i, reviewer := range pool {
+			if Eligible(student, reviewer) {
+				eligible[student.ID] = append(eligible[student.ID], i)
+			}
+		}
+	}
+
+	ordered := make([]Student, len(students))
+	copy(ordered, students)
+	sort.SliceStable(ordered, func(a, b int) bool {
+		return len(eligible[ordered[a].ID]) < len(eligible[ordered[b].ID])
+	})
+
+	var proposals []Proposal
+	var unassigned []int
+	for _, student := range ordered {
+		best := -1
+		var bestMatches []string
+		for _, i := range eligible[student.ID] {
+			reviewer := pool[i]
+			if reviewer.Capacity > 0 && reviewer.Load >= reviewer.Capacity {
+				continue
+			}
+			matches := MatchKeywords(student.Topic, reviewer.Keywords)
+			if best == -1 || better(reviewer, len(matches), pool[best], len(bestMatches)) {
+				best = i
+				bestMatches = matches
+			}
+		}
+		if best == -1 {
+			unassigned = append(unassigned, student.ID)
+			continue
+		}
+		pool[best].Load++
+		proposals = append(proposals, Proposal{
+			StudentID:       student.ID,
+			ReviewerEmail:   pool[best].Email,
+			ReviewerName:    pool[best].Name,
+			MatchedKeywords: bestMatches,
+		})
+	}
+
+	return proposals, unassigned
+}
+
+func better(candidate Reviewer, candidateMatches int, current Reviewer, currentMatches int) bool {
+	if candidateMatches != currentMatches {
+		return candidateMatches > currentMatches
+	}
+	if ca, cu := loadRatio(candidate), loadRatio(current); ca != cu {
+		return ca < cu
+	}
+	if candidate.Load != current.Load {
+		return candidate.Load < current.Load
+	}
+	return candidate.Email < current.Email
+}
+
+func loadRatio(reviewer Reviewer) float64 {
+	if reviewer.Capacity <= 0 {
+		return float64(reviewer.Load) / 10
+	}
+	return float64(reviewer.Load) / float64(reviewer.Capacity)
+}
+
+// SameWorkplace compares workplace names ignoring case, punctuation and extra detail such as departments
+func SameWorkplace(a, b string) bool {
+	a, b = normalize(a), normalize(b)
+	if len(a) < 4 || len(b) < 4 {
+		return false
+	}
+	return a == b || strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+// ParseKeywords splits a comma or semicolon separated keyword list
+func ParseKeywords(list string) []string {
+	var keywords []string
+	for _, keyword := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+func normalize(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,80 @@
+package assignment
+
+import "testing"
+
+func proposalsByStudent(proposals []Proposal) map[int]string {
+	result := make(map[int]string, len(proposals))
+	for _, p := range proposals {
+		result[p.StudentID] = p.ReviewerEmail
+	}
+	return result
+}
+
+func TestAssignBalancesLoad(t *testing.T) {
+	reviewers := []Reviewer{
+		{Email: "a@example.com", Capacity: 3, Load: 2},
+		{Email: "b@example.com", Capacity: 3},
+	}
+	students := []Student{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	proposals, unassigned := Assign(students, reviewers)
+	if len(unassigned) != 0 {
+		t.Fatalf("unassigned = %v", unassigned)
+	}
+	counts := map[string]int{}
+	for _, p := range proposals {
+		counts[p.ReviewerEmail]++
+	}
+	if counts["b@example.com"] != 2 || counts["a@example.com"] != 1 {
+		t.Errorf("counts = %v, want b=2 a=1", counts)
+	}
+}
+
+func TestAssignPrefersKeywordsAndRespectsCapacity(t *testing.T) {
+	reviewers := []Reviewer{
+		{Email: "net@example.com", Keywords: []string{"tinkl", "network"}, Capacity: 1},
+		{Email: "web@example.com", Keywords: []string{"web"}, Capacity: 5},
+	}
+	students := []Student{
+		{ID: 1, Topic: "Kompiuterių tinklų stebėsenos sistema"},
+		{ID: 2, Topic: "Network monitoring for small offices"},
+	}
+
+	proposals, unassigned := Assign(students, reviewers)
+	if len(unassigned) != 0 {
+		t.Fatalf("unassigned = %v", unassigned)
+	}
+	got := proposalsByStudent(proposals)
+	if got[1] != "net@example.com" {
+		t.Errorf("student 1 -> %s, want net@example.com", got[1])
+	}
+	if got[2] != "web@example.com" {
+		t.Errorf("student 2 -> %s, want web@example.com (net is full)", got[2])
+	}
+}
+
+func TestAssignAvoidsConflicts(t *testing.T) {
+	reviewers := []Reviewer{
+		{Email: "Supervisor@example.com"},
+		{Email: "colleague@example.com", Workplace: "Vilniaus kolegija, EIF"},
+		{Email: "previous@example.com"},
+	}
+	students := []Student{{
+		ID:                  7,
+		SupervisorEmail:     "supervisor@example.com",
+		SupervisorWorkplace: "VILNIAUS KOLEGIJA",
+		Excluded:            []string{"previous@example.com"},
+	}}
+
+	proposals, unassigned := Assign(students, reviewers)
+	if len(proposals) != 0 || len(unassigned) != 1 || unassigned[0] != 7 {
+		t.Errorf("proposals = %v, unassigned = %v; want student 7 unassigned", proposals, unassigned)
+	}
+}
+
+func TestMatchKeywords(t *testing.T) {
+	got := MatchKeywords("Mašininio mokymosi taikymas vaizdams", ParseKeywords("mašinin; vaizd, duomenų bazės"))
+	if len(got) != 2 || got[0] != "mašinin" || got[1] != "vaizd" {
+		t.Errorf("MatchKeywords = %v", got)
+	}
+}
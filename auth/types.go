@@ -112,6 +112,7 @@ const (
 	PermissionSystemConfig          = "system_config"
 	PermissionManageCommission      = "manage_commission"
 	PermissionViewDepartmentReports = "view_department_reports"
+	PermissionUploadSourceOnBehalf  = "upload_source_on_behalf"
 )
 
 // ================================
@@ -0,0 +1,178 @@
+// codeanalysis/analysis.go - Pluggable static analysis of submitted source code
+package codeanalysis
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxContentSize is the largest file whose content is read for analysis
+const maxContentSize = 1 << 20
+
+// File is one file of a submission. Content is nil for excluded files and files above maxContentSize.
+type File struct {
+	Path     string // slash separated, relative to the submission root
+	Name     string
+	Ext      string // lower case, including the dot
+	Size     int64
+	Excluded bool // part of the upload but filtered out before publishing
+	Binary   bool
+	Content  []byte
+}
+
+// Dirs returns the directory names on the path of the file
+func (f *File) Dirs() []string {
+	dir := path.Dir(f.Path)
+	if dir == "." {
+		return nil
+	}
+	return strings.Split(dir, "/")
+}
+
+// ExcludedFile is an upload entry that extraction filtered out
+type ExcludedFile struct {
+	Path string
+	Size int64
+}
+
+// Analyzer inspects every file of a submission and adds its findings to the report.
+// A fresh analyzer is used for every run, so analyzers may keep state between Visit and Finish.
+type Analyzer interface {
+	Name() string
+	Visit(file *File, report *Report)
+	Finish(report *Report)
+}
+
+// DefaultAnalyzers returns new instances of the built-in analyzers
+func DefaultAnalyzers() []Analyzer {
+	return []Analyzer{
+		&languageAnalyzer{},
+		&buildAnalyzer{},
+		&testAnalyzer{},
+		&secretAnalyzer{},
+		&vendorAnalyzer{},
+		&binaryAnalyzer{},
+	}
+}
+
+// Run walks the extracted submission in root, plus the excluded upload entries, through the analyzers
+func Run(root string, excluded []ExcludedFile, analyzers ...Analyzer) (*Report, error) {
+	report := &Report{GeneratedAt: time.Now()}
+	for _, analyzer := range analyzers {
+		report.Analyzers = append(report.Analyzers, analyzer.Name())
+	}
+
+	visit := func(file *File) {
+		for _, analyzer := range analyzers {
+			analyzer.Visit(file, report)
+		}
+	}
+
+	err := filepath.Walk(root, func(fullPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, fullPath)
+		if err != nil {
+			return err
+		}
+
+		file := newFile(filepath.ToSlash(rel), info.Size(), false)
+		if file.Size <= maxContentSize {
+			content, err := os.ReadFile(fullPath)
+			if err != nil {
+				return err
+			}
+			file.Content = content
+			file.Binary = isBinary(content)
+		} else {
+			file.Binary = binaryExtensions[file.Ext] != ""
+		}
+		report.TotalFiles++
+		report.TotalSize += file.Size
+		visit(file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(excluded, func(i, j int) bool { return excluded[i].Path < excluded[j].Path })
+	for _, entry := range excluded {
+		if strings.HasSuffix(entry.Path, "/") {
+			continue
+		}
+		file := newFile(strings.TrimPrefix(path.Clean("/"+entry.Path), "/"), entry.Size, true)
+		file.Binary = binaryExtensions[file.Ext] != ""
+		report.ExcludedFiles++
+		visit(file)
+	}
+
+	for _, analyzer := range analyzers {
+		analyzer.Finish(report)
+	}
+	return report, nil
+}
+
+func newFile(filePath string, size int64, excluded bool) *File {
+	name := path.Base(filePath)
+	return &File{
+		Path:     filePath,
+		Name:     name,
+		Ext:      strings.ToLower(path.Ext(name)),
+		Size:     size,
+		Excluded: excluded,
+	}
+}
+
+// isBinary uses the same heuristic as git: a NUL byte in the first 8000 bytes
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// countLines returns all lines and the non-blank lines of content
+func countLines(content []byte) (lines, code int) {
+	readLines(content, func(_ int, line string) bool {
+		lines++
+		if strings.TrimSpace(line) != "" {
+			code++
+		}
+		return true
+	})
+	return lines, code
+}
+
+// readLines calls fn for every line of content with its 1-based number until fn returns false
+func readLines(content []byte, fn func(number int, line string) bool) {
+	number := 0
+	for len(content) > 0 {
+		number++
+		i := bytes.IndexByte(content, '\n')
+		var line []byte
+		if i < 0 {
+			line, content = content, nil
+		} else {
+			line, content = content[:i], content[i+1:]
+		}
+		if !fn(number, string(line)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// codeanalysis/binaries.go - Binary blobs committed with the submission
+package codeanalysis
+
+import "sort"
+
+// binaryExtensions classifies binary files by extension
+var binaryExtensions = map[string]string{
+	".exe": "Executable", ".dll": "Library", ".so": "Library", ".dylib": "Library",
+	".a": "Library", ".lib": "Library", ".o": "Object file", ".obj": "Object file",
+	".class": "Compiled class", ".jar": "Java archive", ".war": "Java archive", ".ear": "Java archive",
+	".pyc": "Compiled Python", ".pyo": "Compiled Python", ".pyd": "Library", ".whl": "Python package",
+	".apk": "Android package", ".aab": "Android package", ".ipa": "iOS package", ".msi": "Installer", ".deb": "Installer",
+	".zip": "Archive", ".tar": "Archive", ".gz": "Archive", ".tgz": "Archive", ".7z": "Archive", ".rar": "Archive",
+	".bin": "Binary data", ".dat": "Binary data", ".db": "Database", ".sqlite": "Database", ".sqlite3": "Database", ".mdf": "Database",
+	".h5": "Model weights", ".pt": "Model weights", ".pth": "Model weights", ".onnx": "Model weights", ".pkl": "Serialized data",
+	".mp4": "Media", ".avi": "Media", ".mov": "Media", ".mkv": "Media", ".mp3": "Media", ".wav": "Media",
+}
+
+// assetExtensions are binary formats expected in projects (images, fonts, documents); only large ones are reported
+var assetExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".ico": true, ".bmp": true,
+	".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true, ".pdf": true,
+}
+
+// largeAssetSize is the size from which an expected asset is still reported
+const largeAssetSize = 5 << 20
+
+type binaryAnalyzer struct{}
+
+func (a *binaryAnalyzer) Name() string {
+	return "binaries"
+}
+
+func (a *binaryAnalyzer) Visit(file *File, report *Report) {
+	// Dependency directories are reported as a whole by the vendored analyzer
+	if root, _ := vendorRoot(file); root != "" {
+		return
+	}
+
+	kind := binaryExtensions[file.Ext]
+	switch {
+	case assetExtensions[file.Ext]:
+		if file.Size < largeAssetSize {
+			return
+		}
+		kind = "Large asset"
+	case kind == "" && file.Binary:
+		kind = "Binary data"
+	case kind == "":
+		return
+	}
+	report.Binaries = append(report.Binaries, BinaryFile{Path: file.Path, Kind: kind, Size: file.Size, Excluded: file.Excluded})
+}
+
+func (a *binaryAnalyzer) Finish(report *Report) {
+	sort.Slice(report.Binaries, func(i, j int) bool { return report.Binaries[i].Path < report.Binaries[j].Path })
+}
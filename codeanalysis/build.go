@@ -0,0 +1,132 @@
+// codeanalysis/build.go - Build system and framework detection from project manifests
+package codeanalysis
+
+import (
+	"sort"
+	"strings"
+)
+
+// buildSystemByName maps manifest file names (lower case) to the build system they belong to
+var buildSystemByName = map[string]string{
+	"go.mod":              "Go modules",
+	"package.json":        "npm",
+	"yarn.lock":           "Yarn",
+	"pnpm-lock.yaml":      "pnpm",
+	"pom.xml":             "Maven",
+	"build.gradle":        "Gradle",
+	"build.gradle.kts":    "Gradle",
+	"requirements.txt":    "pip",
+	"pyproject.toml":      "Python (pyproject)",
+	"pipfile":             "Pipenv",
+	"setup.py":            "setuptools",
+	"cargo.toml":          "Cargo",
+	"cmakelists.txt":      "CMake",
+	"makefile":            "Make",
+	"composer.json":       "Composer",
+	"gemfile":             "Bundler",
+	"pubspec.yaml":        "Dart pub",
+	"dockerfile":          "Docker",
+	"docker-compose.yml":  "Docker Compose",
+	"docker-compose.yaml": "Docker Compose",
+}
+
+var buildSystemByExt = map[string]string{
+	".csproj": "MSBuild (.NET)",
+	".sln":    "MSBuild (.NET)",
+}
+
+// frameworkMarkers are dependency names looked up in the manifests that declare dependencies
+var frameworkMarkers = map[string][]struct{ marker, framework string }{
+	"package.json": {
+		{`"react"`, "React"}, {`"react-native"`, "React Native"}, {`"vue"`, "Vue.js"},
+		{`"@angular/core"`, "Angular"}, {`"next"`, "Next.js"}, {`"nuxt"`, "Nuxt"},
+		{`"svelte"`, "Svelte"}, {`"express"`, "Express"}, {`"@nestjs/core"`, "NestJS"},
+		{`"electron"`, "Electron"},
+	},
+	"requirements.txt": {
+		{"django", "Django"}, {"flask", "Flask"}, {"fastapi", "FastAPI"},
+		{"tensorflow", "TensorFlow"}, {"torch", "PyTorch"}, {"scikit-learn", "scikit-learn"},
+	},
+	"pyproject.toml": {
+		{"django", "Django"}, {"flask", "Flask"}, {"fastapi", "FastAPI"},
+		{"tensorflow", "TensorFlow"}, {"torch", "PyTorch"},
+	},
+	"pipfile":          {{"django", "Django"}, {"flask", "Flask"}, {"fastapi", "FastAPI"}},
+	"pom.xml":          {{"spring-boot", "Spring Boot"}, {"quarkus", "Quarkus"}},
+	"build.gradle":     {{"spring-boot", "Spring Boot"}, {"com.android", "Android"}},
+	"build.gradle.kts": {{"spring-boot", "Spring Boot"}, {"com.android", "Android"}, {"ktor", "Ktor"}},
+	"go.mod": {
+		{"github.com/gin-gonic/gin", "Gin"}, {"github.com/labstack/echo", "Echo"},
+		{"github.com/go-chi/chi", "chi"}, {"github.com/gofiber/fiber", "Fiber"},
+	},
+	"composer.json": {{"laravel/framework", "Laravel"}, {"symfony/", "Symfony"}},
+	"gemfile":       {{"rails", "Ruby on Rails"}, {"sinatra", "Sinatra"}},
+	"pubspec.yaml":  {{"flutter:", "Flutter"}},
+	"cargo.toml":    {{"actix-web", "Actix Web"}, {"rocket", "Rocket"}, {"tokio", "Tokio"}},
+}
+
+// csprojFrameworks are looked up in .csproj files
+var csprojFrameworks = []struct{ marker, framework string }{
+	{"microsoft.aspnetcore", "ASP.NET Core"}, {"microsoft.net.sdk.web", "ASP.NET Core"},
+	{"microsoft.entityframeworkcore", "Entity Framework Core"}, {"xamarin", "Xamarin"},
+}
+
+type buildAnalyzer struct {
+	buildSystems map[string]bool
+	frameworks   map[string]bool
+}
+
+func (a *buildAnalyzer) Name() string {
+	return "build_systems"
+}
+
+func (a *buildAnalyzer) Visit(file *File, report *Report) {
+	if isVendored(file) {
+		return
+	}
+	if a.buildSystems == nil {
+		a.buildSystems, a.frameworks = map[string]bool{}, map[string]bool{}
+	}
+
+	name := strings.ToLower(file.Name)
+	if system, ok := buildSystemByName[name]; ok {
+		a.buildSystems[system] = true
+	}
+	if system, ok := buildSystemByExt[file.Ext]; ok {
+		a.buildSystems[system] = true
+	}
+	// Xcode projects are directories
+	for _, dir := range file.Dirs() {
+		if strings.HasSuffix(dir, ".xcodeproj") {
+			a.buildSystems["Xcode"] = true
+		}
+	}
+
+	if file.Content == nil {
+		return
+	}
+	content := strings.ToLower(string(file.Content))
+	markers := frameworkMarkers[name]
+	if file.Ext == ".csproj" {
+		markers = csprojFrameworks
+	}
+	for _, m := range markers {
+		if strings.Contains(content, m.marker) {
+			a.frameworks[m.framework] = true
+		}
+	}
+}
+
+func (a *buildAnalyzer) Finish(report *Report) {
+	report.BuildSystems = sortedKeys(a.buildSystems)
+	report.Frameworks = sortedKeys(a.frameworks)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+// codeanalysis/languages.go - Language breakdown and lines of code
+package codeanalysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// languageByExt maps file extensions to languages; data and documentation formats are left out of the LOC count
+var languageByExt = map[string]string{
+	".go": "Go", ".py": "Python", ".java": "Java", ".kt": "Kotlin", ".kts": "Kotlin",
+	".js": "JavaScript", ".jsx": "JavaScript", ".mjs": "JavaScript", ".cjs": "JavaScript",
+	".ts": "TypeScript", ".tsx": "TypeScript", ".vue": "Vue", ".svelte": "Svelte",
+	".c": "C", ".h": "C", ".cpp": "C++", ".cc": "C++", ".cxx": "C++", ".hpp": "C++",
+	".cs": "C#", ".php": "PHP", ".rb": "Ruby", ".rs": "Rust", ".swift": "Swift",
+	".dart": "Dart", ".scala": "Scala", ".r": "R", ".m": "Objective-C", ".lua": "Lua",
+	".pl": "Perl", ".hs": "Haskell", ".elm": "Elm", ".clj": "Clojure", ".ex": "Elixir", ".exs": "Elixir",
+	".html": "HTML", ".htm": "HTML", ".css": "CSS", ".scss": "SCSS", ".sass": "SCSS", ".less": "Less",
+	".sql": "SQL", ".sh": "Shell", ".bash": "Shell", ".ps1": "PowerShell", ".bat": "Batch",
+	".ipynb": "Jupyter Notebook", ".templ": "templ",
+}
+
+var languageByName = map[string]string{
+	"dockerfile": "Dockerfile",
+	"makefile":   "Makefile",
+}
+
+// languageOf returns the language of a file, or "" for files that are not source code
+func languageOf(file *File) string {
+	if language, ok := languageByName[strings.ToLower(file.Name)]; ok {
+		return language
+	}
+	return languageByExt[file.Ext]
+}
+
+type languageAnalyzer struct {
+	stats map[string]*LanguageStat
+}
+
+func (a *languageAnalyzer) Name() string {
+	return "languages"
+}
+
+func (a *languageAnalyzer) Visit(file *File, report *Report) {
+	// Only published first-party code counts
+	if file.Excluded || file.Binary || file.Content == nil || isVendored(file) {
+		return
+	}
+	language := languageOf(file)
+	if language == "" {
+		return
+	}
+	if a.stats == nil {
+		a.stats = map[string]*LanguageStat{}
+	}
+	stat, ok := a.stats[language]
+	if !ok {
+		stat = &LanguageStat{Name: language}
+		a.stats[language] = stat
+	}
+
+	lines, code := countLines(file.Content)
+	stat.Files++
+	stat.Lines += lines
+	stat.CodeLines += code
+	report.TotalLines += lines
+	report.CodeLines += code
+}
+
+func (a *languageAnalyzer) Finish(report *Report) {
+	report.Languages = make([]LanguageStat, 0, len(a.stats))
+	for _, stat := range a.stats {
+		if report.CodeLines > 0 {
+			stat.Percent = math.Round(float64(stat.CodeLines)*1000/float64(report.CodeLines)) / 10
+		}
+		report.Languages = append(report.Languages, *stat)
+	}
+	sort.Slice(report.Languages, func(i, j int) bool {
+		if report.Languages[i].CodeLines != report.Languages[j].CodeLines {
+			return report.Languages[i].CodeLines > report.Languages[j].CodeLines
+		}
+		return report.Languages[i].Name < report.Languages[j].Name
+	})
+}
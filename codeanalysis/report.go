@@ -0,0 +1,102 @@
+// codeanalysis/report.go - Code report stored with every source code submission
+package codeanalysis
+
+import (
+	"fmt"
+	"time"
+)
+
+// Report is the result of running the analyzers over one submission
+type Report struct {
+	GeneratedAt   time.Time `json:"generated_at"`
+	Analyzers     []string  `json:"analyzers"`
+	TotalFiles    int       `json:"total_files"`
+	TotalSize     int64     `json:"total_size"`
+	ExcludedFiles int       `json:"excluded_files"`
+
+	Languages  []LanguageStat `json:"languages"`
+	TotalLines int            `json:"total_lines"`
+	CodeLines  int            `json:"code_lines"` // non-blank lines
+
+	BuildSystems []string `json:"build_systems"`
+	Frameworks   []string `json:"frameworks"`
+
+	Tests TestSummary `json:"tests"`
+
+	Secrets  []SecretFinding `json:"secrets"`
+	Vendored []VendoredDir   `json:"vendored"`
+	Binaries []BinaryFile    `json:"binaries"`
+}
+
+// LanguageStat is the share of one language in the submission
+type LanguageStat struct {
+	Name      string  `json:"name"`
+	Files     int     `json:"files"`
+	Lines     int     `json:"lines"`
+	CodeLines int     `json:"code_lines"`
+	Percent   float64 `json:"percent"` // of all code lines
+}
+
+// TestSummary describes the automated tests found in the submission
+type TestSummary struct {
+	TestFiles  int      `json:"test_files"`
+	TestLines  int      `json:"test_lines"`
+	Frameworks []string `json:"frameworks"`
+}
+
+// HasTests reports whether any test file was found
+func (t TestSummary) HasTests() bool {
+	return t.TestFiles > 0
+}
+
+// SecretFinding is a committed credential or secret file. The secret value itself is never stored.
+type SecretFinding struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"` // 0 when the whole file is the finding
+	Kind     string `json:"kind"`
+	Excluded bool   `json:"excluded"` // filtered out of the published repository
+}
+
+// Location returns the path, with the line number when known
+func (s SecretFinding) Location() string {
+	if s.Line > 0 {
+		return fmt.Sprintf("%s:%d", s.Path, s.Line)
+	}
+	return s.Path
+}
+
+// VendoredDir is a directory of third-party code included in the upload
+type VendoredDir struct {
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	Files    int    `json:"files"`
+	Size     int64  `json:"size"`
+	Excluded bool   `json:"excluded"` // every file was filtered out of the published repository
+}
+
+// BinaryFile is a binary blob included in the upload
+type BinaryFile struct {
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	Size     int64  `json:"size"`
+	Excluded bool   `json:"excluded"`
+}
+
+// PublishedSecrets counts the secret findings that made it into the repository
+func (r *Report) PublishedSecrets() int {
+	count := 0
+	for _, secret := range r.Secrets {
+		if !secret.Excluded {
+			count++
+		}
+	}
+	return count
+}
+
+// PrimaryLanguage returns the language with the most code lines, or "" when none was detected
+func (r *Report) PrimaryLanguage() string {
+	if len(r.Languages) == 0 {
+		return ""
+	}
+	return r.Languages[0].Name
+}
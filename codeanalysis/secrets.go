@@ -0,0 +1,96 @@
+// codeanalysis/secrets.go - Committed secrets: environment files, private keys and credentials in code
+package codeanalysis
+
+import (
+	"regexp"
+	"strings"
+)
+
+// secretFileNames are files that hold secrets by their nature
+var secretFileNames = map[string]string{
+	"id_rsa":           "SSH private key",
+	"id_dsa":           "SSH private key",
+	"id_ecdsa":         "SSH private key",
+	"id_ed25519":       "SSH private key",
+	".htpasswd":        "Password file",
+	".npmrc":           "Package registry credentials",
+	".pypirc":          "Package registry credentials",
+	"credentials.json": "Credentials file",
+	"secrets.json":     "Credentials file",
+}
+
+var secretFileExts = map[string]string{
+	".pem":      "Private key or certificate",
+	".key":      "Private key",
+	".p12":      "Certificate store",
+	".pfx":      "Certificate store",
+	".jks":      "Java keystore",
+	".keystore": "Java keystore",
+}
+
+// envTemplateSuffixes mark .env files that document variables instead of holding values
+var envTemplateSuffixes = []string{".example", ".sample", ".template", ".dist"}
+
+// secretPatterns detect credentials inside text files
+var secretPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"Private key", regexp.MustCompile(`-----BEGIN (RSA |DSA |EC |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY( BLOCK)?-----`)},
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{50,})\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{"Stripe secret key", regexp.MustCompile(`\b[sr]k_live_[0-9A-Za-z]{20,}\b`)},
+	{"Database URL with password", regexp.MustCompile(`\b(mysql|postgres(ql)?|mongodb(\+srv)?|redis|amqp)://[^\s:/@"']+:[^\s@"'$]{3,}@`)},
+	{"Hardcoded credential", regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|api[_-]?key|access[_-]?token|auth[_-]?token|client[_-]?secret)\b["']?\s*[:=]\s*["'][^"'\s$%{}<>]{8,}["']`)},
+}
+
+// maxSecretFindings bounds the findings per file, so one dump of keys does not flood the report
+const maxSecretFindings = 5
+
+type secretAnalyzer struct{}
+
+func (a *secretAnalyzer) Name() string {
+	return "secrets"
+}
+
+func (a *secretAnalyzer) Visit(file *File, report *Report) {
+	if kind := secretFileKind(file); kind != "" {
+		report.Secrets = append(report.Secrets, SecretFinding{Path: file.Path, Kind: kind, Excluded: file.Excluded})
+	}
+	if file.Content == nil || file.Binary || isVendored(file) {
+		return
+	}
+
+	found := 0
+	readLines(file.Content, func(number int, line string) bool {
+		for _, p := range secretPatterns {
+			if p.pattern.MatchString(line) {
+				report.Secrets = append(report.Secrets, SecretFinding{Path: file.Path, Line: number, Kind: p.kind, Excluded: file.Excluded})
+				found++
+				break
+			}
+		}
+		return found < maxSecretFindings
+	})
+}
+
+func (a *secretAnalyzer) Finish(report *Report) {}
+
+// secretFileKind classifies files that are secrets as a whole, such as .env files and key stores
+func secretFileKind(file *File) string {
+	name := strings.ToLower(file.Name)
+	if name == ".env" || strings.HasPrefix(name, ".env.") || strings.HasSuffix(name, ".env") {
+		for _, suffix := range envTemplateSuffixes {
+			if strings.HasSuffix(name, suffix) {
+				return ""
+			}
+		}
+		return "Environment file"
+	}
+	if kind, ok := secretFileNames[name]; ok {
+		return kind
+	}
+	return secretFileExts[file.Ext]
+}
@@ -0,0 +1,74 @@
+// codeanalysis/testsuite.go - Detection of automated tests
+package codeanalysis
+
+import (
+	"regexp"
+	"strings"
+)
+
+// testFilePattern matches the test file naming conventions of common languages
+var testFilePattern = regexp.MustCompile(`(?i)(_test\.go|^test_.+\.py|_test\.py|\.(test|spec)\.(js|jsx|ts|tsx|mjs)|tests?\.(java|kt|cs)|_spec\.rb|_test\.(rb|exs|dart)|test\.php)$`)
+
+var testDirs = map[string]bool{"test": true, "tests": true, "__tests__": true, "spec": true, "androidtest": true}
+
+// testFrameworkMarkers are looked up in manifests and test files
+var testFrameworkMarkers = []struct{ marker, framework string }{
+	{`"jest"`, "Jest"}, {`"vitest"`, "Vitest"}, {`"mocha"`, "Mocha"}, {`"cypress"`, "Cypress"},
+	{`"@playwright/test"`, "Playwright"}, {"import pytest", "pytest"}, {"pytest", "pytest"},
+	{"import unittest", "unittest"}, {"junit", "JUnit"}, {"org.testng", "TestNG"},
+	{"xunit", "xUnit"}, {"nunit", "NUnit"}, {"mstest", "MSTest"}, {"phpunit", "PHPUnit"},
+	{"rspec", "RSpec"}, {"\"testing\"", "Go testing"}, {"flutter_test", "flutter_test"},
+}
+
+type testAnalyzer struct {
+	frameworks map[string]bool
+}
+
+func (a *testAnalyzer) Name() string {
+	return "tests"
+}
+
+func (a *testAnalyzer) Visit(file *File, report *Report) {
+	if file.Excluded || isVendored(file) {
+		return
+	}
+	if a.frameworks == nil {
+		a.frameworks = map[string]bool{}
+	}
+
+	isTest := testFilePattern.MatchString(file.Name)
+	if !isTest && languageOf(file) != "" {
+		for _, dir := range file.Dirs() {
+			if testDirs[strings.ToLower(dir)] {
+				isTest = true
+				break
+			}
+		}
+	}
+
+	_, isManifest := frameworkMarkers[strings.ToLower(file.Name)]
+	if (isTest || isManifest || file.Ext == ".csproj") && file.Content != nil {
+		content := strings.ToLower(string(file.Content))
+		for _, m := range testFrameworkMarkers {
+			// Go's "testing" import only tells something inside test files
+			if m.framework == "Go testing" && !isTest {
+				continue
+			}
+			if strings.Contains(content, m.marker) {
+				a.frameworks[m.framework] = true
+			}
+		}
+	}
+
+	if isTest {
+		report.Tests.TestFiles++
+		if file.Content != nil {
+			lines, _ := countLines(file.Content)
+			report.Tests.TestLines += lines
+		}
+	}
+}
+
+func (a *testAnalyzer) Finish(report *Report) {
+	report.Tests.Frameworks = sortedKeys(a.frameworks)
+}
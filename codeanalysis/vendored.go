@@ -0,0 +1,79 @@
+// codeanalysis/vendored.go - Third-party code and dependencies committed with the submission
+package codeanalysis
+
+import (
+	"sort"
+	"strings"
+)
+
+// vendorDirs are directory names holding dependencies instead of the student's own code
+var vendorDirs = map[string]string{
+	"node_modules":     "npm packages",
+	"bower_components": "Bower packages",
+	"vendor":           "Vendored dependencies",
+	"third_party":      "Third-party code",
+	"thirdparty":       "Third-party code",
+	"site-packages":    "Python packages",
+	"venv":             "Python virtual environment",
+	".venv":            "Python virtual environment",
+	"pods":             "CocoaPods",
+	"packages":         "NuGet packages",
+	".gradle":          "Gradle cache",
+}
+
+// vendorRoot returns the path of the dependency directory the file lives in and its kind
+func vendorRoot(file *File) (string, string) {
+	dirs := file.Dirs()
+	for i, dir := range dirs {
+		if kind, ok := vendorDirs[strings.ToLower(dir)]; ok {
+			return strings.Join(dirs[:i+1], "/"), kind
+		}
+	}
+	return "", ""
+}
+
+// isVendored reports whether the file is third-party code: inside a dependency directory or a minified bundle
+func isVendored(file *File) bool {
+	if root, _ := vendorRoot(file); root != "" {
+		return true
+	}
+	name := strings.ToLower(file.Name)
+	return strings.HasSuffix(name, ".min.js") || strings.HasSuffix(name, ".min.css")
+}
+
+type vendorAnalyzer struct {
+	dirs map[string]*VendoredDir
+}
+
+func (a *vendorAnalyzer) Name() string {
+	return "vendored"
+}
+
+func (a *vendorAnalyzer) Visit(file *File, report *Report) {
+	root, kind := vendorRoot(file)
+	if root == "" {
+		if !isVendored(file) {
+			return
+		}
+		root, kind = file.Path, "Minified bundle"
+	}
+	if a.dirs == nil {
+		a.dirs = map[string]*VendoredDir{}
+	}
+	dir, ok := a.dirs[root]
+	if !ok {
+		dir = &VendoredDir{Path: root, Kind: kind, Excluded: true}
+		a.dirs[root] = dir
+	}
+	dir.Files++
+	dir.Size += file.Size
+	dir.Excluded = dir.Excluded && file.Excluded
+}
+
+func (a *vendorAnalyzer) Finish(report *Report) {
+	report.Vendored = make([]VendoredDir, 0, len(a.dirs))
+	for _, dir := range a.dirs {
+		report.Vendored = append(report.Vendored, *dir)
+	}
+	sort.Slice(report.Vendored, func(i, j int) bool { return report.Vendored[i].Path < report.Vendored[j].Path })
+}
@@ -48,7 +48,7 @@ func RepositoryLayout(user *auth.AuthenticatedUser, student *database.StudentRec
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, " <style>\r\n            .file-tree {\r\n                font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', 'Noto Sans', Helvetica, Arial, sans-serif;\r\n            }\r\n            .file-explorer {\r\n                border: 1px solid #d0d7de;\r\n                border-radius: 6px;\r\n                overflow: hidden;\r\n            }\r\n            .file-header {\r\n                background-color: #f6f8fa;\r\n                border-bottom: 1px solid #d0d7de;\r\n                padding: 16px;\r\n            }\r\n            .file-row {\r\n                display: flex;\r\n                align-items: center;\r\n                padding: 8px 16px;\r\n                border-bottom: 1px solid #d0d7de;\r\n                transition: background-color 0.1s;\r\n            }\r\n            .file-row:hover {\r\n                background-color: #f6f8fa;\r\n            }\r\n            .file-row:last-child {\r\n                border-bottom: none;\r\n            }\r\n            .file-icon {\r\n                margin-right: 12px;\r\n                flex-shrink: 0;\r\n            }\r\n            .file-name {\r\n                flex-grow: 1;\r\n                color: #0969da;\r\n                text-decoration: none;\r\n                font-size: 14px;\r\n            }\r\n            .file-name:hover {\r\n                text-decoration: underline;\r\n            }\r\n            .file-meta {\r\n                color: #656d76;\r\n                font-size: 12px;\r\n                margin-left: auto;\r\n                flex-shrink: 0;\r\n            }\r\n            .breadcrumb {\r\n                display: flex;\r\n                align-items: center;\r\n                gap: 8px;\r\n                font-size: 14px;\r\n                color: #656d76;\r\n                margin-bottom: 16px;\r\n            }\r\n            .breadcrumb a {\r\n                color: #0969da;\r\n                text-decoration: none;\r\n            }\r\n            .breadcrumb a:hover {\r\n                text-decoration: underline;\r\n            }\r\n            .code-viewer {\r\n                background-color: #f6f8fa;\r\n                border-radius: 6px;\r\n                overflow: hidden;\r\n                margin-top: 16px;\r\n            }\r\n            .code-header {\r\n                background-color: #f6f8fa;\r\n                border-bottom: 1px solid #d0d7de;\r\n                padding: 8px 16px;\r\n                display: flex;\r\n                justify-content: space-between;\r\n                align-items: center;\r\n            }\r\n            .code-content {\r\n                background-color: #ffffff;\r\n                overflow-x: auto;\r\n            }\r\n            pre {\r\n                margin: 0;\r\n                font-size: 13px;\r\n                line-height: 20px;\r\n            }\r\n            .line-numbers {\r\n                user-select: none;\r\n                width: 50px;\r\n                color: #656d76;\r\n                text-align: right;\r\n                padding-right: 16px;\r\n                background-color: #f6f8fa;\r\n                border-right: 1px solid #d0d7de;\r\n            }\r\n            .status-badge {\r\n                padding: 2px 8px;\r\n                border-radius: 12px;\r\n                font-size: 12px;\r\n                font-weight: 500;\r\n            }\r\n            .status-success {\r\n                background-color: #dafbe1;\r\n                color: #1a7f37;\r\n            }\r\n            .status-pending {\r\n                background-color: #fff8c5;\r\n                color: #9a6700;\r\n            }\r\n        </style>  <link rel=\"stylesheet\" href=\"https://cdnjs.cloudflare.com/ajax/libs/prism/1.29.0/themes/prism-tomorrow.min.css\"><script src=\"https://cdnjs.cloudflare.com/ajax/libs/prism/1.29.0/prism.min.js\"></script> <script src=\"https://cdnjs.cloudflare.com/ajax/libs/prism/1.29.0/plugins/autoloader/prism-autoloader.min.js\"></script> ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, " <style>\n            .file-tree {\n                font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', 'Noto Sans', Helvetica, Arial, sans-serif;\n            }\n            .file-explorer {\n                border: 1px solid #d0d7de;\n                border-radius: 6px;\n                overflow: hidden;\n            }\n            .file-header {\n                background-color: #f6f8fa;\n                border-bottom: 1px solid #d0d7de;\n                padding: 16px;\n            }\n            .file-row {\n                display: flex;\n                align-items: center;\n                padding: 8px 16px;\n                border-bottom: 1px solid #d0d7de;\n                transition: background-color 0.1s;\n            }\n            .file-row:hover {\n                background-color: #f6f8fa;\n            }\n            .file-row:last-child {\n                border-bottom: none;\n            }\n            .file-icon {\n                margin-right: 12px;\n                flex-shrink: 0;\n            }\n            .file-name {\n                flex-grow: 1;\n                color: #0969da;\n                text-decoration: none;\n                font-size: 14px;\n            }\n            .file-name:hover {\n                text-decoration: underline;\n            }\n            .file-meta {\n                color: #656d76;\n                font-size: 12px;\n                margin-left: auto;\n                flex-shrink: 0;\n            }\n            .breadcrumb {\n                display: flex;\n                align-items: center;\n                gap: 8px;\n                font-size: 14px;\n                color: #656d76;\n                margin-bottom: 16px;\n            }\n            .breadcrumb a {\n                color: #0969da;\n                text-decoration: none;\n            }\n            .breadcrumb a:hover {\n                text-decoration: underline;\n            }\n            .code-viewer {\n                background-color: #f6f8fa;\n                border-radius: 6px;\n                overflow: hidden;\n                margin-top: 16px;\n            }\n            .code-header {\n                background-color: #f6f8fa;\n                border-bottom: 1px solid #d0d7de;\n                padding: 8px 16px;\n                display: flex;\n                justify-content: space-between;\n                align-items: center;\n            }\n            .code-content {\n                background-color: #ffffff;\n                overflow-x: auto;\n            }\n            pre {\n                margin: 0;\n                font-size: 13px;\n                line-height: 20px;\n            }\n            .line-numbers {\n                user-select: none;\n                width: 50px;\n                color: #656d76;\n                text-align: right;\n                padding-right: 16px;\n                background-color: #f6f8fa;\n                border-right: 1px solid #d0d7de;\n            }\n            .status-badge {\n                padding: 2px 8px;\n                border-radius: 12px;\n                font-size: 12px;\n                font-weight: 500;\n            }\n            .status-success {\n                background-color: #dafbe1;\n                color: #1a7f37;\n            }\n            .status-pending {\n                background-color: #fff8c5;\n                color: #9a6700;\n            }\n            .status-error {\n                background-color: #ffebe9;\n                color: #cf222e;\n            }\n        </style>  <link rel=\"stylesheet\" href=\"https://cdnjs.cloudflare.com/ajax/libs/prism/1.29.0/themes/prism-tomorrow.min.css\"><script src=\"https://cdnjs.cloudflare.com/ajax/libs/prism/1.29.0/prism.min.js\"></script> <script src=\"https://cdnjs.cloudflare.com/ajax/libs/prism/1.29.0/plugins/autoloader/prism-autoloader.min.js\"></script> ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -57,20 +57,20 @@ func CommissionManagement(user *auth.AuthenticatedUser, locale string, data Comm
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"max-w-6xl mx-auto space-y-6\"><div class=\"flex justify-between items-center\"><h1 class=\"text-2xl font-bold\">Commission Access Tokens</h1><div class=\"text-sm text-gray-600\">Department: ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"max-w-6xl mx-auto space-y-6\"><div class=\"flex justify-between items-center\"><h1 class=\"text-2xl font-bold\">Commission Access Tokens</h1><div class=\"flex gap-4 text-sm\"><a href=\"/admin/defense-periods\" class=\"text-blue-600 hover:underline\">Defense periods</a> <span class=\"text-gray-600\">Department: ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var3 string
 			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(data.Department)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 24, Col: 34}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 25, Col: 62}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</div></div><!-- Simple Create Form --><div class=\"bg-white rounded-lg shadow p-6\"><h2 class=\"text-lg font-semibold mb-4\">Generate New Access Token</h2><form hx-post=\"/admin/commission/create\" hx-target=\"#access-codes-list\" hx-swap=\"afterbegin\" class=\"space-y-4\"><div class=\"grid grid-cols-1 md:grid-cols-3 gap-4\"><div><label class=\"block text-sm font-medium mb-1\">Study Program</label> <select name=\"study_program\" required class=\"w-full border rounded-md px-3 py-2\"><option value=\"\">Select Program</option> ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</span></div></div><!-- Simple Create Form --><div class=\"bg-white rounded-lg shadow p-6\"><h2 class=\"text-lg font-semibold mb-4\">Generate New Access Token</h2><form hx-post=\"/admin/commission/create\" hx-target=\"#access-codes-list\" hx-swap=\"afterbegin\" class=\"space-y-4\"><div class=\"grid grid-cols-1 md:grid-cols-3 gap-4\"><div><label class=\"block text-sm font-medium mb-1\">Study Program</label> <select name=\"study_program\" required class=\"w-full border rounded-md px-3 py-2\"><option value=\"\">Select Program</option> ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -82,7 +82,7 @@ func CommissionManagement(user *auth.AuthenticatedUser, locale string, data Comm
 				var templ_7745c5c3_Var4 string
 				templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(program)
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 43, Col: 32}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 44, Col: 32}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 				if templ_7745c5c3_Err != nil {
@@ -95,7 +95,7 @@ func CommissionManagement(user *auth.AuthenticatedUser, locale string, data Comm
 				var templ_7745c5c3_Var5 string
 				templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(program)
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 43, Col: 44}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 44, Col: 44}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 				if templ_7745c5c3_Err != nil {
@@ -116,7 +116,7 @@ func CommissionManagement(user *auth.AuthenticatedUser, locale string, data Comm
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</tbody></table></div></div></div><script>\r\n\t\t\t// Use event delegation for copy buttons\r\n\t\t\tdocument.addEventListener('click', function(e) {\r\n\t\t\t\tif (e.target.classList.contains('copy-btn') || e.target.parentElement.classList.contains('copy-btn')) {\r\n\t\t\t\t\tconst btn = e.target.classList.contains('copy-btn') ? e.target : e.target.parentElement;\r\n\t\t\t\t\tconst code = btn.getAttribute('data-code');\r\n\t\t\t\t\tconst url = window.location.origin + '/commission/' + code;\r\n\r\n\t\t\t\t\tnavigator.clipboard.writeText(url).then(function() {\r\n\t\t\t\t\t\tconst originalText = btn.textContent;\r\n\t\t\t\t\t\tbtn.textContent = '✓ Copied!';\r\n\t\t\t\t\t\tbtn.classList.add('text-green-600');\r\n\t\t\t\t\t\tbtn.classList.remove('text-blue-600');\r\n\r\n\t\t\t\t\t\tsetTimeout(() => {\r\n\t\t\t\t\t\t\tbtn.textContent = originalText;\r\n\t\t\t\t\t\t\tbtn.classList.remove('text-green-600');\r\n\t\t\t\t\t\t\tbtn.classList.add('text-blue-600');\r\n\t\t\t\t\t\t}, 2000);\r\n\t\t\t\t\t}).catch(function(err) {\r\n\t\t\t\t\t\talert('Failed to copy: ' + err);\r\n\t\t\t\t\t});\r\n\t\t\t\t}\r\n\t\t\t});\r\n\t\t</script>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</tbody></table></div></div></div><script>\n\t\t\t// Use event delegation for copy buttons\n\t\t\tdocument.addEventListener('click', function(e) {\n\t\t\t\tif (e.target.classList.contains('copy-btn') || e.target.parentElement.classList.contains('copy-btn')) {\n\t\t\t\t\tconst btn = e.target.classList.contains('copy-btn') ? e.target : e.target.parentElement;\n\t\t\t\t\tconst code = btn.getAttribute('data-code');\n\t\t\t\t\tconst url = window.location.origin + '/commission/' + code;\n\n\t\t\t\t\tnavigator.clipboard.writeText(url).then(function() {\n\t\t\t\t\t\tconst originalText = btn.textContent;\n\t\t\t\t\t\tbtn.textContent = '✓ Copied!';\n\t\t\t\t\t\tbtn.classList.add('text-green-600');\n\t\t\t\t\t\tbtn.classList.remove('text-blue-600');\n\n\t\t\t\t\t\tsetTimeout(() => {\n\t\t\t\t\t\t\tbtn.textContent = originalText;\n\t\t\t\t\t\t\tbtn.classList.remove('text-green-600');\n\t\t\t\t\t\t\tbtn.classList.add('text-blue-600');\n\t\t\t\t\t\t}, 2000);\n\t\t\t\t\t}).catch(function(err) {\n\t\t\t\t\t\talert('Failed to copy: ' + err);\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t});\n\t\t</script>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -158,7 +158,7 @@ func SimpleAccessCodeRow(member *database.CommissionMember, locale string) templ
 		var templ_7745c5c3_Var7 string
 		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(member.StudyProgram.String)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 125, Col: 31}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 126, Col: 31}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 		if templ_7745c5c3_Err != nil {
@@ -171,7 +171,7 @@ func SimpleAccessCodeRow(member *database.CommissionMember, locale string) templ
 		var templ_7745c5c3_Var8 string
 		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%s/commission/%s", "http://localhost:8080", member.AccessCode))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 130, Col: 82}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 131, Col: 82}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 		if templ_7745c5c3_Err != nil {
@@ -184,7 +184,7 @@ func SimpleAccessCodeRow(member *database.CommissionMember, locale string) templ
 		var templ_7745c5c3_Var9 string
 		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(member.AccessCode)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 134, Col: 34}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 135, Col: 34}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
 		if templ_7745c5c3_Err != nil {
@@ -197,7 +197,7 @@ func SimpleAccessCodeRow(member *database.CommissionMember, locale string) templ
 		var templ_7745c5c3_Var10 string
 		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(time.Unix(member.ExpiresAt, 0).Format("Jan 2, 2006"))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 141, Col: 57}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 142, Col: 57}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
 		if templ_7745c5c3_Err != nil {
@@ -220,7 +220,7 @@ func SimpleAccessCodeRow(member *database.CommissionMember, locale string) templ
 		var templ_7745c5c3_Var11 string
 		templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", member.AccessCount))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 147, Col: 42}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 148, Col: 42}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
 		if templ_7745c5c3_Err != nil {
@@ -238,7 +238,7 @@ func SimpleAccessCodeRow(member *database.CommissionMember, locale string) templ
 			var templ_7745c5c3_Var12 string
 			templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", member.MaxAccess))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 149, Col: 43}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 150, Col: 43}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
 			if templ_7745c5c3_Err != nil {
@@ -257,7 +257,7 @@ func SimpleAccessCodeRow(member *database.CommissionMember, locale string) templ
 		var templ_7745c5c3_Var13 string
 		templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("/admin/commission/%s", member.AccessCode))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/commission_management.templ`, Line: 155, Col: 77}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `commission_management.templ`, Line: 156, Col: 77}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
 		if templ_7745c5c3_Err != nil {
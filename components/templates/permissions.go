@@ -119,6 +119,10 @@ func canExportData(role string) bool {
 	return role == "admin" || role == "department_head"
 }
 
+func canDownloadTopicForms(role string) bool {
+	return role == "admin" || role == "department_head" || role == "supervisor"
+}
+
 func canExportStudentData(role string) bool {
 	return role == "admin" || role == "department_head"
 }
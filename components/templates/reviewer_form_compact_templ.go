@@ -161,7 +161,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						var templ_7745c5c3_Var5 string
 						templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("/reviewer/%s/student/%d/review/submit", props.AccessToken, props.StudentRecord.ID))
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 68, Col: 133}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 68, Col: 133}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 						if templ_7745c5c3_Err != nil {
@@ -174,7 +174,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						var templ_7745c5c3_Var6 string
 						templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", props.StudentRecord.ID))
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 72, Col: 87}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 72, Col: 87}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 						if templ_7745c5c3_Err != nil {
@@ -187,7 +187,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						var templ_7745c5c3_Var7 string
 						templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(props.AccessToken)
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 73, Col: 65}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 73, Col: 65}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 						if templ_7745c5c3_Err != nil {
@@ -213,7 +213,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						var templ_7745c5c3_Var8 string
 						templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("/reviewer-report/%d/submit", props.StudentRecord.ID))
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 80, Col: 103}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 80, Col: 103}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 						if templ_7745c5c3_Err != nil {
@@ -226,7 +226,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						var templ_7745c5c3_Var9 string
 						templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", props.StudentRecord.ID))
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 84, Col: 87}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 84, Col: 87}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
 						if templ_7745c5c3_Err != nil {
@@ -239,7 +239,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						var templ_7745c5c3_Var10 string
 						templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(props.AccessToken)
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 85, Col: 65}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 85, Col: 65}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
 						if templ_7745c5c3_Err != nil {
@@ -266,7 +266,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 					var templ_7745c5c3_Var11 string
 					templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", props.StudentRecord.ID))
 					if templ_7745c5c3_Err != nil {
-						return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 94, Col: 83}
+						return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 94, Col: 83}
 					}
 					_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
 					if templ_7745c5c3_Err != nil {
@@ -279,7 +279,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 					var templ_7745c5c3_Var12 string
 					templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(props.AccessToken)
 					if templ_7745c5c3_Err != nil {
-						return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 95, Col: 61}
+						return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 95, Col: 61}
 					}
 					_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
 					if templ_7745c5c3_Err != nil {
@@ -328,7 +328,162 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var14 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				if props.IsSigned {
+					templ_7745c5c3_Var14 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+						if !templ_7745c5c3_IsBuffer {
+							defer func() {
+								templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+								if templ_7745c5c3_Err == nil {
+									templ_7745c5c3_Err = templ_7745c5c3_BufErr
+								}
+							}()
+						}
+						ctx = templ.InitializeContext(ctx)
+						templ_7745c5c3_Err = icon.Download(icon.Props{Size: 14, Class: "mr-1"}).Render(ctx, templ_7745c5c3_Buffer)
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, " PDF")
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						return nil
+					})
+					templ_7745c5c3_Err = button.Button(button.Props{
+						Variant: button.VariantOutline,
+						Class:   "h-9 px-4 text-sm",
+						Href:    reviewerReportPDFURL(props),
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var14), templ_7745c5c3_Buffer)
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, " ")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					if props.AccessToken == "" {
+						templ_7745c5c3_Var15 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+							templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+							templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+							if !templ_7745c5c3_IsBuffer {
+								defer func() {
+									templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+									if templ_7745c5c3_Err == nil {
+										templ_7745c5c3_Err = templ_7745c5c3_BufErr
+									}
+								}()
+							}
+							ctx = templ.InitializeContext(ctx)
+							if props.FormVariant == "en" {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "Verify signature")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							} else {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 34, "Tikrinti parašą")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							}
+							return nil
+						})
+						templ_7745c5c3_Err = button.Button(button.Props{
+							Variant: button.VariantOutline,
+							Class:   "h-9 px-4 text-sm",
+							Attributes: templ.Attributes{
+								"hx-get":    fmt.Sprintf("/api/reports/reviewer/%d/verify", props.StudentRecord.ID),
+								"hx-target": "#modal-result",
+							},
+						}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var15), templ_7745c5c3_Buffer)
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+					}
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 35, " ")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					if props.CanUnlock {
+						templ_7745c5c3_Var16 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+							templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+							templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+							if !templ_7745c5c3_IsBuffer {
+								defer func() {
+									templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+									if templ_7745c5c3_Err == nil {
+										templ_7745c5c3_Err = templ_7745c5c3_BufErr
+									}
+								}()
+							}
+							ctx = templ.InitializeContext(ctx)
+							if props.FormVariant == "en" {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 36, "Unlock")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							} else {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 37, "Atrakinti")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							}
+							return nil
+						})
+						templ_7745c5c3_Err = button.Button(button.Props{
+							Variant: button.VariantDestructive,
+							Class:   "h-9 px-4 text-sm",
+							Attributes: templ.Attributes{
+								"hx-post":   fmt.Sprintf("/api/reports/reviewer/%d/unlock", props.StudentRecord.ID),
+								"hx-prompt": getUnlockPrompt(props.FormVariant),
+								"hx-target": "#modal-result",
+							},
+						}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var16), templ_7745c5c3_Buffer)
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+					}
+				}
+				if props.CanViewHistory {
+					templ_7745c5c3_Var17 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+						if !templ_7745c5c3_IsBuffer {
+							defer func() {
+								templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+								if templ_7745c5c3_Err == nil {
+									templ_7745c5c3_Err = templ_7745c5c3_BufErr
+								}
+							}()
+						}
+						ctx = templ.InitializeContext(ctx)
+						if props.FormVariant == "en" {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, "Version history")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						} else {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, "Versijų istorija")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						}
+						return nil
+					})
+					templ_7745c5c3_Err = button.Button(button.Props{
+						Variant: button.VariantOutline,
+						Class:   "h-9 px-4 text-sm",
+						Attributes: templ.Attributes{
+							"hx-get":    fmt.Sprintf("/api/reports/reviewer/%d/versions?locale=%s", props.StudentRecord.ID, props.FormVariant),
+							"hx-target": "#modal-result",
+						},
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var17), templ_7745c5c3_Buffer)
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+				}
+				templ_7745c5c3_Var18 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -340,7 +495,7 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var15 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var19 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -353,12 +508,12 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, "Close")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, "Close")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, "Uždaryti")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, "Uždaryti")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -368,22 +523,22 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 					templ_7745c5c3_Err = button.Button(button.Props{
 						Variant: button.VariantGhost,
 						Class:   "h-9 px-4 text-sm",
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var15), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var19), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = modal.Close(modal.CloseProps{ModalID: "reviewer-modal"}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var14), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = modal.Close(modal.CloseProps{ModalID: "reviewer-modal"}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var18), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "<!-- Save as Draft button --> ")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, "<!-- Save as Draft button --> ")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Var16 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var20 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -399,22 +554,22 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 34, " <span class=\"ml-1\">")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, " <span class=\"ml-1\">")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 35, "Save Draft")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, "Save Draft")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 36, "Išsaugoti juodraštį")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, "Išsaugoti juodraštį")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						}
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 37, "</span>")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, "</span>")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
@@ -426,15 +581,15 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						Attributes: templ.Attributes{
 							"onclick": "reviewerSaveDraft()",
 						},
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var16), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var20), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, " <!-- Submit button --> ")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, " <!-- Submit button --> ")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Var17 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var21 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -447,12 +602,12 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, "💾 Submit & Sign")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, "💾 Submit & Sign")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, "💾 Pateikti ir pasirašyti")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, "💾 Pateikti ir pasirašyti")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -467,12 +622,12 @@ func CompactReviewerForm(props database.ReviewerReportFormProps, formData *datab
 							"form":    "compact-reviewer-form",
 							"onclick": "return reviewerValidateAndSubmit()",
 						},
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var17), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var21), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, "</div></div>")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, "</div></div>")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
@@ -512,82 +667,82 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var18 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var18 == nil {
-			templ_7745c5c3_Var18 = templ.NopComponent
+		templ_7745c5c3_Var22 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var22 == nil {
+			templ_7745c5c3_Var22 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, "<!-- Add hidden field to track if this is a draft --><input type=\"hidden\" name=\"is_draft\" id=\"is_draft\" value=\"false\"><!-- Add style for read-only textareas -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 51, "<!-- Add hidden field to track if this is a draft --><input type=\"hidden\" name=\"is_draft\" id=\"is_draft\" value=\"false\"><!-- Add style for read-only textareas -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if props.IsReadOnly {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, "<style>\r\n         textarea[disabled] {\r\n             resize: none !important;\r\n             overflow: hidden !important;\r\n             background-color: transparent !important;\r\n             border: 1px solid transparent !important;\r\n             padding: 0.5rem !important;\r\n             min-height: 1.5rem !important;\r\n             height: auto !important;\r\n             white-space: pre-wrap !important;\r\n             word-wrap: break-word !important;\r\n             line-height: 1.5 !important;\r\n             font-family: inherit !important;\r\n             font-size: inherit !important;\r\n         }\r\n         textarea[disabled]:focus {\r\n             outline: none !important;\r\n             box-shadow: none !important;\r\n             border-color: transparent !important;\r\n         }\r\n         /* Remove any conflicting styles from the templUI component */\r\n         textarea[disabled][data-textarea] {\r\n             min-height: 1.5rem !important;\r\n         }\r\n     </style>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 52, "<style>\n         textarea[disabled] {\n             resize: none !important;\n             overflow: hidden !important;\n             background-color: transparent !important;\n             border: 1px solid transparent !important;\n             padding: 0.5rem !important;\n             min-height: 1.5rem !important;\n             height: auto !important;\n             white-space: pre-wrap !important;\n             word-wrap: break-word !important;\n             line-height: 1.5 !important;\n             font-family: inherit !important;\n             font-size: inherit !important;\n         }\n         textarea[disabled]:focus {\n             outline: none !important;\n             box-shadow: none !important;\n             border-color: transparent !important;\n         }\n         /* Remove any conflicting styles from the templUI component */\n         textarea[disabled][data-textarea] {\n             min-height: 1.5rem !important;\n         }\n     </style>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, "<!-- Compact Header Info --><div class=\"bg-gray-50 dark:bg-gray-800 rounded p-3 space-y-2 text-sm\"><div><span class=\"font-medium\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 53, "<!-- Compact Header Info --><div class=\"bg-gray-50 dark:bg-gray-800 rounded p-3 space-y-2 text-sm\"><div><span class=\"font-medium\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if props.FormVariant == "en" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, "Title:")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 54, "Title:")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		} else {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, "Tema:")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 55, "Tema:")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, "</span> <span class=\"ml-2\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, "</span> <span class=\"ml-2\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var19 string
-		templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(props.StudentRecord.GetLocalizedTitle(props.FormVariant))
+		var templ_7745c5c3_Var23 string
+		templ_7745c5c3_Var23, templ_7745c5c3_Err = templ.JoinStringErrs(props.StudentRecord.GetLocalizedTitle(props.FormVariant))
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 204, Col: 89}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 263, Col: 89}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var23))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, "</span></div><div><span class=\"font-medium\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 57, "</span></div><div><span class=\"font-medium\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		if props.FormVariant == "en" {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, "Author:")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 58, "Author:")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		} else {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, "Autorius:")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 59, "Autorius:")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 51, "</span> <span class=\"ml-2\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 60, "</span> <span class=\"ml-2\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var20 string
-		templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(props.StudentRecord.GetFullName())
+		var templ_7745c5c3_Var24 string
+		templ_7745c5c3_Var24, templ_7745c5c3_Err = templ.JoinStringErrs(props.StudentRecord.GetFullName())
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 214, Col: 66}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 273, Col: 66}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var24))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 52, "</span></div></div><!-- Reviewer Info using Form components -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, "</span></div></div><!-- Reviewer Info using Form components -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var21 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var25 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -599,7 +754,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var22 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var26 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -612,22 +767,22 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}
 				ctx = templ.InitializeContext(ctx)
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 53, "Reviewer Details")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "Reviewer Details")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 54, "Recenzento duomenys")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, "Recenzento duomenys")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 55, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, "<span class=\"text-red-500 ml-1\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, "<span class=\"text-red-500 ml-1\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -636,11 +791,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			})
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For: "reviewer_personal_details",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var22), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var26), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 57, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 66, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -658,12 +813,12 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 58, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 67, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if !props.IsReadOnly {
-				templ_7745c5c3_Var23 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				templ_7745c5c3_Var27 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -676,34 +831,34 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 					}
 					ctx = templ.InitializeContext(ctx)
 					if props.FormVariant == "en" {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 59, "Include name, workplace, position, and academic titles")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 68, "Include name, workplace, position, and academic titles")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					} else {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 60, "Įtraukite vardą, darbovietę, pareigas ir akademinius titulus")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 69, "Įtraukite vardą, darbovietę, pareigas ir akademinius titulus")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = form.Description().Render(templ.WithChildren(ctx, templ_7745c5c3_Var23), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = form.Description().Render(templ.WithChildren(ctx, templ_7745c5c3_Var27), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var21), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var25), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, "<!-- Review Sections --><div class=\"space-y-3\"><!-- Section 1-3 --><div class=\"border rounded-lg p-3 space-y-3\"><!-- Goals -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 70, "<!-- Review Sections --><div class=\"space-y-3\"><!-- Section 1-3 --><div class=\"border rounded-lg p-3 space-y-3\"><!-- Goals -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var24 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var28 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -715,7 +870,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var25 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var29 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -727,27 +882,27 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 					}()
 				}
 				ctx = templ.InitializeContext(ctx)
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "<span class=\"text-blue-600\">1.</span> ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 71, "<span class=\"text-blue-600\">1.</span> ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, "Goals & Tasks")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 72, "Goals & Tasks")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, "Tikslai ir uždaviniai")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 73, "Tikslai ir uždaviniai")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 74, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 66, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 75, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -757,11 +912,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For:   "review_goals",
 				Class: "flex items-center gap-1",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var25), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var29), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 67, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 76, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -781,15 +936,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var24), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var28), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 68, "<!-- Theory -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 77, "<!-- Theory -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var26 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var30 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -801,7 +956,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var27 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var31 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -813,27 +968,27 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 					}()
 				}
 				ctx = templ.InitializeContext(ctx)
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 69, "<span class=\"text-blue-600\">2.</span> ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 78, "<span class=\"text-blue-600\">2.</span> ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 70, "Theory")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 79, "Theory")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 71, "Teorija")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 80, "Teorija")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 72, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 81, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 73, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 82, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -843,11 +998,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For:   "review_theory",
 				Class: "flex items-center gap-1",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var27), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var31), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 74, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 83, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -867,15 +1022,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var26), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var30), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 75, "<!-- Practice -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 84, "<!-- Practice -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var28 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var32 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -887,7 +1042,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var29 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var33 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -899,27 +1054,27 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 					}()
 				}
 				ctx = templ.InitializeContext(ctx)
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 76, "<span class=\"text-blue-600\">3.</span> ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 85, "<span class=\"text-blue-600\">3.</span> ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 77, "Practice")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 86, "Practice")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 78, "Praktika")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 87, "Praktika")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 79, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 88, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 80, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 89, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -929,11 +1084,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For:   "review_practical",
 				Class: "flex items-center gap-1",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var29), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var33), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 81, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 90, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -953,15 +1108,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var28), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var32), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 82, "</div><!-- Section 4-6 --><div class=\"border rounded-lg p-3 space-y-3\"><!-- Connection -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 91, "</div><!-- Section 4-6 --><div class=\"border rounded-lg p-3 space-y-3\"><!-- Connection -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var30 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var34 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -973,7 +1128,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var31 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var35 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -985,27 +1140,27 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 					}()
 				}
 				ctx = templ.InitializeContext(ctx)
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 83, "<span class=\"text-blue-600\">4.</span> ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 92, "<span class=\"text-blue-600\">4.</span> ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 84, "Theory-Practice Link")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 93, "Theory-Practice Link")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 85, "Teorijos-praktikos ryšys")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 94, "Teorijos-praktikos ryšys")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 86, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 95, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 87, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 96, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -1015,11 +1170,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For:   "review_theory_practical_link",
 				Class: "flex items-center gap-1",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var31), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var35), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 88, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 97, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1039,15 +1194,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var30), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var34), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 89, "<!-- Results -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 98, "<!-- Results -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var32 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var36 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -1059,7 +1214,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var33 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var37 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -1071,27 +1226,27 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 					}()
 				}
 				ctx = templ.InitializeContext(ctx)
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 90, "<span class=\"text-blue-600\">5.</span> ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 99, "<span class=\"text-blue-600\">5.</span> ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 91, "Results")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 100, "Results")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 92, "Rezultatai")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 101, "Rezultatai")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 93, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 102, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 94, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 103, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -1101,11 +1256,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For:   "review_results",
 				Class: "flex items-center gap-1",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var33), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var37), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 95, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 104, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1125,15 +1280,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var32), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var36), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 96, "<!-- Significance -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 105, "<!-- Significance -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var34 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var38 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -1145,7 +1300,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var35 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var39 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -1157,22 +1312,22 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 					}()
 				}
 				ctx = templ.InitializeContext(ctx)
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 97, "<span class=\"text-gray-400\">6.</span> ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 106, "<span class=\"text-gray-400\">6.</span> ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 98, "Significance")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 107, "Significance")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 99, "Reikšmė")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 108, "Reikšmė")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 100, " <span class=\"text-xs text-gray-500 ml-1\">(optional)</span>")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 109, " <span class=\"text-xs text-gray-500 ml-1\">(optional)</span>")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
@@ -1181,11 +1336,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For:   "review_practical_significance",
 				Class: "flex items-center gap-1",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var35), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var39), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 101, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 110, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1204,15 +1359,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var34), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var38), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 102, "</div><!-- Evaluation section --><div class=\"border rounded-lg p-3\"><div class=\"grid grid-cols-1 md:grid-cols-3 gap-3\"><!-- Language -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 111, "</div><!-- Evaluation section --><div class=\"border rounded-lg p-3\"><div class=\"grid grid-cols-1 md:grid-cols-3 gap-3\"><!-- Language -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var36 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var40 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -1224,7 +1379,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var37 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var41 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -1237,22 +1392,22 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}
 				ctx = templ.InitializeContext(ctx)
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 103, "Language")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 112, "Language")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 104, "Kalba")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 113, "Kalba")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 105, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 114, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 106, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 115, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -1261,11 +1416,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			})
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For: "review_language",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var37), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var41), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 107, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 116, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1285,15 +1440,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var36), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var40), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 108, "<!-- Pros -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 117, "<!-- Pros -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var38 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var42 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -1305,7 +1460,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var39 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var43 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -1318,22 +1473,22 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}
 				ctx = templ.InitializeContext(ctx)
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 109, "Pros")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 118, "Pros")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 110, "Privalumai")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 119, "Privalumai")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 111, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 120, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 112, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 121, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -1342,11 +1497,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			})
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For: "review_pros",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var39), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var43), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 113, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 122, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1366,15 +1521,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var38), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var42), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 114, "<!-- Cons -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 123, "<!-- Cons -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var40 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var44 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -1386,7 +1541,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var41 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var45 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -1399,22 +1554,22 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}
 				ctx = templ.InitializeContext(ctx)
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 115, "Cons")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 124, "Cons")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 116, "Trūkumai")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 125, "Trūkumai")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 117, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 126, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 118, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 127, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -1423,11 +1578,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			})
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For: "review_cons",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var41), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var45), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 119, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 128, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1447,15 +1602,15 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var40), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var44), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 120, "</div><!-- Questions -->")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 129, "</div><!-- Questions -->")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var42 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var46 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -1467,7 +1622,7 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Var43 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var47 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -1480,22 +1635,22 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}
 				ctx = templ.InitializeContext(ctx)
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 121, "Questions")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 130, "Questions")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 122, "Klausimai")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 131, "Klausimai")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 123, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 132, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 124, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 133, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -1504,11 +1659,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			})
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For: "review_questions",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var43), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var47), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 125, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 134, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1528,15 +1683,19 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item(form.ItemProps{Class: "mt-3"}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var42), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item(form.ItemProps{Class: "mt-3"}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var46), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 126, "<!-- Grade -->")
+		templ_7745c5c3_Err = reviewerRubric(props, formData).Render(ctx, templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var44 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 135, "<!-- Grade -->")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Var48 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -1548,11 +1707,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 127, "<div class=\"flex items-center justify-between\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 136, "<div class=\"flex items-center justify-between\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Var45 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var49 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -1565,22 +1724,22 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 				}
 				ctx = templ.InitializeContext(ctx)
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 128, "Grade")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 137, "Grade")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 129, "Įvertinimas")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 138, "Įvertinimas")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 130, " ")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 139, " ")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 131, "<span class=\"text-red-500\">*</span>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 140, "<span class=\"text-red-500\">*</span>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -1589,11 +1748,11 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			})
 			templ_7745c5c3_Err = form.Label(form.LabelProps{
 				For: "grade",
-			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var45), templ_7745c5c3_Buffer)
+			}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var49), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 132, "<div class=\"flex items-center gap-2\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 141, "<div class=\"flex items-center gap-2\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1614,17 +1773,17 @@ func formContent(props database.ReviewerReportFormProps, formData *database.Revi
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 133, "<span class=\"text-sm text-gray-600\">/10</span></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 142, "<span class=\"text-sm text-gray-600\">/10</span></div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = form.Item(form.ItemProps{Class: "mt-3 pt-3 border-t"}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var44), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = form.Item(form.ItemProps{Class: "mt-3 pt-3 border-t"}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var48), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 134, "</div></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 143, "</div></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1648,36 +1807,36 @@ func ReviewerModalScripts(accessToken string, isReadOnly bool) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var46 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var46 == nil {
-			templ_7745c5c3_Var46 = templ.NopComponent
+		templ_7745c5c3_Var50 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var50 == nil {
+			templ_7745c5c3_Var50 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 135, "<script>\r\n        (function() {\r\n            // Store access token\r\n            const reviewerAccessToken = ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 144, "<script>\n        (function() {\n            // Store access token\n            const reviewerAccessToken = ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var47, templ_7745c5c3_Err := templruntime.ScriptContentOutsideStringLiteral(accessToken)
+		templ_7745c5c3_Var51, templ_7745c5c3_Err := templruntime.ScriptContentOutsideStringLiteral(accessToken)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 587, Col: 54}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 648, Col: 54}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ_7745c5c3_Var47)
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ_7745c5c3_Var51)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 136, ";\r\n            const isReadOnly = ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 145, ";\n            const isReadOnly = ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Var48, templ_7745c5c3_Err := templruntime.ScriptContentOutsideStringLiteral(isReadOnly)
+		templ_7745c5c3_Var52, templ_7745c5c3_Err := templruntime.ScriptContentOutsideStringLiteral(isReadOnly)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/reviewer_form_compact.templ`, Line: 588, Col: 44}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `reviewer_form_compact.templ`, Line: 649, Col: 44}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ_7745c5c3_Var48)
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ_7745c5c3_Var52)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 137, ";\r\n\r\n            // Auto-resize textareas for read-only mode\r\n            if (isReadOnly) {\r\n                const autoResizeTextarea = function(textarea) {\r\n                    // Store original styles\r\n                    const originalHeight = textarea.style.height;\r\n                    const originalOverflow = textarea.style.overflow;\r\n\r\n                    // Reset to get accurate measurement\r\n                    textarea.style.height = 'auto';\r\n                    textarea.style.overflow = 'hidden';\r\n\r\n                    // Calculate the needed height\r\n                    const scrollHeight = textarea.scrollHeight;\r\n                    const lineHeight = parseInt(window.getComputedStyle(textarea).lineHeight) || 20;\r\n                    const paddingTop = parseInt(window.getComputedStyle(textarea).paddingTop) || 0;\r\n                    const paddingBottom = parseInt(window.getComputedStyle(textarea).paddingBottom) || 0;\r\n\r\n                    // Set minimum height to at least one line\r\n                    const minHeight = lineHeight + paddingTop + paddingBottom;\r\n                    const finalHeight = Math.max(scrollHeight, minHeight);\r\n\r\n                    // Apply the calculated height\r\n                    textarea.style.height = finalHeight + 'px';\r\n                    textarea.style.minHeight = finalHeight + 'px';\r\n                    textarea.style.overflow = 'hidden';\r\n                    textarea.style.resize = 'none';\r\n                };\r\n\r\n                // Function to resize all textareas\r\n                const resizeAllTextareas = function() {\r\n                    const textareas = document.querySelectorAll('textarea[disabled]');\r\n                    textareas.forEach(autoResizeTextarea);\r\n                };\r\n\r\n                // Initial resize with multiple attempts to ensure proper rendering\r\n                const initResize = function() {\r\n                    resizeAllTextareas();\r\n\r\n                    // Additional resize attempts to handle dynamic content loading\r\n                    setTimeout(resizeAllTextareas, 50);\r\n                    setTimeout(resizeAllTextareas, 100);\r\n                    setTimeout(resizeAllTextareas, 200);\r\n                    setTimeout(resizeAllTextareas, 300);\r\n                };\r\n\r\n                // Run initial resize\r\n                initResize();\r\n\r\n                // Resize on window resize\r\n                window.addEventListener('resize', resizeAllTextareas);\r\n\r\n                // Also observe for content changes\r\n                const observer = new MutationObserver(function(mutations) {\r\n                    mutations.forEach(function(mutation) {\r\n                        if (mutation.type === 'childList' || mutation.type === 'characterData') {\r\n                            setTimeout(resizeAllTextareas, 10);\r\n                        }\r\n                    });\r\n                });\r\n\r\n                observer.observe(document.body, {\r\n                    childList: true,\r\n                    subtree: true,\r\n                    characterData: true\r\n                });\r\n            }\r\n\r\n            // Auto-save functionality - encapsulated in function scope\r\n            let autoSaveTimer;\r\n            let hasUnsavedChanges = false;\r\n            const AUTOSAVE_DELAY = 3000; // 3 seconds\r\n\r\n            // Modal opening logic\r\n            (function() {\r\n                requestAnimationFrame(function() {\r\n                    requestAnimationFrame(function() {\r\n                        const modal = document.getElementById('reviewer-modal');\r\n                        if (modal) {\r\n                            if (window.modalState && window.modalState.openModalId) {\r\n                                const existingModal = document.getElementById(window.modalState.openModalId);\r\n                                if (existingModal && existingModal !== modal) {\r\n                                    existingModal.style.display = 'none';\r\n                                    existingModal.classList.add('opacity-0');\r\n                                }\r\n                            }\r\n\r\n                            if (!window.modalState) {\r\n                                window.modalState = { openModalId: null };\r\n                            }\r\n\r\n                            window.modalState.openModalId = 'reviewer-modal';\r\n                            document.body.style.overflow = 'hidden';\r\n                            modal.style.display = 'flex';\r\n                            modal.offsetHeight;\r\n                            modal.classList.remove('opacity-0', 'hidden');\r\n                            modal.classList.add('opacity-100');\r\n\r\n                            const content = modal.querySelector('[data-modal-content]');\r\n                            if (content) {\r\n                                content.classList.remove('scale-95', 'opacity-0');\r\n                                content.classList.add('scale-100', 'opacity-100');\r\n                            }\r\n\r\n                            // Initialize auto-save listeners\r\n                            if (!isReadOnly) {\r\n                                initializeAutoSave();\r\n                            }\r\n                        }\r\n                    });\r\n                });\r\n            })();\r\n\r\n            function initializeAutoSave() {\r\n                const form = document.getElementById('compact-reviewer-form');\r\n                if (!form || form.querySelector('[disabled]')) return;\r\n\r\n                const fields = form.querySelectorAll('.auto-save-field');\r\n                fields.forEach(field => {\r\n                    field.addEventListener('input', handleFieldChange);\r\n                    field.addEventListener('change', handleFieldChange);\r\n                });\r\n            }\r\n\r\n            function handleFieldChange() {\r\n                hasUnsavedChanges = true;\r\n                clearTimeout(autoSaveTimer);\r\n                updateSaveStatus('pending');\r\n                autoSaveTimer = setTimeout(() => {\r\n                    autoSave();\r\n                }, AUTOSAVE_DELAY);\r\n            }\r\n\r\n            function updateSaveStatus(status) {\r\n                const saveIcon = document.getElementById('save-icon');\r\n                const saveText = document.getElementById('save-text');\r\n                const lastSaved = document.getElementById('last-saved');\r\n\r\n                switch(status) {\r\n                    case 'pending':\r\n                        saveIcon?.classList.remove('hidden');\r\n                        saveText.textContent = 'Changes detected...';\r\n                        saveText.classList.add('text-yellow-600');\r\n                        break;\r\n                    case 'saving':\r\n                        saveIcon?.classList.remove('hidden');\r\n                        saveText.textContent = 'Saving...';\r\n                        saveText.classList.add('text-blue-600');\r\n                        saveText.classList.remove('text-yellow-600', 'text-green-600');\r\n                        break;\r\n                    case 'saved':\r\n                        saveIcon?.classList.remove('hidden');\r\n                        saveText.textContent = 'All changes saved';\r\n                        saveText.classList.remove('text-blue-600', 'text-yellow-600');\r\n                        saveText.classList.add('text-green-600');\r\n                        const now = new Date();\r\n                        lastSaved.textContent = `Last saved: ${now.toLocaleTimeString()}`;\r\n                        hasUnsavedChanges = false;\r\n                        break;\r\n                    case 'error':\r\n                        saveIcon?.classList.add('hidden');\r\n                        saveText.textContent = 'Error saving';\r\n                        saveText.classList.add('text-red-600');\r\n                        break;\r\n                }\r\n            }\r\n\r\n            function autoSave() {\r\n                const form = document.getElementById('compact-reviewer-form');\r\n                const studentId = form.dataset.studentId;\r\n\r\n                document.getElementById('is_draft').value = 'true';\r\n                updateSaveStatus('saving');\r\n\r\n                let submitUrl;\r\n                if (reviewerAccessToken) {\r\n                    submitUrl = `/reviewer/${reviewerAccessToken}/student/${studentId}/review/submit`;\r\n                } else {\r\n                    submitUrl = `/reviewer-report/${studentId}/save-draft`;\r\n                }\r\n\r\n                const formData = new FormData(form);\r\n\r\n                htmx.ajax('POST', submitUrl, {\r\n                    values: Object.fromEntries(formData),\r\n                    target: '#modal-result',\r\n                    swap: 'innerHTML'\r\n                }).then(() => {\r\n                    updateSaveStatus('saved');\r\n                }).catch(() => {\r\n                    updateSaveStatus('error');\r\n                });\r\n            }\r\n\r\n            // Make functions available in window scope with unique names\r\n            window.reviewerSaveDraft = function() {\r\n                const form = document.getElementById('compact-reviewer-form');\r\n                const studentId = form.dataset.studentId;\r\n\r\n                document.getElementById('is_draft').value = 'true';\r\n                const formData = new FormData(form);\r\n                updateSaveStatus('saving');\r\n\r\n                let submitUrl;\r\n                if (reviewerAccessToken) {\r\n                    submitUrl = `/reviewer/${reviewerAccessToken}/student/${studentId}/review/submit`;\r\n                } else {\r\n                    submitUrl = `/reviewer-report/${studentId}/save-draft`;\r\n                }\r\n\r\n                htmx.ajax('POST', submitUrl, {\r\n                    values: Object.fromEntries(formData),\r\n                    target: '#modal-result',\r\n                    swap: 'innerHTML'\r\n                }).then(() => {\r\n                    updateSaveStatus('saved');\r\n                    setTimeout(() => {\r\n                        showSuccessMessage('Draft saved successfully!');\r\n                    }, 500);\r\n                });\r\n            };\r\n\r\n            window.reviewerValidateAndSubmit = function() {\r\n                document.getElementById('is_draft').value = 'false';\r\n                return validateReviewerForm();\r\n            };\r\n\r\n            function validateReviewerForm() {\r\n                const form = document.getElementById('compact-reviewer-form');\r\n                let isValid = true;\r\n\r\n                document.querySelectorAll('[id$=\"-error\"]').forEach(el => el.classList.add('hidden'));\r\n\r\n                const requiredFields = [\r\n                    'reviewer_personal_details',\r\n                    'review_goals',\r\n                    'review_theory',\r\n                    'review_practical',\r\n                    'review_theory_practical_link',\r\n                    'review_results',\r\n                    'review_language',\r\n                    'review_pros',\r\n                    'review_cons',\r\n                    'review_questions'\r\n                ];\r\n\r\n                for (const fieldName of requiredFields) {\r\n                    const field = form.querySelector(`[name=\"${fieldName}\"]`);\r\n                    if (!field || !field.value.trim()) {\r\n                        isValid = false;\r\n                        field?.classList.add('border-red-500');\r\n                        if (!field?.closest('.border')?.querySelector('.text-red-500')) {\r\n                            field?.focus();\r\n                            break;\r\n                        }\r\n                    } else {\r\n                        field?.classList.remove('border-red-500');\r\n                    }\r\n                }\r\n\r\n                const gradeField = form.querySelector('[name=\"grade\"]');\r\n                const grade = parseFloat(gradeField?.value || '0');\r\n                if (!grade || grade < 1 || grade > 10) {\r\n                    isValid = false;\r\n                    gradeField?.classList.add('border-red-500');\r\n                    document.getElementById('grade-error')?.classList.remove('hidden');\r\n                } else {\r\n                    gradeField?.classList.remove('border-red-500');\r\n                }\r\n\r\n                return isValid;\r\n            }\r\n\r\n            function showSuccessMessage(message) {\r\n                const result = document.getElementById('modal-result');\r\n                result.innerHTML = `\r\n                    <div class=\"bg-green-50 border border-green-200 text-green-800 px-4 py-3 rounded\">\r\n                        <div class=\"flex items-center\">\r\n                            <svg class=\"h-5 w-5 text-green-400 mr-2\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\">\r\n                                <path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M9 12l2 2 4-4m6 2a9 9 0 11-18 0 9 9 0 0118 0z\"></path>\r\n                            </svg>\r\n                            <span>${message}</span>\r\n                        </div>\r\n                    </div>\r\n                `;\r\n\r\n                setTimeout(() => {\r\n                    result.innerHTML = '';\r\n                }, 3000);\r\n            }\r\n\r\n            // Event listeners\r\n            const beforeUnloadHandler = function(e) {\r\n                if (hasUnsavedChanges && !isReadOnly) {\r\n                    e.preventDefault();\r\n                    e.returnValue = '';\r\n                }\r\n            };\r\n            window.addEventListener('beforeunload', beforeUnloadHandler);\r\n\r\n            const htmxAfterRequestHandler = function(evt) {\r\n                if (evt.detail.successful && (\r\n                    evt.target.closest('#modal-result') ||\r\n                    evt.detail.xhr.getResponseHeader('HX-Trigger') === 'reviewerReportSaved'\r\n                )) {\r\n                    hasUnsavedChanges = false;\r\n\r\n                    const isDraft = document.getElementById('is_draft').value === 'true';\r\n\r\n                    if (!isDraft) {\r\n                        const modal = document.getElementById('reviewer-modal');\r\n                        if (modal && window.modalState) {\r\n                            setTimeout(() => {\r\n                                window.modalState.openModalId = null;\r\n                                document.body.style.overflow = '';\r\n                                window.location.reload();\r\n                            }, 300);\r\n                        }\r\n                    }\r\n                }\r\n            };\r\n            document.addEventListener('htmx:afterRequest', htmxAfterRequestHandler);\r\n\r\n            const keydownHandler = function(e) {\r\n                if (e.key === 'Escape' && window.modalState && window.modalState.openModalId === 'reviewer-modal') {\r\n                    if (hasUnsavedChanges && !isReadOnly) {\r\n                        if (confirm('You have unsaved changes. Are you sure you want to close?')) {\r\n                            closeReviewerModal();\r\n                        }\r\n                    } else {\r\n                        closeReviewerModal();\r\n                    }\r\n                }\r\n            };\r\n            document.addEventListener('keydown', keydownHandler);\r\n\r\n            // Store cleanup function\r\n            window.reviewerModalCleanup = function() {\r\n                window.removeEventListener('beforeunload', beforeUnloadHandler);\r\n                document.removeEventListener('htmx:afterRequest', htmxAfterRequestHandler);\r\n                document.removeEventListener('keydown', keydownHandler);\r\n                clearTimeout(autoSaveTimer);\r\n            };\r\n        })();\r\n\r\n        // Global functions that don't conflict\r\n        window.closeReviewerModal = function() {\r\n            // Call cleanup if it exists\r\n            if (window.reviewerModalCleanup) {\r\n                window.reviewerModalCleanup();\r\n            }\r\n\r\n            const modal = document.getElementById('reviewer-modal');\r\n            if (modal) {\r\n                modal.classList.remove('opacity-100');\r\n                modal.classList.add('opacity-0');\r\n                setTimeout(() => {\r\n                    modal.style.display = 'none';\r\n                    document.body.style.overflow = '';\r\n                    if (window.modalState) {\r\n                        window.modalState.openModalId = null;\r\n                    }\r\n\r\n                    // Clean up modal container\r\n                    const modalContainer = document.getElementById('modal-container');\r\n                    if (modalContainer) {\r\n                        modalContainer.innerHTML = '';\r\n                        modalContainer.style.display = 'none';\r\n                    }\r\n                }, 300);\r\n            }\r\n        };\r\n    </script>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 146, ";\n\n            // Auto-resize textareas for read-only mode\n            if (isReadOnly) {\n                const autoResizeTextarea = function(textarea) {\n                    // Store original styles\n                    const originalHeight = textarea.style.height;\n                    const originalOverflow = textarea.style.overflow;\n\n                    // Reset to get accurate measurement\n                    textarea.style.height = 'auto';\n                    textarea.style.overflow = 'hidden';\n\n                    // Calculate the needed height\n                    const scrollHeight = textarea.scrollHeight;\n                    const lineHeight = parseInt(window.getComputedStyle(textarea).lineHeight) || 20;\n                    const paddingTop = parseInt(window.getComputedStyle(textarea).paddingTop) || 0;\n                    const paddingBottom = parseInt(window.getComputedStyle(textarea).paddingBottom) || 0;\n\n                    // Set minimum height to at least one line\n                    const minHeight = lineHeight + paddingTop + paddingBottom;\n                    const finalHeight = Math.max(scrollHeight, minHeight);\n\n                    // Apply the calculated height\n                    textarea.style.height = finalHeight + 'px';\n                    textarea.style.minHeight = finalHeight + 'px';\n                    textarea.style.overflow = 'hidden';\n                    textarea.style.resize = 'none';\n                };\n\n                // Function to resize all textareas\n                const resizeAllTextareas = function() {\n                    const textareas = document.querySelectorAll('textarea[disabled]');\n                    textareas.forEach(autoResizeTextarea);\n                };\n\n                // Initial resize with multiple attempts to ensure proper rendering\n                const initResize = function() {\n                    resizeAllTextareas();\n\n                    // Additional resize attempts to handle dynamic content loading\n                    setTimeout(resizeAllTextareas, 50);\n                    setTimeout(resizeAllTextareas, 100);\n                    setTimeout(resizeAllTextareas, 200);\n                    setTimeout(resizeAllTextareas, 300);\n                };\n\n                // Run initial resize\n                initResize();\n\n                // Resize on window resize\n                window.addEventListener('resize', resizeAllTextareas);\n\n                // Also observe for content changes\n                const observer = new MutationObserver(function(mutations) {\n                    mutations.forEach(function(mutation) {\n                        if (mutation.type === 'childList' || mutation.type === 'characterData') {\n                            setTimeout(resizeAllTextareas, 10);\n                        }\n                    });\n                });\n\n                observer.observe(document.body, {\n                    childList: true,\n                    subtree: true,\n                    characterData: true\n                });\n            }\n\n            // Auto-save functionality - encapsulated in function scope\n            let autoSaveTimer;\n            let hasUnsavedChanges = false;\n            const AUTOSAVE_DELAY = 3000; // 3 seconds\n\n            // Modal opening logic\n            (function() {\n                requestAnimationFrame(function() {\n                    requestAnimationFrame(function() {\n                        const modal = document.getElementById('reviewer-modal');\n                        if (modal) {\n                            if (window.modalState && window.modalState.openModalId) {\n                                const existingModal = document.getElementById(window.modalState.openModalId);\n                                if (existingModal && existingModal !== modal) {\n                                    existingModal.style.display = 'none';\n                                    existingModal.classList.add('opacity-0');\n                                }\n                            }\n\n                            if (!window.modalState) {\n                                window.modalState = { openModalId: null };\n                            }\n\n                            window.modalState.openModalId = 'reviewer-modal';\n                            document.body.style.overflow = 'hidden';\n                            modal.style.display = 'flex';\n                            modal.offsetHeight;\n                            modal.classList.remove('opacity-0', 'hidden');\n                            modal.classList.add('opacity-100');\n\n                            const content = modal.querySelector('[data-modal-content]');\n                            if (content) {\n                                content.classList.remove('scale-95', 'opacity-0');\n                                content.classList.add('scale-100', 'opacity-100');\n                            }\n\n                            // Initialize auto-save listeners\n                            if (!isReadOnly) {\n                                initializeAutoSave();\n                            }\n                        }\n                    });\n                });\n            })();\n\n            function initializeAutoSave() {\n                const form = document.getElementById('compact-reviewer-form');\n                if (!form || form.querySelector('[disabled]')) return;\n\n                const fields = form.querySelectorAll('.auto-save-field');\n                fields.forEach(field => {\n                    field.addEventListener('input', handleFieldChange);\n                    field.addEventListener('change', handleFieldChange);\n                });\n            }\n\n            function handleFieldChange() {\n                hasUnsavedChanges = true;\n                clearTimeout(autoSaveTimer);\n                updateSaveStatus('pending');\n                autoSaveTimer = setTimeout(() => {\n                    autoSave();\n                }, AUTOSAVE_DELAY);\n            }\n\n            function updateSaveStatus(status) {\n                const saveIcon = document.getElementById('save-icon');\n                const saveText = document.getElementById('save-text');\n                const lastSaved = document.getElementById('last-saved');\n\n                switch(status) {\n                    case 'pending':\n                        saveIcon?.classList.remove('hidden');\n                        saveText.textContent = 'Changes detected...';\n                        saveText.classList.add('text-yellow-600');\n                        break;\n                    case 'saving':\n                        saveIcon?.classList.remove('hidden');\n                        saveText.textContent = 'Saving...';\n                        saveText.classList.add('text-blue-600');\n                        saveText.classList.remove('text-yellow-600', 'text-green-600');\n                        break;\n                    case 'saved':\n                        saveIcon?.classList.remove('hidden');\n                        saveText.textContent = 'All changes saved';\n                        saveText.classList.remove('text-blue-600', 'text-yellow-600');\n                        saveText.classList.add('text-green-600');\n                        const now = new Date();\n                        lastSaved.textContent = `Last saved: ${now.toLocaleTimeString()}`;\n                        hasUnsavedChanges = false;\n                        break;\n                    case 'error':\n                        saveIcon?.classList.add('hidden');\n                        saveText.textContent = 'Error saving';\n                        saveText.classList.add('text-red-600');\n                        break;\n                }\n            }\n\n            function autoSave() {\n                const form = document.getElementById('compact-reviewer-form');\n                const studentId = form.dataset.studentId;\n\n                document.getElementById('is_draft').value = 'true';\n                updateSaveStatus('saving');\n\n                let submitUrl;\n                if (reviewerAccessToken) {\n                    submitUrl = `/reviewer/${reviewerAccessToken}/student/${studentId}/review/submit`;\n                } else {\n                    submitUrl = `/reviewer-report/${studentId}/save-draft`;\n                }\n\n                const formData = new FormData(form);\n\n                htmx.ajax('POST', submitUrl, {\n                    values: Object.fromEntries(formData),\n                    target: '#modal-result',\n                    swap: 'innerHTML'\n                }).then(() => {\n                    updateSaveStatus('saved');\n                }).catch(() => {\n                    updateSaveStatus('error');\n                });\n            }\n\n            // Make functions available in window scope with unique names\n            window.reviewerSaveDraft = function() {\n                const form = document.getElementById('compact-reviewer-form');\n                const studentId = form.dataset.studentId;\n\n                document.getElementById('is_draft').value = 'true';\n                const formData = new FormData(form);\n                updateSaveStatus('saving');\n\n                let submitUrl;\n                if (reviewerAccessToken) {\n                    submitUrl = `/reviewer/${reviewerAccessToken}/student/${studentId}/review/submit`;\n                } else {\n                    submitUrl = `/reviewer-report/${studentId}/save-draft`;\n                }\n\n                htmx.ajax('POST', submitUrl, {\n                    values: Object.fromEntries(formData),\n                    target: '#modal-result',\n                    swap: 'innerHTML'\n                }).then(() => {\n                    updateSaveStatus('saved');\n                    setTimeout(() => {\n                        showSuccessMessage('Draft saved successfully!');\n                    }, 500);\n                });\n            };\n\n            window.reviewerValidateAndSubmit = function() {\n                document.getElementById('is_draft').value = 'false';\n                return validateReviewerForm();\n            };\n\n            function validateReviewerForm() {\n                const form = document.getElementById('compact-reviewer-form');\n                let isValid = true;\n\n                document.querySelectorAll('[id$=\"-error\"]').forEach(el => el.classList.add('hidden'));\n\n                const requiredFields = [\n                    'reviewer_personal_details',\n                    'review_goals',\n                    'review_theory',\n                    'review_practical',\n                    'review_theory_practical_link',\n                    'review_results',\n                    'review_language',\n                    'review_pros',\n                    'review_cons',\n                    'review_questions'\n                ];\n\n                for (const fieldName of requiredFields) {\n                    const field = form.querySelector(`[name=\"${fieldName}\"]`);\n                    if (!field || !field.value.trim()) {\n                        isValid = false;\n                        field?.classList.add('border-red-500');\n                        if (!field?.closest('.border')?.querySelector('.text-red-500')) {\n                            field?.focus();\n                            break;\n                        }\n                    } else {\n                        field?.classList.remove('border-red-500');\n                    }\n                }\n\n                const gradeField = form.querySelector('[name=\"grade\"]');\n                const grade = parseFloat(gradeField?.value || '0');\n                if (!grade || grade < 1 || grade > 10) {\n                    isValid = false;\n                    gradeField?.classList.add('border-red-500');\n                    document.getElementById('grade-error')?.classList.remove('hidden');\n                } else {\n                    gradeField?.classList.remove('border-red-500');\n                }\n\n                return isValid;\n            }\n\n            function showSuccessMessage(message) {\n                const result = document.getElementById('modal-result');\n                result.innerHTML = `\n                    <div class=\"bg-green-50 border border-green-200 text-green-800 px-4 py-3 rounded\">\n                        <div class=\"flex items-center\">\n                            <svg class=\"h-5 w-5 text-green-400 mr-2\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\">\n                                <path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M9 12l2 2 4-4m6 2a9 9 0 11-18 0 9 9 0 0118 0z\"></path>\n                            </svg>\n                            <span>${message}</span>\n                        </div>\n                    </div>\n                `;\n\n                setTimeout(() => {\n                    result.innerHTML = '';\n                }, 3000);\n            }\n\n            // Event listeners\n            const beforeUnloadHandler = function(e) {\n                if (hasUnsavedChanges && !isReadOnly) {\n                    e.preventDefault();\n                    e.returnValue = '';\n                }\n            };\n            window.addEventListener('beforeunload', beforeUnloadHandler);\n\n            const htmxAfterRequestHandler = function(evt) {\n                if (evt.detail.successful && (\n                    evt.target.closest('#modal-result') ||\n                    evt.detail.xhr.getResponseHeader('HX-Trigger') === 'reviewerReportSaved'\n                )) {\n                    hasUnsavedChanges = false;\n\n                    const isDraft = document.getElementById('is_draft').value === 'true';\n\n                    if (!isDraft) {\n                        const modal = document.getElementById('reviewer-modal');\n                        if (modal && window.modalState) {\n                            setTimeout(() => {\n                                window.modalState.openModalId = null;\n                                document.body.style.overflow = '';\n                                window.location.reload();\n                            }, 300);\n                        }\n                    }\n                }\n            };\n            document.addEventListener('htmx:afterRequest', htmxAfterRequestHandler);\n\n            const keydownHandler = function(e) {\n                if (e.key === 'Escape' && window.modalState && window.modalState.openModalId === 'reviewer-modal') {\n                    if (hasUnsavedChanges && !isReadOnly) {\n                        if (confirm('You have unsaved changes. Are you sure you want to close?')) {\n                            closeReviewerModal();\n                        }\n                    } else {\n                        closeReviewerModal();\n                    }\n                }\n            };\n            document.addEventListener('keydown', keydownHandler);\n\n            // Store cleanup function\n            window.reviewerModalCleanup = function() {\n                window.removeEventListener('beforeunload', beforeUnloadHandler);\n                document.removeEventListener('htmx:afterRequest', htmxAfterRequestHandler);\n                document.removeEventListener('keydown', keydownHandler);\n                clearTimeout(autoSaveTimer);\n            };\n        })();\n\n        // Global functions that don't conflict\n        window.closeReviewerModal = function() {\n            // Call cleanup if it exists\n            if (window.reviewerModalCleanup) {\n                window.reviewerModalCleanup();\n            }\n\n            const modal = document.getElementById('reviewer-modal');\n            if (modal) {\n                modal.classList.remove('opacity-100');\n                modal.classList.add('opacity-0');\n                setTimeout(() => {\n                    modal.style.display = 'none';\n                    document.body.style.overflow = '';\n                    if (window.modalState) {\n                        window.modalState.openModalId = null;\n                    }\n\n                    // Clean up modal container\n                    const modalContainer = document.getElementById('modal-container');\n                    if (modalContainer) {\n                        modalContainer.innerHTML = '';\n                        modalContainer.style.display = 'none';\n                    }\n                }, 300);\n            }\n        };\n    </script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1796,4 +1955,11 @@ func getInputClass(isReadOnly bool) string {
 	return "w-20 text-center auto-save-field"
 }
 
+func reviewerReportPDFURL(props database.ReviewerReportFormProps) string {
+	if props.AccessToken != "" {
+		return fmt.Sprintf("/reviewer/%s/student/%d/review/pdf?lang=%s", props.AccessToken, props.StudentRecord.ID, props.FormVariant)
+	}
+	return fmt.Sprintf("/api/reports/reviewer/%d/pdf?lang=%s", props.StudentRecord.ID, props.FormVariant)
+}
+
 var _ = templruntime.GeneratedTemplate
@@ -164,7 +164,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					var templ_7745c5c3_Var5 string
 					templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("/supervisor-report/%d/submit", props.StudentRecord.ID))
 					if templ_7745c5c3_Err != nil {
-						return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/supervisor_form_compact.templ`, Line: 66, Col: 113}
+						return templ.Error{Err: templ_7745c5c3_Err, FileName: `supervisor_form_compact.templ`, Line: 66, Col: 113}
 					}
 					_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 					if templ_7745c5c3_Err != nil {
@@ -182,7 +182,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 				var templ_7745c5c3_Var6 string
 				templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", props.StudentRecord.ID))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/supervisor_form_compact.templ`, Line: 71, Col: 91}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `supervisor_form_compact.templ`, Line: 71, Col: 91}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 				if templ_7745c5c3_Err != nil {
@@ -210,7 +210,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 				var templ_7745c5c3_Var7 string
 				templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(props.StudentRecord.StudentGroup)
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/supervisor_form_compact.templ`, Line: 86, Col: 60}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `supervisor_form_compact.templ`, Line: 86, Col: 60}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 				if templ_7745c5c3_Err != nil {
@@ -238,7 +238,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 				var templ_7745c5c3_Var8 string
 				templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(props.StudentRecord.GetFullName())
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/supervisor_form_compact.templ`, Line: 96, Col: 61}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `supervisor_form_compact.templ`, Line: 96, Col: 61}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 				if templ_7745c5c3_Err != nil {
@@ -266,7 +266,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 				var templ_7745c5c3_Var9 string
 				templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(props.StudentRecord.GetLocalizedTitle(props.FormVariant))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/supervisor_form_compact.templ`, Line: 106, Col: 98}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `supervisor_form_compact.templ`, Line: 106, Col: 98}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
 				if templ_7745c5c3_Err != nil {
@@ -378,17 +378,112 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 							return templ_7745c5c3_Err
 						}
 					}
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, " ")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					if !props.IsReadOnly {
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, "<div class=\"flex items-center gap-2 text-sm\">")
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						if len(props.CommentSnippets) > 0 {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, "<select onchange=\"insertCommentSnippet(this)\" class=\"border rounded-md px-2 py-1 text-sm\"><option value=\"\">")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+							if props.FormVariant == "en" {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, "Insert snippet…")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							} else {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, "Įterpti šabloną…")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							}
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, "</option> ")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+							for _, snippet := range props.CommentSnippets {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, "<option value=\"")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+								var templ_7745c5c3_Var13 string
+								templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(snippet.Render(&props.StudentRecord, props.FormVariant))
+								if templ_7745c5c3_Err != nil {
+									return templ.Error{Err: templ_7745c5c3_Err, FileName: `supervisor_form_compact.templ`, Line: 155, Col: 82}
+								}
+								_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, "\">")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+								var templ_7745c5c3_Var14 string
+								templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(snippet.Title)
+								if templ_7745c5c3_Err != nil {
+									return templ.Error{Err: templ_7745c5c3_Err, FileName: `supervisor_form_compact.templ`, Line: 155, Col: 100}
+								}
+								_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var14))
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, "</option>")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							}
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, "</select> ")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						}
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, "<a href=\"")
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						var templ_7745c5c3_Var15 templ.SafeURL = templ.SafeURL(fmt.Sprintf("/report-templates?locale=%s", props.FormVariant))
+						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(string(templ_7745c5c3_Var15)))
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, "\" target=\"_blank\" class=\"text-blue-600 hover:underline text-xs\">")
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						if props.FormVariant == "en" {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, "Manage snippets")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						} else {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 51, "Tvarkyti šablonus")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						}
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 52, "</a></div>")
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+					}
 					return nil
 				})
 				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var10), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, "<!-- Defense Eligibility --><div class=\"border rounded-lg p-3\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 53, "<!-- Defense Eligibility --><div class=\"border rounded-lg p-3\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var13 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				templ_7745c5c3_Var16 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -400,7 +495,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var14 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var17 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -413,147 +508,155 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 39, "Defense Eligibility")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 54, "Defense Eligibility")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 40, "Gynimo tinkamumas")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 55, "Gynimo tinkamumas")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						}
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 41, " ")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, " ")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 						if !props.IsReadOnly {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 42, "<span class=\"text-red-500 ml-1\">*</span>")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 57, "<span class=\"text-red-500 ml-1\">*</span>")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						}
 						return nil
 					})
-					templ_7745c5c3_Err = form.Label(form.LabelProps{}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var14), templ_7745c5c3_Buffer)
+					templ_7745c5c3_Err = form.Label(form.LabelProps{}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var17), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 43, " <div class=\"space-y-2 mt-2\"><label class=\"flex items-start gap-2\"><input type=\"radio\" id=\"is_pass_or_failed_true\" name=\"is_pass_or_failed\" value=\"true\"")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 58, " <div class=\"space-y-2 mt-2\"><label class=\"flex items-start gap-2\"><input type=\"radio\" id=\"is_pass_or_failed_true\" name=\"is_pass_or_failed\" value=\"true\"")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					if formData.IsPassOrFailed {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 44, " checked")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 59, " checked")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 45, " class=\"form-radio h-4 w-4 text-blue-600 mt-0.5 auto-save-field\"")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 60, " class=\"form-radio h-4 w-4 text-blue-600 mt-0.5 auto-save-field\"")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					if props.IsReadOnly {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 46, " disabled")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, " disabled")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 47, "> <span class=\"text-sm\">")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "> <span class=\"text-sm\">")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					if props.FormVariant == "en" {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 48, "Thesis is suitable for defense at the Final Thesis Defense Commission meeting.")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, "Thesis is suitable for defense at the Final Thesis Defense Commission meeting.")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					} else {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 49, "Baigiamasis darbas tinkamas ginti Baigiamųjų darbų gynimo komisijos posėdyje.")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, "Baigiamasis darbas tinkamas ginti Baigiamųjų darbų gynimo komisijos posėdyje.")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 50, "</span></label> <label class=\"flex items-start gap-2\"><input type=\"radio\" id=\"is_pass_or_failed_false\" name=\"is_pass_or_failed\" value=\"false\"")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, "</span></label> <label class=\"flex items-start gap-2\"><input type=\"radio\" id=\"is_pass_or_failed_false\" name=\"is_pass_or_failed\" value=\"false\"")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					if !formData.IsPassOrFailed {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 51, " checked")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 66, " checked")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 52, " class=\"form-radio h-4 w-4 text-blue-600 mt-0.5 auto-save-field\"")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 67, " class=\"form-radio h-4 w-4 text-blue-600 mt-0.5 auto-save-field\"")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					if props.IsReadOnly {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 53, " disabled")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 68, " disabled")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 54, "> <span class=\"text-sm\">")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 69, "> <span class=\"text-sm\">")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					if props.FormVariant == "en" {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 55, "Thesis is not suitable for defense at the Final Thesis Defense Commission meeting due to plagiarism detection.")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 70, "Thesis is not suitable for defense at the Final Thesis Defense Commission meeting due to plagiarism detection.")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					} else {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, "Baigiamasis darbas netinkamas ginti Baigiamųjų darbų gynimo komisijos posėdyje dėl plagiato fakto nustatymo.")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 71, "Baigiamasis darbas netinkamas ginti Baigiamųjų darbų gynimo komisijos posėdyje dėl plagiato fakto nustatymo.")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 57, "</span></label></div>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 72, "</span></label></div>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var13), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var16), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 58, "</div><!-- Plagiarism Analysis --><div class=\"border rounded-lg p-3\"><h4 class=\"text-sm font-medium mb-3\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 73, "</div><!-- Plagiarism Analysis --><div class=\"border rounded-lg p-3\"><h4 class=\"text-sm font-medium mb-3\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 59, "Plagiarism Analysis")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 74, "Plagiarism Analysis")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 60, "Plagiato analizė")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 75, "Plagiato analizė")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, "</h4><p class=\"text-sm text-gray-600 mb-3\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 76, "</h4><p class=\"text-sm text-gray-600 mb-3\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "Determined similarity with other works:")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 77, "Determined similarity with other works:")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, "Nustatyta sutaptis su kitais darbais:")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 78, "Nustatyta sutaptis su kitais darbais:")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, "</p><div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 79, "</p>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = SimilarityReportUpload(props, formData).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 80, "<div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var15 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				templ_7745c5c3_Var18 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -565,7 +668,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var16 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var19 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -578,12 +681,12 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, "Total similarity")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 81, "Total similarity")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 66, "Bendra sutaptis")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 82, "Bendra sutaptis")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -592,11 +695,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					})
 					templ_7745c5c3_Err = form.Label(form.LabelProps{
 						For: "other_match",
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var16), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var19), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 67, " <div class=\"flex items-center gap-2\">")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 83, " <div class=\"flex items-center gap-2\">")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -616,32 +719,32 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 68, "<span class=\"text-sm\">")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 84, "<span class=\"text-sm\">")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					if props.FormVariant == "en" {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 69, "% of total work")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 85, "% of total work")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					} else {
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 70, "% viso darbo")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 86, "% viso darbo")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 71, "</span></div>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 87, "</span></div>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var15), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var18), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var17 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				templ_7745c5c3_Var20 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -653,7 +756,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var18 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var21 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -666,12 +769,12 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 72, "Similarity with one source")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 88, "Similarity with one source")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 73, "Sutaptis su vienu šaltiniu")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 89, "Sutaptis su vienu šaltiniu")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -680,11 +783,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					})
 					templ_7745c5c3_Err = form.Label(form.LabelProps{
 						For: "one_match",
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var18), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var21), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 74, " <div class=\"flex items-center gap-2\">")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 90, " <div class=\"flex items-center gap-2\">")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -704,17 +807,17 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 75, "<span class=\"text-sm\">%</span></div>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 91, "<span class=\"text-sm\">%</span></div>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var17), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var20), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var19 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				templ_7745c5c3_Var22 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -726,7 +829,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var20 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var23 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -739,12 +842,12 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 76, "Own previous works")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 92, "Own previous works")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 77, "Savi ankstesni darbai")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 93, "Savi ankstesni darbai")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -753,11 +856,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					})
 					templ_7745c5c3_Err = form.Label(form.LabelProps{
 						For: "own_match",
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var20), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var23), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 78, " <div class=\"flex items-center gap-2\">")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 94, " <div class=\"flex items-center gap-2\">")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -777,17 +880,17 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 79, "<span class=\"text-sm\">%</span></div>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 95, "<span class=\"text-sm\">%</span></div>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var19), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var22), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var21 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				templ_7745c5c3_Var24 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -799,7 +902,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var22 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var25 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -812,12 +915,12 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 80, "Joint work authors")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 96, "Joint work authors")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 81, "Bendri autoriai")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 97, "Bendri autoriai")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -826,11 +929,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					})
 					templ_7745c5c3_Err = form.Label(form.LabelProps{
 						For: "join_match",
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var22), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var25), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 82, " <div class=\"flex items-center gap-2\">")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 98, " <div class=\"flex items-center gap-2\">")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -850,64 +953,64 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 83, "<span class=\"text-sm\">%</span></div>")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 99, "<span class=\"text-sm\">%</span></div>")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var21), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var24), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 84, "</div></div><!-- Supervisor Info --><div class=\"border rounded-lg p-3\"><h4 class=\"text-sm font-medium mb-3\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 100, "</div></div><!-- Supervisor Info --><div class=\"border rounded-lg p-3\"><h4 class=\"text-sm font-medium mb-3\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 85, "Supervisor Information")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 101, "Supervisor Information")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 86, "Vadovo informacija")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 102, "Vadovo informacija")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 87, "</h4><div class=\"text-sm text-gray-700 dark:text-gray-300 mb-3\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 103, "</h4><div class=\"text-sm text-gray-700 dark:text-gray-300 mb-3\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if props.FormVariant == "en" {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 88, "Thesis supervisor: ")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 104, "Thesis supervisor: ")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				} else {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 89, "Baigiamojo darbo vadovas: ")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 105, "Baigiamojo darbo vadovas: ")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 90, "<span class=\"font-medium ml-2\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 106, "<span class=\"font-medium ml-2\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				var templ_7745c5c3_Var23 string
-				templ_7745c5c3_Var23, templ_7745c5c3_Err = templ.JoinStringErrs(props.CurrentSupervisorName)
+				var templ_7745c5c3_Var26 string
+				templ_7745c5c3_Var26, templ_7745c5c3_Err = templ.JoinStringErrs(props.CurrentSupervisorName)
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `components/templates/supervisor_form_compact.templ`, Line: 355, Col: 67}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `supervisor_form_compact.templ`, Line: 381, Col: 67}
 				}
-				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var23))
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var26))
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 91, "</span></div><div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 107, "</span></div><div class=\"grid grid-cols-1 md:grid-cols-2 gap-4\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var24 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				templ_7745c5c3_Var27 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -919,7 +1022,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var25 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var28 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -932,22 +1035,22 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 92, "Workplace")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 108, "Workplace")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 93, "Darbovietė")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 109, "Darbovietė")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						}
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 94, " ")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 110, " ")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 						if !props.IsReadOnly {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 95, "<span class=\"text-red-500 ml-1\">*</span>")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 111, "<span class=\"text-red-500 ml-1\">*</span>")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -956,11 +1059,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					})
 					templ_7745c5c3_Err = form.Label(form.LabelProps{
 						For: "supervisor_workplace",
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var25), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var28), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 96, " ")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 112, " ")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -978,11 +1081,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var24), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var27), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var26 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				templ_7745c5c3_Var29 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -994,7 +1097,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var27 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var30 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -1007,22 +1110,22 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 97, "Position")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 113, "Position")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 98, "Pareigos")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 114, "Pareigos")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						}
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 99, " ")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 115, " ")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 						if !props.IsReadOnly {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 100, "<span class=\"text-red-500 ml-1\">*</span>")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 116, "<span class=\"text-red-500 ml-1\">*</span>")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -1031,11 +1134,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					})
 					templ_7745c5c3_Err = form.Label(form.LabelProps{
 						For: "supervisor_position",
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var27), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var30), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 101, " ")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 117, " ")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
@@ -1053,11 +1156,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var26), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = form.Item().Render(templ.WithChildren(ctx, templ_7745c5c3_Var29), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 102, "</div></div><!-- Date --><div class=\"text-center text-sm text-gray-600 dark:text-gray-400 pt-3 border-t\"><span id=\"current-date\"></span></div></form><div id=\"modal-result\" class=\"mt-3\"></div></div>")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 118, "</div></div><!-- Date --><div class=\"text-center text-sm text-gray-600 dark:text-gray-400 pt-3 border-t\"><span id=\"current-date\"></span></div></form><div id=\"modal-result\" class=\"mt-3\"></div></div>")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
@@ -1067,11 +1170,11 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 103, " ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 119, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Var28 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+			templ_7745c5c3_Var31 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 				templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 				templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 				if !templ_7745c5c3_IsBuffer {
@@ -1083,11 +1186,164 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					}()
 				}
 				ctx = templ.InitializeContext(ctx)
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 104, "<div class=\"border-t pt-2 px-6 pb-2\"><div class=\"flex flex-wrap justify-end gap-2\">")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 120, "<div class=\"border-t pt-2 px-6 pb-2\"><div class=\"flex flex-wrap justify-end gap-2\">")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Var29 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+				if props.InitialReport != nil && props.InitialReport.IsSigned {
+					templ_7745c5c3_Var32 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+						if !templ_7745c5c3_IsBuffer {
+							defer func() {
+								templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+								if templ_7745c5c3_Err == nil {
+									templ_7745c5c3_Err = templ_7745c5c3_BufErr
+								}
+							}()
+						}
+						ctx = templ.InitializeContext(ctx)
+						templ_7745c5c3_Err = icon.Download(icon.Props{Size: 14, Class: "mr-1"}).Render(ctx, templ_7745c5c3_Buffer)
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 121, " PDF")
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						return nil
+					})
+					templ_7745c5c3_Err = button.Button(button.Props{
+						Variant: button.VariantOutline,
+						Class:   "h-9 px-4 text-sm",
+						Href:    fmt.Sprintf("/api/reports/supervisor/%d/pdf?lang=%s", props.StudentRecord.ID, props.FormVariant),
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var32), templ_7745c5c3_Buffer)
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 122, " ")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					templ_7745c5c3_Var33 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+						if !templ_7745c5c3_IsBuffer {
+							defer func() {
+								templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+								if templ_7745c5c3_Err == nil {
+									templ_7745c5c3_Err = templ_7745c5c3_BufErr
+								}
+							}()
+						}
+						ctx = templ.InitializeContext(ctx)
+						if props.FormVariant == "en" {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 123, "Verify signature")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						} else {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 124, "Tikrinti parašą")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						}
+						return nil
+					})
+					templ_7745c5c3_Err = button.Button(button.Props{
+						Variant: button.VariantOutline,
+						Class:   "h-9 px-4 text-sm",
+						Attributes: templ.Attributes{
+							"hx-get":    fmt.Sprintf("/api/reports/supervisor/%d/verify", props.StudentRecord.ID),
+							"hx-target": "#modal-result",
+						},
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var33), templ_7745c5c3_Buffer)
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 125, " ")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					if props.CanUnlock {
+						templ_7745c5c3_Var34 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+							templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+							templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+							if !templ_7745c5c3_IsBuffer {
+								defer func() {
+									templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+									if templ_7745c5c3_Err == nil {
+										templ_7745c5c3_Err = templ_7745c5c3_BufErr
+									}
+								}()
+							}
+							ctx = templ.InitializeContext(ctx)
+							if props.FormVariant == "en" {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 126, "Unlock")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							} else {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 127, "Atrakinti")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							}
+							return nil
+						})
+						templ_7745c5c3_Err = button.Button(button.Props{
+							Variant: button.VariantDestructive,
+							Class:   "h-9 px-4 text-sm",
+							Attributes: templ.Attributes{
+								"hx-post":   fmt.Sprintf("/api/reports/supervisor/%d/unlock", props.StudentRecord.ID),
+								"hx-prompt": getUnlockPrompt(props.FormVariant),
+								"hx-target": "#modal-result",
+							},
+						}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var34), templ_7745c5c3_Buffer)
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+					}
+				}
+				if props.CanViewHistory {
+					templ_7745c5c3_Var35 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+						if !templ_7745c5c3_IsBuffer {
+							defer func() {
+								templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+								if templ_7745c5c3_Err == nil {
+									templ_7745c5c3_Err = templ_7745c5c3_BufErr
+								}
+							}()
+						}
+						ctx = templ.InitializeContext(ctx)
+						if props.FormVariant == "en" {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 128, "Version history")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						} else {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 129, "Versijų istorija")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						}
+						return nil
+					})
+					templ_7745c5c3_Err = button.Button(button.Props{
+						Variant: button.VariantOutline,
+						Class:   "h-9 px-4 text-sm",
+						Attributes: templ.Attributes{
+							"hx-get":    fmt.Sprintf("/api/reports/supervisor/%d/versions?locale=%s", props.StudentRecord.ID, props.FormVariant),
+							"hx-target": "#modal-result",
+						},
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var35), templ_7745c5c3_Buffer)
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+				}
+				templ_7745c5c3_Var36 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 					templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 					templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 					if !templ_7745c5c3_IsBuffer {
@@ -1099,7 +1355,7 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}()
 					}
 					ctx = templ.InitializeContext(ctx)
-					templ_7745c5c3_Var30 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var37 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -1112,12 +1368,12 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 105, "Close")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 130, "Close")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 106, "Uždaryti")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 131, "Uždaryti")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -1127,22 +1383,22 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 					templ_7745c5c3_Err = button.Button(button.Props{
 						Variant: button.VariantGhost,
 						Class:   "h-9 px-4 text-sm",
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var30), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var37), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 					return nil
 				})
-				templ_7745c5c3_Err = modal.Close(modal.CloseProps{ModalID: "supervisor-modal"}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var29), templ_7745c5c3_Buffer)
+				templ_7745c5c3_Err = modal.Close(modal.CloseProps{ModalID: "supervisor-modal"}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var36), templ_7745c5c3_Buffer)
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				if !props.IsReadOnly {
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 107, "<!-- Save as Draft button --> ")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 132, "<!-- Save as Draft button --> ")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Var31 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var38 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -1158,22 +1414,22 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 108, " <span class=\"ml-1\">")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 133, " <span class=\"ml-1\">")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 109, "Save Draft")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 134, "Save Draft")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 110, "Išsaugoti juodraštį")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 135, "Išsaugoti juodraštį")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						}
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 111, "</span>")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 136, "</span>")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
@@ -1185,15 +1441,15 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						Attributes: templ.Attributes{
 							"onclick": "saveSupervisorDraft()",
 						},
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var31), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var38), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 112, " <!-- Submit button --> ")
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 137, " <!-- Submit button --> ")
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
-					templ_7745c5c3_Var32 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+					templ_7745c5c3_Var39 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 						templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 						templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 						if !templ_7745c5c3_IsBuffer {
@@ -1206,12 +1462,12 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 						}
 						ctx = templ.InitializeContext(ctx)
 						if props.FormVariant == "en" {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 113, "💾 Confirm and Submit")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 138, "💾 Confirm and Submit")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
 						} else {
-							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 114, "💾 Patvirtinti ir pateikti")
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 139, "💾 Patvirtinti ir pateikti")
 							if templ_7745c5c3_Err != nil {
 								return templ_7745c5c3_Err
 							}
@@ -1226,18 +1482,18 @@ func CompactSupervisorForm(props database.SupervisorReportFormProps, formData *d
 							"form":    "compact-supervisor-form",
 							"onclick": "return validateAndSubmitSupervisor()",
 						},
-					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var32), templ_7745c5c3_Buffer)
+					}).Render(templ.WithChildren(ctx, templ_7745c5c3_Var39), templ_7745c5c3_Buffer)
 					if templ_7745c5c3_Err != nil {
 						return templ_7745c5c3_Err
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 115, "</div></div>")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 140, "</div></div>")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 				return nil
 			})
-			templ_7745c5c3_Err = modal.Footer().Render(templ.WithChildren(ctx, templ_7745c5c3_Var28), templ_7745c5c3_Buffer)
+			templ_7745c5c3_Err = modal.Footer().Render(templ.WithChildren(ctx, templ_7745c5c3_Var31), templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -1271,12 +1527,12 @@ func SupervisorModalScripts() templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var33 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var33 == nil {
-			templ_7745c5c3_Var33 = templ.NopComponent
+		templ_7745c5c3_Var40 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var40 == nil {
+			templ_7745c5c3_Var40 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 116, "<script>\r\n        console.log('SupervisorModalScripts: Starting initialization');\r\n\r\n        // Wrap everything in an IIFE and use a namespace to avoid global scope pollution\r\n        (function() {\r\n            // Create namespace for supervisor form\r\n            window.supervisorForm = {\r\n                autoSaveTimer: null,\r\n                hasUnsavedChanges: false,\r\n                AUTOSAVE_DELAY: 3000\r\n            };\r\n\r\n            // Set current date SAFELY\r\n            function setCurrentDate() {\r\n                const dateElement = document.getElementById('current-date');\r\n                if (dateElement) {\r\n                    dateElement.textContent = new Date().toLocaleDateString('lt-LT');\r\n                }\r\n            }\r\n\r\n            // SAFE modal initialization with proper element checking\r\n            function initializeModal() {\r\n                const modal = document.getElementById('supervisor-modal');\r\n                if (!modal) {\r\n                    console.log('SupervisorModalScripts: Modal not found, retrying...');\r\n                    setTimeout(initializeModal, 100);\r\n                    return;\r\n                }\r\n\r\n                console.log('SupervisorModalScripts: Initializing modal without z-index changes');\r\n\r\n                modal.classList.remove('opacity-0', 'hidden');\r\n                modal.classList.add('opacity-100');\r\n\r\n                const content = modal.querySelector('[data-modal-content]');\r\n                if (content) {\r\n                    content.classList.remove('scale-95', 'opacity-0');\r\n                    content.classList.add('scale-100', 'opacity-100');\r\n                }\r\n\r\n                // SAFE initialization of auto-save\r\n                setTimeout(() => {\r\n                    initializeSupervisorAutoSave();\r\n                    setCurrentDate();\r\n                    initializeCharCount();\r\n                }, 200);\r\n\r\n                console.log('SupervisorModalScripts: Modal initialized successfully');\r\n            }\r\n\r\n            // SAFE auto-save initialization with null checks\r\n            function initializeSupervisorAutoSave() {\r\n                const form = document.getElementById('compact-supervisor-form');\r\n                if (!form) {\r\n                    console.log('SupervisorModalScripts: Form not found');\r\n                    return;\r\n                }\r\n\r\n                if (form.querySelector('[disabled]')) {\r\n                    console.log('SupervisorModalScripts: Form is disabled, skipping auto-save');\r\n                    return;\r\n                }\r\n\r\n                const fields = form.querySelectorAll('.auto-save-field');\r\n                console.log('SupervisorModalScripts: Found', fields.length, 'auto-save fields');\r\n\r\n                fields.forEach((field, index) => {\r\n                    if (!field) {\r\n                        console.log('SupervisorModalScripts: Field', index, 'is null, skipping');\r\n                        return;\r\n                    }\r\n\r\n                    try {\r\n                        // Clone node to remove existing listeners\r\n                        const newField = field.cloneNode(true);\r\n                        if (field.parentNode) {\r\n                            field.parentNode.replaceChild(newField, field);\r\n\r\n                            // Add new listeners SAFELY\r\n                            newField.addEventListener('input', handleSupervisorFieldChange);\r\n                            newField.addEventListener('change', handleSupervisorFieldChange);\r\n                        }\r\n                    } catch (error) {\r\n                        console.error('SupervisorModalScripts: Error setting up field', index, error);\r\n                    }\r\n                });\r\n            }\r\n\r\n            function initializeCharCount() {\r\n                const textarea = document.getElementById('supervisor_comments');\r\n                if (textarea && !textarea.disabled && window.updateCharCount) {\r\n                    window.updateCharCount(textarea);\r\n                }\r\n            }\r\n\r\n            function handleSupervisorFieldChange() {\r\n                window.supervisorForm.hasUnsavedChanges = true;\r\n                clearTimeout(window.supervisorForm.autoSaveTimer);\r\n                window.updateSupervisorSaveStatus('pending');\r\n                window.supervisorForm.autoSaveTimer = setTimeout(() => {\r\n                    window.supervisorAutoSave();\r\n                }, window.supervisorForm.AUTOSAVE_DELAY);\r\n            }\r\n\r\n            // Make functions global\r\n            window.initializeSupervisorAutoSave = initializeSupervisorAutoSave;\r\n            window.handleSupervisorFieldChange = handleSupervisorFieldChange;\r\n\r\n            window.updateSupervisorSaveStatus = function(status) {\r\n                const saveIcon = document.getElementById('save-icon');\r\n                const saveText = document.getElementById('save-text');\r\n                const lastSaved = document.getElementById('last-saved');\r\n\r\n                if (!saveText) return;\r\n\r\n                switch(status) {\r\n                    case 'pending':\r\n                        if (saveIcon) saveIcon.classList.remove('hidden');\r\n                        saveText.textContent = 'Changes detected...';\r\n                        saveText.classList.add('text-yellow-600');\r\n                        saveText.classList.remove('text-green-600', 'text-red-600');\r\n                        break;\r\n                    case 'saving':\r\n                        if (saveIcon) saveIcon.classList.remove('hidden');\r\n                        saveText.textContent = 'Saving...';\r\n                        saveText.classList.add('text-blue-600');\r\n                        saveText.classList.remove('text-yellow-600', 'text-green-600');\r\n                        break;\r\n                    case 'saved':\r\n                        if (saveIcon) saveIcon.classList.remove('hidden');\r\n                        saveText.textContent = 'All changes saved';\r\n                        saveText.classList.remove('text-blue-600', 'text-yellow-600');\r\n                        saveText.classList.add('text-green-600');\r\n                        const now = new Date();\r\n                        if (lastSaved) {\r\n                            lastSaved.textContent = `Last saved: ${now.toLocaleTimeString()}`;\r\n                        }\r\n                        window.supervisorForm.hasUnsavedChanges = false;\r\n                        break;\r\n                    case 'error':\r\n                        if (saveIcon) saveIcon.classList.add('hidden');\r\n                        saveText.textContent = 'Error saving';\r\n                        saveText.classList.add('text-red-600');\r\n                        break;\r\n                }\r\n            };\r\n\r\n            window.supervisorAutoSave = function() {\r\n                const form = document.getElementById('compact-supervisor-form');\r\n                if (!form) return;\r\n\r\n                const studentId = form.dataset.studentId;\r\n                const draftInput = document.getElementById('is_draft');\r\n                if (draftInput) draftInput.value = 'true';\r\n\r\n                window.updateSupervisorSaveStatus('saving');\r\n                const formData = new FormData(form);\r\n\r\n                htmx.ajax('POST', `/supervisor-report/${studentId}/save-draft`, {\r\n                    values: Object.fromEntries(formData),\r\n                    target: '#modal-result',\r\n                    swap: 'innerHTML'\r\n                }).then(() => {\r\n                    window.updateSupervisorSaveStatus('saved');\r\n                }).catch(() => {\r\n                    window.updateSupervisorSaveStatus('error');\r\n                });\r\n            };\r\n\r\n            window.saveSupervisorDraft = function() {\r\n                const form = document.getElementById('compact-supervisor-form');\r\n                if (!form) return;\r\n\r\n                const studentId = form.dataset.studentId;\r\n                const draftInput = document.getElementById('is_draft');\r\n                if (draftInput) draftInput.value = 'true';\r\n\r\n                const formData = new FormData(form);\r\n                window.updateSupervisorSaveStatus('saving');\r\n\r\n                htmx.ajax('POST', `/supervisor-report/${studentId}/save-draft`, {\r\n                    values: Object.fromEntries(formData),\r\n                    target: '#modal-result',\r\n                    swap: 'innerHTML'\r\n                }).then(() => {\r\n                    window.updateSupervisorSaveStatus('saved');\r\n                    setTimeout(() => {\r\n                        window.showSupervisorSuccessMessage('Draft saved successfully!');\r\n                    }, 500);\r\n                });\r\n            };\r\n\r\n            window.updateCharCount = function(textarea) {\r\n                if (!textarea) return;\r\n                const charCount = document.getElementById('char-count');\r\n                if (charCount) {\r\n                    const length = textarea.value.length;\r\n                    charCount.textContent = length;\r\n                    charCount.style.color = length < 50 ? 'red' : 'green';\r\n                }\r\n            };\r\n\r\n            window.validateAndSubmitSupervisor = function() {\r\n                const draftInput = document.getElementById('is_draft');\r\n                if (draftInput) draftInput.value = 'false';\r\n                return window.validateSupervisorForm();\r\n            };\r\n\r\n            window.validateSupervisorForm = function() {\r\n                const form = document.getElementById('compact-supervisor-form');\r\n                if (!form) return false;\r\n\r\n                const comments = form.querySelector('#supervisor_comments');\r\n                const workplace = form.querySelector('#supervisor_workplace');\r\n                const position = form.querySelector('#supervisor_position');\r\n\r\n                if (!comments || !workplace || !position) {\r\n                    alert('Form fields not found');\r\n                    return false;\r\n                }\r\n\r\n                const commentsValue = comments.value.trim();\r\n                const workplaceValue = workplace.value.trim();\r\n                const positionValue = position.value.trim();\r\n\r\n                if (!commentsValue || !workplaceValue || !positionValue) {\r\n                    alert('Please fill in all required fields');\r\n                    return false;\r\n                }\r\n\r\n                if (commentsValue.length < 50) {\r\n                    alert('Supervisor comments must be at least 50 characters long. Current length: ' + commentsValue.length);\r\n                    return false;\r\n                }\r\n\r\n                const defenseEligibility = form.querySelector('input[name=\"is_pass_or_failed\"]:checked');\r\n                if (!defenseEligibility) {\r\n                    alert('Please select defense eligibility status');\r\n                    return false;\r\n                }\r\n\r\n                return true;\r\n            };\r\n\r\n            window.showSupervisorSuccessMessage = function(message) {\r\n                const result = document.getElementById('modal-result');\r\n                if (!result) return;\r\n\r\n                result.innerHTML = `\r\n                    <div class=\"bg-green-50 border border-green-200 text-green-800 px-4 py-3 rounded\">\r\n                        <div class=\"flex items-center\">\r\n                            <svg class=\"h-5 w-5 text-green-400 mr-2\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\">\r\n                                <path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M9 12l2 2 4-4m6 2a9 9 0 11-18 0 9 9 0 0118 0z\"></path>\r\n                            </svg>\r\n                            <span>${message}</span>\r\n                        </div>\r\n                    </div>\r\n                `;\r\n\r\n                setTimeout(() => {\r\n                    result.innerHTML = '';\r\n                }, 3000);\r\n            };\r\n\r\n            window.closeSupervisorModal = function() {\r\n                console.log('SupervisorModalScripts: closeSupervisorModal called');\r\n\r\n                if (window.supervisorForm && window.supervisorForm.hasUnsavedChanges) {\r\n                    if (!confirm('You have unsaved changes. Are you sure you want to close?')) {\r\n                        return;\r\n                    }\r\n                }\r\n\r\n                // Clear timer and reset state\r\n                if (window.supervisorForm && window.supervisorForm.autoSaveTimer) {\r\n                    clearTimeout(window.supervisorForm.autoSaveTimer);\r\n                }\r\n                if (window.supervisorForm) {\r\n                    window.supervisorForm.hasUnsavedChanges = false;\r\n                }\r\n\r\n                // Use ModalManager to close properly\r\n                if (window.ModalManager) {\r\n                    console.log('SupervisorModalScripts: Using ModalManager to close');\r\n                    window.ModalManager.closeAll();\r\n                } else {\r\n                    console.log('SupervisorModalScripts: ModalManager not available, using fallback');\r\n                    const modal = document.getElementById('supervisor-modal');\r\n                    if (modal) {\r\n                        modal.style.display = 'none';\r\n                    }\r\n                    const container = document.getElementById('modal-container');\r\n                    if (container) {\r\n                        container.style.display = 'none';\r\n                        container.innerHTML = '';\r\n                    }\r\n                    document.body.style.overflow = '';\r\n                }\r\n            };\r\n\r\n            // Event listeners with safety checks\r\n            window.addEventListener('beforeunload', function (e) {\r\n                if (window.supervisorForm && window.supervisorForm.hasUnsavedChanges) {\r\n                    e.preventDefault();\r\n                    e.returnValue = '';\r\n                }\r\n            });\r\n\r\n            // HTMX handling with safety checks\r\n            window.supervisorFormHtmxHandler = function(evt) {\r\n                if (evt.detail.successful && (\r\n                    evt.target.closest('#compact-supervisor-form') ||\r\n                    evt.detail.xhr.getResponseHeader('HX-Trigger') === 'supervisorReportSaved'\r\n                )) {\r\n                    if (window.supervisorForm) {\r\n                        window.supervisorForm.hasUnsavedChanges = false;\r\n                    }\r\n\r\n                    const draftInput = document.getElementById('is_draft');\r\n                    const isDraft = draftInput && draftInput.value === 'true';\r\n\r\n                    if (!isDraft) {\r\n                        console.log('SupervisorModalScripts: Form submitted, closing modal');\r\n                        setTimeout(() => {\r\n                            window.closeSupervisorModal();\r\n                            // Refresh the student list\r\n                            if (typeof htmx !== 'undefined') {\r\n                                htmx.ajax('GET', '/my-students', {\r\n                                    target: '#student-table-container',\r\n                                    values: { search: document.getElementById('search')?.value || '' }\r\n                                });\r\n                            }\r\n                        }, 400);\r\n                    }\r\n                }\r\n            };\r\n\r\n            // Remove old listener and add new one\r\n            document.removeEventListener('htmx:afterRequest', window.supervisorFormHtmxHandler);\r\n            document.addEventListener('htmx:afterRequest', window.supervisorFormHtmxHandler);\r\n\r\n            // Escape key handler with safety checks\r\n            document.addEventListener('keydown', function(e) {\r\n                if (e.key === 'Escape') {\r\n                    const modal = document.getElementById('supervisor-modal');\r\n                    if (modal && modal.style.display !== 'none') {\r\n                        console.log('SupervisorModalScripts: Escape key pressed');\r\n                        if (window.supervisorForm && window.supervisorForm.hasUnsavedChanges) {\r\n                            if (confirm('You have unsaved changes. Are you sure you want to close?')) {\r\n                                window.closeSupervisorModal();\r\n                            }\r\n                        } else {\r\n                            window.closeSupervisorModal();\r\n                        }\r\n                    }\r\n                }\r\n            });\r\n\r\n            // START INITIALIZATION - with proper timing\r\n            requestAnimationFrame(function() {\r\n                requestAnimationFrame(function() {\r\n                    initializeModal();\r\n                });\r\n            });\r\n\r\n            console.log('SupervisorModalScripts: Initialization complete');\r\n        })();\r\n    </script>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 141, "<script>\n        console.log('SupervisorModalScripts: Starting initialization');\n\n        // Wrap everything in an IIFE and use a namespace to avoid global scope pollution\n        (function() {\n            // Create namespace for supervisor form\n            window.supervisorForm = {\n                autoSaveTimer: null,\n                hasUnsavedChanges: false,\n                AUTOSAVE_DELAY: 3000\n            };\n\n            // Set current date SAFELY\n            function setCurrentDate() {\n                const dateElement = document.getElementById('current-date');\n                if (dateElement) {\n                    dateElement.textContent = new Date().toLocaleDateString('lt-LT');\n                }\n            }\n\n            // SAFE modal initialization with proper element checking\n            function initializeModal() {\n                const modal = document.getElementById('supervisor-modal');\n                if (!modal) {\n                    console.log('SupervisorModalScripts: Modal not found, retrying...');\n                    setTimeout(initializeModal, 100);\n                    return;\n                }\n\n                console.log('SupervisorModalScripts: Initializing modal without z-index changes');\n\n                modal.classList.remove('opacity-0', 'hidden');\n                modal.classList.add('opacity-100');\n\n                const content = modal.querySelector('[data-modal-content]');\n                if (content) {\n                    content.classList.remove('scale-95', 'opacity-0');\n                    content.classList.add('scale-100', 'opacity-100');\n                }\n\n                // SAFE initialization of auto-save\n                setTimeout(() => {\n                    initializeSupervisorAutoSave();\n                    setCurrentDate();\n                    initializeCharCount();\n                }, 200);\n\n                console.log('SupervisorModalScripts: Modal initialized successfully');\n            }\n\n            // SAFE auto-save initialization with null checks\n            function initializeSupervisorAutoSave() {\n                const form = document.getElementById('compact-supervisor-form');\n                if (!form) {\n                    console.log('SupervisorModalScripts: Form not found');\n                    return;\n                }\n\n                if (form.querySelector('[disabled]')) {\n                    console.log('SupervisorModalScripts: Form is disabled, skipping auto-save');\n                    return;\n                }\n\n                const fields = form.querySelectorAll('.auto-save-field');\n                console.log('SupervisorModalScripts: Found', fields.length, 'auto-save fields');\n\n                fields.forEach((field, index) => {\n                    if (!field) {\n                        console.log('SupervisorModalScripts: Field', index, 'is null, skipping');\n                        return;\n                    }\n\n                    try {\n                        // Clone node to remove existing listeners\n                        const newField = field.cloneNode(true);\n                        if (field.parentNode) {\n                            field.parentNode.replaceChild(newField, field);\n\n                            // Add new listeners SAFELY\n                            newField.addEventListener('input', handleSupervisorFieldChange);\n                            newField.addEventListener('change', handleSupervisorFieldChange);\n                        }\n                    } catch (error) {\n                        console.error('SupervisorModalScripts: Error setting up field', index, error);\n                    }\n                });\n            }\n\n            function initializeCharCount() {\n                const textarea = document.getElementById('supervisor_comments');\n                if (textarea && !textarea.disabled && window.updateCharCount) {\n                    window.updateCharCount(textarea);\n                }\n            }\n\n            function handleSupervisorFieldChange() {\n                window.supervisorForm.hasUnsavedChanges = true;\n                clearTimeout(window.supervisorForm.autoSaveTimer);\n                window.updateSupervisorSaveStatus('pending');\n                window.supervisorForm.autoSaveTimer = setTimeout(() => {\n                    window.supervisorAutoSave();\n                }, window.supervisorForm.AUTOSAVE_DELAY);\n            }\n\n            // Make functions global\n            window.initializeSupervisorAutoSave = initializeSupervisorAutoSave;\n            window.handleSupervisorFieldChange = handleSupervisorFieldChange;\n\n            window.updateSupervisorSaveStatus = function(status) {\n                const saveIcon = document.getElementById('save-icon');\n                const saveText = document.getElementById('save-text');\n                const lastSaved = document.getElementById('last-saved');\n\n                if (!saveText) return;\n\n                switch(status) {\n                    case 'pending':\n                        if (saveIcon) saveIcon.classList.remove('hidden');\n                        saveText.textContent = 'Changes detected...';\n                        saveText.classList.add('text-yellow-600');\n                        saveText.classList.remove('text-green-600', 'text-red-600');\n                        break;\n                    case 'saving':\n                        if (saveIcon) saveIcon.classList.remove('hidden');\n                        saveText.textContent = 'Saving...';\n                        saveText.classList.add('text-blue-600');\n                        saveText.classList.remove('text-yellow-600', 'text-green-600');\n                        break;\n                    case 'saved':\n                        if (saveIcon) saveIcon.classList.remove('hidden');\n                        saveText.textContent = 'All changes saved';\n                        saveText.classList.remove('text-blue-600', 'text-yellow-600');\n                        saveText.classList.add('text-green-600');\n                        const now = new Date();\n                        if (lastSaved) {\n                            lastSaved.textContent = `Last saved: ${now.toLocaleTimeString()}`;\n                        }\n                        window.supervisorForm.hasUnsavedChanges = false;\n                        break;\n                    case 'error':\n                        if (saveIcon) saveIcon.classList.add('hidden');\n                        saveText.textContent = 'Error saving';\n                        saveText.classList.add('text-red-600');\n                        break;\n                }\n            };\n\n            window.supervisorAutoSave = function() {\n                const form = document.getElementById('compact-supervisor-form');\n                if (!form) return;\n\n                const studentId = form.dataset.studentId;\n                const draftInput = document.getElementById('is_draft');\n                if (draftInput) draftInput.value = 'true';\n\n                window.updateSupervisorSaveStatus('saving');\n                const formData = new FormData(form);\n\n                htmx.ajax('POST', `/supervisor-report/${studentId}/save-draft`, {\n                    values: Object.fromEntries(formData),\n                    target: '#modal-result',\n                    swap: 'innerHTML'\n                }).then(() => {\n                    window.updateSupervisorSaveStatus('saved');\n                }).catch(() => {\n                    window.updateSupervisorSaveStatus('error');\n                });\n            };\n\n            window.saveSupervisorDraft = function() {\n                const form = document.getElementById('compact-supervisor-form');\n                if (!form) return;\n\n                const studentId = form.dataset.studentId;\n                const draftInput = document.getElementById('is_draft');\n                if (draftInput) draftInput.value = 'true';\n\n                const formData = new FormData(form);\n                window.updateSupervisorSaveStatus('saving');\n\n                htmx.ajax('POST', `/supervisor-report/${studentId}/save-draft`, {\n                    values: Object.fromEntries(formData),\n                    target: '#modal-result',\n                    swap: 'innerHTML'\n                }).then(() => {\n                    window.updateSupervisorSaveStatus('saved');\n                    setTimeout(() => {\n                        window.showSupervisorSuccessMessage('Draft saved successfully!');\n                    }, 500);\n                });\n            };\n\n            window.updateCharCount = function(textarea) {\n                if (!textarea) return;\n                const charCount = document.getElementById('char-count');\n                if (charCount) {\n                    const length = textarea.value.length;\n                    charCount.textContent = length;\n                    charCount.style.color = length < 50 ? 'red' : 'green';\n                }\n            };\n\n            // Inserts the selected comment snippet at the cursor of the evaluation text\n            window.insertCommentSnippet = function(select) {\n                const textarea = document.getElementById('supervisor_comments');\n                if (!select || !textarea || !select.value) return;\n                const start = textarea.selectionStart ?? textarea.value.length;\n                const end = textarea.selectionEnd ?? textarea.value.length;\n                textarea.value = textarea.value.slice(0, start) + select.value + textarea.value.slice(end);\n                textarea.selectionStart = textarea.selectionEnd = start + select.value.length;\n                textarea.dispatchEvent(new Event('input', { bubbles: true }));\n                textarea.focus();\n                select.value = '';\n            };\n\n            window.validateAndSubmitSupervisor = function() {\n                const draftInput = document.getElementById('is_draft');\n                if (draftInput) draftInput.value = 'false';\n                return window.validateSupervisorForm();\n            };\n\n            window.validateSupervisorForm = function() {\n                const form = document.getElementById('compact-supervisor-form');\n                if (!form) return false;\n\n                const comments = form.querySelector('#supervisor_comments');\n                const workplace = form.querySelector('#supervisor_workplace');\n                const position = form.querySelector('#supervisor_position');\n\n                if (!comments || !workplace || !position) {\n                    alert('Form fields not found');\n                    return false;\n                }\n\n                const commentsValue = comments.value.trim();\n                const workplaceValue = workplace.value.trim();\n                const positionValue = position.value.trim();\n\n                if (!commentsValue || !workplaceValue || !positionValue) {\n                    alert('Please fill in all required fields');\n                    return false;\n                }\n\n                if (commentsValue.length < 50) {\n                    alert('Supervisor comments must be at least 50 characters long. Current length: ' + commentsValue.length);\n                    return false;\n                }\n\n                const defenseEligibility = form.querySelector('input[name=\"is_pass_or_failed\"]:checked');\n                if (!defenseEligibility) {\n                    alert('Please select defense eligibility status');\n                    return false;\n                }\n\n                return true;\n            };\n\n            window.showSupervisorSuccessMessage = function(message) {\n                const result = document.getElementById('modal-result');\n                if (!result) return;\n\n                result.innerHTML = `\n                    <div class=\"bg-green-50 border border-green-200 text-green-800 px-4 py-3 rounded\">\n                        <div class=\"flex items-center\">\n                            <svg class=\"h-5 w-5 text-green-400 mr-2\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\">\n                                <path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M9 12l2 2 4-4m6 2a9 9 0 11-18 0 9 9 0 0118 0z\"></path>\n                            </svg>\n                            <span>${message}</span>\n                        </div>\n                    </div>\n                `;\n\n                setTimeout(() => {\n                    result.innerHTML = '';\n                }, 3000);\n            };\n\n            window.closeSupervisorModal = function() {\n                console.log('SupervisorModalScripts: closeSupervisorModal called');\n\n                if (window.supervisorForm && window.supervisorForm.hasUnsavedChanges) {\n                    if (!confirm('You have unsaved changes. Are you sure you want to close?')) {\n                        return;\n                    }\n                }\n\n                // Clear timer and reset state\n                if (window.supervisorForm && window.supervisorForm.autoSaveTimer) {\n                    clearTimeout(window.supervisorForm.autoSaveTimer);\n                }\n                if (window.supervisorForm) {\n                    window.supervisorForm.hasUnsavedChanges = false;\n                }\n\n                // Use ModalManager to close properly\n                if (window.ModalManager) {\n                    console.log('SupervisorModalScripts: Using ModalManager to close');\n                    window.ModalManager.closeAll();\n                } else {\n                    console.log('SupervisorModalScripts: ModalManager not available, using fallback');\n                    const modal = document.getElementById('supervisor-modal');\n                    if (modal) {\n                        modal.style.display = 'none';\n                    }\n                    const container = document.getElementById('modal-container');\n                    if (container) {\n                        container.style.display = 'none';\n                        container.innerHTML = '';\n                    }\n                    document.body.style.overflow = '';\n                }\n            };\n\n            // Event listeners with safety checks\n            window.addEventListener('beforeunload', function (e) {\n                if (window.supervisorForm && window.supervisorForm.hasUnsavedChanges) {\n                    e.preventDefault();\n                    e.returnValue = '';\n                }\n            });\n\n            // HTMX handling with safety checks\n            window.supervisorFormHtmxHandler = function(evt) {\n                if (evt.detail.successful && (\n                    evt.target.closest('#compact-supervisor-form') ||\n                    evt.detail.xhr.getResponseHeader('HX-Trigger') === 'supervisorReportSaved'\n                )) {\n                    if (window.supervisorForm) {\n                        window.supervisorForm.hasUnsavedChanges = false;\n                    }\n\n                    const draftInput = document.getElementById('is_draft');\n                    const isDraft = draftInput && draftInput.value === 'true';\n\n                    if (!isDraft) {\n                        console.log('SupervisorModalScripts: Form submitted, closing modal');\n                        setTimeout(() => {\n                            window.closeSupervisorModal();\n                            // Refresh the student list\n                            if (typeof htmx !== 'undefined') {\n                                htmx.ajax('GET', '/my-students', {\n                                    target: '#student-table-container',\n                                    values: { search: document.getElementById('search')?.value || '' }\n                                });\n                            }\n                        }, 400);\n                    }\n                }\n            };\n\n            // Remove old listener and add new one\n            document.removeEventListener('htmx:afterRequest', window.supervisorFormHtmxHandler);\n            document.addEventListener('htmx:afterRequest', window.supervisorFormHtmxHandler);\n\n            // Pre-fill similarity fields from an attached similarity report\n            window.supervisorSimilarityHandler = function(evt) {\n                const values = evt.detail || {};\n                ['other_match', 'one_match', 'own_match', 'join_match'].forEach(function(name) {\n                    const field = document.getElementById(name);\n                    if (field && values[name] !== undefined) {\n                        field.value = Number(values[name]).toFixed(1);\n                        field.dispatchEvent(new Event('input', { bubbles: true }));\n                    }\n                });\n            };\n            document.body.removeEventListener('similarityReportParsed', window.supervisorSimilarityHandler);\n            document.body.addEventListener('similarityReportParsed', window.supervisorSimilarityHandler);\n\n            // Escape key handler with safety checks\n            document.addEventListener('keydown', function(e) {\n                if (e.key === 'Escape') {\n                    const modal = document.getElementById('supervisor-modal');\n                    if (modal && modal.style.display !== 'none') {\n                        console.log('SupervisorModalScripts: Escape key pressed');\n                        if (window.supervisorForm && window.supervisorForm.hasUnsavedChanges) {\n                            if (confirm('You have unsaved changes. Are you sure you want to close?')) {\n                                window.closeSupervisorModal();\n                            }\n                        } else {\n                            window.closeSupervisorModal();\n                        }\n                    }\n                }\n            });\n\n            // START INITIALIZATION - with proper timing\n            requestAnimationFrame(function() {\n                requestAnimationFrame(function() {\n                    initializeModal();\n                });\n            });\n\n            console.log('SupervisorModalScripts: Initialization complete');\n        })();\n    </script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1292,4 +1548,11 @@ func getPlaceholder(formVariant, fieldType string) string {
 	return "Įveskite vadovo atsiliepimą ir komentarus..."
 }
 
+func getUnlockPrompt(variant string) string {
+	if variant == "en" {
+		return "Reason for unlocking the signed report"
+	}
+	return "Pasirašyto dokumento atrakinimo priežastis"
+}
+
 var _ = templruntime.GeneratedTemplate
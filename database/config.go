@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"time"
 
+	"FinalProjectManagementApp/extract"
+	"FinalProjectManagementApp/githosting"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 )
@@ -93,9 +95,11 @@ var _ Database = (*sqlx.DB)(nil)
 
 // ===== APPLICATION CONFIG =====
 type AppConfig struct {
-	Database *Config
-	GitHub   *GitHubConfig // CHANGED: From AzureDevOps to GitHub
-	Server   *ServerConfig
+	Database   *Config
+	GitHub     *GitHubConfig // CHANGED: From AzureDevOps to GitHub
+	GitHosting *githosting.Config
+	Extraction *extract.Limits
+	Server     *ServerConfig
 }
 
 // CHANGED: Renamed from AzureDevOpsConfig to GitHubConfig
@@ -122,6 +126,18 @@ func LoadAppConfig() *AppConfig {
 			PAT:          getEnv("GITHUB_PAT", ""),     // CHANGED: From AZURE_PAT
 		},
 
+		// GIT_PROVIDER selects github, gitlab, gitea or local; the GitHub variables remain the defaults
+		GitHosting: &githosting.Config{
+			Provider:     getEnv("GIT_PROVIDER", "github"),
+			BaseURL:      getEnv("GIT_API_URL", ""),
+			Organization: getEnv("GIT_ORG", getEnv("GITHUB_ORG", "")),
+			Token:        getEnv("GIT_TOKEN", getEnv("GITHUB_PAT", "")),
+			LocalRoot:    getEnv("GIT_LOCAL_ROOT", "repositories"),
+			Cache:        loadGitCacheOptions(),
+		},
+
+		Extraction: loadExtractionLimits(),
+
 		Server: &ServerConfig{
 			Port:        getEnv("PORT", "8080"),
 			Environment: getEnv("RAILWAY_ENVIRONMENT", "development"),
@@ -134,11 +150,39 @@ func LoadAppConfig() *AppConfig {
 	return config
 }
 
+// loadExtractionLimits reads the limits for uploaded archives; sizes are in megabytes and 0 disables a check
+func loadExtractionLimits() *extract.Limits {
+	defaults := extract.DefaultLimits()
+	return &extract.Limits{
+		MaxTotalSize:  int64(getEnvInt("EXTRACT_MAX_TOTAL_MB", int(defaults.MaxTotalSize>>20))) << 20,
+		MaxFileSize:   int64(getEnvInt("EXTRACT_MAX_FILE_MB", int(defaults.MaxFileSize>>20))) << 20,
+		MaxEntries:    getEnvInt("EXTRACT_MAX_ENTRIES", defaults.MaxEntries),
+		MaxRatio:      float64(getEnvInt("EXTRACT_MAX_RATIO", int(defaults.MaxRatio))),
+		MaxDepth:      getEnvInt("EXTRACT_MAX_DEPTH", defaults.MaxDepth),
+		MaxPathLength: getEnvInt("EXTRACT_MAX_PATH_LENGTH", defaults.MaxPathLength),
+	}
+}
+
+// loadGitCacheOptions sizes the repository browsing cache; GIT_CACHE_ENTRIES=0 disables it
+func loadGitCacheOptions() githosting.CacheOptions {
+	defaults := githosting.DefaultCacheOptions()
+	return githosting.CacheOptions{
+		MaxEntries: getEnvInt("GIT_CACHE_ENTRIES", defaults.MaxEntries),
+		MaxBytes:   int64(getEnvInt("GIT_CACHE_MAX_MB", int(defaults.MaxBytes>>20))) << 20,
+		RefTTL:     time.Duration(getEnvInt("GIT_CACHE_REF_TTL_SECONDS", int(defaults.RefTTL/time.Second))) * time.Second,
+	}
+}
+
 // CHANGED: Updated function name and logic for GitHub
 func (c *AppConfig) HasGitHub() bool {
 	return c.GitHub.Organization != "" && c.GitHub.PAT != ""
 }
 
+// HasGitHosting reports whether a code hosting provider is configured
+func (c *AppConfig) HasGitHosting() bool {
+	return c.GitHosting.Enabled()
+}
+
 // CHANGED: Updated logging for GitHub
 func (c *AppConfig) logConfig() {
 	log.Printf("Configuration loaded:")
@@ -147,11 +191,10 @@ func (c *AppConfig) logConfig() {
 	log.Printf("  Database Name: %s", c.Database.Database)
 	log.Printf("  Server Port: %s", c.Server.Port)
 
-	if c.GitHub.Organization != "" {
-		log.Printf("  GitHub Org: %s", c.GitHub.Organization)
-		log.Printf("  GitHub: ENABLED")
+	if c.HasGitHosting() {
+		log.Printf("  Git hosting: %s (%s)", c.GitHosting.Provider, c.GitHosting.Organization)
 	} else {
-		log.Printf("  GitHub: DISABLED")
+		log.Printf("  Git hosting: DISABLED")
 	}
 }
 
@@ -2,12 +2,19 @@
 package database
 
 import (
+	"crypto/sha256"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
+
+	"FinalProjectManagementApp/codeanalysis"
+	"FinalProjectManagementApp/similarity"
 )
 
 // ================================
@@ -50,6 +57,19 @@ const (
 	TopicStatusApproved           = "approved"
 	TopicStatusRejected           = "rejected"
 	TopicStatusRevisionRequested  = "revision_requested"
+
+	// Topic comment types
+	CommentTypeComment        = "comment"
+	CommentTypeRequiredChange = "required_change"
+	CommentTypeRevision       = "revision"
+
+	// Topic change request statuses
+	ChangeRequestPending            = "pending"
+	ChangeRequestSupervisorApproved = "supervisor_approved"
+	ChangeRequestApproved           = "approved"
+	ChangeRequestRejected           = "rejected"
+	ChangeRequestWithdrawn          = "withdrawn"
+
 	// Document types
 	DocumentTypeThesis       = "thesis"
 	DocumentTypePresentation = "presentation"
@@ -525,6 +545,27 @@ type Document struct {
 	ValidationStatus string    `json:"validation_status" db:"validation_status"`
 	UploadStatus     string    `json:"upload_status" db:"upload_status"`
 	ValidationErrors *string   `json:"validation_errors" db:"validation_errors"`
+	UploadedByEmail  *string   `json:"uploaded_by_email" db:"uploaded_by_email"`
+	UploadedByRole   *string   `json:"uploaded_by_role" db:"uploaded_by_role"`
+	SubmissionBranch *string   `json:"submission_branch" db:"submission_branch"`
+	AnalysisReport   *string   `json:"-" db:"analysis_report"`
+}
+
+// Analysis decodes the code report stored with the submission, or returns nil when there is none
+func (d *Document) Analysis() *codeanalysis.Report {
+	if d.AnalysisReport == nil || *d.AnalysisReport == "" {
+		return nil
+	}
+	var report codeanalysis.Report
+	if err := json.Unmarshal([]byte(*d.AnalysisReport), &report); err != nil {
+		return nil
+	}
+	return &report
+}
+
+// IsUploadedOnBehalf reports whether someone other than the student uploaded the document
+func (d *Document) IsUploadedOnBehalf(studentEmail string) bool {
+	return d.UploadedByEmail != nil && *d.UploadedByEmail != "" && !strings.EqualFold(*d.UploadedByEmail, studentEmail)
 }
 
 // GetFileSizeFormatted returns formatted file size
@@ -589,24 +630,30 @@ func (d *Document) CanBeAccessedBy(accessType string) bool {
 
 // ReviewerReport represents a reviewer's report (enhanced)
 type ReviewerReport struct {
-	ID                          int       `json:"id" db:"id"`
-	StudentRecordID             int       `json:"student_record_id" db:"student_record_id"`
-	ReviewerPersonalDetails     string    `json:"reviewer_personal_details" db:"reviewer_personal_details"`
-	Grade                       float64   `json:"grade" db:"grade"`
-	ReviewGoals                 string    `json:"review_goals" db:"review_goals"`
-	ReviewTheory                string    `json:"review_theory" db:"review_theory"`
-	ReviewPractical             string    `json:"review_practical" db:"review_practical"`
-	ReviewTheoryPracticalLink   string    `json:"review_theory_practical_link" db:"review_theory_practical_link"`
-	ReviewResults               string    `json:"review_results" db:"review_results"`
-	ReviewPracticalSignificance *string   `json:"review_practical_significance" db:"review_practical_significance"`
-	ReviewLanguage              string    `json:"review_language" db:"review_language"`
-	ReviewPros                  string    `json:"review_pros" db:"review_pros"`
-	ReviewCons                  string    `json:"review_cons" db:"review_cons"`
-	ReviewQuestions             string    `json:"review_questions" db:"review_questions"`
-	IsSigned                    bool      `json:"is_signed" db:"is_signed"`
-	CreatedDate                 time.Time `json:"created_date" db:"created_date"`
-	UpdatedDate                 time.Time `json:"updated_date" db:"updated_date"`
-	ReviewerInvitationID        *int      `json:"reviewer_invitation_id" db:"reviewer_invitation_id"`
+	ID                          int        `json:"id" db:"id"`
+	StudentRecordID             int        `json:"student_record_id" db:"student_record_id"`
+	ReviewerPersonalDetails     string     `json:"reviewer_personal_details" db:"reviewer_personal_details"`
+	Grade                       float64    `json:"grade" db:"grade"`
+	ReviewGoals                 string     `json:"review_goals" db:"review_goals"`
+	ReviewTheory                string     `json:"review_theory" db:"review_theory"`
+	ReviewPractical             string     `json:"review_practical" db:"review_practical"`
+	ReviewTheoryPracticalLink   string     `json:"review_theory_practical_link" db:"review_theory_practical_link"`
+	ReviewResults               string     `json:"review_results" db:"review_results"`
+	ReviewPracticalSignificance *string    `json:"review_practical_significance" db:"review_practical_significance"`
+	ReviewLanguage              string     `json:"review_language" db:"review_language"`
+	ReviewPros                  string     `json:"review_pros" db:"review_pros"`
+	ReviewCons                  string     `json:"review_cons" db:"review_cons"`
+	ReviewQuestions             string     `json:"review_questions" db:"review_questions"`
+	IsSigned                    bool       `json:"is_signed" db:"is_signed"`
+	CreatedDate                 time.Time  `json:"created_date" db:"created_date"`
+	UpdatedDate                 time.Time  `json:"updated_date" db:"updated_date"`
+	ReviewerInvitationID        *int       `json:"reviewer_invitation_id" db:"reviewer_invitation_id"`
+	Revision                    int        `json:"revision" db:"revision"`
+	ContentHash                 *string    `json:"content_hash" db:"content_hash"`
+	SignedAt                    *time.Time `json:"signed_at" db:"signed_at"`
+
+	// Rubric criterion scores by criterion ID, part of the signed content
+	RubricScores map[int]float64 `json:"rubric_scores,omitempty" db:"-"`
 }
 
 // GetGradeDisplay returns formatted grade display
@@ -1085,6 +1132,12 @@ type SupervisorReport struct {
 	UpdatedDate         time.Time      `json:"updated_date" db:"updated_date"`
 	Grade               *int           `json:"grade" db:"grade"`
 	//FinalComments       sql.NullString `json:"final_comments" db:"final_comments"` // Changed
+	Revision    int        `json:"revision" db:"revision"`
+	ContentHash *string    `json:"content_hash" db:"content_hash"`
+	SignedAt    *time.Time `json:"signed_at" db:"signed_at"`
+	// Attached similarity report and whether the typed percentages disagree with it
+	SimilarityReportID *int `json:"similarity_report_id" db:"similarity_report_id"`
+	SimilarityMismatch bool `json:"similarity_mismatch" db:"similarity_mismatch"`
 }
 
 // [Keep all existing methods for SupervisorReport unchanged...]
@@ -1572,6 +1625,15 @@ type SupervisorReportFormProps struct {
 	// Current user info (supervisor)
 	CurrentSupervisorName  string `json:"current_supervisor_name"`
 	CurrentSupervisorEmail string `json:"current_supervisor_email"`
+
+	// Administrators may unlock a signed report
+	CanUnlock bool `json:"can_unlock"`
+	// Administrators and department heads may browse saved versions
+	CanViewHistory bool `json:"can_view_history"`
+	// Latest attached similarity check report, if any
+	SimilarityReport *SimilarityReport `json:"similarity_report,omitempty"`
+	// Comment snippets of the current supervisor
+	CommentSnippets []CommentSnippet `json:"comment_snippets,omitempty"`
 }
 
 // SupervisorReportFormData represents the data being edited in the form
@@ -1749,6 +1811,8 @@ type StudentSummaryView struct {
 	HasSourceCode bool           `db:"has_source_code"`            // Add this line
 	RepositoryURL sql.NullString `db:"repository_url"`             // Optional: if you want the URL too
 
+	// Student answers to the reviewer questions, loaded separately
+	QuestionAnswers []ReviewerQuestionAnswer `db:"-"`
 }
 
 // GetCompletionStatus returns overall completion status
@@ -2120,6 +2184,124 @@ type TopicRegistrationComment struct {
 	ParentCommentID     *int      `json:"parent_comment_id" db:"parent_comment_id"`
 	IsRead              bool      `json:"is_read" db:"is_read"`
 	CommentType         string    `json:"comment_type" db:"comment_type"`
+	IsResolved          bool      `json:"is_resolved" db:"is_resolved"`
+	ResolvedBy          *string   `json:"resolved_by" db:"resolved_by"`
+	ResolvedAt          *int64    `json:"resolved_at" db:"resolved_at"`
+
+	// Populated per viewer / per thread, not stored in the comments table
+	IsUnread bool                       `json:"is_unread" db:"-"`
+	Replies  []TopicRegistrationComment `json:"replies,omitempty" db:"-"`
+}
+
+// TopicCommentFields lists the topic fields review comments can be anchored to
+var TopicCommentFields = []string{"title", "title_en", "problem", "objective", "tasks"}
+
+// IsValidTopicCommentField checks if a field name can carry review comments
+func IsValidTopicCommentField(field string) bool {
+	for _, f := range TopicCommentFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFieldName returns the anchored field name or empty string for general comments
+func (trc *TopicRegistrationComment) GetFieldName() string {
+	if trc.FieldName == nil {
+		return ""
+	}
+	return *trc.FieldName
+}
+
+// IsRequiredChange checks if the comment demands a change before resubmission
+func (trc *TopicRegistrationComment) IsRequiredChange() bool {
+	return trc.CommentType == CommentTypeRequiredChange
+}
+
+// IsAnswered checks if the student replied to the comment thread
+func (trc *TopicRegistrationComment) IsAnswered() bool {
+	for _, reply := range trc.Replies {
+		if reply.AuthorRole == "student" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBlocking checks if the comment still blocks topic resubmission
+func (trc *TopicRegistrationComment) IsBlocking() bool {
+	return trc.IsRequiredChange() && !trc.IsResolved && !trc.IsAnswered()
+}
+
+// CanBeResolvedBy checks if a user may resolve or reopen the thread. Those who supervise
+// the topic - its supervisor and department head - can resolve any thread, others only
+// their own; students only their own general comments, since required changes must be
+// resolved by a reviewer or answered by the student.
+func (trc *TopicRegistrationComment) CanBeResolvedBy(role, email string, supervisesTopic bool) bool {
+	if supervisesTopic {
+		return true
+	}
+	if role == "student" && trc.IsRequiredChange() {
+		return false
+	}
+	return trc.AuthorEmail == email
+}
+
+// GetResolvedAtFormatted returns formatted resolution date
+func (trc *TopicRegistrationComment) GetResolvedAtFormatted() string {
+	if trc.ResolvedAt == nil {
+		return ""
+	}
+	return time.Unix(*trc.ResolvedAt, 0).Format("2006-01-02 15:04")
+}
+
+// UnreadCount returns the number of unread comments in the thread
+func (trc *TopicRegistrationComment) UnreadCount() int {
+	count := 0
+	if trc.IsUnread {
+		count++
+	}
+	for _, reply := range trc.Replies {
+		count += reply.UnreadCount()
+	}
+	return count
+}
+
+// BuildCommentThreads nests replies under their root comments, keeping creation order
+func BuildCommentThreads(comments []TopicRegistrationComment) []TopicRegistrationComment {
+	byID := make(map[int]int, len(comments))
+	var roots []TopicRegistrationComment
+	for _, c := range comments {
+		if c.ParentCommentID == nil {
+			byID[c.ID] = len(roots)
+			roots = append(roots, c)
+		}
+	}
+	for _, c := range comments {
+		if c.ParentCommentID == nil {
+			continue
+		}
+		if idx, ok := byID[*c.ParentCommentID]; ok {
+			roots[idx].Replies = append(roots[idx].Replies, c)
+		} else {
+			// Root comment is missing - show the reply as its own thread
+			c.ParentCommentID = nil
+			roots = append(roots, c)
+		}
+	}
+	return roots
+}
+
+// CountBlockingComments returns how many threads still block resubmission
+func CountBlockingComments(threads []TopicRegistrationComment) int {
+	count := 0
+	for i := range threads {
+		if threads[i].IsBlocking() {
+			count++
+		}
+	}
+	return count
 }
 
 // IsReply checks if this is a reply to another comment
@@ -2134,6 +2316,10 @@ func (trc *TopicRegistrationComment) GetCommentTypeDisplay() string {
 		return "Comment"
 	case "suggestion":
 		return "Suggestion"
+	case "required_change":
+		return "Required change"
+	case "revision":
+		return "Revision"
 	case "approval":
 		return "Approval"
 	case "rejection":
@@ -2150,6 +2336,8 @@ func (trc *TopicRegistrationComment) GetCommentTypeColor() string {
 		return "text-blue-600"
 	case "suggestion":
 		return "text-yellow-600"
+	case "required_change", "revision":
+		return "text-orange-600"
 	case "approval":
 		return "text-green-600"
 	case "rejection":
@@ -2179,6 +2367,342 @@ func (ptrv *ProjectTopicRegistrationVersion) GetVersionData() (*ProjectTopicRegi
 	return &topicData, nil
 }
 
+// TopicChangeRequest represents a proposed change to an already approved topic
+type TopicChangeRequest struct {
+	ID                   int       `json:"id" db:"id"`
+	TopicRegistrationID  int       `json:"topic_registration_id" db:"topic_registration_id"`
+	StudentRecordID      int       `json:"student_record_id" db:"student_record_id"`
+	ProposedTitle        string    `json:"proposed_title" db:"proposed_title"`
+	ProposedTitleEn      string    `json:"proposed_title_en" db:"proposed_title_en"`
+	ProposedObjective    string    `json:"proposed_objective" db:"proposed_objective"`
+	PreviousTitle        string    `json:"previous_title" db:"previous_title"`
+	PreviousTitleEn      string    `json:"previous_title_en" db:"previous_title_en"`
+	PreviousObjective    string    `json:"previous_objective" db:"previous_objective"`
+	Justification        string    `json:"justification" db:"justification"`
+	Status               string    `json:"status" db:"status"` // pending, supervisor_approved, approved, rejected, withdrawn
+	RequestedBy          string    `json:"requested_by" db:"requested_by"`
+	CreatedAt            time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`
+	SupervisorDecisionBy *string   `json:"supervisor_decision_by" db:"supervisor_decision_by"`
+	SupervisorDecisionAt *int64    `json:"supervisor_decision_at" db:"supervisor_decision_at"`
+	SupervisorComment    *string   `json:"supervisor_comment" db:"supervisor_comment"`
+	DepartmentDecisionBy *string   `json:"department_decision_by" db:"department_decision_by"`
+	DepartmentDecisionAt *int64    `json:"department_decision_at" db:"department_decision_at"`
+	DepartmentComment    *string   `json:"department_comment" db:"department_comment"`
+	AppliedVersion       *int      `json:"applied_version" db:"applied_version"`
+}
+
+// IsOpen checks if the change request still awaits a decision
+func (tcr *TopicChangeRequest) IsOpen() bool {
+	return tcr.Status == ChangeRequestPending || tcr.Status == ChangeRequestSupervisorApproved
+}
+
+// CanSupervisorDecide checks if the request awaits the supervisor
+func (tcr *TopicChangeRequest) CanSupervisorDecide() bool {
+	return tcr.Status == ChangeRequestPending
+}
+
+// CanDepartmentDecide checks if the request awaits the department head
+func (tcr *TopicChangeRequest) CanDepartmentDecide() bool {
+	return tcr.Status == ChangeRequestSupervisorApproved
+}
+
+// GetStatusDisplay returns localized change request status
+func (tcr *TopicChangeRequest) GetStatusDisplay(locale string) string {
+	statusMap := map[string]map[string]string{
+		"en": {
+			ChangeRequestPending:            "Awaiting supervisor",
+			ChangeRequestSupervisorApproved: "Awaiting department",
+			ChangeRequestApproved:           "Approved",
+			ChangeRequestRejected:           "Rejected",
+			ChangeRequestWithdrawn:          "Withdrawn",
+		},
+		"lt": {
+			ChangeRequestPending:            "Laukia vadovo",
+			ChangeRequestSupervisorApproved: "Laukia katedros",
+			ChangeRequestApproved:           "Patvirtinta",
+			ChangeRequestRejected:           "Atmesta",
+			ChangeRequestWithdrawn:          "Atšaukta",
+		},
+	}
+
+	if statusMap[locale] != nil && statusMap[locale][tcr.Status] != "" {
+		return statusMap[locale][tcr.Status]
+	}
+	return tcr.Status
+}
+
+// GetStatusColor returns CSS color class for change request status
+func (tcr *TopicChangeRequest) GetStatusColor() string {
+	switch tcr.Status {
+	case ChangeRequestPending:
+		return "text-yellow-600"
+	case ChangeRequestSupervisorApproved:
+		return "text-blue-600"
+	case ChangeRequestApproved:
+		return "text-green-600"
+	case ChangeRequestRejected:
+		return "text-red-600"
+	default:
+		return "text-gray-500"
+	}
+}
+
+// FormatDecisionTime formats a decision timestamp for display
+func (tcr *TopicChangeRequest) FormatDecisionTime(ts *int64) string {
+	if ts == nil {
+		return ""
+	}
+	return time.Unix(*ts, 0).Format("2006-01-02 15:04")
+}
+
+// TopicChangeRequestData represents change request form data
+type TopicChangeRequestData struct {
+	Title         string `json:"title" form:"title"`
+	TitleEn       string `json:"title_en" form:"title_en"`
+	Objective     string `json:"objective" form:"objective"`
+	Justification string `json:"justification" form:"justification"`
+}
+
+// Validate validates the change request data against the current topic
+func (d *TopicChangeRequestData) Validate(current *ProjectTopicRegistration) error {
+	if strings.TrimSpace(d.Title) == "" || strings.TrimSpace(d.TitleEn) == "" {
+		return fmt.Errorf("both Lithuanian and English titles are required")
+	}
+	if strings.TrimSpace(d.Objective) == "" {
+		return fmt.Errorf("objective is required")
+	}
+	if len(strings.TrimSpace(d.Justification)) < 20 {
+		return fmt.Errorf("justification must be at least 20 characters")
+	}
+	if d.Title == current.Title && d.TitleEn == current.TitleEn && d.Objective == current.Objective {
+		return fmt.Errorf("the proposed values are identical to the approved topic")
+	}
+	return nil
+}
+
+// PendingTopicSummary is a topic awaiting department review in the bulk review list
+type PendingTopicSummary struct {
+	TopicID              int    `json:"topic_id" db:"topic_id"`
+	StudentRecordID      int    `json:"student_record_id" db:"student_record_id"`
+	Title                string `json:"title" db:"title"`
+	TitleEn              string `json:"title_en" db:"title_en"`
+	Status               string `json:"status" db:"status"`
+	StudentName          string `json:"student_name" db:"student_name"`
+	StudentLastname      string `json:"student_lastname" db:"student_lastname"`
+	StudentGroup         string `json:"student_group" db:"student_group"`
+	StudyProgram         string `json:"study_program" db:"study_program"`
+	Department           string `json:"department" db:"department"`
+	SupervisorEmail      string `json:"supervisor_email" db:"supervisor_email"`
+	SupervisorApprovedAt *int64 `json:"supervisor_approved_at" db:"supervisor_approved_at"`
+}
+
+// GetStudentFullName returns the student's name and surname
+func (p *PendingTopicSummary) GetStudentFullName() string {
+	return strings.TrimSpace(p.StudentName + " " + p.StudentLastname)
+}
+
+// IsAwaitingDepartment checks if the topic can still be decided by the department
+func (p *PendingTopicSummary) IsAwaitingDepartment() bool {
+	return p.Status == TopicStatusSupervisorApproved || p.Status == TopicStatusSubmitted
+}
+
+// BulkTopicReviewResult is the outcome of one topic in a bulk department review
+type BulkTopicReviewResult struct {
+	TopicID         int    `json:"topic_id"`
+	StudentName     string `json:"student_name"`
+	Title           string `json:"title"`
+	SupervisorEmail string `json:"supervisor_email"`
+	Reason          string `json:"reason,omitempty"`
+	Success         bool   `json:"success"`
+	Message         string `json:"message"`
+}
+
+// Report types that can be signed
+const (
+	ReportTypeSupervisor = "supervisor"
+	ReportTypeReviewer   = "reviewer"
+)
+
+// ReportSignature is an append-only record of one signed report revision
+type ReportSignature struct {
+	ID              int       `json:"id" db:"id"`
+	ReportType      string    `json:"report_type" db:"report_type"`
+	ReportID        int       `json:"report_id" db:"report_id"`
+	StudentRecordID int       `json:"student_record_id" db:"student_record_id"`
+	Revision        int       `json:"revision" db:"revision"`
+	ContentHash     string    `json:"content_hash" db:"content_hash"`
+	HashAlgorithm   string    `json:"hash_algorithm" db:"hash_algorithm"`
+	SignerEmail     string    `json:"signer_email" db:"signer_email"`
+	SignerName      string    `json:"signer_name" db:"signer_name"`
+	SignerRole      string    `json:"signer_role" db:"signer_role"`
+	IPAddress       *string   `json:"ip_address" db:"ip_address"`
+	UserAgent       *string   `json:"user_agent" db:"user_agent"`
+	SignedAt        time.Time `json:"signed_at" db:"signed_at"`
+}
+
+// ReportUnlock is an append-only record of an administrator unlocking a signed report
+type ReportUnlock struct {
+	ID              int       `json:"id" db:"id"`
+	ReportType      string    `json:"report_type" db:"report_type"`
+	ReportID        int       `json:"report_id" db:"report_id"`
+	StudentRecordID int       `json:"student_record_id" db:"student_record_id"`
+	FromRevision    int       `json:"from_revision" db:"from_revision"`
+	Reason          string    `json:"reason" db:"reason"`
+	UnlockedBy      string    `json:"unlocked_by" db:"unlocked_by"`
+	IPAddress       *string   `json:"ip_address" db:"ip_address"`
+	UnlockedAt      time.Time `json:"unlocked_at" db:"unlocked_at"`
+}
+
+// ReportVerification is the result of checking a report against its signature
+type ReportVerification struct {
+	ReportType   string           `json:"report_type"`
+	ReportID     int              `json:"report_id"`
+	Revision     int              `json:"revision"`
+	IsSigned     bool             `json:"is_signed"`
+	Valid        bool             `json:"valid"`
+	StoredHash   string           `json:"stored_hash,omitempty"`
+	ComputedHash string           `json:"computed_hash"`
+	Signature    *ReportSignature `json:"signature,omitempty"`
+	Issues       []string         `json:"issues,omitempty"`
+}
+
+// ReportVersion is a snapshot of a supervisor or reviewer report taken after a save
+type ReportVersion struct {
+	ID              int       `json:"id" db:"id"`
+	ReportType      string    `json:"report_type" db:"report_type"`
+	ReportID        int       `json:"report_id" db:"report_id"`
+	StudentRecordID int       `json:"student_record_id" db:"student_record_id"`
+	VersionNumber   int       `json:"version_number" db:"version_number"`
+	Revision        int       `json:"revision" db:"revision"`
+	VersionData     string    `json:"version_data" db:"version_data"`
+	ContentHash     string    `json:"content_hash" db:"content_hash"`
+	IsSigned        bool      `json:"is_signed" db:"is_signed"`
+	CreatedBy       string    `json:"created_by" db:"created_by"`
+	CreatedByRole   string    `json:"created_by_role" db:"created_by_role"`
+	ChangeSummary   *string   `json:"change_summary" db:"change_summary"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// GetSupervisorReport parses the snapshot of a supervisor report
+func (rv *ReportVersion) GetSupervisorReport() (*SupervisorReport, error) {
+	var report SupervisorReport
+	if err := json.Unmarshal([]byte(rv.VersionData), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetReviewerReport parses the snapshot of a reviewer report
+func (rv *ReportVersion) GetReviewerReport() (*ReviewerReport, error) {
+	var report ReviewerReport
+	if err := json.Unmarshal([]byte(rv.VersionData), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// SimilarityReport holds percentages parsed from an attached similarity check report
+type SimilarityReport struct {
+	ID              int       `json:"id" db:"id"`
+	StudentRecordID int       `json:"student_record_id" db:"student_record_id"`
+	DocumentID      int       `json:"document_id" db:"document_id"`
+	SourceFormat    string    `json:"source_format" db:"source_format"`
+	OtherMatch      *float64  `json:"other_match" db:"other_match"`
+	OneMatch        *float64  `json:"one_match" db:"one_match"`
+	OwnMatch        *float64  `json:"own_match" db:"own_match"`
+	JoinMatch       *float64  `json:"join_match" db:"join_match"`
+	UploadedBy      string    `json:"uploaded_by" db:"uploaded_by"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+
+	// Joined from documents
+	OriginalFilename *string `json:"original_filename" db:"original_filename"`
+}
+
+// Values returns the parsed percentages keyed by supervisor report field
+func (sr *SimilarityReport) Values() map[string]float64 {
+	values := make(map[string]float64)
+	for field, value := range map[string]*float64{
+		"other_match": sr.OtherMatch,
+		"one_match":   sr.OneMatch,
+		"own_match":   sr.OwnMatch,
+		"join_match":  sr.JoinMatch,
+	} {
+		if value != nil {
+			values[field] = *value
+		}
+	}
+	return values
+}
+
+// ReportContentHash returns the hex encoded SHA-256 hash of canonical report content
+func ReportContentHash(canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalReportValue normalizes line endings and surrounding whitespace before hashing
+func canonicalReportValue(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	return fmt.Sprintf("%q", strings.TrimSpace(value))
+}
+
+// CanonicalContent returns the signed fields of the report in a stable textual form
+func (sr *SupervisorReport) CanonicalContent() string {
+	grade := ""
+	if sr.Grade != nil {
+		grade = fmt.Sprintf("%d", *sr.Grade)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "report_type=%s\n", ReportTypeSupervisor)
+	fmt.Fprintf(&b, "student_record_id=%d\n", sr.StudentRecordID)
+	fmt.Fprintf(&b, "revision=%d\n", sr.Revision)
+	fmt.Fprintf(&b, "supervisor_comments=%s\n", canonicalReportValue(sr.SupervisorComments))
+	fmt.Fprintf(&b, "supervisor_name=%s\n", canonicalReportValue(sr.SupervisorName.String))
+	fmt.Fprintf(&b, "supervisor_position=%s\n", canonicalReportValue(sr.SupervisorPosition.String))
+	fmt.Fprintf(&b, "supervisor_workplace=%s\n", canonicalReportValue(sr.SupervisorWorkplace))
+	fmt.Fprintf(&b, "is_pass_or_failed=%t\n", sr.IsPassOrFailed)
+	fmt.Fprintf(&b, "other_match=%.2f\n", sr.OtherMatch)
+	fmt.Fprintf(&b, "one_match=%.2f\n", sr.OneMatch)
+	fmt.Fprintf(&b, "own_match=%.2f\n", sr.OwnMatch)
+	fmt.Fprintf(&b, "join_match=%.2f\n", sr.JoinMatch)
+	fmt.Fprintf(&b, "grade=%s\n", grade)
+	fmt.Fprintf(&b, "final_comments=%s\n", canonicalReportValue(sr.FinalComments.String))
+	return b.String()
+}
+
+// CanonicalContent returns the signed fields of the report in a stable textual form
+func (rr *ReviewerReport) CanonicalContent() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "report_type=%s\n", ReportTypeReviewer)
+	fmt.Fprintf(&b, "student_record_id=%d\n", rr.StudentRecordID)
+	fmt.Fprintf(&b, "revision=%d\n", rr.Revision)
+	fmt.Fprintf(&b, "reviewer_personal_details=%s\n", canonicalReportValue(rr.ReviewerPersonalDetails))
+	fmt.Fprintf(&b, "grade=%.1f\n", rr.Grade)
+	fmt.Fprintf(&b, "review_goals=%s\n", canonicalReportValue(rr.ReviewGoals))
+	fmt.Fprintf(&b, "review_theory=%s\n", canonicalReportValue(rr.ReviewTheory))
+	fmt.Fprintf(&b, "review_practical=%s\n", canonicalReportValue(rr.ReviewPractical))
+	fmt.Fprintf(&b, "review_theory_practical_link=%s\n", canonicalReportValue(rr.ReviewTheoryPracticalLink))
+	fmt.Fprintf(&b, "review_results=%s\n", canonicalReportValue(rr.ReviewResults))
+	fmt.Fprintf(&b, "review_practical_significance=%s\n", canonicalReportValue(StringValue(rr.ReviewPracticalSignificance)))
+	fmt.Fprintf(&b, "review_language=%s\n", canonicalReportValue(rr.ReviewLanguage))
+	fmt.Fprintf(&b, "review_pros=%s\n", canonicalReportValue(rr.ReviewPros))
+	fmt.Fprintf(&b, "review_cons=%s\n", canonicalReportValue(rr.ReviewCons))
+	fmt.Fprintf(&b, "review_questions=%s\n", canonicalReportValue(rr.ReviewQuestions))
+
+	// Reports graded without a rubric keep the content they were signed with
+	criterionIDs := make([]int, 0, len(rr.RubricScores))
+	for criterionID := range rr.RubricScores {
+		criterionIDs = append(criterionIDs, criterionID)
+	}
+	sort.Ints(criterionIDs)
+	for _, criterionID := range criterionIDs {
+		fmt.Fprintf(&b, "rubric_score[%d]=%.1f\n", criterionID, rr.RubricScores[criterionID])
+	}
+	return b.String()
+}
+
 // TopicWithDetails represents topic with additional details
 type TopicWithDetails struct {
 	ProjectTopicRegistration
@@ -2448,11 +2972,21 @@ type StudentInfo struct {
 }
 
 type ValidationResult struct {
-	Valid     bool     `json:"valid"`
-	Warnings  []string `json:"warnings"`
-	Errors    []string `json:"errors"`
-	FileCount int      `json:"file_count"`
-	TotalSize int64    `json:"total_size"`
+	Valid     bool              `json:"valid"`
+	Warnings  []string          `json:"warnings"`
+	Errors    []string          `json:"errors"`
+	Issues    []ValidationIssue `json:"issues,omitempty"`
+	FileCount int               `json:"file_count"`
+	TotalSize int64             `json:"total_size"`
+}
+
+// ValidationIssue is a machine readable rejection reason, such as an archive limit that was exceeded
+type ValidationIssue struct {
+	Code    string `json:"code"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+	Limit   int64  `json:"limit,omitempty"`
+	Actual  int64  `json:"actual,omitempty"`
 }
 
 type RepositoryInfo struct {
@@ -2468,6 +3002,7 @@ type CommitInfo struct {
 	Timestamp  time.Time `json:"timestamp"`
 	FilesCount int       `json:"files_count"`
 	CommitID   string    `json:"commit_id"`
+	Branch     string    `json:"branch,omitempty"` // submission-YYYYMMDD-HHMMSS branch holding this snapshot
 }
 
 // Add to database/models.go if not present
@@ -2477,19 +3012,23 @@ type FilterInfo struct {
 	FilesSkipped     int   `json:"files_skipped"`
 	OriginalSize     int64 `json:"original_size"`
 	SizeAfterFilter  int64 `json:"size_after_filter"`
+
+	// Skipped lists the filtered out entries for the code analysis; it is not part of the stored result
+	Skipped []codeanalysis.ExcludedFile `json:"-"`
 }
 
 // Update SubmissionResult if FilterInfo not included
 type SubmissionResult struct {
-	Success        bool              `json:"success"`
-	Message        string            `json:"message"`
-	Error          string            `json:"error,omitempty"`
-	RepositoryInfo *RepositoryInfo   `json:"repository_info,omitempty"`
-	Validation     *ValidationResult `json:"validation,omitempty"`
-	CommitInfo     *CommitInfo       `json:"commit_info,omitempty"`
-	SubmissionID   string            `json:"submission_id"`
-	DocumentID     int               `json:"document_id,omitempty"`
-	FilterInfo     *FilterInfo       `json:"filter_info,omitempty"`
+	Success        bool                 `json:"success"`
+	Message        string               `json:"message"`
+	Error          string               `json:"error,omitempty"`
+	RepositoryInfo *RepositoryInfo      `json:"repository_info,omitempty"`
+	Validation     *ValidationResult    `json:"validation,omitempty"`
+	CommitInfo     *CommitInfo          `json:"commit_info,omitempty"`
+	SubmissionID   string               `json:"submission_id"`
+	DocumentID     int                  `json:"document_id,omitempty"`
+	FilterInfo     *FilterInfo          `json:"filter_info,omitempty"`
+	Analysis       *codeanalysis.Report `json:"analysis,omitempty"`
 }
 
 func (d *Document) IsSourceCode() bool {
@@ -2532,6 +3071,9 @@ type StudentDashboardData struct {
 	SupervisorReport *SupervisorReport `json:"supervisor_report,omitempty"`
 	ReviewerReport   *ReviewerReport   `json:"reviewer_report,omitempty"`
 
+	// Whether the signed reviewer report is already published to the student
+	ReviewerReportPublished bool `json:"reviewer_report_published"`
+
 	// Documents and uploads - using the specific field names your templates expect
 	Documents             []Document `json:"documents,omitempty"`
 	Videos                []Video    `json:"videos,omitempty"`
@@ -2949,11 +3491,15 @@ type ImportOptions struct {
 
 // ReviewerReportFormProps for the template
 type ReviewerReportFormProps struct {
-	StudentRecord *StudentRecord
-	IsReadOnly    bool
-	FormVariant   string // "en" or "lt"
-	ReviewerName  string
-	AccessToken   string // Add this field
+	StudentRecord  *StudentRecord
+	IsReadOnly     bool
+	FormVariant    string // "en" or "lt"
+	ReviewerName   string
+	AccessToken    string // Add this field
+	IsSigned       bool
+	CanUnlock      bool
+	CanViewHistory bool
+	Rubric         *GradingRubric // Grading rubric of the student's study program
 }
 
 // ReviewerReportFormData for form data
@@ -2970,6 +3516,687 @@ type ReviewerReportFormData struct {
 	ReviewPros                  string
 	ReviewCons                  string
 	ReviewQuestions             string
+	RubricScores                map[int]float64 // criterion ID -> score
+}
+
+// GRADING RUBRICS
+
+// GradingRubric is a set of weighted criteria used to suggest the reviewer grade
+type GradingRubric struct {
+	ID                 int       `json:"id" db:"id"`
+	StudyProgram       *string   `json:"study_program" db:"study_program"`
+	Name               string    `json:"name" db:"name"`
+	DeviationThreshold float64   `json:"deviation_threshold" db:"deviation_threshold"`
+	IsActive           bool      `json:"is_active" db:"is_active"`
+	CreatedBy          string    `json:"created_by" db:"created_by"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+
+	Criteria []GradingRubricCriterion `json:"criteria" db:"-"`
+}
+
+// GradingRubricCriterion is one weighted criterion mapped to a reviewer report section
+type GradingRubricCriterion struct {
+	ID           int     `json:"id" db:"id"`
+	RubricID     int     `json:"rubric_id" db:"rubric_id"`
+	Section      string  `json:"section" db:"section"`
+	Title        string  `json:"title" db:"title"`
+	TitleEn      string  `json:"title_en" db:"title_en"`
+	Weight       float64 `json:"weight" db:"weight"`
+	MinScore     float64 `json:"min_score" db:"min_score"`
+	MaxScore     float64 `json:"max_score" db:"max_score"`
+	DisplayOrder int     `json:"display_order" db:"display_order"`
+}
+
+// ReviewerReportScore is the score given for one rubric criterion
+type ReviewerReportScore struct {
+	ID               int       `json:"id" db:"id"`
+	ReviewerReportID int       `json:"reviewer_report_id" db:"reviewer_report_id"`
+	CriterionID      int       `json:"criterion_id" db:"criterion_id"`
+	Score            float64   `json:"score" db:"score"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// GetTitle returns the localized criterion title
+func (c *GradingRubricCriterion) GetTitle(locale string) string {
+	if locale == "en" && c.TitleEn != "" {
+		return c.TitleEn
+	}
+	return c.Title
+}
+
+// GetDisplayName returns the study program the rubric applies to
+func (gr *GradingRubric) GetDisplayName(locale string) string {
+	if gr.StudyProgram != nil && *gr.StudyProgram != "" {
+		return *gr.StudyProgram
+	}
+	if locale == "en" {
+		return "All study programs (default)"
+	}
+	return "Visos studijų programos (numatytasis)"
+}
+
+// TotalWeight returns the sum of criterion weights
+func (gr *GradingRubric) TotalWeight() float64 {
+	total := 0.0
+	for _, c := range gr.Criteria {
+		total += c.Weight
+	}
+	return total
+}
+
+// WeightShare returns the criterion weight as a percentage of the total
+func (gr *GradingRubric) WeightShare(c GradingRubricCriterion) float64 {
+	total := gr.TotalWeight()
+	if total == 0 {
+		return 0
+	}
+	return c.Weight / total * 100
+}
+
+// SuggestedGrade maps the weighted criterion scores to the 1-10 grade scale, rounded to 0.5.
+// A grade is suggested only when every criterion has been scored.
+func (gr *GradingRubric) SuggestedGrade(scores map[int]float64) (float64, bool) {
+	total := gr.TotalWeight()
+	if total == 0 {
+		return 0, false
+	}
+
+	weighted := 0.0
+	for _, c := range gr.Criteria {
+		score, ok := scores[c.ID]
+		if !ok {
+			return 0, false
+		}
+		normalized := 1.0
+		if c.MaxScore > c.MinScore {
+			normalized = (score - c.MinScore) / (c.MaxScore - c.MinScore)
+		}
+		weighted += c.Weight * normalized
+	}
+
+	grade := 1 + 9*weighted/total
+	return math.Round(grade*2) / 2, true
+}
+
+// GradeDeviates reports whether the entered grade differs from the suggested one by more than the threshold
+func (gr *GradingRubric) GradeDeviates(grade float64, scores map[int]float64) bool {
+	suggested, ok := gr.SuggestedGrade(scores)
+	if !ok || grade == 0 {
+		return false
+	}
+	return math.Abs(grade-suggested) > gr.DeviationThreshold
+}
+
+// REVIEWER CONFLICT DECLARATIONS
+
+const (
+	ConflictFlagSelfDeclared     = "self_declared"
+	ConflictFlagSameAsSupervisor = "same_as_supervisor"
+	ConflictFlagSameWorkplace    = "same_workplace"
+	ConflictFlagReviewedBefore   = "reviewed_before"
+
+	ConflictStatusClear    = "clear"
+	ConflictStatusFlagged  = "flagged"
+	ConflictStatusApproved = "approved"
+	ConflictStatusRejected = "rejected"
+)
+
+// ReviewerConflictDeclaration is the reviewer's conflict-of-interest statement for one assignment
+type ReviewerConflictDeclaration struct {
+	ID                int        `json:"id" db:"id"`
+	StudentRecordID   int        `json:"student_record_id" db:"student_record_id"`
+	ReviewerEmail     string     `json:"reviewer_email" db:"reviewer_email"`
+	ReviewerName      string     `json:"reviewer_name" db:"reviewer_name"`
+	ReviewerWorkplace string     `json:"reviewer_workplace" db:"reviewer_workplace"`
+	HasConflict       bool       `json:"has_conflict" db:"has_conflict"`
+	ConflictDetails   *string    `json:"conflict_details" db:"conflict_details"`
+	AutoFlags         string     `json:"auto_flags" db:"auto_flags"`
+	Status            string     `json:"status" db:"status"`
+	ReviewedBy        *string    `json:"reviewed_by" db:"reviewed_by"`
+	ReviewedAt        *time.Time `json:"reviewed_at" db:"reviewed_at"`
+	ReviewNote        *string    `json:"review_note" db:"review_note"`
+	IPAddress         *string    `json:"ip_address" db:"ip_address"`
+	DeclaredAt        time.Time  `json:"declared_at" db:"declared_at"`
+}
+
+// Flags returns the conflict flags, the self-declared one first
+func (d *ReviewerConflictDeclaration) Flags() []string {
+	var flags []string
+	if d.HasConflict {
+		flags = append(flags, ConflictFlagSelfDeclared)
+	}
+	for _, flag := range strings.Split(d.AutoFlags, ",") {
+		if flag != "" {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// HasFlag reports whether the declaration carries the given flag
+func (d *ReviewerConflictDeclaration) HasFlag(flag string) bool {
+	for _, f := range d.Flags() {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBlocking reports whether the flags prevent reviewing until a department head decides.
+// Workplace and repeated-review flags are only surfaced; the supervisor acting as reviewer can never be approved.
+func (d *ReviewerConflictDeclaration) IsBlocking() bool {
+	return d.HasConflict || d.HasFlag(ConflictFlagSameAsSupervisor)
+}
+
+// CanReview reports whether the reviewer may open the review form
+func (d *ReviewerConflictDeclaration) CanReview() bool {
+	switch d.Status {
+	case ConflictStatusRejected:
+		return false
+	case ConflictStatusApproved:
+		return !d.HasFlag(ConflictFlagSameAsSupervisor)
+	default:
+		return !d.IsBlocking()
+	}
+}
+
+// FlaggedReviewerAssignment is a flagged declaration with the assignment details for department heads
+type FlaggedReviewerAssignment struct {
+	ReviewerConflictDeclaration
+	StudentName       string  `json:"student_name" db:"student_name"`
+	StudentLastname   string  `json:"student_lastname" db:"student_lastname"`
+	StudentGroup      string  `json:"student_group" db:"student_group"`
+	StudyProgram      string  `json:"study_program" db:"study_program"`
+	Department        string  `json:"department" db:"department"`
+	SupervisorEmail   string  `json:"supervisor_email" db:"supervisor_email"`
+	CurrentReviewer   *string `json:"current_reviewer" db:"current_reviewer"`
+	FinalProjectTitle string  `json:"final_project_title" db:"final_project_title"`
+}
+
+// IsCurrent reports whether the declaring reviewer is still assigned to the student
+func (f *FlaggedReviewerAssignment) IsCurrent() bool {
+	return f.CurrentReviewer != nil && strings.EqualFold(*f.CurrentReviewer, f.ReviewerEmail)
+}
+
+// REVIEWER POOL AND ASSIGNMENT
+
+// ReviewerPoolMember is a reviewer available to a department for assignments
+type ReviewerPoolMember struct {
+	ID            int       `json:"id" db:"id"`
+	Department    string    `json:"department" db:"department"`
+	ReviewerEmail string    `json:"reviewer_email" db:"reviewer_email"`
+	ReviewerName  string    `json:"reviewer_name" db:"reviewer_name"`
+	Workplace     string    `json:"workplace" db:"workplace"`
+	Keywords      *string   `json:"keywords" db:"keywords"`
+	MaxLoad       int       `json:"max_load" db:"max_load"`
+	IsActive      bool      `json:"is_active" db:"is_active"`
+	CreatedBy     string    `json:"created_by" db:"created_by"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+
+	CurrentLoad int `json:"current_load" db:"current_load"`
+}
+
+// HasCapacity reports whether the reviewer can take another student
+func (m *ReviewerPoolMember) HasCapacity() bool {
+	return m.MaxLoad == 0 || m.CurrentLoad < m.MaxLoad
+}
+
+// ReviewerAssignmentCandidate is a student waiting for a (new) reviewer
+type ReviewerAssignmentCandidate struct {
+	StudentRecordID     int     `json:"student_record_id" db:"student_record_id"`
+	StudentName         string  `json:"student_name" db:"student_name"`
+	StudentLastname     string  `json:"student_lastname" db:"student_lastname"`
+	StudentGroup        string  `json:"student_group" db:"student_group"`
+	StudyProgram        string  `json:"study_program" db:"study_program"`
+	Department          string  `json:"department" db:"department"`
+	SupervisorEmail     string  `json:"supervisor_email" db:"supervisor_email"`
+	FinalProjectTitle   string  `json:"final_project_title" db:"final_project_title"`
+	TopicTitle          *string `json:"topic_title" db:"topic_title"`
+	TopicTitleEn        *string `json:"topic_title_en" db:"topic_title_en"`
+	TopicProblem        *string `json:"topic_problem" db:"topic_problem"`
+	CurrentReviewer     *string `json:"current_reviewer" db:"current_reviewer"`
+	SupervisorWorkplace *string `json:"supervisor_workplace" db:"supervisor_workplace"`
+
+	ProposedReviewer string   `json:"proposed_reviewer" db:"-"`
+	MatchedKeywords  []string `json:"matched_keywords" db:"-"`
+}
+
+// GetTitle returns the approved topic title, falling back to the imported project title
+func (c *ReviewerAssignmentCandidate) GetTitle() string {
+	if c.TopicTitle != nil && *c.TopicTitle != "" {
+		return *c.TopicTitle
+	}
+	return c.FinalProjectTitle
+}
+
+// TopicText is the text matched against reviewer keywords
+func (c *ReviewerAssignmentCandidate) TopicText() string {
+	return strings.Join([]string{c.GetTitle(), StringValue(c.TopicTitleEn), StringValue(c.TopicProblem)}, " ")
+}
+
+// ReviewerAssignmentResult is the outcome of confirming one assignment
+type ReviewerAssignmentResult struct {
+	StudentName   string
+	ReviewerEmail string
+	AccessURL     string
+	Error         string
+}
+
+// REVIEWER QUESTION ANSWERS
+
+// DepartmentReviewPolicy controls publication of signed reviewer reports to students
+type DepartmentReviewPolicy struct {
+	ID                  int       `json:"id" db:"id"`
+	Department          string    `json:"department" db:"department"`
+	PublishToStudents   bool      `json:"publish_to_students" db:"publish_to_students"`
+	PublishDelayHours   int       `json:"publish_delay_hours" db:"publish_delay_hours"`
+	AnswerDeadlineHours int       `json:"answer_deadline_hours" db:"answer_deadline_hours"`
+	UpdatedBy           string    `json:"updated_by" db:"updated_by"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultDepartmentReviewPolicy publishes on signing and closes answers a day before the defense
+func DefaultDepartmentReviewPolicy(department string) *DepartmentReviewPolicy {
+	return &DepartmentReviewPolicy{
+		Department:          department,
+		PublishToStudents:   true,
+		AnswerDeadlineHours: 24,
+	}
+}
+
+// PublishedAt returns when a signed report becomes visible to the student
+func (p *DepartmentReviewPolicy) PublishedAt(report *ReviewerReport) (time.Time, bool) {
+	if report == nil || !report.IsSigned || !p.PublishToStudents {
+		return time.Time{}, false
+	}
+	signedAt := report.UpdatedDate
+	if report.SignedAt != nil {
+		signedAt = *report.SignedAt
+	}
+	return signedAt.Add(time.Duration(p.PublishDelayHours) * time.Hour), true
+}
+
+// IsPublished reports whether the student may see the reviewer report now
+func (p *DepartmentReviewPolicy) IsPublished(report *ReviewerReport) bool {
+	publishedAt, ok := p.PublishedAt(report)
+	return ok && !time.Now().Before(publishedAt)
+}
+
+// AnswerDeadline returns the last moment for answers; without a defense date there is none yet
+func (p *DepartmentReviewPolicy) AnswerDeadline(defenseDate sql.NullTime) (time.Time, bool) {
+	if !defenseDate.Valid {
+		return time.Time{}, false
+	}
+	return defenseDate.Time.Add(-time.Duration(p.AnswerDeadlineHours) * time.Hour), true
+}
+
+// ReviewerQuestionAnswer is the student's written answer to one reviewer question
+type ReviewerQuestionAnswer struct {
+	ID              int       `json:"id" db:"id"`
+	StudentRecordID int       `json:"student_record_id" db:"student_record_id"`
+	QuestionNumber  int       `json:"question_number" db:"question_number"`
+	QuestionText    string    `json:"question_text" db:"question_text"`
+	Answer          string    `json:"answer" db:"answer"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// StudentReviewPageData is the student view of the published reviewer report
+type StudentReviewPageData struct {
+	Student     *StudentRecord
+	Report      *ReviewerReport
+	Questions   []string
+	Answers     map[int]ReviewerQuestionAnswer
+	PublishedAt time.Time
+	Deadline    *time.Time
+	CanAnswer   bool
+}
+
+// SplitReviewQuestions splits the free-text questions field into separate questions.
+// Numbered or bulleted lines start a new question; without markers every line is a question.
+func SplitReviewQuestions(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	hasMarkers := false
+	for _, line := range lines {
+		if _, ok := trimQuestionMarker(line); ok {
+			hasMarkers = true
+			break
+		}
+	}
+	if !hasMarkers {
+		return lines
+	}
+
+	var questions []string
+	for _, line := range lines {
+		if question, ok := trimQuestionMarker(line); ok || len(questions) == 0 {
+			questions = append(questions, question)
+		} else {
+			questions[len(questions)-1] += " " + line
+		}
+	}
+	return questions
+}
+
+// trimQuestionMarker removes a leading "1.", "1)", "-" or "•" list marker
+func trimQuestionMarker(line string) (string, bool) {
+	for _, bullet := range []string{"-", "•", "*"} {
+		if strings.HasPrefix(line, bullet+" ") {
+			return strings.TrimSpace(line[len(bullet):]), true
+		}
+	}
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < len(line) && (line[digits] == '.' || line[digits] == ')') {
+		return strings.TrimSpace(line[digits+1:]), true
+	}
+	return line, false
+}
+
+// SUPERVISOR REPORT TEMPLATES
+
+// Placeholders that comment snippets may contain
+const (
+	SnippetPlaceholderStudentName = "{student_name}"
+	SnippetPlaceholderThesisTitle = "{thesis_title}"
+)
+
+// SupervisorProfile holds the personal defaults used to pre-fill new supervisor reports
+type SupervisorProfile struct {
+	ID                  int       `json:"id" db:"id"`
+	SupervisorEmail     string    `json:"supervisor_email" db:"supervisor_email"`
+	SupervisorPosition  string    `json:"supervisor_position" db:"supervisor_position"`
+	SupervisorWorkplace string    `json:"supervisor_workplace" db:"supervisor_workplace"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CommentSnippet is a reusable piece of evaluation text owned by one supervisor
+type CommentSnippet struct {
+	ID              int       `json:"id" db:"id"`
+	SupervisorEmail string    `json:"supervisor_email" db:"supervisor_email"`
+	Title           string    `json:"title" db:"title"`
+	Body            string    `json:"body" db:"body"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Render fills the placeholders with the student's name and the thesis title in the form language
+func (c *CommentSnippet) Render(student *StudentRecord, lang string) string {
+	return strings.NewReplacer(
+		SnippetPlaceholderStudentName, student.GetFullName(),
+		SnippetPlaceholderThesisTitle, student.GetLocalizedTitle(lang),
+	).Replace(c.Body)
+}
+
+// SUPERVISOR REPORT BATCH
+
+// SupervisorBatchItem is one student in the supervisor's batch report overview
+type SupervisorBatchItem struct {
+	Student StudentRecord
+	Report  *SupervisorReport
+	// Why the draft cannot be signed yet, empty when it can
+	ValidationError string
+}
+
+// Status returns "missing", "draft" or "signed"
+func (i *SupervisorBatchItem) Status() string {
+	switch {
+	case i.Report == nil:
+		return "missing"
+	case i.Report.IsSigned:
+		return "signed"
+	default:
+		return "draft"
+	}
+}
+
+// CanSign reports whether the draft passes validation and may be signed
+func (i *SupervisorBatchItem) CanSign() bool {
+	return i.Status() == "draft" && i.ValidationError == ""
+}
+
+// SupervisorBatchSummary counts the reports of a batch overview
+type SupervisorBatchSummary struct {
+	Total             int
+	Missing           int
+	Drafts            int
+	Ready             int
+	Signed            int
+	AverageSimilarity float64
+}
+
+// NewSupervisorBatchSummary totals the items; the similarity average covers filled reports only
+func NewSupervisorBatchSummary(items []SupervisorBatchItem) SupervisorBatchSummary {
+	summary := SupervisorBatchSummary{Total: len(items)}
+	similarity, filled := 0.0, 0
+	for i := range items {
+		switch items[i].Status() {
+		case "missing":
+			summary.Missing++
+			continue
+		case "signed":
+			summary.Signed++
+		default:
+			summary.Drafts++
+			if items[i].CanSign() {
+				summary.Ready++
+			}
+		}
+		similarity += items[i].Report.GetTotalSimilarity()
+		filled++
+	}
+	if filled > 0 {
+		summary.AverageSimilarity = similarity / float64(filled)
+	}
+	return summary
+}
+
+// SupervisorBatchSignResult is the outcome of signing one report in a bulk action
+type SupervisorBatchSignResult struct {
+	StudentID   int
+	StudentName string
+	Revision    int
+	Error       string
+}
+
+// ToReportData converts a stored report back to submission data for validation
+func (sr *SupervisorReport) ToReportData() *SupervisorReportData {
+	return &SupervisorReportData{
+		StudentRecordID:     sr.StudentRecordID,
+		SupervisorComments:  sr.SupervisorComments,
+		SupervisorName:      sr.GetSupervisorName(),
+		SupervisorPosition:  sr.GetSupervisorPosition(),
+		SupervisorWorkplace: sr.SupervisorWorkplace,
+		IsPassOrFailed:      sr.IsPassOrFailed,
+		OtherMatch:          sr.OtherMatch,
+		OneMatch:            sr.OneMatch,
+		OwnMatch:            sr.OwnMatch,
+		JoinMatch:           sr.JoinMatch,
+		Grade:               sr.Grade,
+		FinalComments:       sr.GetFinalComments(),
+	}
+}
+
+// SOURCE UPLOAD JOBS
+
+const (
+	UploadJobQueued     = "queued"
+	UploadJobProcessing = "processing"
+	UploadJobDone       = "done"
+	UploadJobFailed     = "failed"
+)
+
+// Upload sources: a spooled archive or a Git repository imported with its history
+const (
+	UploadSourceArchive = "archive"
+	UploadSourceGit     = "git"
+)
+
+// SourceUploadJob is a persisted source code upload; an archive stays spooled at SpoolPath until the job finishes
+type SourceUploadJob struct {
+	ID               int        `db:"id" json:"-"`
+	SubmissionID     string     `db:"submission_id" json:"submission_id"`
+	StudentRecordID  int        `db:"student_record_id" json:"student_record_id"`
+	StudentName      string     `db:"student_name" json:"-"`
+	StudentNumber    string     `db:"student_number" json:"-"`
+	StudentEmail     string     `db:"student_email" json:"-"`
+	ThesisTitle      *string    `db:"thesis_title" json:"-"`
+	UploadedByEmail  string     `db:"uploaded_by_email" json:"uploaded_by_email"`
+	UploadedByRole   string     `db:"uploaded_by_role" json:"uploaded_by_role"`
+	UploadIP         *string    `db:"upload_ip" json:"-"`
+	UploadUserAgent  *string    `db:"upload_user_agent" json:"-"`
+	OriginalFilename string     `db:"original_filename" json:"filename"`
+	FileSize         int64      `db:"file_size" json:"file_size"`
+	SpoolPath        string     `db:"spool_path" json:"-"`
+	SourceType       string     `db:"source_type" json:"source_type"`
+	ImportURL        *string    `db:"import_url" json:"import_url,omitempty"`
+	ImportRef        *string    `db:"import_ref" json:"import_ref,omitempty"`
+	Status           string     `db:"status" json:"status"`
+	Stage            string     `db:"stage" json:"stage"`
+	Progress         int        `db:"progress" json:"progress"`
+	Attempts         int        `db:"attempts" json:"attempts"`
+	MaxAttempts      int        `db:"max_attempts" json:"max_attempts"`
+	LeaseOwner       *string    `db:"lease_owner" json:"-"`
+	LeaseExpiresAt   *time.Time `db:"lease_expires_at" json:"-"`
+	NextAttemptAt    time.Time  `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError        *string    `db:"last_error" json:"last_error,omitempty"`
+	ResultJSON       *string    `db:"result_json" json:"-"`
+	DocumentID       *int       `db:"document_id" json:"document_id,omitempty"`
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at" json:"updated_at"`
+	StartedAt        *time.Time `db:"started_at" json:"started_at,omitempty"`
+	FinishedAt       *time.Time `db:"finished_at" json:"finished_at,omitempty"`
+}
+
+// StudentInfo rebuilds the form data the job was submitted with
+func (j *SourceUploadJob) StudentInfo() *StudentInfo {
+	return &StudentInfo{
+		Name:        j.StudentName,
+		StudentID:   j.StudentNumber,
+		Email:       j.StudentEmail,
+		ThesisTitle: StringValue(j.ThesisTitle),
+	}
+}
+
+// IsGitImport reports whether the job clones a Git repository instead of extracting an archive
+func (j *SourceUploadJob) IsGitImport() bool {
+	return j.SourceType == UploadSourceGit
+}
+
+// IsOnBehalf reports whether the upload was made by someone other than the student
+func (j *SourceUploadJob) IsOnBehalf() bool {
+	return j.UploadedByEmail != "" && !strings.EqualFold(j.UploadedByEmail, j.StudentEmail)
+}
+
+// IsFinished reports whether the job reached done or failed
+func (j *SourceUploadJob) IsFinished() bool {
+	return j.Status == UploadJobDone || j.Status == UploadJobFailed
+}
+
+// Result decodes the stored submission result, nil while the job is unfinished
+func (j *SourceUploadJob) Result() *SubmissionResult {
+	if j.ResultJSON == nil {
+		return nil
+	}
+	var result SubmissionResult
+	if err := json.Unmarshal([]byte(*j.ResultJSON), &result); err != nil {
+		return nil
+	}
+	return &result
+}
+
+// CODE SIMILARITY
+
+const (
+	CodeSimilarityPending   = "pending"
+	CodeSimilarityRunning   = "running"
+	CodeSimilarityCompleted = "completed"
+	CodeSimilarityFailed    = "failed"
+)
+
+// CodeSimilarityRun compares the source code submissions of one program and year with each other
+// and, optionally, with earlier years of the same program
+type CodeSimilarityRun struct {
+	ID                       int        `db:"id" json:"id"`
+	Department               string     `db:"department" json:"department"`
+	StudyProgram             string     `db:"study_program" json:"study_program"`
+	CurrentYear              int        `db:"current_year" json:"current_year"`
+	IncludePreviousYears     bool       `db:"include_previous_years" json:"include_previous_years"`
+	Status                   string     `db:"status" json:"status"`
+	SubmissionsCount         int        `db:"submissions_count" json:"submissions_count"`
+	PreviousSubmissionsCount int        `db:"previous_submissions_count" json:"previous_submissions_count"`
+	PairsCount               int        `db:"pairs_count" json:"pairs_count"`
+	ErrorMessage             *string    `db:"error_message" json:"error_message,omitempty"`
+	CreatedBy                string     `db:"created_by" json:"created_by"`
+	CreatedAt                time.Time  `db:"created_at" json:"created_at"`
+	CompletedAt              *time.Time `db:"completed_at" json:"completed_at,omitempty"`
+}
+
+// IsFinished reports whether the run completed or failed
+func (r *CodeSimilarityRun) IsFinished() bool {
+	return r.Status == CodeSimilarityCompleted || r.Status == CodeSimilarityFailed
+}
+
+// CodeSimilarityScope is a program and year with source code submissions that a run can cover
+type CodeSimilarityScope struct {
+	Department   string `db:"department" json:"department"`
+	StudyProgram string `db:"study_program" json:"study_program"`
+	CurrentYear  int    `db:"current_year" json:"current_year"`
+	Submissions  int    `db:"submissions" json:"submissions"`
+}
+
+// Key identifies the scope in forms
+func (s CodeSimilarityScope) Key() string {
+	return fmt.Sprintf("%d:%s", s.CurrentYear, s.StudyProgram)
+}
+
+// CodeSimilarityPair is a suspicious pair of submissions found by a run. Student B belongs to an
+// earlier year when PreviousYear is set.
+type CodeSimilarityPair struct {
+	ID                 int     `db:"id" json:"id"`
+	RunID              int     `db:"run_id" json:"run_id"`
+	DocumentAID        int     `db:"document_a_id" json:"document_a_id"`
+	StudentAID         int     `db:"student_a_id" json:"student_a_id"`
+	DocumentBID        int     `db:"document_b_id" json:"document_b_id"`
+	StudentBID         int     `db:"student_b_id" json:"student_b_id"`
+	PreviousYear       bool    `db:"previous_year" json:"previous_year"`
+	Score              float64 `db:"score" json:"score"`
+	PercentA           float64 `db:"percent_a" json:"percent_a"`
+	PercentB           float64 `db:"percent_b" json:"percent_b"`
+	SharedFingerprints int     `db:"shared_fingerprints" json:"shared_fingerprints"`
+	MatchesJSON        *string `db:"matches_json" json:"-"`
+
+	// Joined from student_records
+	StudentAName  string `db:"student_a_name" json:"student_a_name"`
+	StudentAGroup string `db:"student_a_group" json:"student_a_group"`
+	StudentBName  string `db:"student_b_name" json:"student_b_name"`
+	StudentBGroup string `db:"student_b_group" json:"student_b_group"`
+	StudentBYear  int    `db:"student_b_year" json:"student_b_year"`
+}
+
+// Matches decodes the matched fragments, largest first
+func (p *CodeSimilarityPair) Matches() []similarity.CodeMatch {
+	if p.MatchesJSON == nil {
+		return nil
+	}
+	var matches []similarity.CodeMatch
+	if err := json.Unmarshal([]byte(*p.MatchesJSON), &matches); err != nil {
+		return nil
+	}
+	return matches
 }
 
 // COMMISION
@@ -3035,3 +4262,38 @@ func (a AccessInfo) BuildPath(pattern string, args ...interface{}) string {
 	}
 	return fmt.Sprintf("/%s/%s%s", a.Type, a.Code, fmt.Sprintf(pattern, args...))
 }
+
+// DEFENSE PERIODS
+
+// Access link types, as they appear in AccessInfo.Type
+const (
+	AccessTypeCommission = "commission"
+	AccessTypeReviewer   = "reviewer"
+)
+
+// DefensePeriod is a window in which commission and reviewer links may open students' work.
+// StudyProgram nil covers every program of the department.
+type DefensePeriod struct {
+	ID             int        `json:"id" db:"id"`
+	Department     string     `json:"department" db:"department"`
+	StudyProgram   *string    `json:"study_program" db:"study_program"`
+	ReviewStartsAt *time.Time `json:"review_starts_at" db:"review_starts_at"`
+	StartsAt       time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt         time.Time  `json:"ends_at" db:"ends_at"`
+	Description    *string    `json:"description" db:"description"`
+	CreatedBy      string     `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// OpensAt is when the period opens for the access type; reviewers may be let in before the defense
+func (p *DefensePeriod) OpensAt(accessType string) time.Time {
+	if accessType == AccessTypeReviewer && p.ReviewStartsAt != nil && p.ReviewStartsAt.Before(p.StartsAt) {
+		return *p.ReviewStartsAt
+	}
+	return p.StartsAt
+}
+
+// IsOpen reports whether the access type may use the period at the given time
+func (p *DefensePeriod) IsOpen(accessType string, at time.Time) bool {
+	return !at.Before(p.OpensAt(accessType)) && at.Before(p.EndsAt)
+}
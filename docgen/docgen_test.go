@@ -0,0 +1,107 @@
+package docgen
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func sampleDocument() *Document {
+	doc := New("Baigiamojo darbo temos registracijos lapas", "Final Project Topic Registration Form")
+	doc.Heading("Studentas / Student")
+	doc.Field("Vardas, pavardė / Name", "Žygimantas Šėtaitis")
+	doc.Field("Tema / Title", "Mašininio mokymosi taikymas <&> analizei")
+	doc.Paragraph(strings.Repeat("Ilgas tekstas, kuris turi būti perkeltas į kelias eilutes. ", 200))
+	doc.Signature("Vadovas / Supervisor", "dr. Jonas Jonaitis")
+	doc.Footer = "Sugeneruota / Generated"
+	return doc
+}
+
+func TestParseFormat(t *testing.T) {
+	cases := map[string]Format{"": FormatPDF, "PDF": FormatPDF, "docx": FormatDOCX, "word": FormatDOCX}
+	for in, want := range cases {
+		got, err := ParseFormat(in)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("odt"); err == nil {
+		t.Error("expected an error for unsupported format")
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	data, err := sampleDocument().Bytes(FormatPDF)
+	if err != nil {
+		t.Fatalf("RenderPDF: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("output is not a complete PDF file")
+	}
+	if !bytes.Contains(data, []byte("/zcaron")) {
+		t.Error("custom encoding for Lithuanian glyphs is missing")
+	}
+	// The long paragraph must flow onto a second page
+	if !bytes.Contains(data, []byte("/Count 2")) && !bytes.Contains(data, []byte("/Count 3")) {
+		t.Error("expected the document to span multiple pages")
+	}
+}
+
+func TestPDFWrap(t *testing.T) {
+	lines := pdfWrap("vienas du trys keturi penki šeši", fontRegular, 10, 60)
+	if len(lines) < 2 {
+		t.Fatalf("expected wrapped lines, got %v", lines)
+	}
+	for _, line := range lines {
+		if pdfTextWidth(line, fontRegular, 10) > 60 {
+			t.Errorf("line %q exceeds the width", line)
+		}
+	}
+	if got := pdfWrap(strings.Repeat("x", 100), fontRegular, 10, 50); len(got) < 2 {
+		t.Errorf("long word was not split: %v", got)
+	}
+}
+
+func TestRenderDOCX(t *testing.T) {
+	data, err := sampleDocument().Bytes(FormatDOCX)
+	if err != nil {
+		t.Fatalf("RenderDOCX: %v", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a zip package: %v", err)
+	}
+
+	var body string
+	for _, f := range reader.File {
+		if f.Name != "word/document.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		body = string(content)
+	}
+
+	if !strings.Contains(body, "Žygimantas Šėtaitis") {
+		t.Error("document body does not contain the field value")
+	}
+	if !strings.Contains(body, "&lt;&amp;&gt;") {
+		t.Error("special characters were not escaped")
+	}
+}
+
+func TestSafeFilename(t *testing.T) {
+	if got := SafeFilename("Žygimantas Šėtaitis/IF-1"); got != "Zygimantas_SetaitisIF-1" {
+		t.Errorf("SafeFilename = %q", got)
+	}
+	if got := SafeFilename("///"); got != "document" {
+		t.Errorf("SafeFilename of empty name = %q", got)
+	}
+}
@@ -0,0 +1,154 @@
+// Package docgen renders simple official documents (forms, reports) as PDF or DOCX
+// without any external dependencies.
+package docgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is an output format of a generated document
+type Format string
+
+const (
+	FormatPDF  Format = "pdf"
+	FormatDOCX Format = "docx"
+)
+
+// ParseFormat converts a query parameter value into a Format, defaulting to PDF
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "pdf":
+		return FormatPDF, nil
+	case "docx", "word":
+		return FormatDOCX, nil
+	default:
+		return "", fmt.Errorf("unsupported document format: %s", value)
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	if f == FormatDOCX {
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	}
+	return "application/pdf"
+}
+
+// Extension returns the file extension of the format including the dot
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// BlockKind identifies how a block is laid out
+type BlockKind int
+
+const (
+	BlockHeading BlockKind = iota
+	BlockField
+	BlockParagraph
+	BlockSignature
+	BlockSpacer
+)
+
+// Block is a single element of a document body
+type Block struct {
+	Kind  BlockKind
+	Label string
+	Text  string
+}
+
+// Document is a format-neutral description of an official document
+type Document struct {
+	Title    string
+	Subtitle string
+	Footer   string
+	Blocks   []Block
+}
+
+// New creates an empty document with a title and optional subtitle
+func New(title, subtitle string) *Document {
+	return &Document{Title: title, Subtitle: subtitle}
+}
+
+// Heading starts a new section
+func (d *Document) Heading(text string) *Document {
+	d.Blocks = append(d.Blocks, Block{Kind: BlockHeading, Text: text})
+	return d
+}
+
+// Field adds a labelled value; empty values are rendered as a dash
+func (d *Document) Field(label, value string) *Document {
+	if strings.TrimSpace(value) == "" {
+		value = "-"
+	}
+	d.Blocks = append(d.Blocks, Block{Kind: BlockField, Label: label, Text: value})
+	return d
+}
+
+// Paragraph adds free text; line breaks are preserved
+func (d *Document) Paragraph(text string) *Document {
+	d.Blocks = append(d.Blocks, Block{Kind: BlockParagraph, Text: text})
+	return d
+}
+
+// Signature adds a signature line with the signer's label and name
+func (d *Document) Signature(label, name string) *Document {
+	d.Blocks = append(d.Blocks, Block{Kind: BlockSignature, Label: label, Text: name})
+	return d
+}
+
+// Spacer adds vertical space between blocks
+func (d *Document) Spacer() *Document {
+	d.Blocks = append(d.Blocks, Block{Kind: BlockSpacer})
+	return d
+}
+
+// Render writes the document in the requested format
+func (d *Document) Render(w io.Writer, format Format) error {
+	switch format {
+	case FormatPDF:
+		return d.RenderPDF(w)
+	case FormatDOCX:
+		return d.RenderDOCX(w)
+	default:
+		return fmt.Errorf("unsupported document format: %s", format)
+	}
+}
+
+// Bytes renders the document into memory
+func (d *Document) Bytes(format Format) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.Render(&buf, format); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SafeFilename replaces characters that are not safe in download file names
+func SafeFilename(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('_')
+		default:
+			if ascii, ok := asciiFold[r]; ok {
+				b.WriteRune(ascii)
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "document"
+	}
+	return b.String()
+}
+
+var asciiFold = map[rune]rune{
+	'ą': 'a', 'č': 'c', 'ę': 'e', 'ė': 'e', 'į': 'i', 'š': 's', 'ų': 'u', 'ū': 'u', 'ž': 'z',
+	'Ą': 'A', 'Č': 'C', 'Ę': 'E', 'Ė': 'E', 'Į': 'I', 'Š': 'S', 'Ų': 'U', 'Ū': 'U', 'Ž': 'Z',
+}
@@ -0,0 +1,150 @@
+package docgen
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`
+
+const docxRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+// RenderDOCX writes the document as a minimal WordprocessingML package
+func (d *Document) RenderDOCX(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRelationships},
+		{"word/document.xml", d.docxBody()},
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func (d *Document) docxBody() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	b.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`)
+
+	b.WriteString(docxParagraph(d.Title, 28, true, "center"))
+	if d.Subtitle != "" {
+		b.WriteString(docxParagraph(d.Subtitle, 20, false, "center"))
+	}
+
+	// Consecutive fields are grouped into one two-column table
+	var fields []Block
+	flushFields := func() {
+		if len(fields) == 0 {
+			return
+		}
+		b.WriteString(docxFieldTable(fields))
+		fields = nil
+	}
+
+	for _, block := range d.Blocks {
+		if block.Kind == BlockField {
+			fields = append(fields, block)
+			continue
+		}
+		flushFields()
+
+		switch block.Kind {
+		case BlockHeading:
+			b.WriteString(docxParagraph(block.Text, 22, true, ""))
+		case BlockParagraph:
+			b.WriteString(docxParagraph(block.Text, 20, false, ""))
+		case BlockSignature:
+			b.WriteString(`<w:p><w:pPr><w:spacing w:before="480"/></w:pPr>`)
+			b.WriteString(docxRun(block.Label+"  ", 18, true))
+			b.WriteString(docxRun("______________________________  "+block.Text, 18, false))
+			b.WriteString(`</w:p>`)
+		case BlockSpacer:
+			b.WriteString(`<w:p/>`)
+		}
+	}
+	flushFields()
+
+	if d.Footer != "" {
+		b.WriteString(docxParagraph(d.Footer, 16, false, ""))
+	}
+
+	b.WriteString(`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/>`)
+	b.WriteString(`<w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" w:header="709" w:footer="709" w:gutter="0"/>`)
+	b.WriteString(`</w:sectPr></w:body></w:document>`)
+	return b.String()
+}
+
+func docxFieldTable(fields []Block) string {
+	var b strings.Builder
+	b.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		b.WriteString(`<w:` + side + ` w:val="single" w:sz="4" w:space="0" w:color="999999"/>`)
+	}
+	b.WriteString(`</w:tblBorders></w:tblPr><w:tblGrid><w:gridCol w:w="3200"/><w:gridCol w:w="6400"/></w:tblGrid>`)
+	for _, field := range fields {
+		b.WriteString(`<w:tr><w:tc><w:tcPr><w:tcW w:w="3200" w:type="dxa"/></w:tcPr>`)
+		b.WriteString(docxParagraph(field.Label, 18, true, ""))
+		b.WriteString(`</w:tc><w:tc><w:tcPr><w:tcW w:w="6400" w:type="dxa"/></w:tcPr>`)
+		b.WriteString(docxParagraph(field.Text, 20, false, ""))
+		b.WriteString(`</w:tc></w:tr>`)
+	}
+	b.WriteString(`</w:tbl>`)
+	return b.String()
+}
+
+func docxParagraph(text string, halfPoints int, bold bool, align string) string {
+	var b strings.Builder
+	b.WriteString(`<w:p>`)
+	if align != "" {
+		b.WriteString(`<w:pPr><w:jc w:val="` + align + `"/></w:pPr>`)
+	}
+	b.WriteString(docxRun(text, halfPoints, bold))
+	b.WriteString(`</w:p>`)
+	return b.String()
+}
+
+// docxRun renders text with line breaks preserved as <w:br/>
+func docxRun(text string, halfPoints int, bold bool) string {
+	var b strings.Builder
+	b.WriteString(`<w:r><w:rPr>`)
+	if bold {
+		b.WriteString(`<w:b/>`)
+	}
+	b.WriteString(`<w:sz w:val="` + strconv.Itoa(halfPoints) + `"/></w:rPr>`)
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString(`<w:br/>`)
+		}
+		b.WriteString(`<w:t xml:space="preserve">`)
+		xml.EscapeText(&b, []byte(line))
+		b.WriteString(`</w:t>`)
+	}
+	b.WriteString(`</w:r>`)
+	return b.String()
+}
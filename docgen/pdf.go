@@ -0,0 +1,411 @@
+package docgen
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// A4 page geometry in PDF points
+const (
+	pdfPageWidth    = 595.28
+	pdfPageHeight   = 841.89
+	pdfMarginX      = 56.0
+	pdfMarginTop    = 60.0
+	pdfMarginBottom = 60.0
+	pdfLabelWidth   = 170.0
+	pdfContentWidth = pdfPageWidth - 2*pdfMarginX
+)
+
+type pdfFont int
+
+const (
+	fontRegular pdfFont = iota
+	fontBold
+)
+
+// pdfGlyph maps a non-ASCII rune to a glyph of the standard Helvetica fonts.
+// The glyphs are addressed through a custom encoding starting at code 128, so
+// Lithuanian text can be rendered without embedding a font.
+type pdfGlyph struct {
+	r     rune
+	name  string
+	width int
+}
+
+var pdfExtraGlyphs = []pdfGlyph{
+	{'ą', "aogonek", 556}, {'Ą', "Aogonek", 667},
+	{'č', "ccaron", 500}, {'Č', "Ccaron", 722},
+	{'ę', "eogonek", 556}, {'Ę', "Eogonek", 667},
+	{'ė', "edotaccent", 556}, {'Ė', "Edotaccent", 667},
+	{'į', "iogonek", 222}, {'Į', "Iogonek", 278},
+	{'š', "scaron", 500}, {'Š', "Scaron", 667},
+	{'ų', "uogonek", 556}, {'Ų', "Uogonek", 722},
+	{'ū', "umacron", 556}, {'Ū', "Umacron", 722},
+	{'ž', "zcaron", 500}, {'Ž', "Zcaron", 611},
+	{'ä', "adieresis", 556}, {'Ä', "Adieresis", 667},
+	{'ö', "odieresis", 556}, {'Ö', "Odieresis", 778},
+	{'ü', "udieresis", 556}, {'Ü', "Udieresis", 722},
+	{'é', "eacute", 556}, {'É', "Eacute", 667},
+	{'–', "endash", 556}, {'—', "emdash", 1000},
+	{'„', "quotedblbase", 333}, {'“', "quotedblleft", 333}, {'”', "quotedblright", 333},
+	{'‘', "quoteleft", 222}, {'’', "quoteright", 222},
+	{'•', "bullet", 350}, {'·', "periodcentered", 278}, {'°', "degree", 400},
+}
+
+var pdfGlyphCodes = func() map[rune]byte {
+	codes := make(map[rune]byte, len(pdfExtraGlyphs))
+	for i, g := range pdfExtraGlyphs {
+		codes[g.r] = byte(128 + i)
+	}
+	return codes
+}()
+
+// Helvetica and Helvetica-Bold advance widths for ASCII 32..126
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// pdfEncode converts text to single-byte codes of the custom font encoding
+func pdfEncode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 32 && r < 127:
+			out = append(out, byte(r))
+		default:
+			if code, ok := pdfGlyphCodes[r]; ok {
+				out = append(out, code)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+func pdfCodeWidth(code byte, font pdfFont) int {
+	if code >= 128 {
+		if idx := int(code) - 128; idx < len(pdfExtraGlyphs) {
+			return pdfExtraGlyphs[idx].width
+		}
+		return 556
+	}
+	if code < 32 || code > 126 {
+		return 556
+	}
+	if font == fontBold {
+		return helveticaBoldWidths[code-32]
+	}
+	return helveticaWidths[code-32]
+}
+
+func pdfTextWidth(s string, font pdfFont, size float64) float64 {
+	total := 0
+	for _, code := range pdfEncode(s) {
+		total += pdfCodeWidth(code, font)
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfWrap breaks text into lines that fit the given width, keeping explicit line breaks
+func pdfWrap(text string, font pdfFont, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		current := ""
+		for _, word := range words {
+			// Words longer than a whole line are split by characters
+			for pdfTextWidth(word, font, size) > width {
+				if current != "" {
+					lines = append(lines, current)
+					current = ""
+				}
+				runes := []rune(word)
+				cut := len(runes)
+				for cut > 1 && pdfTextWidth(string(runes[:cut]), font, size) > width {
+					cut--
+				}
+				lines = append(lines, string(runes[:cut]))
+				word = string(runes[cut:])
+			}
+			if word == "" {
+				continue
+			}
+
+			candidate := word
+			if current != "" {
+				candidate = current + " " + word
+			}
+			if pdfTextWidth(candidate, font, size) <= width {
+				current = candidate
+			} else {
+				lines = append(lines, current)
+				current = word
+			}
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
+	}
+	return lines
+}
+
+func pdfEscape(encoded []byte) string {
+	var b strings.Builder
+	for _, c := range encoded {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c >= 128:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// pdfTextString encodes a string for the document info dictionary as UTF-16BE
+func pdfTextString(s string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", unit)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// pdfLayout places blocks onto pages and collects the page content streams
+type pdfLayout struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64
+}
+
+func (l *pdfLayout) newPage() {
+	l.page = &bytes.Buffer{}
+	l.pages = append(l.pages, l.page)
+	l.y = pdfPageHeight - pdfMarginTop
+}
+
+func (l *pdfLayout) ensure(height float64) {
+	if l.y-height < pdfMarginBottom {
+		l.newPage()
+	}
+}
+
+func (l *pdfLayout) text(x, baseline float64, font pdfFont, size float64, s string) {
+	if s == "" {
+		return
+	}
+	fmt.Fprintf(l.page, "BT /F%d %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+		int(font)+1, size, x, baseline, pdfEscape(pdfEncode(s)))
+}
+
+func (l *pdfLayout) rule(x1, x2, y float64) {
+	fmt.Fprintf(l.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y, x2, y)
+}
+
+func (l *pdfLayout) centered(text string, font pdfFont, size float64) {
+	lineHeight := size * 1.35
+	for _, line := range pdfWrap(text, font, size, pdfContentWidth) {
+		l.ensure(lineHeight)
+		x := (pdfPageWidth - pdfTextWidth(line, font, size)) / 2
+		l.text(x, l.y-size, font, size, line)
+		l.y -= lineHeight
+	}
+}
+
+func (l *pdfLayout) block(b Block) {
+	switch b.Kind {
+	case BlockHeading:
+		l.y -= 8
+		l.ensure(30)
+		l.text(pdfMarginX, l.y-11, fontBold, 11, b.Text)
+		l.y -= 15
+		l.rule(pdfMarginX, pdfPageWidth-pdfMarginX, l.y)
+		l.y -= 8
+
+	case BlockField:
+		labelLines := pdfWrap(b.Label, fontBold, 9, pdfLabelWidth-10)
+		valueLines := pdfWrap(b.Text, fontRegular, 10, pdfContentWidth-pdfLabelWidth)
+		rows := len(labelLines)
+		if len(valueLines) > rows {
+			rows = len(valueLines)
+		}
+		const lineHeight = 13.0
+		for i := 0; i < rows; i++ {
+			l.ensure(lineHeight)
+			if i < len(labelLines) {
+				l.text(pdfMarginX, l.y-10, fontBold, 9, labelLines[i])
+			}
+			if i < len(valueLines) {
+				l.text(pdfMarginX+pdfLabelWidth, l.y-10, fontRegular, 10, valueLines[i])
+			}
+			l.y -= lineHeight
+		}
+		l.y -= 4
+
+	case BlockParagraph:
+		const lineHeight = 13.0
+		for _, line := range pdfWrap(b.Text, fontRegular, 10, pdfContentWidth) {
+			l.ensure(lineHeight)
+			l.text(pdfMarginX, l.y-10, fontRegular, 10, line)
+			l.y -= lineHeight
+		}
+		l.y -= 4
+
+	case BlockSignature:
+		l.y -= 20
+		l.ensure(36)
+		lineStart := pdfMarginX + pdfLabelWidth
+		l.text(pdfMarginX, l.y-9, fontBold, 9, b.Label)
+		l.rule(lineStart, lineStart+190, l.y-11)
+		l.text(lineStart, l.y-22, fontRegular, 8, b.Text)
+		l.y -= 30
+
+	case BlockSpacer:
+		l.y -= 10
+	}
+}
+
+// RenderPDF writes the document as an A4 PDF
+func (d *Document) RenderPDF(w io.Writer) error {
+	layout := &pdfLayout{}
+	layout.newPage()
+
+	layout.centered(d.Title, fontBold, 14)
+	if d.Subtitle != "" {
+		layout.centered(d.Subtitle, fontRegular, 10)
+	}
+	layout.y -= 10
+
+	for _, b := range d.Blocks {
+		layout.block(b)
+	}
+
+	// Footer with page numbers once the page count is known
+	for i, page := range layout.pages {
+		layout.page = page
+		footer := fmt.Sprintf("%d / %d", i+1, len(layout.pages))
+		if d.Footer != "" {
+			layout.text(pdfMarginX, 30, fontRegular, 8, d.Footer)
+		}
+		layout.text(pdfPageWidth-pdfMarginX-pdfTextWidth(footer, fontRegular, 8), 30, fontRegular, 8, footer)
+	}
+
+	return writePDF(w, d.Title, layout.pages)
+}
+
+func writePDF(w io.Writer, title string, pages []*bytes.Buffer) error {
+	var out bytes.Buffer
+	var offsets []int
+
+	beginObject := func() int {
+		offsets = append(offsets, out.Len())
+		id := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n", id)
+		return id
+	}
+	endObject := func() {
+		out.WriteString("endobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// 1: catalog, 2: page tree, 3-4: fonts, 5: encoding, 6: info, then page/content pairs
+	const firstPageObject = 7
+
+	beginObject()
+	out.WriteString("<< /Type /Catalog /Pages 2 0 R >>\n")
+	endObject()
+
+	beginObject()
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d >>\n", strings.Join(kids, " "), len(pages))
+	endObject()
+
+	for _, baseFont := range []string{"Helvetica", "Helvetica-Bold"} {
+		beginObject()
+		fmt.Fprintf(&out, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding 5 0 R >>\n", baseFont)
+		endObject()
+	}
+
+	beginObject()
+	out.WriteString("<< /Type /Encoding /BaseEncoding /WinAnsiEncoding /Differences [128")
+	for _, g := range pdfExtraGlyphs {
+		out.WriteString(" /" + g.name)
+	}
+	out.WriteString("] >>\n")
+	endObject()
+
+	beginObject()
+	fmt.Fprintf(&out, "<< /Title %s /Producer (FinalProjectManagementApp) /CreationDate (D:%s) >>\n",
+		pdfTextString(title), time.Now().UTC().Format("20060102150405Z"))
+	endObject()
+
+	for i, page := range pages {
+		beginObject()
+		fmt.Fprintf(&out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>\n",
+			pdfPageWidth, pdfPageHeight, firstPageObject+2*i+1)
+		endObject()
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+
+		beginObject()
+		fmt.Fprintf(&out, "<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+		out.Write(compressed.Bytes())
+		out.WriteString("\nendstream\n")
+		endObject()
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 6 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, xrefOffset)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
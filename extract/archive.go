@@ -0,0 +1,127 @@
+// extract/archive.go - Archive format detection and directory copies
+package extract
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	zipMagic  = []byte("PK")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// extensions are the accepted upload names, longest first so .tar.gz wins over .gz
+var extensions = []string{".tar.gz", ".tgz", ".tar", ".zip"}
+
+// Extension returns the archive extension of an upload name, or "" when the format is not accepted
+func Extension(name string) string {
+	lower := strings.ToLower(name)
+	for _, ext := range extensions {
+		if strings.HasSuffix(lower, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// Archive extracts a ZIP or (gzip compressed) tar file, detecting the format from its content
+func Archive(src, dst string, limits Limits, skip SkipFunc) (*Result, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	magic := make([]byte, 2)
+	_, err = io.ReadFull(file, magic)
+	file.Close()
+	if err != nil {
+		return nil, &Error{Code: CodeInvalidArchive, Reason: "file is too short"}
+	}
+
+	if bytes.Equal(magic, zipMagic) {
+		return Zip(src, dst, limits, skip)
+	}
+	return Tar(src, dst, limits, skip)
+}
+
+// Entry is a file or directory of a source that is not an archive file, such as a directory or a Git tree
+type Entry struct {
+	Name string // slash separated, relative to the root of the source
+	Size int64
+	Mode fs.FileMode
+	Open func() (io.ReadCloser, error) // only called for extracted regular files
+}
+
+// WalkFunc passes every entry of a source to visit. A directory whose visit returns fs.SkipDir is skipped,
+// so its entries must not be passed on.
+type WalkFunc func(visit func(Entry) error) error
+
+// Walk extracts the entries of a source into dst under the same limits as an archive
+func Walk(dst string, limits Limits, skip SkipFunc, walk WalkFunc) (*Result, error) {
+	e, err := newExtractor(dst, limits, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	err = walk(func(entry Entry) error {
+		if err := e.countEntry(entry.Size); err != nil {
+			return err
+		}
+		name, err := e.cleanName(entry.Name)
+		if err != nil {
+			return err
+		}
+
+		switch mode := entry.Mode; {
+		case mode&fs.ModeSymlink != 0:
+			return &Error{Code: CodeLink, Entry: name}
+		case mode.IsDir():
+			skipped := len(e.result.Skipped)
+			if err := e.dir(name); err != nil {
+				return err
+			}
+			if len(e.result.Skipped) > skipped {
+				return fs.SkipDir
+			}
+			return nil
+		case mode.IsRegular():
+			if name == "" {
+				return &Error{Code: CodeUnsafePath, Entry: entry.Name, Reason: "file without a name"}
+			}
+			return e.file(name, entry.Size, 0, entry.Open)
+		default:
+			return &Error{Code: CodeSpecialFile, Entry: name}
+		}
+	})
+	return e.result, err
+}
+
+// Dir copies the directory tree src into dst under the same limits as an archive. Skipped directories
+// are not descended into.
+func Dir(src, dst string, limits Limits, skip SkipFunc) (*Result, error) {
+	return Walk(dst, limits, skip, func(visit func(Entry) error) error {
+		return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(src, path)
+			if err != nil || rel == "." {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return visit(Entry{
+				Name: filepath.ToSlash(rel),
+				Size: info.Size(),
+				Mode: info.Mode(),
+				Open: func() (io.ReadCloser, error) { return os.Open(path) },
+			})
+		})
+	})
+}
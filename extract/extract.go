@@ -0,0 +1,303 @@
+// extract/extract.go - Hardened extraction of uploaded archives
+package extract
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Limits bound what an uploaded archive may expand to. A zero field disables that check.
+type Limits struct {
+	MaxTotalSize  int64   // bytes written over all entries
+	MaxFileSize   int64   // bytes written for one entry
+	MaxEntries    int     // entries in the archive, including skipped ones
+	MaxRatio      float64 // uncompressed to compressed size, per entry and for the whole archive
+	MaxDepth      int     // directory levels of an entry path
+	MaxPathLength int     // bytes of an entry path
+}
+
+// DefaultLimits fit thesis source code: generous for real projects, far below what a zip bomb expands to
+func DefaultLimits() Limits {
+	return Limits{
+		MaxTotalSize:  1 << 30,
+		MaxFileSize:   200 << 20,
+		MaxEntries:    50000,
+		MaxRatio:      200,
+		MaxDepth:      32,
+		MaxPathLength: 1024,
+	}
+}
+
+// ratioThreshold is the size below which the compression ratio is not checked; small text files compress very well
+const ratioThreshold = 1 << 20
+
+// Error codes reported to the uploader
+const (
+	CodeInvalidArchive = "invalid_archive"
+	CodeUnsafePath     = "unsafe_path"
+	CodeLink           = "link"
+	CodeSpecialFile    = "special_file"
+	CodeDuplicateEntry = "duplicate_entry"
+	CodeTooManyEntries = "too_many_entries"
+	CodeFileTooLarge   = "file_too_large"
+	CodeTotalTooLarge  = "total_too_large"
+	CodeRatioExceeded  = "compression_ratio_exceeded"
+	CodeTooDeep        = "too_deep"
+	CodePathTooLong    = "path_too_long"
+)
+
+// Error is a rejected archive. Limit and Actual are set for the limit checks.
+type Error struct {
+	Code   string
+	Entry  string
+	Limit  int64
+	Actual int64
+	Reason string
+}
+
+func (e *Error) Error() string {
+	var message string
+	switch e.Code {
+	case CodeTooManyEntries:
+		message = fmt.Sprintf("archive has more than %d entries", e.Limit)
+	case CodeFileTooLarge:
+		message = fmt.Sprintf("%s is larger than %s", e.Entry, formatSize(e.Limit))
+	case CodeTotalTooLarge:
+		message = fmt.Sprintf("archive expands to more than %s", formatSize(e.Limit))
+	case CodeRatioExceeded:
+		message = fmt.Sprintf("%s is compressed more than %d:1, which looks like a zip bomb", e.Entry, e.Limit)
+	case CodeTooDeep:
+		message = fmt.Sprintf("%s is nested deeper than %d directories", e.Entry, e.Limit)
+	case CodePathTooLong:
+		message = fmt.Sprintf("path of %.80q is longer than %d characters", e.Entry, e.Limit)
+	case CodeLink:
+		message = fmt.Sprintf("%s is a link; links are not allowed", e.Entry)
+	case CodeSpecialFile:
+		message = fmt.Sprintf("%s is not a regular file or directory", e.Entry)
+	case CodeDuplicateEntry:
+		message = fmt.Sprintf("%s appears more than once", e.Entry)
+	case CodeUnsafePath:
+		message = fmt.Sprintf("unsafe path %q", e.Entry)
+	default:
+		message = "the file is not a valid archive"
+	}
+	if e.Reason != "" {
+		message += ": " + e.Reason
+	}
+	return message
+}
+
+// SkippedEntry is an entry the skip function filtered out
+type SkippedEntry struct {
+	Path string
+	Size int64 // as declared by the archive
+}
+
+// Result summarizes an extraction
+type Result struct {
+	Entries       int // all entries, directories included
+	Extracted     int // entries written, directories included
+	Skipped       []SkippedEntry
+	DeclaredSize  int64 // uncompressed size of all entries as declared by the archive
+	ExtractedSize int64 // bytes actually written
+}
+
+// SkipFunc decides whether an entry is left out; directories are passed with a trailing slash
+type SkipFunc func(name string, size int64) bool
+
+// extractor enforces the limits shared by all archive formats
+type extractor struct {
+	dst         string
+	limits      Limits
+	skip        SkipFunc
+	result      *Result
+	seen        map[string]bool
+	archiveSize int64 // size of the archive file, for the ratio of the whole archive
+}
+
+func newExtractor(dst string, limits Limits, skip SkipFunc) (*extractor, error) {
+	dst, err := filepath.Abs(dst)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return nil, err
+	}
+	return &extractor{dst: dst, limits: limits, skip: skip, result: &Result{}, seen: make(map[string]bool)}, nil
+}
+
+// cleanName validates an entry name and returns it slash separated and relative, "" for the root itself
+func (e *extractor) cleanName(name string) (string, error) {
+	if e.limits.MaxPathLength > 0 && len(name) > e.limits.MaxPathLength {
+		return "", &Error{Code: CodePathTooLong, Entry: name, Limit: int64(e.limits.MaxPathLength), Actual: int64(len(name))}
+	}
+	if strings.ContainsRune(name, 0) {
+		return "", &Error{Code: CodeUnsafePath, Entry: name, Reason: "contains a NUL character"}
+	}
+
+	// Archives created on Windows may use backslashes
+	slashed := strings.ReplaceAll(name, `\`, "/")
+	if strings.HasPrefix(slashed, "/") || (len(slashed) >= 2 && slashed[1] == ':') {
+		return "", &Error{Code: CodeUnsafePath, Entry: name, Reason: "absolute path"}
+	}
+	for _, part := range strings.Split(slashed, "/") {
+		if part == ".." {
+			return "", &Error{Code: CodeUnsafePath, Entry: name, Reason: "path leaves the extraction directory"}
+		}
+	}
+
+	clean := path.Clean(slashed)
+	if clean == "." {
+		return "", nil
+	}
+	if depth := strings.Count(clean, "/") + 1; e.limits.MaxDepth > 0 && depth > e.limits.MaxDepth {
+		return "", &Error{Code: CodeTooDeep, Entry: name, Limit: int64(e.limits.MaxDepth), Actual: int64(depth)}
+	}
+	return clean, nil
+}
+
+// countEntry enforces the entry limit
+func (e *extractor) countEntry(declaredSize int64) error {
+	e.result.Entries++
+	e.result.DeclaredSize += declaredSize
+	if e.limits.MaxEntries > 0 && e.result.Entries > e.limits.MaxEntries {
+		return &Error{Code: CodeTooManyEntries, Limit: int64(e.limits.MaxEntries), Actual: int64(e.result.Entries)}
+	}
+	return nil
+}
+
+// target resolves the path an entry is written to and makes sure no existing link redirects it
+func (e *extractor) target(name string) (string, error) {
+	target := filepath.Join(e.dst, filepath.FromSlash(name))
+	if target != e.dst && !strings.HasPrefix(target, e.dst+string(os.PathSeparator)) {
+		return "", &Error{Code: CodeUnsafePath, Entry: name, Reason: "path leaves the extraction directory"}
+	}
+	// Links are never created, but a directory tree prepared by someone else must not redirect writes either
+	for dir := filepath.Dir(target); dir != e.dst && strings.HasPrefix(dir, e.dst); dir = filepath.Dir(dir) {
+		if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", &Error{Code: CodeLink, Entry: name}
+		}
+	}
+	return target, nil
+}
+
+// dir creates a directory entry with fixed permissions
+func (e *extractor) dir(name string) error {
+	if name == "" {
+		return nil
+	}
+	if e.skip != nil && e.skip(name+"/", 0) {
+		e.result.Skipped = append(e.result.Skipped, SkippedEntry{Path: name + "/"})
+		return nil
+	}
+	target, err := e.target(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return err
+	}
+	e.result.Extracted++
+	return nil
+}
+
+// file streams a regular file entry to disk, enforcing the size and ratio limits on the bytes actually read.
+// compressedSize is 0 when the format has no per-entry compression; open is only called for extracted entries.
+func (e *extractor) file(name string, declaredSize, compressedSize int64, open func() (io.ReadCloser, error)) error {
+	if e.skip != nil && e.skip(name, declaredSize) {
+		e.result.Skipped = append(e.result.Skipped, SkippedEntry{Path: name, Size: declaredSize})
+		return nil
+	}
+	if e.seen[name] {
+		return &Error{Code: CodeDuplicateEntry, Entry: name}
+	}
+	e.seen[name] = true
+
+	if e.limits.MaxFileSize > 0 && declaredSize > e.limits.MaxFileSize {
+		return &Error{Code: CodeFileTooLarge, Entry: name, Limit: e.limits.MaxFileSize, Actual: declaredSize}
+	}
+
+	target, err := e.target(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	r, err := open()
+	if err != nil {
+		return &Error{Code: CodeInvalidArchive, Entry: name, Reason: err.Error()}
+	}
+	defer r.Close()
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+
+	written, copyErr := io.Copy(out, &limitReader{e: e, name: name, compressedSize: compressedSize, r: r})
+	closeErr := out.Close()
+	e.result.ExtractedSize += written
+	if copyErr != nil {
+		return copyErr
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	e.result.Extracted++
+	return nil
+}
+
+// limitReader fails as soon as an entry exceeds a limit, whatever its header claims
+type limitReader struct {
+	e              *extractor
+	name           string
+	compressedSize int64
+	r              io.Reader
+	read           int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	if err != nil && err != io.EOF {
+		return 0, &Error{Code: CodeInvalidArchive, Entry: l.name, Reason: err.Error()}
+	}
+
+	// Bytes past a limit are never passed on, so nothing larger than the limits reaches the disk
+	read := l.read + int64(n)
+	limits := l.e.limits
+	if limits.MaxFileSize > 0 && read > limits.MaxFileSize {
+		return 0, &Error{Code: CodeFileTooLarge, Entry: l.name, Limit: limits.MaxFileSize, Actual: read}
+	}
+	if total := l.e.result.ExtractedSize + read; limits.MaxTotalSize > 0 && total > limits.MaxTotalSize {
+		return 0, &Error{Code: CodeTotalTooLarge, Limit: limits.MaxTotalSize, Actual: total}
+	}
+	if limits.MaxRatio > 0 && l.compressedSize > 0 && read > ratioThreshold {
+		if ratio := float64(read) / float64(l.compressedSize); ratio > limits.MaxRatio {
+			return 0, &Error{Code: CodeRatioExceeded, Entry: l.name, Limit: int64(limits.MaxRatio), Actual: int64(ratio)}
+		}
+	}
+	// Many small entries can each stay below the threshold, so the whole archive is checked as well
+	if total := l.e.result.ExtractedSize + read; limits.MaxRatio > 0 && l.e.archiveSize > 0 && total > ratioThreshold {
+		if ratio := float64(total) / float64(l.e.archiveSize); ratio > limits.MaxRatio {
+			return 0, &Error{Code: CodeRatioExceeded, Entry: "archive", Limit: int64(limits.MaxRatio), Actual: int64(ratio)}
+		}
+	}
+	l.read = read
+	return n, err
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
@@ -0,0 +1,84 @@
+// extract/tar.go - Tar archives, plain or gzip compressed
+package extract
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+)
+
+// Tar extracts the tar file src into dst; gzip compression is detected from the content, not the name.
+// Tar entries carry no compressed size, so the ratio is checked for the archive as a whole.
+func Tar(src, dst string, limits Limits, skip SkipFunc) (*Result, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader = bufio.NewReader(file)
+	if magic, _ := r.(*bufio.Reader).Peek(2); bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, &Error{Code: CodeInvalidArchive, Reason: err.Error()}
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	e, err := newExtractor(dst, limits, skip)
+	if err != nil {
+		return nil, err
+	}
+	e.archiveSize = info.Size()
+
+	reader := tar.NewReader(r)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return e.result, &Error{Code: CodeInvalidArchive, Reason: err.Error()}
+		}
+		// Global pax headers describe the archive, not a file
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		if err := e.countEntry(header.Size); err != nil {
+			return e.result, err
+		}
+		name, err := e.cleanName(header.Name)
+		if err != nil {
+			return e.result, err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeSymlink, tar.TypeLink:
+			return e.result, &Error{Code: CodeLink, Entry: header.Name}
+		case tar.TypeDir:
+			err = e.dir(name)
+		case tar.TypeReg:
+			if name == "" {
+				return e.result, &Error{Code: CodeUnsafePath, Entry: header.Name, Reason: "file without a name"}
+			}
+			err = e.file(name, header.Size, 0, func() (io.ReadCloser, error) { return io.NopCloser(reader), nil })
+		default:
+			return e.result, &Error{Code: CodeSpecialFile, Entry: header.Name}
+		}
+		if err != nil {
+			return e.result, err
+		}
+	}
+	return e.result, nil
+}
@@ -0,0 +1,224 @@
+package extract
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tarEntry describes one entry of a test archive
+type tarEntry struct {
+	name     string
+	content  []byte
+	typeflag byte // tar.TypeReg when zero
+	linkname string
+}
+
+func buildTar(t testing.TB, compress bool, entries ...tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var gz *gzip.Writer
+	w := tar.NewWriter(&buf)
+	if compress {
+		gz = gzip.NewWriter(&buf)
+		w = tar.NewWriter(gz)
+	}
+	for _, entry := range entries {
+		typeflag := entry.typeflag
+		if typeflag == 0 {
+			typeflag = tar.TypeReg
+		}
+		header := &tar.Header{Name: entry.name, Typeflag: typeflag, Linkname: entry.linkname, Mode: 0755}
+		if typeflag == tar.TypeReg {
+			header.Size = int64(len(entry.content))
+		}
+		if err := w.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(entry.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gz != nil {
+		gz.Close()
+	}
+	return buf.Bytes()
+}
+
+func extractTarBytes(t testing.TB, name string, data []byte, limits Limits, skip SkipFunc) (string, *Result, error) {
+	t.Helper()
+	dir := t.TempDir()
+	src := filepath.Join(dir, name)
+	if err := os.WriteFile(src, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	dst := filepath.Join(dir, "out")
+	result, err := Archive(src, dst, limits, skip)
+	return dst, result, err
+}
+
+func TestExtension(t *testing.T) {
+	tests := map[string]string{
+		"project.zip":    ".zip",
+		"Project.ZIP":    ".zip",
+		"project.tar":    ".tar",
+		"project.tar.gz": ".tar.gz",
+		"project.tgz":    ".tgz",
+		"project.gz":     "",
+		"project.7z":     "",
+		"project.rar":    "",
+	}
+	for name, want := range tests {
+		if got := Extension(name); got != want {
+			t.Errorf("Extension(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestTarExtractsValidArchives(t *testing.T) {
+	entries := []tarEntry{
+		{name: "project/", typeflag: tar.TypeDir},
+		{name: "project/main.py", content: []byte("print('hi')\n")},
+		{name: "project/__pycache__/main.pyc", content: []byte("\x00\x01")},
+		{name: "project/scripts/run.sh", content: []byte("#!/bin/sh\n")},
+	}
+	skip := func(name string, size int64) bool { return strings.Contains(name, "__pycache__") }
+
+	for _, compress := range []bool{false, true} {
+		dst, result, err := extractTarBytes(t, "upload.tgz", buildTar(t, compress, entries...), DefaultLimits(), skip)
+		if err != nil {
+			t.Fatalf("compress=%v: Archive() error = %v", compress, err)
+		}
+		if result.Entries != 4 || result.Extracted != 3 || len(result.Skipped) != 1 {
+			t.Errorf("compress=%v: result = %+v", compress, result)
+		}
+		info, err := os.Stat(filepath.Join(dst, "project/scripts/run.sh"))
+		if err != nil {
+			t.Fatalf("compress=%v: run.sh not extracted: %v", compress, err)
+		}
+		if info.Mode().Perm() != 0644 {
+			t.Errorf("compress=%v: mode = %v, want 0644", compress, info.Mode().Perm())
+		}
+	}
+}
+
+// TestTarRejectsAdversarialArchives is the tar counterpart of the ZIP corpus
+func TestTarRejectsAdversarialArchives(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []tarEntry
+		compress bool
+		limits   func(*Limits)
+		code     string
+	}{
+		{name: "parent traversal", entries: []tarEntry{{name: "../evil.sh", content: []byte("x")}}, code: CodeUnsafePath},
+		{name: "absolute path", entries: []tarEntry{{name: "/etc/cron.d/evil", content: []byte("x")}}, code: CodeUnsafePath},
+		{name: "symlink", entries: []tarEntry{{name: "link", typeflag: tar.TypeSymlink, linkname: "/etc"}}, code: CodeLink},
+		{name: "hard link", entries: []tarEntry{{name: "link", typeflag: tar.TypeLink, linkname: "/etc/passwd"}}, code: CodeLink},
+		{name: "character device", entries: []tarEntry{{name: "tty", typeflag: tar.TypeChar}}, code: CodeSpecialFile},
+		{name: "fifo", entries: []tarEntry{{name: "fifo", typeflag: tar.TypeFifo}}, code: CodeSpecialFile},
+		{
+			name:    "duplicate entry",
+			entries: []tarEntry{{name: "a.txt", content: []byte("a")}, {name: "a.txt", content: []byte("b")}},
+			code:    CodeDuplicateEntry,
+		},
+		{
+			name:    "too many entries",
+			entries: []tarEntry{{name: "1"}, {name: "2"}, {name: "3"}},
+			limits:  func(l *Limits) { l.MaxEntries = 2 },
+			code:    CodeTooManyEntries,
+		},
+		{
+			name:    "file too large",
+			entries: []tarEntry{{name: "big.bin", content: make([]byte, 2048)}},
+			limits:  func(l *Limits) { l.MaxFileSize = 1024 },
+			code:    CodeFileTooLarge,
+		},
+		{
+			name:     "gzip bomb",
+			entries:  []tarEntry{{name: "bomb.bin", content: make([]byte, 8<<20)}},
+			compress: true,
+			code:     CodeRatioExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := DefaultLimits()
+			if tt.limits != nil {
+				tt.limits(&limits)
+			}
+			dst, _, err := extractTarBytes(t, "upload.tar", buildTar(t, tt.compress, tt.entries...), limits, nil)
+
+			var extractErr *Error
+			if !errors.As(err, &extractErr) {
+				t.Fatalf("Archive() error = %v, want *Error with code %s", err, tt.code)
+			}
+			if extractErr.Code != tt.code {
+				t.Errorf("code = %s (%v), want %s", extractErr.Code, err, tt.code)
+			}
+			assertContained(t, dst, limits)
+		})
+	}
+}
+
+func TestDirSkipsIgnoredDirectoriesAndRejectsLinks(t *testing.T) {
+	src := t.TempDir()
+	for name, content := range map[string]string{
+		"main.go":           "package main\n",
+		".git/HEAD":         "ref: refs/heads/main\n",
+		"node_modules/x.js": "x\n",
+	} {
+		path := filepath.Join(src, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	skip := func(name string, size int64) bool {
+		return strings.HasPrefix(name, ".git/") || strings.HasPrefix(name, "node_modules/")
+	}
+
+	dst := filepath.Join(t.TempDir(), "out")
+	result, err := Dir(src, dst, DefaultLimits(), skip)
+	if err != nil {
+		t.Fatalf("Dir() error = %v", err)
+	}
+	if result.Extracted != 1 || len(result.Skipped) != 2 {
+		t.Errorf("result = %+v, want 1 extracted and 2 skipped directories", result)
+	}
+	assertContained(t, dst, DefaultLimits())
+
+	if err := os.Symlink("/etc", filepath.Join(src, "etc")); err != nil {
+		t.Skip("symlinks not supported:", err)
+	}
+	_, err = Dir(src, filepath.Join(t.TempDir(), "out"), DefaultLimits(), skip)
+	var extractErr *Error
+	if !errors.As(err, &extractErr) || extractErr.Code != CodeLink {
+		t.Errorf("Dir() error = %v, want %s", err, CodeLink)
+	}
+}
+
+func FuzzTar(f *testing.F) {
+	f.Add(buildTar(f, false, tarEntry{name: "project/main.go", content: []byte("package main\n")}))
+	f.Add(buildTar(f, true, tarEntry{name: "project/main.go", content: []byte("package main\n")}))
+	f.Add(buildTar(f, false, tarEntry{name: "../evil.sh", content: []byte("rm -rf /")}))
+	f.Add(buildTar(f, false, tarEntry{name: "link", typeflag: tar.TypeSymlink, linkname: "/etc"}, tarEntry{name: "link/passwd"}))
+
+	limits := Limits{MaxTotalSize: 1 << 20, MaxFileSize: 256 << 10, MaxEntries: 100, MaxRatio: 50, MaxDepth: 8, MaxPathLength: 256}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dst, _, _ := extractTarBytes(t, "upload.tar", data, limits, nil)
+		assertContained(t, dst, limits)
+		if entries, err := os.ReadDir(filepath.Dir(dst)); err == nil && len(entries) > 2 {
+			t.Errorf("extraction wrote next to the destination: %v", entries)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+// extract/zip.go - ZIP archives
+package extract
+
+import (
+	"archive/zip"
+	"os"
+)
+
+// Zip extracts the ZIP file src into dst. Entries are checked against the limits before and while they are
+// written; links and special files are rejected and permissions from the archive are ignored.
+func Zip(src, dst string, limits Limits, skip SkipFunc) (*Result, error) {
+	reader, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, &Error{Code: CodeInvalidArchive, Reason: err.Error()}
+	}
+	defer reader.Close()
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := newExtractor(dst, limits, skip)
+	if err != nil {
+		return nil, err
+	}
+	e.archiveSize = info.Size()
+
+	for _, file := range reader.File {
+		if err := e.countEntry(int64(file.UncompressedSize64)); err != nil {
+			return e.result, err
+		}
+		name, err := e.cleanName(file.Name)
+		if err != nil {
+			return e.result, err
+		}
+
+		mode := file.Mode()
+		switch {
+		case mode&os.ModeSymlink != 0:
+			return e.result, &Error{Code: CodeLink, Entry: file.Name}
+		case mode.IsDir():
+			err = e.dir(name)
+		case !mode.IsRegular():
+			return e.result, &Error{Code: CodeSpecialFile, Entry: file.Name}
+		case name == "":
+			return e.result, &Error{Code: CodeUnsafePath, Entry: file.Name, Reason: "file without a name"}
+		default:
+			err = e.zipFile(file, name)
+		}
+		if err != nil {
+			return e.result, err
+		}
+	}
+
+	return e.result, nil
+}
+
+func (e *extractor) zipFile(file *zip.File, name string) error {
+	if file.Flags&0x1 != 0 {
+		return &Error{Code: CodeInvalidArchive, Entry: file.Name, Reason: "encrypted entries are not supported"}
+	}
+	return e.file(name, int64(file.UncompressedSize64), int64(file.CompressedSize64), file.Open)
+}
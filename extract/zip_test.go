@@ -0,0 +1,281 @@
+package extract
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"errors"
+	"hash/crc32"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// zipEntry describes one entry of a test archive
+type zipEntry struct {
+	name    string
+	content []byte
+	mode    os.FileMode // 0 for a regular file
+	flags   uint16
+	// declared overrides the uncompressed size written to the header; the content is stored raw
+	declared int64
+}
+
+func buildZip(t testing.TB, entries ...zipEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate, Flags: entry.flags}
+		if entry.mode != 0 {
+			header.SetMode(entry.mode)
+		}
+		if entry.declared > 0 {
+			compressed := deflate(t, entry.content)
+			header.CRC32 = crc32.ChecksumIEEE(entry.content)
+			header.CompressedSize64 = uint64(len(compressed))
+			header.UncompressedSize64 = uint64(entry.declared)
+			out, err := w.CreateRaw(header)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := out.Write(compressed); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		out, err := w.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := out.Write(entry.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func deflate(t testing.TB, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(content)
+	w.Close()
+	return buf.Bytes()
+}
+
+func extractBytes(t testing.TB, data []byte, limits Limits, skip SkipFunc) (string, *Result, error) {
+	t.Helper()
+	dir := t.TempDir()
+	src := filepath.Join(dir, "upload.zip")
+	if err := os.WriteFile(src, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	dst := filepath.Join(dir, "out")
+	result, err := Zip(src, dst, limits, skip)
+	return dst, result, err
+}
+
+func TestZipExtractsValidArchive(t *testing.T) {
+	data := buildZip(t,
+		zipEntry{name: "project/"},
+		zipEntry{name: "project/main.go", content: []byte("package main\n")},
+		zipEntry{name: "project/cmd/run.sh", content: []byte("#!/bin/sh\n"), mode: 0777},
+		zipEntry{name: "project/node_modules/lib/index.js", content: []byte("module.exports = 1\n")},
+		zipEntry{name: `project\docs\README.md`, content: []byte("# Docs\n")},
+	)
+	skip := func(name string, size int64) bool { return strings.Contains(name, "node_modules") }
+
+	dst, result, err := extractBytes(t, data, DefaultLimits(), skip)
+	if err != nil {
+		t.Fatalf("Zip() error = %v", err)
+	}
+	if result.Entries != 5 || result.Extracted != 4 || len(result.Skipped) != 1 {
+		t.Errorf("result = %+v, want 5 entries, 4 extracted, 1 skipped", result)
+	}
+	if result.Skipped[0].Path != "project/node_modules/lib/index.js" {
+		t.Errorf("skipped = %+v", result.Skipped)
+	}
+	if result.ExtractedSize != int64(len("package main\n#!/bin/sh\n# Docs\n")) {
+		t.Errorf("ExtractedSize = %d", result.ExtractedSize)
+	}
+
+	for _, name := range []string{"project/main.go", "project/cmd/run.sh", "project/docs/README.md"} {
+		info, err := os.Stat(filepath.Join(dst, name))
+		if err != nil {
+			t.Fatalf("%s not extracted: %v", name, err)
+		}
+		if info.Mode().Perm() != 0644 {
+			t.Errorf("%s mode = %v, want 0644 whatever the archive says", name, info.Mode().Perm())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dst, "project/node_modules")); !os.IsNotExist(err) {
+		t.Errorf("skipped entry was extracted")
+	}
+}
+
+// TestZipRejectsAdversarialArchives is the corpus of hostile uploads; each must fail with its error code
+func TestZipRejectsAdversarialArchives(t *testing.T) {
+	zeros := make([]byte, 8<<20)
+	small := func(size int) []byte { return bytes.Repeat([]byte("x"), size) }
+
+	tests := []struct {
+		name    string
+		entries []zipEntry
+		limits  func(*Limits)
+		code    string
+	}{
+		{name: "parent traversal", entries: []zipEntry{{name: "../evil.sh", content: small(4)}}, code: CodeUnsafePath},
+		{name: "nested traversal", entries: []zipEntry{{name: "project/../../evil.sh", content: small(4)}}, code: CodeUnsafePath},
+		{name: "windows traversal", entries: []zipEntry{{name: `project\..\..\evil.sh`, content: small(4)}}, code: CodeUnsafePath},
+		{name: "absolute path", entries: []zipEntry{{name: "/etc/cron.d/evil", content: small(4)}}, code: CodeUnsafePath},
+		{name: "windows absolute path", entries: []zipEntry{{name: `C:\Windows\evil.exe`, content: small(4)}}, code: CodeUnsafePath},
+		{name: "windows root path", entries: []zipEntry{{name: `\evil.sh`, content: small(4)}}, code: CodeUnsafePath},
+		{name: "nul in name", entries: []zipEntry{{name: "evil.sh\x00.txt", content: small(4)}}, code: CodeUnsafePath},
+		{name: "symlink", entries: []zipEntry{{name: "link", content: []byte("/etc/passwd"), mode: os.ModeSymlink | 0777}}, code: CodeLink},
+		{
+			name: "symlink followed by write through it",
+			entries: []zipEntry{
+				{name: "project/link", content: []byte("../../"), mode: os.ModeSymlink | 0777},
+				{name: "project/link/evil.sh", content: small(4)},
+			},
+			code: CodeLink,
+		},
+		{name: "named pipe", entries: []zipEntry{{name: "fifo", mode: os.ModeNamedPipe | 0644}}, code: CodeSpecialFile},
+		{name: "device", entries: []zipEntry{{name: "dev", mode: os.ModeDevice | 0644}}, code: CodeSpecialFile},
+		{name: "encrypted entry", entries: []zipEntry{{name: "secret.txt", content: small(4), flags: 0x1}}, code: CodeInvalidArchive},
+		{
+			name:    "duplicate entry",
+			entries: []zipEntry{{name: "a.txt", content: small(4)}, {name: "./a.txt", content: small(4)}},
+			code:    CodeDuplicateEntry,
+		},
+		{
+			name:    "too many entries",
+			entries: []zipEntry{{name: "1"}, {name: "2"}, {name: "3"}, {name: "4"}},
+			limits:  func(l *Limits) { l.MaxEntries = 3 },
+			code:    CodeTooManyEntries,
+		},
+		{
+			name:    "too deep",
+			entries: []zipEntry{{name: "a/b/c/d/e/f.txt", content: small(4)}},
+			limits:  func(l *Limits) { l.MaxDepth = 5 },
+			code:    CodeTooDeep,
+		},
+		{name: "path too long", entries: []zipEntry{{name: strings.Repeat("a/", 600) + "x", content: small(4)}}, code: CodePathTooLong},
+		{
+			name:    "declared file too large",
+			entries: []zipEntry{{name: "big.bin", content: small(2048)}},
+			limits:  func(l *Limits) { l.MaxFileSize = 1024 },
+			code:    CodeFileTooLarge,
+		},
+		{
+			name:    "header understates the size",
+			entries: []zipEntry{{name: "liar.bin", content: small(2048), declared: 10}},
+			code:    CodeInvalidArchive,
+		},
+		{
+			name:    "total too large",
+			entries: []zipEntry{{name: "a.bin", content: small(600)}, {name: "b.bin", content: small(600)}},
+			limits:  func(l *Limits) { l.MaxTotalSize = 1000 },
+			code:    CodeTotalTooLarge,
+		},
+		{name: "zip bomb", entries: []zipEntry{{name: "bomb.bin", content: zeros}}, code: CodeRatioExceeded},
+		{
+			name: "zip bomb spread over small entries",
+			entries: []zipEntry{
+				{name: "1.bin", content: zeros[:1<<19]}, {name: "2.bin", content: zeros[:1<<19]},
+				{name: "3.bin", content: zeros[:1<<19]}, {name: "4.bin", content: zeros[:1<<19]},
+			},
+			limits: func(l *Limits) { l.MaxRatio = 100 },
+			code:   CodeRatioExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := DefaultLimits()
+			if tt.limits != nil {
+				tt.limits(&limits)
+			}
+			dst, _, err := extractBytes(t, buildZip(t, tt.entries...), limits, nil)
+
+			var extractErr *Error
+			if !errors.As(err, &extractErr) {
+				t.Fatalf("Zip() error = %v, want *Error with code %s", err, tt.code)
+			}
+			if extractErr.Code != tt.code {
+				t.Errorf("code = %s (%v), want %s", extractErr.Code, err, tt.code)
+			}
+			assertContained(t, dst, limits)
+			if _, err := os.Lstat(filepath.Join(filepath.Dir(dst), "evil.sh")); err == nil {
+				t.Errorf("file written outside the extraction directory")
+			}
+		})
+	}
+}
+
+func TestZipRejectsCorruptArchive(t *testing.T) {
+	_, _, err := extractBytes(t, []byte("PK\x03\x04 not really a zip"), DefaultLimits(), nil)
+	var extractErr *Error
+	if !errors.As(err, &extractErr) || extractErr.Code != CodeInvalidArchive {
+		t.Errorf("Zip() error = %v, want %s", err, CodeInvalidArchive)
+	}
+}
+
+// assertContained checks what ended up on disk: no links, nothing special and nothing over the limits
+func assertContained(t testing.TB, dst string, limits Limits) {
+	t.Helper()
+	var total int64
+	filepath.WalkDir(dst, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		switch {
+		case info.IsDir():
+		case info.Mode().IsRegular():
+			total += info.Size()
+			if limits.MaxFileSize > 0 && info.Size() > limits.MaxFileSize {
+				t.Errorf("%s is %d bytes, over the file limit", p, info.Size())
+			}
+			if info.Mode().Perm()&0111 != 0 {
+				t.Errorf("%s is executable", p)
+			}
+		default:
+			t.Errorf("%s has mode %v", p, info.Mode())
+		}
+		return nil
+	})
+	if limits.MaxTotalSize > 0 && total > limits.MaxTotalSize {
+		t.Errorf("extracted %d bytes, over the total limit", total)
+	}
+}
+
+func FuzzZip(f *testing.F) {
+	f.Add(buildZip(f, zipEntry{name: "project/main.go", content: []byte("package main\n")}))
+	f.Add(buildZip(f, zipEntry{name: "../evil.sh", content: []byte("rm -rf /")}))
+	f.Add(buildZip(f, zipEntry{name: "link", content: []byte("/etc"), mode: os.ModeSymlink | 0777}, zipEntry{name: "link/passwd"}))
+	f.Add(buildZip(f, zipEntry{name: "a.txt", content: []byte("a")}, zipEntry{name: "a.txt", content: []byte("b")}))
+	f.Add(buildZip(f, zipEntry{name: "liar.bin", content: bytes.Repeat([]byte("x"), 4096), declared: 1}))
+	f.Add([]byte("PK\x05\x06\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"))
+
+	limits := Limits{MaxTotalSize: 1 << 20, MaxFileSize: 256 << 10, MaxEntries: 100, MaxRatio: 50, MaxDepth: 8, MaxPathLength: 256}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dst, _, _ := extractBytes(t, data, limits, nil)
+		assertContained(t, dst, limits)
+		if entries, err := os.ReadDir(filepath.Dir(dst)); err == nil && len(entries) > 2 {
+			t.Errorf("extraction wrote next to the destination: %v", entries)
+		}
+	})
+}
@@ -0,0 +1,353 @@
+package githosting
+
+import (
+	"container/list"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// commitSHAPattern matches full SHA-1 and SHA-256 commit hashes, which need no resolving
+var commitSHAPattern = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// CacheOptions sizes a Cached provider
+type CacheOptions struct {
+	MaxEntries int
+	// MaxBytes bounds the estimated memory of the cached values; an entry may take at most a tenth of it
+	MaxBytes int64
+	// RefTTL is how long a branch name stays resolved to the same commit, for pushes made outside the application
+	RefTTL time.Duration
+}
+
+// DefaultCacheOptions keeps a couple of thousand listings and files in up to 64 MiB and re-resolves
+// branches every minute
+func DefaultCacheOptions() CacheOptions {
+	return CacheOptions{MaxEntries: 2000, MaxBytes: 64 << 20, RefTTL: time.Minute}
+}
+
+// CacheStats counts the lookups of a Cached provider and the conditional requests of its host API
+type CacheStats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Entries       int   `json:"entries"`
+	Bytes         int64 `json:"bytes"`
+	Invalidations int64 `json:"invalidations"`
+	// Conditional requests sent with a stored ETag, and how many the host answered 304 Not Modified
+	Conditional int64 `json:"conditional_requests"`
+	NotModified int64 `json:"not_modified"`
+}
+
+// conditionalCounter is implemented by providers whose API client revalidates responses by ETag
+type conditionalCounter interface {
+	conditionalStats() (requests, notModified int64)
+}
+
+// Cached serves repository contents from memory. Content at a commit never changes, so listings, files,
+// trees and history are keyed by repository and commit SHA; branch names are resolved to a commit at most
+// once per RefTTL, and a push through the provider drops everything cached for the repository.
+// Archives, downloaded whole or read as a file, always come from the provider.
+type Cached struct {
+	Provider
+	opts    CacheOptions
+	entries *lru
+
+	hits, misses, invalidations atomic.Int64
+}
+
+// NewCached wraps provider with an in-memory cache
+func NewCached(provider Provider, opts CacheOptions) *Cached {
+	return &Cached{Provider: provider, opts: opts, entries: newLRU(opts.MaxEntries, opts.MaxBytes)}
+}
+
+// Stats returns the cache counters
+func (c *Cached) Stats() CacheStats {
+	entries, size := c.entries.usage()
+	stats := CacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Entries:       entries,
+		Bytes:         size,
+		Invalidations: c.invalidations.Load(),
+	}
+	if counter, ok := c.Provider.(conditionalCounter); ok {
+		stats.Conditional, stats.NotModified = counter.conditionalStats()
+	}
+	return stats
+}
+
+// Invalidate drops everything cached for the repository, e.g. after a new submission
+func (c *Cached) Invalidate(name string) {
+	c.entries.removeGroup(name)
+	c.invalidations.Add(1)
+}
+
+// Push uploads the branches and invalidates the repository, so the new submission is shown right away
+func (c *Cached) Push(ctx context.Context, repo *Repository, local *git.Repository, branches ...string) error {
+	err := c.Provider.Push(ctx, repo, local, branches...)
+	if repo != nil {
+		c.Invalidate(repo.Name)
+	}
+	return err
+}
+
+func (c *Cached) ListContents(ctx context.Context, name, ref, dir string) ([]Entry, error) {
+	sha, err := c.resolve(ctx, name, ref)
+	if err != nil {
+		return nil, err
+	}
+	dir = strings.Trim(dir, "/")
+	value, err := c.load(name, cacheKey(name, "contents", sha, dir), 0, func() (interface{}, error) {
+		return c.Provider.ListContents(ctx, name, sha, dir)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]Entry(nil), value.([]Entry)...), nil
+}
+
+func (c *Cached) ReadFile(ctx context.Context, name, ref, path string) (*File, error) {
+	sha, err := c.resolve(ctx, name, ref)
+	if err != nil {
+		return nil, err
+	}
+	if isArchivePath(path) {
+		c.misses.Add(1)
+		return c.Provider.ReadFile(ctx, name, sha, path)
+	}
+	value, err := c.load(name, cacheKey(name, "file", sha, path), 0, func() (interface{}, error) {
+		return c.Provider.ReadFile(ctx, name, sha, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := *value.(*File)
+	return &file, nil
+}
+
+func (c *Cached) Tree(ctx context.Context, name, ref string) ([]Entry, error) {
+	sha, err := c.resolve(ctx, name, ref)
+	if err != nil {
+		return nil, err
+	}
+	value, err := c.load(name, cacheKey(name, "tree", sha), 0, func() (interface{}, error) {
+		return c.Provider.Tree(ctx, name, sha)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]Entry(nil), value.([]Entry)...), nil
+}
+
+func (c *Cached) ListCommits(ctx context.Context, name, ref string, limit int) ([]Commit, error) {
+	sha, err := c.resolve(ctx, name, ref)
+	if err != nil {
+		return nil, err
+	}
+	value, err := c.load(name, cacheKey(name, "commits", sha, strconv.Itoa(limit)), 0, func() (interface{}, error) {
+		return c.Provider.ListCommits(ctx, name, sha, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]Commit(nil), value.([]Commit)...), nil
+}
+
+// ListBranches is cached like a branch name: for RefTTL, or until the next push
+func (c *Cached) ListBranches(ctx context.Context, name string) ([]Branch, error) {
+	value, err := c.load(name, cacheKey(name, "branches"), c.opts.RefTTL, func() (interface{}, error) {
+		return c.Provider.ListBranches(ctx, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]Branch(nil), value.([]Branch)...), nil
+}
+
+// resolve turns a branch or tag into the commit it points to; commit hashes are returned as they are
+func (c *Cached) resolve(ctx context.Context, name, ref string) (string, error) {
+	if commitSHAPattern.MatchString(ref) {
+		return ref, nil
+	}
+	value, err := c.load(name, cacheKey(name, "ref", refOrDefault(ref)), c.opts.RefTTL, func() (interface{}, error) {
+		commits, err := c.Provider.ListCommits(ctx, name, ref, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(commits) == 0 {
+			return nil, ErrNotFound
+		}
+		return commits[0].SHA, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+// load returns the cached value of key or fetches and stores it; ttl 0 keeps the value until evicted.
+// Errors are not cached.
+func (c *Cached) load(name, key string, ttl time.Duration, fetch func() (interface{}, error)) (interface{}, error) {
+	if value, ok := c.entries.get(key); ok {
+		c.hits.Add(1)
+		return value, nil
+	}
+	c.misses.Add(1)
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.entries.add(name, key, value, cacheValueSize(key, value), ttl)
+	return value, nil
+}
+
+func cacheKey(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+// archiveExtensions are file types served by downloading them whole, never worth keeping in memory
+var archiveExtensions = []string{".zip", ".tar", ".gz", ".tgz", ".bz2", ".xz", ".7z", ".rar", ".jar", ".war"}
+
+func isArchivePath(path string) bool {
+	path = strings.ToLower(path)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheEntryOverhead approximates the bookkeeping of an entry and of each struct held in it
+const cacheEntryOverhead = 64
+
+// cacheValueSize estimates the memory an entry holds, its strings and file contents
+func cacheValueSize(key string, value interface{}) int64 {
+	size := int64(cacheEntryOverhead + len(key))
+	switch v := value.(type) {
+	case []Entry:
+		for _, entry := range v {
+			size += int64(cacheEntryOverhead + len(entry.Name) + len(entry.Path) + len(entry.Type) + len(entry.URL) + len(entry.SHA))
+		}
+	case *File:
+		size += int64(cacheEntryOverhead + len(v.Name) + len(v.Path) + len(v.SHA) + len(v.URL) + len(v.Content))
+	case []Commit:
+		for _, commit := range v {
+			size += int64(cacheEntryOverhead + len(commit.SHA) + len(commit.Message) + len(commit.Author) + len(commit.Email) + len(commit.URL))
+		}
+	case []Branch:
+		for _, branch := range v {
+			size += int64(cacheEntryOverhead + len(branch.Name) + len(branch.SHA))
+		}
+	case string:
+		size += int64(len(v))
+	case *etagResponse:
+		size += int64(len(v.etag) + len(v.body))
+	}
+	return size
+}
+
+// lru is a map bounded by entry count and total size, evicting the least recently used entry; entries
+// belong to a group (the repository) so that all of them can be dropped at once
+type lru struct {
+	mu       sync.Mutex
+	max      int
+	maxBytes int64 // 0 bounds the entry count only
+	bytes    int64
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	group   string
+	value   interface{}
+	size    int64
+	expires time.Time // zero when the entry does not expire
+}
+
+// lruMaxEntryShare keeps a single entry from taking more than a tenth of the byte budget, so one large
+// value does not flush everything else
+const lruMaxEntryShare = 10
+
+func newLRU(max int, maxBytes int64) *lru {
+	return &lru{max: max, maxBytes: maxBytes, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (l *lru) get(key string) (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		l.remove(element)
+		return nil, false
+	}
+	l.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// add stores the value of key; size is its estimated memory, and values too large for the budget are not kept
+func (l *lru) add(group, key string, value interface{}, size int64, ttl time.Duration) {
+	if l.max <= 0 {
+		return
+	}
+	entry := &lruEntry{key: key, group: group, value: value, size: size}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if element, ok := l.entries[key]; ok {
+		l.remove(element)
+	}
+	if l.maxBytes > 0 && size > l.maxBytes/lruMaxEntryShare {
+		return
+	}
+	l.entries[key] = l.order.PushFront(entry)
+	l.bytes += size
+	for l.order.Len() > l.max || (l.maxBytes > 0 && l.bytes > l.maxBytes) {
+		l.remove(l.order.Back())
+	}
+}
+
+// remove drops an entry; the caller holds the lock
+func (l *lru) remove(element *list.Element) {
+	entry := element.Value.(*lruEntry)
+	l.order.Remove(element)
+	delete(l.entries, entry.key)
+	l.bytes -= entry.size
+}
+
+func (l *lru) removeGroup(group string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for element := l.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*lruEntry).group == group {
+			l.remove(element)
+		}
+		element = next
+	}
+}
+
+func (l *lru) len() int {
+	entries, _ := l.usage()
+	return entries
+}
+
+// usage returns the number of entries and their estimated size in bytes
+func (l *lru) usage() (int, int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len(), l.bytes
+}
@@ -0,0 +1,247 @@
+package githosting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingProvider counts the calls that reach the wrapped provider
+type countingProvider struct {
+	Provider
+	calls map[string]int
+}
+
+func (p *countingProvider) ListContents(ctx context.Context, name, ref, dir string) ([]Entry, error) {
+	p.calls["ListContents"]++
+	return p.Provider.ListContents(ctx, name, ref, dir)
+}
+
+func (p *countingProvider) ReadFile(ctx context.Context, name, ref, path string) (*File, error) {
+	p.calls["ReadFile"]++
+	return p.Provider.ReadFile(ctx, name, ref, path)
+}
+
+func (p *countingProvider) Tree(ctx context.Context, name, ref string) ([]Entry, error) {
+	p.calls["Tree"]++
+	return p.Provider.Tree(ctx, name, ref)
+}
+
+func (p *countingProvider) ListCommits(ctx context.Context, name, ref string, limit int) ([]Commit, error) {
+	p.calls["ListCommits"]++
+	return p.Provider.ListCommits(ctx, name, ref, limit)
+}
+
+func newTestCached(t *testing.T) (*Cached, *countingProvider, *Repository) {
+	t.Helper()
+	local := newTestLocal(t)
+	repo, err := local.CreateRepository(context.Background(), "thesis-1", "Final thesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushSubmission(t, local, repo, map[string]string{"main.go": "package main\n"}, "submission-1")
+
+	counting := &countingProvider{Provider: local, calls: map[string]int{}}
+	return NewCached(counting, DefaultCacheOptions()), counting, repo
+}
+
+func TestCachedServesRepeatedReadsFromMemory(t *testing.T) {
+	ctx := context.Background()
+	cached, counting, _ := newTestCached(t)
+
+	for i := 0; i < 3; i++ {
+		if _, err := cached.ListContents(ctx, "thesis-1", "", ""); err != nil {
+			t.Fatalf("ListContents() error = %v", err)
+		}
+		if _, err := cached.Tree(ctx, "thesis-1", ""); err != nil {
+			t.Fatalf("Tree() error = %v", err)
+		}
+		file, err := cached.ReadFile(ctx, "thesis-1", "", "main.go")
+		if err != nil || string(file.Content) != "package main\n" {
+			t.Fatalf("ReadFile() = %+v, %v", file, err)
+		}
+	}
+
+	// One resolve of main, then one call per distinct listing
+	want := map[string]int{"ListCommits": 1, "ListContents": 1, "Tree": 1, "ReadFile": 1}
+	for method, count := range want {
+		if counting.calls[method] != count {
+			t.Errorf("%s reached the provider %d times, want %d", method, counting.calls[method], count)
+		}
+	}
+	stats := cached.Stats()
+	if stats.Misses != 4 || stats.Hits != 14 {
+		t.Errorf("Stats() = %+v, want 4 misses and 14 hits", stats)
+	}
+
+	// Errors are not cached
+	if _, err := cached.ReadFile(ctx, "thesis-1", "", "missing.go"); err != ErrNotFound {
+		t.Errorf("ReadFile() on missing file error = %v, want ErrNotFound", err)
+	}
+	cached.ReadFile(ctx, "thesis-1", "", "missing.go")
+	if counting.calls["ReadFile"] != 3 {
+		t.Errorf("missing file reached the provider %d times, want 2", counting.calls["ReadFile"]-1)
+	}
+}
+
+func TestCachedKeysByCommit(t *testing.T) {
+	ctx := context.Background()
+	cached, counting, _ := newTestCached(t)
+
+	commits, err := cached.ListCommits(ctx, "thesis-1", "", 1)
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("ListCommits() = %v, %v", commits, err)
+	}
+	calls := counting.calls["Tree"]
+	cached.Tree(ctx, "thesis-1", "")
+	cached.Tree(ctx, "thesis-1", commits[0].SHA)
+	if counting.calls["Tree"] != calls+1 {
+		t.Errorf("the branch and its commit were listed %d times, want once", counting.calls["Tree"]-calls)
+	}
+}
+
+func TestCachedPushInvalidatesRepository(t *testing.T) {
+	ctx := context.Background()
+	cached, _, repo := newTestCached(t)
+
+	if tree, _ := cached.Tree(ctx, "thesis-1", ""); len(tree) != 2 {
+		t.Fatalf("Tree() = %v, want README.md and main.go", entryPaths(tree))
+	}
+
+	// A new submission pushed through the cache is visible right away, without waiting for RefTTL
+	pushSubmission(t, cached, repo, map[string]string{"util.go": "package main\n"}, "submission-2")
+	tree, err := cached.Tree(ctx, "thesis-1", "")
+	if err != nil {
+		t.Fatalf("Tree() error = %v", err)
+	}
+	if got := entryPaths(tree); !equalStrings(got, []string{"README.md", "main.go", "util.go"}) {
+		t.Errorf("Tree() after push = %v", got)
+	}
+	if stats := cached.Stats(); stats.Invalidations == 0 {
+		t.Errorf("Stats() = %+v, want an invalidation", stats)
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsedAndExpires(t *testing.T) {
+	cache := newLRU(2, 0)
+	cache.add("repo", "a", 1, 1, 0)
+	cache.add("repo", "b", 2, 1, 0)
+	cache.get("a")
+	cache.add("repo", "c", 3, 1, 0)
+	if _, ok := cache.get("b"); ok {
+		t.Errorf("b was not evicted")
+	}
+	if _, ok := cache.get("a"); !ok {
+		t.Errorf("recently used a was evicted")
+	}
+
+	cache.add("other", "d", 4, 1, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok := cache.get("d"); ok {
+		t.Errorf("expired entry was returned")
+	}
+
+	cache.removeGroup("repo")
+	if cache.len() != 0 {
+		t.Errorf("len() = %d after removing the group", cache.len())
+	}
+}
+
+func TestLRUBoundsTotalSize(t *testing.T) {
+	cache := newLRU(100, 1000)
+	for _, key := range []string{"a", "b", "c"} {
+		cache.add("repo", key, key, 90, 0)
+	}
+	cache.get("a")
+	for i := 0; i < 9; i++ {
+		cache.add("repo", fmt.Sprintf("fill-%d", i), i, 90, 0)
+	}
+	if entries, size := cache.usage(); size > 1000 || entries != 11 {
+		t.Errorf("usage() = %d entries, %d bytes; want 11 entries within 1000 bytes", entries, size)
+	}
+	if _, ok := cache.get("b"); ok {
+		t.Errorf("least recently used b was not evicted")
+	}
+	if _, ok := cache.get("a"); !ok {
+		t.Errorf("recently used a was evicted")
+	}
+
+	// An entry larger than its share of the budget is not kept and does not flush the others
+	cache.add("repo", "large", "large", 101, 0)
+	if _, ok := cache.get("large"); ok {
+		t.Errorf("oversized entry was cached")
+	}
+	if cache.len() != 11 {
+		t.Errorf("len() = %d after an oversized entry, want 11", cache.len())
+	}
+
+	cache.removeGroup("repo")
+	if entries, size := cache.usage(); entries != 0 || size != 0 {
+		t.Errorf("usage() = %d entries, %d bytes after removing the group", entries, size)
+	}
+}
+
+func TestCachedDoesNotKeepArchives(t *testing.T) {
+	ctx := context.Background()
+	local := newTestLocal(t)
+	repo, err := local.CreateRepository(ctx, "thesis-1", "Final thesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushSubmission(t, local, repo, map[string]string{"main.go": "package main\n", "dist/app.zip": "PK"}, "submission-1")
+	counting := &countingProvider{Provider: local, calls: map[string]int{}}
+	cached := NewCached(counting, DefaultCacheOptions())
+
+	for i := 0; i < 2; i++ {
+		if _, err := cached.ReadFile(ctx, "thesis-1", "", "dist/app.zip"); err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+	}
+	if counting.calls["ReadFile"] != 2 {
+		t.Errorf("archive reached the provider %d times, want every time", counting.calls["ReadFile"])
+	}
+	if stats := cached.Stats(); stats.Entries != 1 || stats.Bytes == 0 {
+		t.Errorf("Stats() = %+v, want only the resolved branch cached", stats)
+	}
+}
+
+func TestGitHubRevalidatesWithETag(t *testing.T) {
+	var requests, conditional atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"name": "main", "commit": map[string]string{"sha": "abc"}},
+		})
+	}))
+	defer server.Close()
+
+	provider := NewGitHub(server.URL, "org", "token")
+	for i := 0; i < 3; i++ {
+		branches, err := provider.ListBranches(context.Background(), "thesis-1")
+		if err != nil {
+			t.Fatalf("ListBranches() error = %v", err)
+		}
+		if len(branches) != 1 || branches[0].Name != "main" || branches[0].SHA != "abc" {
+			t.Fatalf("ListBranches() = %+v", branches)
+		}
+	}
+
+	if requests.Load() != 3 || conditional.Load() != 2 {
+		t.Errorf("server saw %d requests, %d conditional; want 3 and 2", requests.Load(), conditional.Load())
+	}
+	stats := NewCached(provider, DefaultCacheOptions()).Stats()
+	if stats.Conditional != 2 || stats.NotModified != 2 {
+		t.Errorf("Stats() = %+v, want 2 conditional requests answered 304", stats)
+	}
+}
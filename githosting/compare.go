@@ -0,0 +1,65 @@
+package githosting
+
+import (
+	"sort"
+	"strings"
+)
+
+// Change statuses reported by CompareTrees
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// FileChange is one file that differs between two trees
+type FileChange struct {
+	Path    string
+	Status  string
+	OldSHA  string
+	NewSHA  string
+	OldSize int64
+	NewSize int64
+}
+
+// CompareTrees lists the files added, removed or modified between two Tree listings, sorted by path.
+// Files are compared by blob hash, so no content has to be fetched.
+func CompareTrees(oldTree, newTree []Entry) []FileChange {
+	oldFiles := filesByPath(oldTree)
+	newFiles := filesByPath(newTree)
+
+	var changes []FileChange
+	for path, newEntry := range newFiles {
+		oldEntry, ok := oldFiles[path]
+		switch {
+		case !ok:
+			changes = append(changes, FileChange{Path: path, Status: ChangeAdded, NewSHA: newEntry.SHA, NewSize: newEntry.Size})
+		case oldEntry.SHA != newEntry.SHA || oldEntry.SHA == "":
+			changes = append(changes, FileChange{
+				Path: path, Status: ChangeModified,
+				OldSHA: oldEntry.SHA, NewSHA: newEntry.SHA,
+				OldSize: oldEntry.Size, NewSize: newEntry.Size,
+			})
+		}
+	}
+	for path, oldEntry := range oldFiles {
+		if _, ok := newFiles[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Status: ChangeRemoved, OldSHA: oldEntry.SHA, OldSize: oldEntry.Size})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return strings.ToLower(changes[i].Path) < strings.ToLower(changes[j].Path)
+	})
+	return changes
+}
+
+func filesByPath(entries []Entry) map[string]Entry {
+	files := make(map[string]Entry, len(entries))
+	for _, entry := range entries {
+		if entry.Type == "file" {
+			files[entry.Path] = entry
+		}
+	}
+	return files
+}
@@ -0,0 +1,37 @@
+package githosting
+
+import "testing"
+
+func TestCompareTrees(t *testing.T) {
+	oldTree := []Entry{
+		{Path: "src", Type: "dir", SHA: "t1"},
+		{Path: "src/main.go", Type: "file", SHA: "a"},
+		{Path: "src/util.go", Type: "file", SHA: "b"},
+		{Path: "README.md", Type: "file", SHA: "c"},
+	}
+	newTree := []Entry{
+		{Path: "src", Type: "dir", SHA: "t2"},
+		{Path: "src/main.go", Type: "file", SHA: "a2"},
+		{Path: "README.md", Type: "file", SHA: "c"},
+		{Path: "docs/guide.md", Type: "file", SHA: "d"},
+	}
+
+	changes := CompareTrees(oldTree, newTree)
+	want := []FileChange{
+		{Path: "docs/guide.md", Status: ChangeAdded, NewSHA: "d"},
+		{Path: "src/main.go", Status: ChangeModified, OldSHA: "a", NewSHA: "a2"},
+		{Path: "src/util.go", Status: ChangeRemoved, OldSHA: "b"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("CompareTrees() = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	if changes := CompareTrees(oldTree, oldTree); len(changes) != 0 {
+		t.Errorf("CompareTrees() of identical trees = %+v", changes)
+	}
+}
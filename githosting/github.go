@@ -0,0 +1,291 @@
+package githosting
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// githubPageSize stays within Gitea's default maximum page size
+const githubPageSize = 50
+
+// GitHub talks to the GitHub REST API. Gitea exposes a compatible API, so the same type serves
+// both with a few endpoint differences.
+type GitHub struct {
+	remoteGit
+	api   *apiClient
+	org   string
+	gitea bool
+}
+
+// NewGitHub creates a provider for github.com, or for GitHub Enterprise when baseURL is set
+func NewGitHub(baseURL, org, token string) *GitHub {
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &GitHub{
+		remoteGit: remoteGit{auth: &githttp.BasicAuth{Username: token}},
+		api: newAPIClient(strings.TrimSuffix(baseURL, "/"), func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Accept", "application/vnd.github.v3+json")
+		}),
+		org: org,
+	}
+}
+
+// NewGitea creates a provider for a Gitea (or Forgejo) instance; baseURL ends with /api/v1
+func NewGitea(baseURL, org, token string) *GitHub {
+	return &GitHub{
+		remoteGit: remoteGit{auth: &githttp.BasicAuth{Username: token, Password: token}},
+		api: newAPIClient(strings.TrimSuffix(baseURL, "/"), func(req *http.Request) {
+			req.Header.Set("Authorization", "token "+token)
+			req.Header.Set("Accept", "application/json")
+		}),
+		org:   org,
+		gitea: true,
+	}
+}
+
+func (g *GitHub) Name() string {
+	if g.gitea {
+		return "gitea"
+	}
+	return "github"
+}
+
+func (g *GitHub) Owner() string {
+	return g.org
+}
+
+func (g *GitHub) conditionalStats() (requests, notModified int64) {
+	return g.api.conditionalStats()
+}
+
+type githubRepository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+func (r githubRepository) toRepository() *Repository {
+	return &Repository{
+		ID:            strconv.FormatInt(r.ID, 10),
+		Name:          r.Name,
+		WebURL:        r.HTMLURL,
+		CloneURL:      r.CloneURL,
+		DefaultBranch: r.DefaultBranch,
+	}
+}
+
+func (g *GitHub) repoPath(name string) string {
+	return "/repos/" + url.PathEscape(g.org) + "/" + url.PathEscape(name)
+}
+
+func (g *GitHub) GetRepository(ctx context.Context, name string) (*Repository, error) {
+	var repo githubRepository
+	if err := g.api.getJSON(ctx, g.repoPath(name), nil, &repo); err != nil {
+		return nil, err
+	}
+	return repo.toRepository(), nil
+}
+
+func (g *GitHub) CreateRepository(ctx context.Context, name, description string) (*Repository, error) {
+	payload := map[string]interface{}{
+		"name":           name,
+		"description":    description,
+		"private":        true,
+		"auto_init":      true,
+		"default_branch": DefaultBranch,
+	}
+	var repo githubRepository
+	if err := g.api.postJSON(ctx, "/orgs/"+url.PathEscape(g.org)+"/repos", payload, &repo); err != nil {
+		return nil, fmt.Errorf("failed to create repository %s: %w", name, err)
+	}
+	return repo.toRepository(), nil
+}
+
+type githubContent struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	SHA      string `json:"sha"`
+	Type     string `json:"type"`
+	Size     int64  `json:"size"`
+	HTMLURL  string `json:"html_url"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+func (g *GitHub) ListContents(ctx context.Context, name, ref, dir string) ([]Entry, error) {
+	var items []githubContent
+	query := url.Values{"ref": {refOrDefault(ref)}}
+	if err := g.api.getJSON(ctx, g.repoPath(name)+"/contents/"+escapePath(dir), query, &items); err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(items))
+	for _, item := range items {
+		entryType := "file"
+		if item.Type == "dir" {
+			entryType = "dir"
+		}
+		entries = append(entries, Entry{Name: item.Name, Path: item.Path, Type: entryType, Size: item.Size, URL: item.HTMLURL, SHA: item.SHA})
+	}
+	return entries, nil
+}
+
+func (g *GitHub) ReadFile(ctx context.Context, name, ref, filePath string) (*File, error) {
+	var item githubContent
+	query := url.Values{"ref": {refOrDefault(ref)}}
+	if err := g.api.getJSON(ctx, g.repoPath(name)+"/contents/"+escapePath(filePath), query, &item); err != nil {
+		return nil, err
+	}
+	if item.Type != "file" {
+		return nil, ErrNotFound
+	}
+	file := &File{Name: item.Name, Path: item.Path, SHA: item.SHA, Size: item.Size, URL: item.HTMLURL}
+	if item.Encoding == "base64" && item.Content != "" {
+		content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(item.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", filePath, err)
+		}
+		file.Content = content
+	}
+	return file, nil
+}
+
+type githubCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+func (g *GitHub) ListCommits(ctx context.Context, name, ref string, limit int) ([]Commit, error) {
+	query := url.Values{"sha": {refOrDefault(ref)}}
+	if g.gitea {
+		query.Set("limit", strconv.Itoa(limit))
+	} else {
+		query.Set("per_page", strconv.Itoa(limit))
+	}
+	var items []githubCommit
+	if err := g.api.getJSON(ctx, g.repoPath(name)+"/commits", query, &items); err != nil {
+		return nil, err
+	}
+	commits := make([]Commit, 0, len(items))
+	for _, item := range items {
+		commits = append(commits, Commit{
+			SHA:     item.SHA,
+			Message: item.Commit.Message,
+			Author:  item.Commit.Author.Name,
+			Email:   item.Commit.Author.Email,
+			Date:    item.Commit.Author.Date,
+			URL:     item.HTMLURL,
+		})
+	}
+	return commits, nil
+}
+
+// ListBranches pages through the branches endpoint, which GitHub and Gitea share
+func (g *GitHub) ListBranches(ctx context.Context, name string) ([]Branch, error) {
+	var branches []Branch
+	for page := 1; ; page++ {
+		query := url.Values{"page": {strconv.Itoa(page)}}
+		if g.gitea {
+			query.Set("limit", strconv.Itoa(githubPageSize))
+		} else {
+			query.Set("per_page", strconv.Itoa(githubPageSize))
+		}
+		var items []struct {
+			Name   string `json:"name"`
+			Commit struct {
+				SHA string `json:"sha"`
+				ID  string `json:"id"` // Gitea
+			} `json:"commit"`
+		}
+		if err := g.api.getJSON(ctx, g.repoPath(name)+"/branches", query, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			sha := item.Commit.SHA
+			if sha == "" {
+				sha = item.Commit.ID
+			}
+			branches = append(branches, Branch{Name: item.Name, SHA: sha})
+		}
+		if len(items) < githubPageSize {
+			return branches, nil
+		}
+	}
+}
+
+func (g *GitHub) Tree(ctx context.Context, name, ref string) ([]Entry, error) {
+	query := url.Values{"recursive": {"1"}}
+	if g.gitea {
+		query = url.Values{"recursive": {"true"}, "per_page": {"10000"}}
+	}
+	var tree struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+			Size int64  `json:"size"`
+			SHA  string `json:"sha"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+	if err := g.api.getJSON(ctx, g.repoPath(name)+"/git/trees/"+url.PathEscape(refOrDefault(ref)), query, &tree); err != nil {
+		return nil, err
+	}
+	if tree.Truncated {
+		return nil, fmt.Errorf("tree of %s is too large to list at once", name)
+	}
+
+	entries := make([]Entry, 0, len(tree.Tree))
+	for _, item := range tree.Tree {
+		entryType := "file"
+		switch item.Type {
+		case "tree":
+			entryType = "dir"
+		case "commit":
+			// Submodules are not part of the submitted sources
+			continue
+		}
+		entries = append(entries, Entry{Name: path.Base(item.Path), Path: item.Path, Type: entryType, Size: item.Size, SHA: item.SHA})
+	}
+	return entries, nil
+}
+
+func (g *GitHub) Archive(ctx context.Context, name, ref string, w io.Writer) error {
+	if g.gitea {
+		return g.api.download(ctx, g.repoPath(name)+"/archive/"+url.PathEscape(refOrDefault(ref))+".zip", nil, w)
+	}
+	return g.api.download(ctx, g.repoPath(name)+"/zipball/"+url.PathEscape(refOrDefault(ref)), nil, w)
+}
+
+// escapePath escapes every segment of a repository path but keeps the slashes
+func escapePath(p string) string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return ""
+	}
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,239 @@
+package githosting
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+const gitlabPageSize = 100
+
+// GitLab talks to the GitLab REST API (v4); repositories live in the configured group
+type GitLab struct {
+	remoteGit
+	api   *apiClient
+	group string
+}
+
+// NewGitLab creates a provider for gitlab.com, or for a self-hosted instance when baseURL is set
+func NewGitLab(baseURL, group, token string) *GitLab {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	return &GitLab{
+		remoteGit: remoteGit{auth: &githttp.BasicAuth{Username: "oauth2", Password: token}},
+		api: newAPIClient(strings.TrimSuffix(baseURL, "/"), func(req *http.Request) {
+			req.Header.Set("PRIVATE-TOKEN", token)
+		}),
+		group: group,
+	}
+}
+
+func (g *GitLab) Name() string {
+	return "gitlab"
+}
+
+func (g *GitLab) Owner() string {
+	return g.group
+}
+
+func (g *GitLab) conditionalStats() (requests, notModified int64) {
+	return g.api.conditionalStats()
+}
+
+// projectPath addresses a project by its URL-encoded full path
+func (g *GitLab) projectPath(name string) string {
+	return "/projects/" + url.PathEscape(g.group+"/"+name)
+}
+
+type gitlabProject struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	WebURL        string `json:"web_url"`
+	HTTPURLToRepo string `json:"http_url_to_repo"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+func (p gitlabProject) toRepository() *Repository {
+	return &Repository{
+		ID:            strconv.FormatInt(p.ID, 10),
+		Name:          p.Name,
+		WebURL:        p.WebURL,
+		CloneURL:      p.HTTPURLToRepo,
+		DefaultBranch: p.DefaultBranch,
+	}
+}
+
+func (g *GitLab) GetRepository(ctx context.Context, name string) (*Repository, error) {
+	var project gitlabProject
+	if err := g.api.getJSON(ctx, g.projectPath(name), nil, &project); err != nil {
+		return nil, err
+	}
+	return project.toRepository(), nil
+}
+
+func (g *GitLab) CreateRepository(ctx context.Context, name, description string) (*Repository, error) {
+	var namespace struct {
+		ID int64 `json:"id"`
+	}
+	if err := g.api.getJSON(ctx, "/namespaces/"+url.PathEscape(g.group), nil, &namespace); err != nil {
+		return nil, fmt.Errorf("failed to find group %s: %w", g.group, err)
+	}
+
+	payload := map[string]interface{}{
+		"name":                   name,
+		"path":                   name,
+		"namespace_id":           namespace.ID,
+		"description":            description,
+		"visibility":             "private",
+		"initialize_with_readme": true,
+		"default_branch":         DefaultBranch,
+	}
+	var project gitlabProject
+	if err := g.api.postJSON(ctx, "/projects", payload, &project); err != nil {
+		return nil, fmt.Errorf("failed to create repository %s: %w", name, err)
+	}
+	return project.toRepository(), nil
+}
+
+type gitlabTreeItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// listTree pages through the repository tree API
+func (g *GitLab) listTree(ctx context.Context, name, ref, dir string, recursive bool) ([]Entry, error) {
+	var entries []Entry
+	for page := 1; ; page++ {
+		query := url.Values{
+			"ref":      {refOrDefault(ref)},
+			"per_page": {strconv.Itoa(gitlabPageSize)},
+			"page":     {strconv.Itoa(page)},
+		}
+		if dir != "" {
+			query.Set("path", strings.Trim(dir, "/"))
+		}
+		if recursive {
+			query.Set("recursive", "true")
+		}
+
+		var items []gitlabTreeItem
+		if err := g.api.getJSON(ctx, g.projectPath(name)+"/repository/tree", query, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			entryType := "file"
+			switch item.Type {
+			case "tree":
+				entryType = "dir"
+			case "commit":
+				continue
+			}
+			// The tree API does not report blob sizes
+			entries = append(entries, Entry{Name: item.Name, Path: item.Path, Type: entryType, SHA: item.ID})
+		}
+		if len(items) < gitlabPageSize {
+			return entries, nil
+		}
+	}
+}
+
+func (g *GitLab) ListContents(ctx context.Context, name, ref, dir string) ([]Entry, error) {
+	return g.listTree(ctx, name, ref, dir, false)
+}
+
+func (g *GitLab) Tree(ctx context.Context, name, ref string) ([]Entry, error) {
+	return g.listTree(ctx, name, ref, "", true)
+}
+
+func (g *GitLab) ReadFile(ctx context.Context, name, ref, filePath string) (*File, error) {
+	var item struct {
+		FileName string `json:"file_name"`
+		FilePath string `json:"file_path"`
+		Size     int64  `json:"size"`
+		Encoding string `json:"encoding"`
+		Content  string `json:"content"`
+		BlobID   string `json:"blob_id"`
+	}
+	filePath = strings.Trim(filePath, "/")
+	query := url.Values{"ref": {refOrDefault(ref)}}
+	if err := g.api.getJSON(ctx, g.projectPath(name)+"/repository/files/"+url.PathEscape(filePath), query, &item); err != nil {
+		return nil, err
+	}
+
+	file := &File{Name: item.FileName, Path: item.FilePath, SHA: item.BlobID, Size: item.Size}
+	if item.Encoding == "base64" {
+		content, err := base64.StdEncoding.DecodeString(item.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", filePath, err)
+		}
+		file.Content = content
+	} else {
+		file.Content = []byte(item.Content)
+	}
+	return file, nil
+}
+
+func (g *GitLab) ListCommits(ctx context.Context, name, ref string, limit int) ([]Commit, error) {
+	query := url.Values{"ref_name": {refOrDefault(ref)}, "per_page": {strconv.Itoa(limit)}}
+	var items []struct {
+		ID           string    `json:"id"`
+		Message      string    `json:"message"`
+		AuthorName   string    `json:"author_name"`
+		AuthorEmail  string    `json:"author_email"`
+		AuthoredDate time.Time `json:"authored_date"`
+		WebURL       string    `json:"web_url"`
+	}
+	if err := g.api.getJSON(ctx, g.projectPath(name)+"/repository/commits", query, &items); err != nil {
+		return nil, err
+	}
+	commits := make([]Commit, 0, len(items))
+	for _, item := range items {
+		commits = append(commits, Commit{
+			SHA:     item.ID,
+			Message: item.Message,
+			Author:  item.AuthorName,
+			Email:   item.AuthorEmail,
+			Date:    item.AuthoredDate,
+			URL:     item.WebURL,
+		})
+	}
+	return commits, nil
+}
+
+func (g *GitLab) ListBranches(ctx context.Context, name string) ([]Branch, error) {
+	var branches []Branch
+	for page := 1; ; page++ {
+		query := url.Values{"per_page": {strconv.Itoa(gitlabPageSize)}, "page": {strconv.Itoa(page)}}
+		var items []struct {
+			Name   string `json:"name"`
+			Commit struct {
+				ID string `json:"id"`
+			} `json:"commit"`
+		}
+		if err := g.api.getJSON(ctx, g.projectPath(name)+"/repository/branches", query, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			branches = append(branches, Branch{Name: item.Name, SHA: item.Commit.ID})
+		}
+		if len(items) < gitlabPageSize {
+			return branches, nil
+		}
+	}
+}
+
+func (g *GitLab) Archive(ctx context.Context, name, ref string, w io.Writer) error {
+	query := url.Values{"sha": {refOrDefault(ref)}}
+	return g.api.download(ctx, g.projectPath(name)+"/repository/archive.zip", query, w)
+}
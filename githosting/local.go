@@ -0,0 +1,359 @@
+package githosting
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage"
+)
+
+// localScheme is served in-process by go-git, so the local provider needs no git binary
+const localScheme = "gitlocal"
+
+var installLocalScheme sync.Once
+
+var validRepositoryName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Local keeps bare repositories in a directory on disk. It needs no network access and backs
+// development setups and tests.
+type Local struct {
+	remoteGit
+	root  string
+	owner string
+}
+
+// NewLocal creates a provider storing "<root>/<name>.git" bare repositories
+func NewLocal(root, owner string) (*Local, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local git hosting requires a root directory")
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(absRoot, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", absRoot, err)
+	}
+	installLocalScheme.Do(func() {
+		client.InstallProtocol(localScheme, server.NewClient(server.DefaultLoader))
+	})
+	if owner == "" {
+		owner = "local"
+	}
+	return &Local{root: absRoot, owner: owner}, nil
+}
+
+func (l *Local) Name() string {
+	return "local"
+}
+
+func (l *Local) Owner() string {
+	return l.owner
+}
+
+func (l *Local) repoDir(name string) (string, error) {
+	if !validRepositoryName.MatchString(name) || strings.Contains(name, "..") {
+		return "", fmt.Errorf("invalid repository name %q", name)
+	}
+	return filepath.Join(l.root, name+".git"), nil
+}
+
+func (l *Local) describe(name, dir string) *Repository {
+	cloneURL := localScheme + "://" + filepath.ToSlash(dir)
+	return &Repository{
+		ID:            name,
+		Name:          name,
+		WebURL:        cloneURL,
+		CloneURL:      cloneURL,
+		DefaultBranch: DefaultBranch,
+	}
+}
+
+func (l *Local) open(name string) (*git.Repository, error) {
+	dir, err := l.repoDir(name)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainOpen(dir)
+	if err == git.ErrRepositoryNotExists {
+		return nil, ErrNotFound
+	}
+	return repo, err
+}
+
+func (l *Local) GetRepository(ctx context.Context, name string) (*Repository, error) {
+	if _, err := l.open(name); err != nil {
+		return nil, err
+	}
+	dir, _ := l.repoDir(name)
+	return l.describe(name, dir), nil
+}
+
+// CreateRepository initializes a bare repository whose main branch holds a README, like auto_init does on the hosted providers
+func (l *Local) CreateRepository(ctx context.Context, name, description string) (*Repository, error) {
+	dir, err := l.repoDir(name)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName(DefaultBranch)},
+		Bare:        true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository %s: %w", name, err)
+	}
+	if err := writeInitialCommit(repo.Storer, name, description); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return l.describe(name, dir), nil
+}
+
+// writeInitialCommit stores README.md and the first commit directly, since a bare repository has no worktree
+func writeInitialCommit(s storage.Storer, name, description string) error {
+	readme := fmt.Sprintf("# %s\n\n%s\n", name, description)
+	blob := s.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+	writer, err := blob.Writer()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write([]byte(readme)); err != nil {
+		return err
+	}
+	writer.Close()
+	blobHash, err := s.SetEncodedObject(blob)
+	if err != nil {
+		return err
+	}
+
+	tree := &object.Tree{Entries: []object.TreeEntry{{Name: "README.md", Mode: filemode.Regular, Hash: blobHash}}}
+	treeObject := s.NewEncodedObject()
+	if err := tree.Encode(treeObject); err != nil {
+		return err
+	}
+	treeHash, err := s.SetEncodedObject(treeObject)
+	if err != nil {
+		return err
+	}
+
+	signature := object.Signature{Name: "Thesis Management System", Email: "noreply@localhost", When: time.Now()}
+	commit := &object.Commit{Author: signature, Committer: signature, Message: "Initial commit", TreeHash: treeHash}
+	commitObject := s.NewEncodedObject()
+	if err := commit.Encode(commitObject); err != nil {
+		return err
+	}
+	commitHash, err := s.SetEncodedObject(commitObject)
+	if err != nil {
+		return err
+	}
+	return s.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(DefaultBranch), commitHash))
+}
+
+// resolve returns the tree of the commit ref points to
+func (l *Local) resolve(name, ref string) (*git.Repository, *object.Commit, *object.Tree, error) {
+	repo, err := l.open(name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(refOrDefault(ref)))
+	if err != nil {
+		return nil, nil, nil, ErrNotFound
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return repo, commit, tree, nil
+}
+
+func (l *Local) ListContents(ctx context.Context, name, ref, dir string) ([]Entry, error) {
+	repo, _, tree, err := l.resolve(name, ref)
+	if err != nil {
+		return nil, err
+	}
+	dir = strings.Trim(dir, "/")
+	if dir != "" {
+		if tree, err = tree.Tree(dir); err != nil {
+			return nil, ErrNotFound
+		}
+	}
+
+	entries := make([]Entry, 0, len(tree.Entries))
+	for _, item := range tree.Entries {
+		entry, ok := treeEntry(repo, path.Join(dir, item.Name), item)
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (l *Local) Tree(ctx context.Context, name, ref string) ([]Entry, error) {
+	repo, _, tree, err := l.resolve(name, ref)
+	if err != nil {
+		return nil, err
+	}
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	var entries []Entry
+	for {
+		entryPath, item, err := walker.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entry, ok := treeEntry(repo, entryPath, item); ok {
+			entries = append(entries, entry)
+		}
+	}
+}
+
+// treeEntry converts a git tree entry, skipping submodules
+func treeEntry(repo *git.Repository, entryPath string, item object.TreeEntry) (Entry, bool) {
+	entry := Entry{Name: item.Name, Path: entryPath, Type: "file", SHA: item.Hash.String()}
+	switch item.Mode {
+	case filemode.Dir:
+		entry.Type = "dir"
+	case filemode.Submodule:
+		return entry, false
+	default:
+		if size, err := repo.Storer.EncodedObjectSize(item.Hash); err == nil {
+			entry.Size = size
+		}
+	}
+	return entry, true
+}
+
+func (l *Local) ReadFile(ctx context.Context, name, ref, filePath string) (*File, error) {
+	_, _, tree, err := l.resolve(name, ref)
+	if err != nil {
+		return nil, err
+	}
+	filePath = strings.Trim(filePath, "/")
+	file, err := tree.File(filePath)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Name: path.Base(filePath), Path: filePath, SHA: file.Hash.String(), Size: file.Size, Content: content}, nil
+}
+
+func (l *Local) ListCommits(ctx context.Context, name, ref string, limit int) ([]Commit, error) {
+	repo, head, _, err := l.resolve(name, ref)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var commits []Commit
+	errLimit := errors.New("limit reached")
+	err = iter.ForEach(func(c *object.Commit) error {
+		if limit > 0 && len(commits) >= limit {
+			return errLimit
+		}
+		commits = append(commits, Commit{
+			SHA:     c.Hash.String(),
+			Message: c.Message,
+			Author:  c.Author.Name,
+			Email:   c.Author.Email,
+			Date:    c.Author.When,
+		})
+		return nil
+	})
+	if err != nil && err != errLimit {
+		return nil, err
+	}
+	return commits, nil
+}
+
+func (l *Local) ListBranches(ctx context.Context, name string) ([]Branch, error) {
+	repo, err := l.open(name)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := repo.Branches()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var branches []Branch
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		branches = append(branches, Branch{Name: ref.Name().Short(), SHA: ref.Hash().String()})
+		return nil
+	})
+	return branches, err
+}
+
+// Archive writes the files of ref under a "<name>-<ref>/" folder, matching the hosted zipballs
+func (l *Local) Archive(ctx context.Context, name, ref string, w io.Writer) error {
+	_, _, tree, err := l.resolve(name, ref)
+	if err != nil {
+		return err
+	}
+	prefix := fmt.Sprintf("%s-%s/", name, strings.ReplaceAll(refOrDefault(ref), "/", "-"))
+
+	archive := zip.NewWriter(w)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		header := &zip.FileHeader{Name: prefix + f.Name, Method: zip.Deflate}
+		if f.Mode == filemode.Executable {
+			header.SetMode(0755)
+		} else {
+			header.SetMode(0644)
+		}
+		dst, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		reader, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		_, err = io.Copy(dst, reader)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return archive.Close()
+}
@@ -0,0 +1,246 @@
+package githosting
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func newTestLocal(t *testing.T) *Local {
+	t.Helper()
+	provider, err := NewLocal(t.TempDir(), "test-org")
+	if err != nil {
+		t.Fatalf("NewLocal() error = %v", err)
+	}
+	return provider
+}
+
+// pushSubmission clones the repository, replaces its content and pushes main plus a history branch
+func pushSubmission(t *testing.T, provider Provider, repo *Repository, files map[string]string, branch string) {
+	t.Helper()
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "work")
+
+	local, err := provider.Clone(ctx, repo, dir)
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	worktree, err := local.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := worktree.AddGlob("."); err != nil {
+		t.Fatal(err)
+	}
+	commit, err := worktree.Commit("Submission", &git.CommitOptions{
+		Author: &object.Signature{Name: "Student", Email: "student@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := local.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), commit)); err != nil {
+		t.Fatal(err)
+	}
+	if err := provider.Push(ctx, repo, local, branch, DefaultBranch); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+}
+
+func TestLocalRepositoryLifecycle(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestLocal(t)
+
+	if _, err := provider.GetRepository(ctx, "thesis-1"); err != ErrNotFound {
+		t.Fatalf("GetRepository() on missing repository error = %v, want ErrNotFound", err)
+	}
+
+	repo, err := provider.CreateRepository(ctx, "thesis-1", "Final thesis")
+	if err != nil {
+		t.Fatalf("CreateRepository() error = %v", err)
+	}
+	if RepositoryName(repo.WebURL) != "thesis-1" {
+		t.Errorf("RepositoryName(%q) = %q, want thesis-1", repo.WebURL, RepositoryName(repo.WebURL))
+	}
+	if _, err := provider.GetRepository(ctx, "thesis-1"); err != nil {
+		t.Fatalf("GetRepository() error = %v", err)
+	}
+
+	pushSubmission(t, provider, repo, map[string]string{
+		"main.go":          "package main\n",
+		"internal/util.go": "package internal\n",
+	}, "submission-1")
+
+	entries, err := provider.ListContents(ctx, "thesis-1", "", "")
+	if err != nil {
+		t.Fatalf("ListContents() error = %v", err)
+	}
+	if got := entryPaths(entries); !equalStrings(got, []string{"README.md", "internal", "main.go"}) {
+		t.Errorf("ListContents() = %v", got)
+	}
+
+	entries, err = provider.ListContents(ctx, "thesis-1", "", "internal")
+	if err != nil {
+		t.Fatalf("ListContents(internal) error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != "internal/util.go" || entries[0].Type != "file" || entries[0].Size == 0 {
+		t.Errorf("ListContents(internal) = %+v", entries)
+	}
+
+	tree, err := provider.Tree(ctx, "thesis-1", "submission-1")
+	if err != nil {
+		t.Fatalf("Tree() error = %v", err)
+	}
+	if got := entryPaths(tree); !equalStrings(got, []string{"README.md", "internal", "internal/util.go", "main.go"}) {
+		t.Errorf("Tree() = %v", got)
+	}
+
+	file, err := provider.ReadFile(ctx, "thesis-1", "", "internal/util.go")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(file.Content) != "package internal\n" || file.Name != "util.go" {
+		t.Errorf("ReadFile() = %+v", file)
+	}
+	if _, err := provider.ReadFile(ctx, "thesis-1", "", "missing.go"); err != ErrNotFound {
+		t.Errorf("ReadFile() on missing file error = %v, want ErrNotFound", err)
+	}
+	if _, err := provider.ListContents(ctx, "thesis-1", "no-such-branch", ""); err != ErrNotFound {
+		t.Errorf("ListContents() on missing ref error = %v, want ErrNotFound", err)
+	}
+
+	commits, err := provider.ListCommits(ctx, "thesis-1", "", 10)
+	if err != nil {
+		t.Fatalf("ListCommits() error = %v", err)
+	}
+	if len(commits) != 2 || commits[0].Message != "Submission" || commits[0].Email != "student@example.com" {
+		t.Errorf("ListCommits() = %+v", commits)
+	}
+	if commits, _ := provider.ListCommits(ctx, "thesis-1", "", 1); len(commits) != 1 {
+		t.Errorf("ListCommits() with limit 1 returned %d commits", len(commits))
+	}
+
+	var archive bytes.Buffer
+	if err := provider.Archive(ctx, "thesis-1", "", &archive); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	if err != nil {
+		t.Fatalf("Archive() is not a ZIP: %v", err)
+	}
+	var names []string
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	want := []string{"thesis-1-main/README.md", "thesis-1-main/internal/util.go", "thesis-1-main/main.go"}
+	if !equalStrings(names, want) {
+		t.Errorf("Archive() entries = %v, want %v", names, want)
+	}
+}
+
+func TestLocalSecondSubmissionKeepsHistory(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestLocal(t)
+	repo, err := provider.CreateRepository(ctx, "thesis-2", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pushSubmission(t, provider, repo, map[string]string{"a.txt": "first"}, "submission-1")
+	pushSubmission(t, provider, repo, map[string]string{"a.txt": "second"}, "submission-2")
+
+	first, err := provider.ReadFile(ctx, "thesis-2", "submission-1", "a.txt")
+	if err != nil || string(first.Content) != "first" {
+		t.Errorf("ReadFile(submission-1) = %v, %v", first, err)
+	}
+	latest, err := provider.ReadFile(ctx, "thesis-2", "", "a.txt")
+	if err != nil || string(latest.Content) != "second" {
+		t.Errorf("ReadFile(main) = %v, %v", latest, err)
+	}
+
+	branches, err := provider.ListBranches(ctx, "thesis-2")
+	if err != nil {
+		t.Fatalf("ListBranches() error = %v", err)
+	}
+	var names []string
+	for _, branch := range branches {
+		if branch.SHA == "" {
+			t.Errorf("branch %s has no commit hash", branch.Name)
+		}
+		names = append(names, branch.Name)
+	}
+	sort.Strings(names)
+	if !equalStrings(names, []string{"main", "submission-1", "submission-2"}) {
+		t.Errorf("ListBranches() = %v", names)
+	}
+
+	oldTree, _ := provider.Tree(ctx, "thesis-2", "submission-1")
+	newTree, _ := provider.Tree(ctx, "thesis-2", "submission-2")
+	changes := CompareTrees(oldTree, newTree)
+	if len(changes) != 1 || changes[0].Path != "a.txt" || changes[0].Status != ChangeModified {
+		t.Errorf("CompareTrees() = %+v", changes)
+	}
+}
+
+func TestLocalRejectsInvalidNames(t *testing.T) {
+	provider := newTestLocal(t)
+	for _, name := range []string{"", "../escape", "a/b", ".hidden"} {
+		if _, err := provider.CreateRepository(context.Background(), name, ""); err == nil {
+			t.Errorf("CreateRepository(%q) succeeded", name)
+		}
+	}
+}
+
+func TestRepositoryName(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/org/thesis-1":           "thesis-1",
+		"https://github.com/org/thesis-1/":          "thesis-1",
+		"https://gitlab.com/group/thesis-1.git":     "thesis-1",
+		"gitlocal:///srv/repositories/thesis-1.git": "thesis-1",
+		"thesis-1": "thesis-1",
+	}
+	for input, want := range tests {
+		if got := RepositoryName(input); got != want {
+			t.Errorf("RepositoryName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func entryPaths(entries []Entry) []string {
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
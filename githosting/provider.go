@@ -0,0 +1,145 @@
+// Package githosting hides where student repositories are hosted behind one interface, so that
+// GitHub, GitLab, Gitea and plain bare repositories on local disk can be used interchangeably
+package githosting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// DefaultBranch is the branch holding the latest submission
+const DefaultBranch = "main"
+
+// ErrNotFound is returned when a repository, ref or path does not exist
+var ErrNotFound = errors.New("githosting: not found")
+
+// Repository describes a hosted repository
+type Repository struct {
+	ID            string
+	Name          string
+	WebURL        string
+	CloneURL      string
+	DefaultBranch string
+}
+
+// Entry is a file or directory inside a repository
+type Entry struct {
+	Name string
+	Path string
+	Type string // "file" or "dir"
+	Size int64
+	URL  string
+	SHA  string // blob or tree hash; equal hashes mean equal content
+}
+
+// Branch is a named ref and the commit it points to
+type Branch struct {
+	Name string
+	SHA  string
+}
+
+// File is the content of one file
+type File struct {
+	Name    string
+	Path    string
+	SHA     string
+	Size    int64
+	Content []byte // nil when the host does not return content inline (e.g. very large files)
+	URL     string
+}
+
+// Commit is one entry of the repository history
+type Commit struct {
+	SHA     string
+	Message string
+	Author  string
+	Email   string
+	Date    time.Time
+	URL     string
+}
+
+// Provider is a code hosting backend. An empty ref means the default branch.
+type Provider interface {
+	// Name identifies the backend, e.g. "github"
+	Name() string
+	// Owner is the organization, group or owner the repositories belong to
+	Owner() string
+
+	GetRepository(ctx context.Context, name string) (*Repository, error)
+	CreateRepository(ctx context.Context, name, description string) (*Repository, error)
+
+	// Clone checks the repository out into dir; Push uploads the given local branches back
+	Clone(ctx context.Context, repo *Repository, dir string) (*git.Repository, error)
+	Push(ctx context.Context, repo *Repository, local *git.Repository, branches ...string) error
+
+	ListContents(ctx context.Context, name, ref, dir string) ([]Entry, error)
+	ReadFile(ctx context.Context, name, ref, path string) (*File, error)
+	ListCommits(ctx context.Context, name, ref string, limit int) ([]Commit, error)
+	ListBranches(ctx context.Context, name string) ([]Branch, error)
+	// Tree lists every file and directory of the ref recursively
+	Tree(ctx context.Context, name, ref string) ([]Entry, error)
+	// Archive writes a ZIP of the ref to w
+	Archive(ctx context.Context, name, ref string, w io.Writer) error
+}
+
+// Config selects and configures a provider
+type Config struct {
+	Provider     string // github, gitlab, gitea or local
+	BaseURL      string // API base URL; empty for public GitHub and GitLab
+	Organization string // organization, group or owner of the repositories
+	Token        string
+	LocalRoot    string // directory of the bare repositories for the local provider
+	Cache        CacheOptions
+}
+
+// Enabled reports whether the configuration is complete enough to create a provider
+func (c *Config) Enabled() bool {
+	if c == nil {
+		return false
+	}
+	if c.Provider == "local" {
+		return c.LocalRoot != ""
+	}
+	return c.Organization != "" && c.Token != ""
+}
+
+// New creates the configured provider
+func New(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", "github":
+		return NewGitHub(cfg.BaseURL, cfg.Organization, cfg.Token), nil
+	case "gitea":
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("gitea requires an API base URL")
+		}
+		return NewGitea(cfg.BaseURL, cfg.Organization, cfg.Token), nil
+	case "gitlab":
+		return NewGitLab(cfg.BaseURL, cfg.Organization, cfg.Token), nil
+	case "local":
+		return NewLocal(cfg.LocalRoot, cfg.Organization)
+	default:
+		return nil, fmt.Errorf("unknown git hosting provider %q", cfg.Provider)
+	}
+}
+
+// RepositoryName extracts the repository name from a stored web or clone URL
+func RepositoryName(repoURL string) string {
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	if i := strings.LastIndexAny(repoURL, `/\`); i >= 0 {
+		return repoURL[i+1:]
+	}
+	return repoURL
+}
+
+func refOrDefault(ref string) string {
+	if ref == "" {
+		return DefaultBranch
+	}
+	return ref
+}
@@ -0,0 +1,194 @@
+package githosting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+const userAgent = "Thesis-Management-System/1.0"
+
+// The GET responses kept for revalidation are bounded by count and by the size of their bodies
+const (
+	etagCacheEntries = 1000
+	etagCacheBytes   = 16 << 20
+)
+
+// apiClient performs authenticated REST calls against a hosting API
+type apiClient struct {
+	baseURL string
+	client  *http.Client
+	// authorize adds the provider specific authentication headers
+	authorize func(req *http.Request)
+
+	// etags holds the last response of each GET URL, revalidated with If-None-Match. GitHub does not count
+	// 304 Not Modified answers against the rate limit.
+	etags                    *lru
+	conditional, notModified atomic.Int64
+}
+
+// etagResponse is a stored GET response body and the ETag it was served with
+type etagResponse struct {
+	etag string
+	body []byte
+}
+
+func newAPIClient(baseURL string, authorize func(req *http.Request)) *apiClient {
+	return &apiClient{
+		baseURL:   baseURL,
+		client:    &http.Client{Timeout: 60 * time.Second},
+		authorize: authorize,
+		etags:     newLRU(etagCacheEntries, etagCacheBytes),
+	}
+}
+
+// do sends the request and returns the response for 2xx statuses; 404 becomes ErrNotFound
+func (c *apiClient) do(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	return c.doWithHeader(ctx, method, path, query, body, nil)
+}
+
+// doWithHeader is do with extra request headers; a 304 Not Modified response is returned as is
+func (c *apiClient) doWithHeader(ctx context.Context, method, path string, query url.Values, body interface{}, header http.Header) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", userAgent)
+	c.authorize(req)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode == http.StatusNotModified && header.Get("If-None-Match") != "" {
+		return resp, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("%s %s returned %d: %s", method, path, resp.StatusCode, message)
+	}
+	return resp, nil
+}
+
+// getJSON decodes a GET response; a response seen before is revalidated by its ETag and reused when unchanged
+func (c *apiClient) getJSON(ctx context.Context, path string, query url.Values, out interface{}) error {
+	key := path + "?" + query.Encode()
+	header := http.Header{}
+	cached, _ := c.etags.get(key)
+	if cached != nil {
+		header.Set("If-None-Match", cached.(*etagResponse).etag)
+		c.conditional.Add(1)
+	}
+
+	resp, err := c.doWithHeader(ctx, http.MethodGet, path, query, nil, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		c.notModified.Add(1)
+		return json.Unmarshal(cached.(*etagResponse).body, out)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// Archive contents are large and read once, so they are not kept
+	if etag := resp.Header.Get("ETag"); etag != "" && !isArchivePath(path) {
+		response := &etagResponse{etag: etag, body: body}
+		c.etags.add("", key, response, cacheValueSize(key, response), 0)
+	}
+	return json.Unmarshal(body, out)
+}
+
+func (c *apiClient) conditionalStats() (requests, notModified int64) {
+	return c.conditional.Load(), c.notModified.Load()
+}
+
+func (c *apiClient) postJSON(ctx context.Context, path string, body, out interface{}) error {
+	resp, err := c.do(ctx, http.MethodPost, path, nil, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// download copies the response body to w; downloads such as archives are streamed and never cached
+func (c *apiClient) download(ctx context.Context, path string, query url.Values, w io.Writer) error {
+	resp, err := c.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// remoteGit clones and pushes through go-git transports; auth is nil for the local provider
+type remoteGit struct {
+	auth transport.AuthMethod
+}
+
+func (g remoteGit) Clone(ctx context.Context, repo *Repository, dir string) (*git.Repository, error) {
+	local, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+		URL:  repo.CloneURL,
+		Auth: g.auth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+	return local, nil
+}
+
+func (g remoteGit) Push(ctx context.Context, repo *Repository, local *git.Repository, branches ...string) error {
+	refSpecs := make([]config.RefSpec, 0, len(branches))
+	for _, branch := range branches {
+		refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch)))
+	}
+	err := local.PushContext(ctx, &git.PushOptions{
+		RemoteName: "origin",
+		Auth:       g.auth,
+		RefSpecs:   refSpecs,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to push %v: %w", branches, err)
+	}
+	return nil
+}
@@ -0,0 +1,665 @@
+// handlers/code_similarity.go - Source code similarity runs between student submissions for department heads
+package handlers
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/githosting"
+	"FinalProjectManagementApp/similarity"
+	"FinalProjectManagementApp/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// Pairs below both thresholds are not stored
+	minPairScore          = 20.0
+	minSharedFingerprints = 10
+	maxPairsPerRun        = 500
+
+	// Fingerprints found in more than boilerplateShare of the submissions (and at least
+	// boilerplateMinSubmissions of them) are treated as templates or scaffolding
+	boilerplateShare          = 0.25
+	boilerplateMinSubmissions = 3
+
+	// maxArchiveSize bounds a repository archive downloaded to fingerprint an older submission
+	maxArchiveSize = 200 << 20
+
+	// Fragments shown on the pair page and the context lines around each of them
+	maxShownFragments = 25
+	fragmentContext   = 2
+)
+
+type CodeSimilarityHandler struct {
+	db       *sqlx.DB
+	provider githosting.Provider
+}
+
+func NewCodeSimilarityHandler(db *sqlx.DB, provider githosting.Provider) *CodeSimilarityHandler {
+	// Runs execute in the background, so a restart leaves unfinished runs behind
+	_, err := db.Exec(`
+        UPDATE code_similarity_runs SET status = ?, error_message = 'Interrupted by a server restart', completed_at = NOW()
+        WHERE status IN (?, ?)`,
+		database.CodeSimilarityFailed, database.CodeSimilarityPending, database.CodeSimilarityRunning)
+	if err != nil {
+		log.Printf("Error failing interrupted code similarity runs: %v", err)
+	}
+	return &CodeSimilarityHandler{db: db, provider: provider}
+}
+
+// codeSubmission is the latest source code document of a student
+type codeSubmission struct {
+	DocumentID       int     `db:"document_id"`
+	StudentRecordID  int     `db:"student_record_id"`
+	CurrentYear      int     `db:"current_year"`
+	RepositoryURL    string  `db:"repository_url"`
+	SubmissionBranch *string `db:"submission_branch"`
+
+	prints *similarity.CodeFingerprints
+}
+
+func (s *codeSubmission) ref() string {
+	return database.StringValue(s.SubmissionBranch)
+}
+
+// ShowRuns lists the runs of the department and the programs a new run can cover
+func (h *CodeSimilarityHandler) ShowRuns(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleAdmin && user.Role != auth.RoleDepartmentHead) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	scopes, err := h.listScopes(user)
+	if err != nil {
+		log.Printf("Error loading code similarity scopes: %v", err)
+		http.Error(w, "Failed to load programs", http.StatusInternalServerError)
+		return
+	}
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	query := "SELECT * FROM code_similarity_runs"
+	var args []interface{}
+	if department != "" {
+		query += " WHERE department = ?"
+		args = append(args, department)
+	}
+	query += " ORDER BY created_at DESC LIMIT 100"
+
+	var runs []database.CodeSimilarityRun
+	if err := h.db.Select(&runs, query, args...); err != nil {
+		log.Printf("Error loading code similarity runs: %v", err)
+		http.Error(w, "Failed to load runs", http.StatusInternalServerError)
+		return
+	}
+
+	templates.CodeSimilarityRuns(user, getLocale(r), scopes, runs).Render(r.Context(), w)
+}
+
+// StartRun queues a run for the selected program and year and executes it in the background
+func (h *CodeSimilarityHandler) StartRun(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleAdmin && user.Role != auth.RoleDepartmentHead) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	scopes, err := h.listScopes(user)
+	if err != nil {
+		log.Printf("Error loading code similarity scopes: %v", err)
+		http.Error(w, "Failed to load programs", http.StatusInternalServerError)
+		return
+	}
+	var scope *database.CodeSimilarityScope
+	for i := range scopes {
+		if scopes[i].Key() == r.FormValue("scope") {
+			scope = &scopes[i]
+			break
+		}
+	}
+	if scope == nil {
+		http.Error(w, "Unknown program or year", http.StatusBadRequest)
+		return
+	}
+
+	var running int
+	err = h.db.Get(&running, `
+        SELECT COUNT(*) FROM code_similarity_runs
+        WHERE department = ? AND study_program = ? AND current_year = ? AND status IN (?, ?)`,
+		scope.Department, scope.StudyProgram, scope.CurrentYear, database.CodeSimilarityPending, database.CodeSimilarityRunning)
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	if running > 0 {
+		http.Error(w, "A similarity check for this program is already running", http.StatusConflict)
+		return
+	}
+
+	includePrevious := r.FormValue("include_previous_years") == "true"
+	result, err := h.db.Exec(`
+        INSERT INTO code_similarity_runs (department, study_program, current_year, include_previous_years, status, created_by)
+        VALUES (?, ?, ?, ?, ?, ?)`,
+		scope.Department, scope.StudyProgram, scope.CurrentYear, includePrevious, database.CodeSimilarityPending, user.Email)
+	if err != nil {
+		log.Printf("Error creating code similarity run: %v", err)
+		http.Error(w, "Failed to start the similarity check", http.StatusInternalServerError)
+		return
+	}
+	id, _ := result.LastInsertId()
+	runID := int(id)
+
+	details, _ := json.Marshal(map[string]interface{}{
+		"study_program":          scope.StudyProgram,
+		"current_year":           scope.CurrentYear,
+		"include_previous_years": includePrevious,
+	})
+	_, err = h.db.Exec(`
+        INSERT INTO audit_logs (user_email, user_role, action, resource_type, resource_id, details, ip_address, user_agent, success, created_at)
+        VALUES (?, ?, 'start_code_similarity_run', 'code_similarity_run', ?, ?, ?, ?, TRUE, ?)`,
+		user.Email, user.Role, strconv.Itoa(runID), string(details),
+		database.NullableString(requestIP(r)), database.NullableString(r.UserAgent()), time.Now())
+	if err != nil {
+		log.Printf("Error writing audit log for code similarity run %d: %v", runID, err)
+	}
+
+	go h.executeRun(runID)
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/code-similarity/%d?locale=%s", runID, getLocale(r)), http.StatusSeeOther)
+}
+
+// ShowRun lists the suspicious pairs of a run, highest score first
+func (h *CodeSimilarityHandler) ShowRun(w http.ResponseWriter, r *http.Request) {
+	user, run, ok := h.loadRun(w, r)
+	if !ok {
+		return
+	}
+
+	var pairs []database.CodeSimilarityPair
+	err := h.db.Select(&pairs, codeSimilarityPairsQuery+" WHERE p.run_id = ? ORDER BY p.score DESC, p.shared_fingerprints DESC", run.ID)
+	if err != nil {
+		log.Printf("Error loading pairs of code similarity run %d: %v", run.ID, err)
+		http.Error(w, "Failed to load pairs", http.StatusInternalServerError)
+		return
+	}
+
+	templates.CodeSimilarityRunPage(user, getLocale(r), run, pairs).Render(r.Context(), w)
+}
+
+// ShowPair shows the matched fragments of a pair side by side
+func (h *CodeSimilarityHandler) ShowPair(w http.ResponseWriter, r *http.Request) {
+	user, run, ok := h.loadRun(w, r)
+	if !ok {
+		return
+	}
+	pairID, err := strconv.Atoi(chi.URLParam(r, "pairId"))
+	if err != nil {
+		http.Error(w, "Invalid pair ID", http.StatusBadRequest)
+		return
+	}
+
+	var pair database.CodeSimilarityPair
+	err = h.db.Get(&pair, codeSimilarityPairsQuery+" WHERE p.id = ? AND p.run_id = ?", pairID, run.ID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "Pair not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
+	submissionA, errA := h.getSubmission(pair.DocumentAID)
+	submissionB, errB := h.getSubmission(pair.DocumentBID)
+	if errA != nil || errB != nil {
+		http.Error(w, "Submission not found", http.StatusNotFound)
+		return
+	}
+
+	matches := pair.Matches()
+	if len(matches) > maxShownFragments {
+		matches = matches[:maxShownFragments]
+	}
+	filesA := newSourceReader(h.provider, submissionA)
+	filesB := newSourceReader(h.provider, submissionB)
+	fragments := make([]types.CodeFragment, 0, len(matches))
+	for _, match := range matches {
+		fragment := types.CodeFragment{Match: match}
+		linesA, errA := filesA.lines(r.Context(), match.PathA, match.StartA, match.EndA)
+		linesB, errB := filesB.lines(r.Context(), match.PathB, match.StartB, match.EndB)
+		if err := errors.Join(errA, errB); err != nil {
+			fragment.Error = err.Error()
+		}
+		fragment.LinesA, fragment.LinesB = linesA, linesB
+		fragments = append(fragments, fragment)
+	}
+
+	templates.CodeSimilarityPairPage(user, getLocale(r), run, &pair, fragments).Render(r.Context(), w)
+}
+
+const codeSimilarityPairsQuery = `
+        SELECT p.*,
+               CONCAT(a.student_name, ' ', a.student_lastname) AS student_a_name, a.student_group AS student_a_group,
+               CONCAT(b.student_name, ' ', b.student_lastname) AS student_b_name, b.student_group AS student_b_group,
+               b.current_year AS student_b_year
+        FROM code_similarity_pairs p
+        JOIN student_records a ON a.id = p.student_a_id
+        JOIN student_records b ON b.id = p.student_b_id`
+
+// loadRun resolves the run of the URL and checks that it belongs to the user's department
+func (h *CodeSimilarityHandler) loadRun(w http.ResponseWriter, r *http.Request) (*auth.AuthenticatedUser, *database.CodeSimilarityRun, bool) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleAdmin && user.Role != auth.RoleDepartmentHead) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return nil, nil, false
+	}
+
+	runID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid run ID", http.StatusBadRequest)
+		return nil, nil, false
+	}
+	var run database.CodeSimilarityRun
+	if err := h.db.Get(&run, "SELECT * FROM code_similarity_runs WHERE id = ?", runID); err != nil {
+		http.Error(w, "Similarity check not found", http.StatusNotFound)
+		return nil, nil, false
+	}
+	if department, err := departmentHeadScope(h.db, user); err != nil || (department != "" && run.Department != department) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return nil, nil, false
+	}
+	return user, &run, true
+}
+
+// listScopes returns the programs and years with source code submissions in the user's department
+func (h *CodeSimilarityHandler) listScopes(user *auth.AuthenticatedUser) ([]database.CodeSimilarityScope, error) {
+	query := `
+        SELECT sr.department, sr.study_program, sr.current_year, COUNT(DISTINCT sr.id) AS submissions
+        FROM student_records sr
+        JOIN documents d ON d.student_record_id = sr.id AND d.document_type = 'thesis_source_code'
+        WHERE d.repository_url IS NOT NULL`
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		return nil, err
+	}
+	var args []interface{}
+	if department != "" {
+		query += " AND sr.department = ?"
+		args = append(args, department)
+	}
+	query += `
+        GROUP BY sr.department, sr.study_program, sr.current_year
+        ORDER BY sr.current_year DESC, sr.study_program`
+
+	var scopes []database.CodeSimilarityScope
+	err = h.db.Select(&scopes, query, args...)
+	return scopes, err
+}
+
+func (h *CodeSimilarityHandler) getSubmission(documentID int) (*codeSubmission, error) {
+	var submission codeSubmission
+	err := h.db.Get(&submission, `
+        SELECT d.id AS document_id, d.student_record_id, sr.current_year, d.repository_url, d.submission_branch
+        FROM documents d
+        JOIN student_records sr ON sr.id = d.student_record_id
+        WHERE d.id = ?`, documentID)
+	if err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// ===== RUN EXECUTION =====
+
+func (h *CodeSimilarityHandler) executeRun(runID int) {
+	if err := h.runComparison(runID); err != nil {
+		log.Printf("Code similarity run %d failed: %v", runID, err)
+		_, dbErr := h.db.Exec(`
+            UPDATE code_similarity_runs SET status = ?, error_message = ?, completed_at = NOW() WHERE id = ?`,
+			database.CodeSimilarityFailed, err.Error(), runID)
+		if dbErr != nil {
+			log.Printf("Error marking code similarity run %d as failed: %v", runID, dbErr)
+		}
+	}
+}
+
+func (h *CodeSimilarityHandler) runComparison(runID int) error {
+	var run database.CodeSimilarityRun
+	if err := h.db.Get(&run, "SELECT * FROM code_similarity_runs WHERE id = ?", runID); err != nil {
+		return err
+	}
+	if _, err := h.db.Exec("UPDATE code_similarity_runs SET status = ? WHERE id = ?", database.CodeSimilarityRunning, runID); err != nil {
+		return err
+	}
+
+	// The latest source code submission of every student of the department's program, this year and optionally before
+	query := `
+        SELECT d.id AS document_id, d.student_record_id, sr.current_year, d.repository_url, d.submission_branch
+        FROM documents d
+        JOIN student_records sr ON sr.id = d.student_record_id
+        WHERE d.document_type = 'thesis_source_code' AND d.repository_url IS NOT NULL AND d.repository_url != ''
+          AND sr.department = ? AND sr.study_program = ?
+          AND d.id = (SELECT MAX(d2.id) FROM documents d2
+                      WHERE d2.student_record_id = d.student_record_id AND d2.document_type = 'thesis_source_code')`
+	if !run.IncludePreviousYears {
+		query += " AND sr.current_year = ?"
+	} else {
+		query += " AND sr.current_year <= ?"
+	}
+	var submissions []*codeSubmission
+	if err := h.db.Select(&submissions, query, run.Department, run.StudyProgram, run.CurrentYear); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var current, previous []*codeSubmission
+	var all []*similarity.CodeFingerprints
+	for _, submission := range submissions {
+		prints, err := h.loadFingerprints(ctx, submission)
+		if err != nil {
+			// One unreachable repository should not fail the whole cohort
+			log.Printf("Skipping document %d in code similarity run %d: %v", submission.DocumentID, runID, err)
+			continue
+		}
+		submission.prints = prints
+		all = append(all, prints)
+		if submission.CurrentYear == run.CurrentYear {
+			current = append(current, submission)
+		} else {
+			previous = append(previous, submission)
+		}
+	}
+
+	common := similarity.CommonFingerprints(all, boilerplateShare, boilerplateMinSubmissions)
+
+	type candidate struct {
+		a, b       *codeSubmission
+		comparison *similarity.CodeComparison
+	}
+	var candidates []candidate
+	compare := func(a, b *codeSubmission) {
+		if a.StudentRecordID == b.StudentRecordID {
+			return
+		}
+		comparison := similarity.CompareCode(a.prints, b.prints, common)
+		if comparison.Score() >= minPairScore && comparison.Shared >= minSharedFingerprints {
+			candidates = append(candidates, candidate{a, b, comparison})
+		}
+	}
+	for i, a := range current {
+		for _, b := range current[i+1:] {
+			compare(a, b)
+		}
+		for _, b := range previous {
+			compare(a, b)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].comparison.Score() > candidates[j].comparison.Score()
+	})
+	if len(candidates) > maxPairsPerRun {
+		candidates = candidates[:maxPairsPerRun]
+	}
+
+	tx, err := h.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range candidates {
+		matches, err := json.Marshal(c.comparison.Matches)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+            INSERT INTO code_similarity_pairs
+            (run_id, document_a_id, student_a_id, document_b_id, student_b_id, previous_year,
+             score, percent_a, percent_b, shared_fingerprints, matches_json)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			runID, c.a.DocumentID, c.a.StudentRecordID, c.b.DocumentID, c.b.StudentRecordID,
+			c.b.CurrentYear != run.CurrentYear, c.comparison.Score(), c.comparison.PercentA, c.comparison.PercentB,
+			c.comparison.Shared, string(matches))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+        UPDATE code_similarity_runs
+        SET status = ?, submissions_count = ?, previous_submissions_count = ?, pairs_count = ?, completed_at = NOW()
+        WHERE id = ?`,
+		database.CodeSimilarityCompleted, len(current), len(previous), len(candidates), runID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// loadFingerprints returns the stored fingerprints of a submission, computing them from the repository archive
+// when the submission predates fingerprinting or the algorithm changed
+func (h *CodeSimilarityHandler) loadFingerprints(ctx context.Context, submission *codeSubmission) (*similarity.CodeFingerprints, error) {
+	var stored string
+	err := h.db.Get(&stored, "SELECT fingerprints FROM source_code_fingerprints WHERE document_id = ? AND version = ?",
+		submission.DocumentID, similarity.CodeFingerprintVersion)
+	if err == nil {
+		var prints similarity.CodeFingerprints
+		if err := json.Unmarshal([]byte(stored), &prints); err == nil {
+			return &prints, nil
+		}
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	prints, err := fingerprintArchive(ctx, h.provider, githosting.RepositoryName(submission.RepositoryURL), submission.ref())
+	if err != nil {
+		return nil, err
+	}
+	if err := storeFingerprints(h.db, submission.DocumentID, submission.StudentRecordID, prints); err != nil {
+		log.Printf("Warning: failed to store fingerprints of document %d: %v", submission.DocumentID, err)
+	}
+	return prints, nil
+}
+
+// ===== FINGERPRINTING =====
+
+// fingerprintDirectory fingerprints an extracted upload
+func fingerprintDirectory(root string) (*similarity.CodeFingerprints, error) {
+	var files []similarity.SourceFile
+	err := filepath.Walk(root, func(fullPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, fullPath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !similarity.IsSourceFile(rel) || info.Size() > similarity.MaxSourceFileSize || isIgnoredSourceFile(rel, info.Size()) {
+			return nil
+		}
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			return err
+		}
+		files = append(files, similarity.SourceFile{Path: rel, Content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return similarity.FingerprintCode(files), nil
+}
+
+// fingerprintArchive downloads the ZIP of a repository ref and fingerprints its source files
+func fingerprintArchive(ctx context.Context, provider githosting.Provider, repoName, ref string) (*similarity.CodeFingerprints, error) {
+	if repoName == "" {
+		return nil, fmt.Errorf("invalid repository URL")
+	}
+
+	tmp, err := os.CreateTemp("", "similarity-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := provider.Archive(ctx, repoName, ref, &limitedWriter{w: tmp, remaining: maxArchiveSize}); err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", repoName, err)
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []similarity.SourceFile
+	for _, entry := range reader.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		// Archives put everything under a "<repository>-<ref>/" directory
+		name := entry.Name
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+		size := int64(entry.UncompressedSize64)
+		if !similarity.IsSourceFile(name) || size > similarity.MaxSourceFileSize || isIgnoredSourceFile(name, size) {
+			continue
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, similarity.MaxSourceFileSize+1))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, similarity.SourceFile{Path: name, Content: content})
+	}
+	return similarity.FingerprintCode(files), nil
+}
+
+// storeFingerprints saves the fingerprints of a source code document, replacing older ones
+func storeFingerprints(db *sqlx.DB, documentID, studentRecordID int, prints *similarity.CodeFingerprints) error {
+	data, err := json.Marshal(prints)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`
+        INSERT INTO source_code_fingerprints (document_id, student_record_id, version, files_count, fingerprints_count, fingerprints)
+        VALUES (?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE version = VALUES(version), files_count = VALUES(files_count),
+                                fingerprints_count = VALUES(fingerprints_count), fingerprints = VALUES(fingerprints),
+                                created_at = CURRENT_TIMESTAMP`,
+		documentID, studentRecordID, prints.Version, len(prints.Files), prints.Count(), string(data))
+	return err
+}
+
+// limitedWriter fails once more than remaining bytes are written
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		return 0, fmt.Errorf("repository archive exceeds %d MB", maxArchiveSize>>20)
+	}
+	l.remaining -= int64(len(p))
+	return l.w.Write(p)
+}
+
+// ===== FRAGMENTS =====
+
+// sourceReader reads files of one submission for the fragment view, caching them per request
+type sourceReader struct {
+	provider githosting.Provider
+	repoName string
+	ref      string
+	files    map[string][]string
+	failures map[string]error
+}
+
+func newSourceReader(provider githosting.Provider, submission *codeSubmission) *sourceReader {
+	return &sourceReader{
+		provider: provider,
+		repoName: githosting.RepositoryName(submission.RepositoryURL),
+		ref:      submission.ref(),
+		files:    make(map[string][]string),
+		failures: make(map[string]error),
+	}
+}
+
+// lines returns the fragment from start to end with a few context lines around it
+func (s *sourceReader) lines(ctx context.Context, filePath string, start, end int) ([]types.CodeLine, error) {
+	if err := s.failures[filePath]; err != nil {
+		return nil, err
+	}
+	content, ok := s.files[filePath]
+	if !ok {
+		file, err := s.provider.ReadFile(ctx, s.repoName, s.ref, filePath)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", filePath, err)
+			s.failures[filePath] = err
+			return nil, err
+		}
+		content = strings.Split(strings.ReplaceAll(string(file.Content), "\r\n", "\n"), "\n")
+		s.files[filePath] = content
+	}
+
+	from := start - fragmentContext
+	if from < 1 {
+		from = 1
+	}
+	to := end + fragmentContext
+	if to > len(content) {
+		to = len(content)
+	}
+	var lines []types.CodeLine
+	for number := from; number <= to; number++ {
+		lines = append(lines, types.CodeLine{
+			Number:  number,
+			Text:    content[number-1],
+			Matched: number >= start && number <= end,
+		})
+	}
+	return lines, nil
+}
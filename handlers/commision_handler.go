@@ -493,6 +493,19 @@ func (h *CommissionHandler) ShowStudentList(w http.ResponseWriter, r *http.Reque
 
 	log.Printf("Found %d students (page %d of %d)", len(students), page, totalPages)
 
+	// Attach the students' answers to the reviewer questions
+	studentIDs := make([]int, len(students))
+	for i := range students {
+		studentIDs[i] = students[i].ID
+	}
+	if answers, err := getQuestionAnswers(h.db, studentIDs); err != nil {
+		log.Printf("Failed to load reviewer question answers: %v", err)
+	} else {
+		for i := range students {
+			students[i].QuestionAnswers = answers[students[i].ID]
+		}
+	}
+
 	// Create pagination info
 	pagination := &database.PaginationInfo{
 		Page:       page,
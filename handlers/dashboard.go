@@ -245,12 +245,14 @@ func (h *DashboardHandlers) getStudentDashboardData(email string) (*database.Stu
 		data.TopicCommentCount = 0
 	}
 
-	// Check for unread comments
+	// Check for comments by others the student has not read yet
 	var unreadCount int
 	err = h.db.Get(&unreadCount,
 		`SELECT COUNT(*) FROM topic_registration_comments tc
          JOIN project_topic_registrations ptr ON tc.topic_registration_id = ptr.id
-         WHERE ptr.student_record_id = ? AND tc.is_read = FALSE`, studentRecord.ID)
+         LEFT JOIN topic_comment_reads tcr ON tcr.comment_id = tc.id AND tcr.user_email = ?
+         WHERE ptr.student_record_id = ? AND tc.author_email <> ? AND tcr.id IS NULL`,
+		studentRecord.StudentEmail, studentRecord.ID, studentRecord.StudentEmail)
 	if err == nil {
 		data.HasUnreadComments = unreadCount > 0
 	}
@@ -283,7 +285,7 @@ func (h *DashboardHandlers) getStudentDashboardData(email string) (*database.Stu
 				} else {
 					data.SourceCodeStatus = "uploaded"
 				}
-				log.Printf("Found source code: %s", doc.OriginalFilename)
+				log.Printf("Found source code: %s", database.StringValue(doc.OriginalFilename))
 			case "company_recommendation", "recommendation.pdf":
 				data.CompanyRecommendation = doc
 			}
@@ -329,6 +331,7 @@ func (h *DashboardHandlers) getStudentDashboardData(email string) (*database.Stu
 	if err == nil {
 		data.ReviewerReport = &reviewerReport
 		data.HasReviewerReport = true
+		data.ReviewerReportPublished = isReviewerReportPublished(h.db, &studentRecord, &reviewerReport)
 	} else if err != sql.ErrNoRows {
 		log.Printf("Error getting reviewer report: %v", err)
 	}
@@ -0,0 +1,420 @@
+// handlers/defense_periods.go - Defense periods bounding commission and reviewer access to students' work
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// periodInputLayout is the value format of datetime-local inputs
+const periodInputLayout = "2006-01-02T15:04"
+
+type DefensePeriodHandler struct {
+	db *sqlx.DB
+}
+
+func NewDefensePeriodHandler(db *sqlx.DB) *DefensePeriodHandler {
+	return &DefensePeriodHandler{db: db}
+}
+
+// ShowPeriods lists the defense periods of the departments the user manages
+func (h *DefensePeriodHandler) ShowPeriods(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	data := templates.DefensePeriodsPageData{Now: time.Now(), Saved: r.URL.Query().Get("saved") == "1"}
+	query := `SELECT * FROM defense_periods`
+	var args []interface{}
+	if department != "" {
+		query += ` WHERE department = ?`
+		args = append(args, department)
+	}
+	if err := h.db.Select(&data.Periods, query+` ORDER BY starts_at DESC, department, study_program`, args...); err != nil {
+		log.Printf("Error loading defense periods: %v", err)
+		http.Error(w, "Failed to load defense periods", http.StatusInternalServerError)
+		return
+	}
+
+	if department == "" {
+		h.db.Select(&data.Departments, `
+            SELECT DISTINCT department FROM student_records
+            WHERE department IS NOT NULL AND department != '' ORDER BY department`)
+		h.db.Select(&data.StudyPrograms, `
+            SELECT DISTINCT study_program FROM student_records
+            WHERE study_program IS NOT NULL AND study_program != '' ORDER BY study_program`)
+	} else {
+		data.Departments = []string{department}
+		h.db.Select(&data.StudyPrograms, `
+            SELECT DISTINCT study_program FROM student_records
+            WHERE department = ? AND study_program IS NOT NULL AND study_program != '' ORDER BY study_program`,
+			department)
+	}
+
+	locale := getLocale(r)
+	templates.DefensePeriodsPage(user, locale, data).Render(r.Context(), w)
+}
+
+// CreatePeriod adds a defense period for a department or one of its study programs
+func (h *DefensePeriodHandler) CreatePeriod(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	locale := getLocale(r)
+
+	department := strings.TrimSpace(r.FormValue("department"))
+	if department == "" {
+		renderTemplateError(w, "#period-error", localized(locale, "Pasirinkite katedrą", "Select a department"))
+		return
+	}
+	if scope, err := departmentHeadScope(h.db, user); err != nil || (scope != "" && department != scope) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	startsAt, errStart := time.ParseInLocation(periodInputLayout, r.FormValue("starts_at"), time.Local)
+	endsAt, errEnd := time.ParseInLocation(periodInputLayout, r.FormValue("ends_at"), time.Local)
+	if errStart != nil || errEnd != nil {
+		renderTemplateError(w, "#period-error", localized(locale, "Nurodykite gynimo pradžią ir pabaigą", "Enter the start and end of the defense"))
+		return
+	}
+	if !endsAt.After(startsAt) {
+		renderTemplateError(w, "#period-error", localized(locale, "Pabaiga turi būti vėlesnė nei pradžia", "The end must be after the start"))
+		return
+	}
+
+	var reviewStartsAt *time.Time
+	if value := r.FormValue("review_starts_at"); value != "" {
+		parsed, err := time.ParseInLocation(periodInputLayout, value, time.Local)
+		if err != nil || parsed.After(startsAt) {
+			renderTemplateError(w, "#period-error", localized(locale,
+				"Recenzentų prieiga turi prasidėti ne vėliau nei gynimas",
+				"Reviewer access must open no later than the defense"))
+			return
+		}
+		reviewStartsAt = &parsed
+	}
+
+	result, err := h.db.Exec(`
+        INSERT INTO defense_periods (department, study_program, review_starts_at, starts_at, ends_at, description, created_by)
+        VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		department, database.NullableString(strings.TrimSpace(r.FormValue("study_program"))), reviewStartsAt,
+		startsAt, endsAt, database.NullableString(strings.TrimSpace(r.FormValue("description"))), user.Email)
+	if err != nil {
+		log.Printf("Error saving defense period of %s: %v", department, err)
+		http.Error(w, "Failed to save defense period", http.StatusInternalServerError)
+		return
+	}
+	id, _ := result.LastInsertId()
+	auditDefensePeriod(h.db, r, user, "create_defense_period", int(id), map[string]interface{}{
+		"department":    department,
+		"study_program": r.FormValue("study_program"),
+		"starts_at":     startsAt,
+		"ends_at":       endsAt,
+	})
+
+	w.Header().Set("HX-Redirect", "/admin/defense-periods?saved=1&locale="+locale)
+}
+
+// DeletePeriod removes a defense period; links of its students are refused from then on
+func (h *DefensePeriodHandler) DeletePeriod(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid period ID", http.StatusBadRequest)
+		return
+	}
+
+	var period database.DefensePeriod
+	if err := h.db.Get(&period, `SELECT * FROM defense_periods WHERE id = ?`, id); err != nil {
+		http.Error(w, "Defense period not found", http.StatusNotFound)
+		return
+	}
+	if scope, err := departmentHeadScope(h.db, user); err != nil || (scope != "" && period.Department != scope) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	if _, err := h.db.Exec(`DELETE FROM defense_periods WHERE id = ?`, id); err != nil {
+		log.Printf("Error deleting defense period %d: %v", id, err)
+		http.Error(w, "Failed to delete defense period", http.StatusInternalServerError)
+		return
+	}
+	auditDefensePeriod(h.db, r, user, "delete_defense_period", id, map[string]interface{}{
+		"department":    period.Department,
+		"study_program": database.StringValue(period.StudyProgram),
+		"starts_at":     period.StartsAt,
+		"ends_at":       period.EndsAt,
+	})
+
+	w.Header().Set("HX-Redirect", "/admin/defense-periods?locale="+getLocale(r))
+}
+
+// auditDefensePeriod records a change to the defense periods
+func auditDefensePeriod(db *sqlx.DB, r *http.Request, user *auth.AuthenticatedUser, action string, periodID int, details map[string]interface{}) {
+	detailsJSON, _ := json.Marshal(details)
+	_, err := db.Exec(`
+        INSERT INTO audit_logs (user_email, user_role, action, resource_type, resource_id, details, ip_address, user_agent, success, created_at)
+        VALUES (?, ?, ?, 'defense_period', ?, ?, ?, ?, TRUE, ?)`,
+		user.Email, user.Role, action, strconv.Itoa(periodID), string(detailsJSON), requestIP(r), r.UserAgent(), time.Now())
+	if err != nil {
+		log.Printf("Error writing audit log for %s: %v", action, err)
+	}
+}
+
+// localized picks the Lithuanian or English text for the locale
+func localized(locale, lt, en string) string {
+	if locale == "en" {
+		return en
+	}
+	return lt
+}
+
+// ================================
+// ACCESS WINDOWS
+// ================================
+
+// Reasons an access link is refused
+const (
+	windowInvalidLink = "invalid_link"
+	windowLinkExpired = "link_expired"
+	windowNoPeriod    = "no_period"
+	windowNotOpenYet  = "not_open_yet"
+	windowClosed      = "closed"
+)
+
+// accessWindowError explains why a commission or reviewer link may not open a student's work right now
+type accessWindowError struct {
+	Reason     string
+	AccessType string
+	Department string
+	Program    string
+	ExpiredAt  time.Time
+	Period     *database.DefensePeriod
+}
+
+func (e *accessWindowError) Error() string {
+	return e.message("en")
+}
+
+// message is the explanation shown to the commission member or reviewer
+func (e *accessWindowError) message(locale string) string {
+	const layout = "2006-01-02 15:04"
+	switch e.Reason {
+	case windowInvalidLink:
+		return localized(locale, "Prieigos nuoroda neteisinga arba išjungta", "The access link is invalid or has been deactivated")
+	case windowLinkExpired:
+		return localized(locale,
+			fmt.Sprintf("Prieigos nuorodos galiojimas baigėsi %s", e.ExpiredAt.Format(layout)),
+			fmt.Sprintf("The access link expired on %s", e.ExpiredAt.Format(layout)))
+	case windowNotOpenYet:
+		opens := e.Period.OpensAt(e.AccessType).Format(layout)
+		closes := e.Period.EndsAt.Format(layout)
+		return localized(locale,
+			fmt.Sprintf("Prieiga prie studento darbo bus atverta nuo %s iki %s", opens, closes),
+			fmt.Sprintf("Access to this student's work opens on %s and lasts until %s", opens, closes))
+	case windowClosed:
+		return localized(locale,
+			fmt.Sprintf("Gynimo laikotarpis baigėsi %s, prieiga prie studento darbo uždaryta", e.Period.EndsAt.Format(layout)),
+			fmt.Sprintf("The defense period ended on %s; access to this student's work is closed", e.Period.EndsAt.Format(layout)))
+	default:
+		scope := e.Department
+		if e.Program != "" {
+			scope = e.Program + ", " + e.Department
+		}
+		return localized(locale,
+			fmt.Sprintf("Programai %s gynimo laikotarpis nenustatytas, prieiga prie studentų darbų uždaryta", scope),
+			fmt.Sprintf("No defense period is scheduled for %s; access to students' work is closed", scope))
+	}
+}
+
+// accessLink is the commission access code or reviewer token a request was made with. A signed-in reviewer
+// or commission member has no link that could expire, so its ExpiresAt is zero.
+type accessLink struct {
+	Owner     string
+	Role      string
+	ExpiresAt time.Time
+}
+
+// loadAccessLink reads the active link of the access info; inactive and unknown links are not found
+func loadAccessLink(db *sqlx.DB, access database.AccessInfo) (*accessLink, error) {
+	switch access.Type {
+	case database.AccessTypeCommission:
+		var expiresAt int64
+		err := db.Get(&expiresAt, `SELECT expires_at FROM commission_members WHERE access_code = ? AND is_active = true`, access.Code)
+		if err != nil {
+			return nil, err
+		}
+		return &accessLink{Owner: "commission_" + access.Code, Role: auth.RoleCommissionMember, ExpiresAt: time.Unix(expiresAt, 0)}, nil
+	case database.AccessTypeReviewer:
+		var token database.ReviewerAccessToken
+		err := db.Get(&token, `SELECT * FROM reviewer_access_tokens WHERE access_token = ? AND is_active = true`, access.Code)
+		if err != nil {
+			return nil, err
+		}
+		return &accessLink{Owner: token.ReviewerEmail, Role: auth.RoleReviewer, ExpiresAt: time.Unix(token.ExpiresAt, 0)}, nil
+	}
+	return nil, errors.New("unknown access type")
+}
+
+// studentDefensePeriods returns the periods of the student's study program, or of the whole department when
+// the program has none of its own
+func studentDefensePeriods(db *sqlx.DB, student *database.StudentRecord) ([]database.DefensePeriod, error) {
+	var periods []database.DefensePeriod
+	err := db.Select(&periods, `
+        SELECT * FROM defense_periods
+        WHERE department = ? AND (study_program IS NULL OR study_program = '' OR study_program = ?)
+        ORDER BY starts_at`, student.Department, student.StudyProgram)
+	if err != nil {
+		return nil, err
+	}
+
+	var program, department []database.DefensePeriod
+	for _, period := range periods {
+		if database.StringValue(period.StudyProgram) != "" {
+			program = append(program, period)
+		} else {
+			department = append(department, period)
+		}
+	}
+	if len(program) > 0 {
+		return program, nil
+	}
+	return department, nil
+}
+
+// checkAccessWindow returns nil when the link is active and a defense period of the student is open
+func checkAccessWindow(db *sqlx.DB, access database.AccessInfo, link *accessLink, student *database.StudentRecord, now time.Time) error {
+	if link == nil {
+		return &accessWindowError{Reason: windowInvalidLink, AccessType: access.Type}
+	}
+	if !link.ExpiresAt.IsZero() && now.After(link.ExpiresAt) {
+		return &accessWindowError{Reason: windowLinkExpired, AccessType: access.Type, ExpiredAt: link.ExpiresAt}
+	}
+
+	periods, err := studentDefensePeriods(db, student)
+	if err != nil {
+		return err
+	}
+	denied := &accessWindowError{
+		Reason:     windowNoPeriod,
+		AccessType: access.Type,
+		Department: student.Department,
+		Program:    student.StudyProgram,
+	}
+	for i := range periods {
+		period := &periods[i]
+		if period.IsOpen(access.Type, now) {
+			return nil
+		}
+		// Periods are sorted by start: report the next one to open, or else the last one that closed
+		if now.Before(period.OpensAt(access.Type)) {
+			if denied.Reason != windowNotOpenYet {
+				denied.Reason, denied.Period = windowNotOpenYet, period
+			}
+		} else if denied.Reason != windowNotOpenYet {
+			denied.Reason, denied.Period = windowClosed, period
+		}
+	}
+	return denied
+}
+
+// EnforceAccessWindow lets a commission or reviewer link through only within a defense period of the student
+// and before the link expires. Refusals are answered with 403 and audited; resource names what was requested.
+func EnforceAccessWindow(db *sqlx.DB, w http.ResponseWriter, r *http.Request, access database.AccessInfo, studentID int, resource string) bool {
+	// An unknown or deactivated link is refused like any other, so the attempt is audited too
+	link, _ := loadAccessLink(db, access)
+	return enforceAccessWindow(db, w, r, access, link, studentID, resource)
+}
+
+// EnforceUserAccessWindow applies the defense periods to a signed-in reviewer or commission member, who see a
+// student's work within the same window as with a link. Other roles are not bound to defense periods.
+func EnforceUserAccessWindow(db *sqlx.DB, w http.ResponseWriter, r *http.Request, user *auth.AuthenticatedUser, studentID int, resource string) bool {
+	var access database.AccessInfo
+	switch user.Role {
+	case auth.RoleReviewer:
+		access.Type = database.AccessTypeReviewer
+	case auth.RoleCommissionMember:
+		access.Type = database.AccessTypeCommission
+	default:
+		return true
+	}
+	return enforceAccessWindow(db, w, r, access, &accessLink{Owner: user.Email, Role: user.Role}, studentID, resource)
+}
+
+func enforceAccessWindow(db *sqlx.DB, w http.ResponseWriter, r *http.Request, access database.AccessInfo, link *accessLink, studentID int, resource string) bool {
+	var student database.StudentRecord
+	if err := db.Get(&student, `SELECT id, study_program, department FROM student_records WHERE id = ?`, studentID); err != nil {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return false
+	}
+
+	err := checkAccessWindow(db, access, link, &student, time.Now())
+	if err == nil {
+		return true
+	}
+
+	var denied *accessWindowError
+	if !errors.As(err, &denied) {
+		log.Printf("Error checking defense periods of student %d: %v", studentID, err)
+		http.Error(w, "Failed to check the defense period", http.StatusInternalServerError)
+		return false
+	}
+
+	owner, role := access.Type+"_link", access.Type
+	if link != nil {
+		owner, role = link.Owner, link.Role
+	}
+	details := map[string]interface{}{
+		"reason":      denied.Reason,
+		"access_type": access.Type,
+		"resource":    resource,
+		"path":        r.URL.Path,
+	}
+	if denied.Period != nil {
+		details["period_id"] = denied.Period.ID
+		details["opens_at"] = denied.Period.OpensAt(access.Type)
+		details["ends_at"] = denied.Period.EndsAt
+	}
+	detailsJSON, _ := json.Marshal(details)
+	_, auditErr := db.Exec(`
+        INSERT INTO audit_logs (user_email, user_role, action, resource_type, resource_id, details, ip_address, user_agent, success, created_at)
+        VALUES (?, ?, 'access_window_denied', ?, ?, ?, ?, ?, FALSE, ?)`,
+		owner, role, resource, strconv.Itoa(studentID), string(detailsJSON), requestIP(r), r.UserAgent(), time.Now())
+	if auditErr != nil {
+		log.Printf("Error writing audit log for access_window_denied: %v", auditErr)
+	}
+
+	http.Error(w, denied.message(getLocale(r)), http.StatusForbidden)
+	return false
+}
@@ -0,0 +1,25 @@
+// handlers/department_scope.go - The department a department head manages
+package handlers
+
+import (
+	"fmt"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/database"
+	"github.com/jmoiron/sqlx"
+)
+
+// departmentHeadScope returns the department an active department head manages, or "" for admins,
+// who see every department
+func departmentHeadScope(q sqlx.Queryer, user *auth.AuthenticatedUser) (string, error) {
+	if user.Role == auth.RoleAdmin {
+		return "", nil
+	}
+
+	var departmentHead database.DepartmentHead
+	err := sqlx.Get(q, &departmentHead, "SELECT * FROM department_heads WHERE email = ? AND is_active = 1", user.Email)
+	if err != nil {
+		return "", fmt.Errorf("failed to get department head info: %w", err)
+	}
+	return departmentHead.Department, nil
+}
@@ -5,14 +5,15 @@ import (
 	"FinalProjectManagementApp/auth"
 	"FinalProjectManagementApp/components/repository"
 	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/githosting"
 	"FinalProjectManagementApp/types"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"github.com/a-h/templ"
 	"github.com/go-chi/chi/v5"
 	"github.com/jmoiron/sqlx"
-	"io"
 	"log"
 	"net/http"
 	"path/filepath"
@@ -22,16 +23,14 @@ import (
 )
 
 type RepositoryHandler struct {
-	db           *sqlx.DB
-	githubConfig *database.GitHubConfig
-	client       *http.Client
+	db       *sqlx.DB
+	provider githosting.Provider
 }
 
-func NewRepositoryHandler(db *sqlx.DB, githubConfig *database.GitHubConfig) *RepositoryHandler {
+func NewRepositoryHandler(db *sqlx.DB, provider githosting.Provider) *RepositoryHandler {
 	return &RepositoryHandler{
-		db:           db,
-		githubConfig: githubConfig,
-		client:       &http.Client{Timeout: 30 * time.Second},
+		db:       db,
+		provider: provider,
 	}
 }
 
@@ -56,21 +55,8 @@ func (h *RepositoryHandler) ViewStudentRepository(w http.ResponseWriter, r *http
 
 	// Extract access code
 	accessInfo := h.extractAccessInfo(r)
-	//accessCode := h.extractAccessCode(r)
-
-	// Skip permission check for commission members
-	// UPDATE PERMISSION CHECK
-	if user.Role == auth.RoleCommissionMember {
-		if !accessInfo.IsValid() {
-			http.Error(w, "Access code required", http.StatusForbidden)
-			return
-		}
-		// Add validation for the access code here if needed
-	} else {
-		if !h.canViewRepository(user, studentID) {
-			http.Error(w, "Access denied", http.StatusForbidden)
-			return
-		}
+	if !h.authorizeRepository(w, r, user, studentID, accessInfo, false) {
+		return
 	}
 
 	student, err := h.getStudentRecord(studentID)
@@ -123,8 +109,7 @@ func (h *RepositoryHandler) GetRepositoryAPI(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if !h.canViewRepository(user, studentID) {
-		w.WriteHeader(http.StatusForbidden)
+	if !h.authorizeRepository(w, r, user, studentID, h.extractAccessInfo(r), false) {
 		return
 	}
 
@@ -169,24 +154,8 @@ func (h *RepositoryHandler) DownloadRepository(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Special handling for commission members
-	if user.Role == auth.RoleCommissionMember {
-		accessInfo := h.extractAccessInfo(r)
-		if !accessInfo.IsValid() {
-			http.Error(w, "Valid access code required for commission members", http.StatusForbidden)
-			return
-		}
-		// Commission members can download during commission period
-		if !h.isCommissionPeriod() {
-			http.Error(w, "Downloads not available outside commission period", http.StatusForbidden)
-			return
-		}
-	} else {
-		// Regular permission check for other roles
-		if !h.canDownloadRepository(user, studentID) {
-			http.Error(w, "Access denied", http.StatusForbidden)
-			return
-		}
+	if !h.authorizeRepository(w, r, user, studentID, h.extractAccessInfo(r), true) {
+		return
 	}
 
 	// Rest of your download logic...
@@ -208,13 +177,9 @@ func (h *RepositoryHandler) DownloadRepository(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Create download URL for GitHub repository
+	// Stream the archive of the main branch from the git host
 	repoName := h.extractRepoName(*repoInfo.RepositoryURL)
-	downloadURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/zipball/main",
-		h.githubConfig.Organization, repoName)
-
-	// Proxy the download with authentication
-	h.proxyRepositoryDownload(w, r, downloadURL, student)
+	h.proxyRepositoryDownload(w, r, repoName, student)
 }
 
 // ================================
@@ -230,7 +195,8 @@ func (h *RepositoryHandler) canViewRepository(user *auth.AuthenticatedUser, stud
 	case auth.RoleReviewer:
 		return h.isReviewerForStudent(user.Email, studentID)
 	case auth.RoleCommissionMember:
-		return h.isCommissionPeriod()
+		// Only with an access link within a defense period, see authorizeRepository
+		return false
 	case auth.RoleStudent:
 		return h.isStudentOwnRepository(user.Email, studentID)
 	default:
@@ -255,8 +221,8 @@ func (h *RepositoryHandler) canDownloadRepository(user *auth.AuthenticatedUser,
 	case auth.RoleReviewer:
 		return h.isReviewerForStudent(user.Email, studentID)
 	case auth.RoleCommissionMember:
-		// Commission members can download if they're in the commission period
-		return h.isCommissionPeriod()
+		// Only with an access link within a defense period, see authorizeRepository
+		return false
 	default:
 		return false
 	}
@@ -276,9 +242,45 @@ func (h *RepositoryHandler) isReviewerForStudent(email string, studentID int) bo
 	return count > 0
 }
 
-func (h *RepositoryHandler) isCommissionPeriod() bool {
-	// Check if we're in defense period (implement your logic)
-	// For now, return true - you can add date range checks here
+// authorizeRepository applies the role rules of viewing or downloading the student's repository. Reviewers and
+// commission members, signed in or with a link, are only let in within a defense period of the student, and
+// links only before they expire.
+func (h *RepositoryHandler) authorizeRepository(w http.ResponseWriter, r *http.Request, user *auth.AuthenticatedUser, studentID int, accessInfo database.AccessInfo, download bool) bool {
+	resource := "repository"
+	if download {
+		resource = "repository_download"
+	}
+
+	linkAccess := accessInfo.IsValid() && (user.Role == auth.RoleCommissionMember || user.Role == auth.RoleReviewer)
+	if linkAccess {
+		if !EnforceAccessWindow(h.db, w, r, accessInfo, studentID, resource) {
+			return false
+		}
+		if user.Role == auth.RoleCommissionMember {
+			member, err := commissionMemberByCode(h.db, accessInfo.Code)
+			if err != nil || !CommissionCoversStudent(h.db, member, studentID) {
+				http.Error(w, "Access denied", http.StatusForbidden)
+				return false
+			}
+			return true
+		}
+	} else if user.Role == auth.RoleCommissionMember {
+		http.Error(w, "Access code required", http.StatusForbidden)
+		return false
+	}
+
+	allowed := h.canViewRepository(user, studentID)
+	if download {
+		allowed = h.canDownloadRepository(user, studentID)
+	}
+	if !allowed {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return false
+	}
+	// A signed-in reviewer is bound to the same window as a reviewer link
+	if !linkAccess && !EnforceUserAccessWindow(h.db, w, r, user, studentID, resource) {
+		return false
+	}
 	return true
 }
 
@@ -303,7 +305,7 @@ func (h *RepositoryHandler) getStudentRepository(studentID int) (*database.Docum
 	query := `
 		SELECT id, student_record_id, document_type, file_path, repository_url,
 			   repository_id, commit_id, submission_id, validation_status, 
-			   upload_status, uploaded_date, file_size, original_filename
+			   upload_status, uploaded_date, file_size, original_filename, analysis_report
 		FROM documents 
 		WHERE student_record_id = ? AND document_type = 'thesis_source_code'
 		ORDER BY uploaded_date DESC LIMIT 1
@@ -350,108 +352,28 @@ func (h *RepositoryHandler) getRepositoryContents(repoInfo *database.Document) (
 }
 
 func (h *RepositoryHandler) getRepositoryFiles(repoName string) ([]types.RepositoryFile, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents",
-		h.githubConfig.Organization, repoName)
-
-	req, _ := http.NewRequest("GET", url, nil)
-	req.Header.Set("Authorization", "Bearer "+h.githubConfig.PAT)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "Thesis-Management-System/1.0")
-
-	resp, err := h.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("GitHub API request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("repository not found or access denied")
-	}
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var githubFiles []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&githubFiles); err != nil {
-		return nil, fmt.Errorf("failed to decode GitHub response: %w", err)
-	}
-
-	var files []types.RepositoryFile
-	for _, file := range githubFiles {
-		fileType := "file"
-		if t, ok := file["type"].(string); ok {
-			fileType = t
-		}
-
-		size := int64(0)
-		if s, ok := file["size"].(float64); ok {
-			size = int64(s)
-		}
-
-		files = append(files, types.RepositoryFile{
-			Name: h.getStringValue(file, "name"),
-			Path: h.getStringValue(file, "path"),
-			Type: fileType,
-			Size: size,
-			URL:  h.getStringValue(file, "html_url"),
-		})
-	}
-
-	return files, nil
+	return h.getRepositoryFilesForPath(repoName, "")
 }
 
 func (h *RepositoryHandler) getRepositoryCommits(repoName string) ([]types.CommitInfo, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits?per_page=10",
-		h.githubConfig.Organization, repoName)
-
-	req, _ := http.NewRequest("GET", url, nil)
-	req.Header.Set("Authorization", "Bearer "+h.githubConfig.PAT)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "Thesis-Management-System/1.0")
-
-	resp, err := h.client.Do(req)
+	hostedCommits, err := h.provider.ListCommits(context.Background(), repoName, "", 10)
 	if err != nil {
-		return nil, fmt.Errorf("GitHub API request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitHub API error: %d", resp.StatusCode)
-	}
-
-	var githubCommits []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&githubCommits); err != nil {
-		return nil, fmt.Errorf("failed to decode commits: %w", err)
+		return nil, fmt.Errorf("%s API request failed: %w", h.provider.Name(), err)
 	}
 
 	var commits []types.CommitInfo
-	for _, commit := range githubCommits {
-		commitData, ok := commit["commit"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		author, ok := commitData["author"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		dateStr := h.getStringValue(author, "date")
-		date, _ := time.Parse(time.RFC3339, dateStr)
-
-		sha := h.getStringValue(commit, "sha")
+	for _, commit := range hostedCommits {
+		sha := commit.SHA
 		if len(sha) > 7 {
 			sha = sha[:7] // Short SHA
 		}
 
 		commits = append(commits, types.CommitInfo{
 			SHA:     sha,
-			Message: h.getStringValue(commitData, "message"),
-			Author:  h.getStringValue(author, "name"),
-			Date:    date,
-			URL:     h.getStringValue(commit, "html_url"),
+			Message: commit.Message,
+			Author:  commit.Author,
+			Date:    commit.Date,
+			URL:     commit.URL,
 		})
 	}
 
@@ -529,44 +451,42 @@ func (h *RepositoryHandler) getLanguageFromExtension(ext string) string {
 }
 
 func (h *RepositoryHandler) extractRepoName(repoURL string) string {
-	// Remove trailing slash if present
-	repoURL = strings.TrimSuffix(repoURL, "/")
-
-	parts := strings.Split(repoURL, "/")
-	if len(parts) > 0 {
-		return parts[len(parts)-1]
-	}
-	return ""
+	return githosting.RepositoryName(repoURL)
 }
 
-func (h *RepositoryHandler) proxyRepositoryDownload(w http.ResponseWriter, r *http.Request, downloadURL string, student *database.StudentRecord) {
-	req, _ := http.NewRequest("GET", downloadURL, nil)
-	req.Header.Set("Authorization", "Bearer "+h.githubConfig.PAT)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "Thesis-Management-System/1.0")
-
-	resp, err := h.client.Do(req)
-	if err != nil {
-		http.Error(w, "Download failed", http.StatusInternalServerError)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		http.Error(w, "Download not available", http.StatusNotFound)
-		return
-	}
-
-	// Set download headers
+func (h *RepositoryHandler) proxyRepositoryDownload(w http.ResponseWriter, r *http.Request, repoName string, student *database.StudentRecord) {
 	filename := fmt.Sprintf("%s_%s_source_code.zip",
 		student.StudentNumber,
 		strings.ReplaceAll(student.StudentName, " ", "_"))
 
-	w.Header().Set("Content-Type", "application/zip")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	// Download headers are only sent once the host starts returning data, so failures can still become an error page
+	out := &archiveResponseWriter{w: w, filename: filename}
+	if err := h.provider.Archive(r.Context(), repoName, "", out); err != nil {
+		log.Printf("Repository download of %s failed: %v", repoName, err)
+		if !out.started {
+			if err == githosting.ErrNotFound {
+				http.Error(w, "Download not available", http.StatusNotFound)
+			} else {
+				http.Error(w, "Download failed", http.StatusInternalServerError)
+			}
+		}
+	}
+}
+
+// archiveResponseWriter sets the attachment headers on the first write
+type archiveResponseWriter struct {
+	w        http.ResponseWriter
+	filename string
+	started  bool
+}
 
-	// Copy response
-	io.Copy(w, resp.Body)
+func (a *archiveResponseWriter) Write(p []byte) (int, error) {
+	if !a.started {
+		a.started = true
+		a.w.Header().Set("Content-Type", "application/zip")
+		a.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", a.filename))
+	}
+	return a.w.Write(p)
 }
 
 // ================================
@@ -643,7 +563,7 @@ func (h *RepositoryHandler) generateRepositoryHTML(user *auth.AuthenticatedUser,
 		.status-pending { background-color: #fef3c7; color: #92400e; }
 		.status-unknown { background-color: #f3f4f6; color: #374151; }
 		.repo-header { 
-			background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); 
+			background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); 
 			color: white; 
 			padding: 2rem; 
 			border-radius: 12px 12px 0 0; 
@@ -888,7 +808,7 @@ func (h *RepositoryHandler) generateGitHubButton(repoURL string) string {
 				<path d="M12 0c-6.626 0-12 5.373-12 12 0 5.302 3.438 9.8 8.207 11.387.599.111.793-.261.793-.577v-2.234c-3.338.726-4.033-1.416-4.033-1.416-.546-1.387-1.333-1.756-1.333-1.756-1.089-.745.083-.729.083-.729 1.205.084 1.839 1.237 1.839 1.237 1.07 1.834 2.807 1.304 3.492.997.107-.775.418-1.305.762-1.604-2.665-.305-5.467-1.334-5.467-5.931 0-1.311.469-2.381 1.236-3.221-.124-.303-.535-1.524.117-3.176 0 0 1.008-.322 3.301 1.23.957-.266 1.983-.399 3.003-.404 1.02.005 2.047.138 3.006.404 2.291-1.552 3.297-1.23 3.297-1.23.653 1.653.242 2.874.118 3.176.77.84 1.235 1.911 1.235 3.221 0 4.609-2.807 5.624-5.479 5.921.43.372.823 1.102.823 2.222v3.293c0 .319.192.694.801.576 4.765-1.589 8.199-6.086 8.199-11.386 0-6.627-5.373-12-12-12z"/>
 			</svg>
 			View on GitHub
-		</a>`)
+		</a>`, repoURL)
 }
 
 // Add these helper methods:
@@ -1366,13 +1286,8 @@ func (h *RepositoryHandler) ViewFileContent(w http.ResponseWriter, r *http.Reque
 
 	// Extract access code
 	accessInfo := h.extractAccessInfo(r)
-
-	// Skip permission check for commission members
-	if user.Role != auth.RoleCommissionMember {
-		if !h.canViewRepository(user, studentID) {
-			http.Error(w, "Access denied", http.StatusForbidden)
-			return
-		}
+	if !h.authorizeRepository(w, r, user, studentID, accessInfo, false) {
+		return
 	}
 
 	repoInfo, err := h.getStudentRepository(studentID)
@@ -1416,8 +1331,7 @@ func (h *RepositoryHandler) GetRepositoryTree(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if !h.canViewRepository(user, studentID) {
-		w.WriteHeader(http.StatusForbidden)
+	if !h.authorizeRepository(w, r, user, studentID, h.extractAccessInfo(r), false) {
 		return
 	}
 
@@ -1467,57 +1381,31 @@ type FileTreeNode struct {
 }
 
 func (h *RepositoryHandler) getFileContent(repoURL, filePath string) (*types.FileContent, error) {
+	return h.getFileContentAt(repoURL, "", filePath)
+}
+
+// getFileContentAt reads a file as of ref; an empty ref reads the latest submission on main
+func (h *RepositoryHandler) getFileContentAt(repoURL, ref, filePath string) (*types.FileContent, error) {
 	repoName := h.extractRepoName(repoURL)
 	if repoName == "" {
 		return nil, fmt.Errorf("invalid repository URL")
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s",
-		h.githubConfig.Organization, repoName, filePath)
-
-	req, _ := http.NewRequest("GET", url, nil)
-	req.Header.Set("Authorization", "Bearer "+h.githubConfig.PAT)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "Thesis-Management-System/1.0")
-
-	resp, err := h.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("GitHub API request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 404 {
+	file, err := h.provider.ReadFile(context.Background(), repoName, ref, filePath)
+	if err == githosting.ErrNotFound {
 		return nil, fmt.Errorf("file not found")
 	}
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var githubFile map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&githubFile); err != nil {
-		return nil, fmt.Errorf("failed to decode GitHub response: %w", err)
-	}
-
-	// Check if it's a file (not directory)
-	fileType := h.getStringValue(githubFile, "type")
-	if fileType != "file" {
-		return nil, fmt.Errorf("path points to a directory, not a file")
+	if err != nil {
+		return nil, fmt.Errorf("%s API request failed: %w", h.provider.Name(), err)
 	}
 
 	fileContent := &types.FileContent{
-		Name:        h.getStringValue(githubFile, "name"),
-		Path:        h.getStringValue(githubFile, "path"),
-		Type:        fileType,
-		SHA:         h.getStringValue(githubFile, "sha"),
-		DownloadURL: h.getStringValue(githubFile, "download_url"),
-		Encoding:    h.getStringValue(githubFile, "encoding"),
-	}
-
-	// Get size
-	if size, ok := githubFile["size"].(float64); ok {
-		fileContent.Size = int64(size)
+		Name:        file.Name,
+		Path:        file.Path,
+		Type:        "file",
+		SHA:         file.SHA,
+		Size:        file.Size,
+		DownloadURL: file.URL,
 	}
 
 	// Determine language
@@ -1526,17 +1414,11 @@ func (h *RepositoryHandler) getFileContent(repoURL, filePath string) (*types.Fil
 
 	// Get content if it's text-based and not too large
 	if fileContent.Size < 1024*1024 { // Limit to 1MB
-		content := h.getStringValue(githubFile, "content")
-		encoding := h.getStringValue(githubFile, "encoding")
-
-		if encoding == "base64" && content != "" {
-			// Decode base64 content
-			decoded, err := h.decodeBase64Content(content)
-			if err == nil {
-				fileContent.Content = decoded
-				fileContent.IsText = h.isTextFile(fileContent.Name, decoded)
-				fileContent.IsBinary = !fileContent.IsText
-			}
+		if file.Content != nil {
+			content := string(file.Content)
+			fileContent.Content = content
+			fileContent.IsText = h.isTextFile(fileContent.Name, content)
+			fileContent.IsBinary = !fileContent.IsText
 		}
 	} else {
 		fileContent.IsBinary = true
@@ -1547,51 +1429,37 @@ func (h *RepositoryHandler) getFileContent(repoURL, filePath string) (*types.Fil
 	return fileContent, nil
 }
 
+// getRepositoryTreeRecursive nests the flat recursive listing of the git host under path
 func (h *RepositoryHandler) getRepositoryTreeRecursive(repoName, path string) ([]*FileTreeNode, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s",
-		h.githubConfig.Organization, repoName, path)
-
-	req, _ := http.NewRequest("GET", url, nil)
-	req.Header.Set("Authorization", "Bearer "+h.githubConfig.PAT)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "Thesis-Management-System/1.0")
-
-	resp, err := h.client.Do(req)
+	entries, err := h.provider.Tree(context.Background(), repoName, "")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitHub API error: %d", resp.StatusCode)
-	}
-
-	var githubFiles []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&githubFiles); err != nil {
-		return nil, err
-	}
 
-	var nodes []*FileTreeNode
-	for _, file := range githubFiles {
-		node := &FileTreeNode{
-			Name: h.getStringValue(file, "name"),
-			Path: h.getStringValue(file, "path"),
-			Type: h.getStringValue(file, "type"),
+	root := &FileTreeNode{Path: strings.Trim(path, "/"), Type: "dir"}
+	dirs := map[string]*FileTreeNode{root.Path: root}
+	// Parents come before their children in the tree listings of every provider
+	for _, entry := range entries {
+		parentPath := ""
+		if i := strings.LastIndex(entry.Path, "/"); i >= 0 {
+			parentPath = entry.Path[:i]
 		}
-
-		if size, ok := file["size"].(float64); ok {
-			node.Size = int64(size)
+		parent, ok := dirs[parentPath]
+		if !ok {
+			continue
 		}
 
+		node := &FileTreeNode{Name: entry.Name, Path: entry.Path, Type: entry.Type, Size: entry.Size}
 		if node.Type == "file" {
 			node.Language = h.getLanguageFromExtension(
 				strings.ToLower(strings.TrimPrefix(filepath.Ext(node.Name), ".")))
+		} else {
+			dirs[node.Path] = node
 		}
-
-		nodes = append(nodes, node)
+		parent.Children = append(parent.Children, node)
 	}
 
-	return nodes, nil
+	return root.Children, nil
 }
 
 // ================================
@@ -1957,13 +1825,8 @@ func (h *RepositoryHandler) ViewStudentRepositoryPath(w http.ResponseWriter, r *
 	// Extract access code
 	accessInfo := h.extractAccessInfo(r)
 	log.Printf("DEBUG: Extracted access code from URL %s: %s", r.URL.Path, accessInfo)
-
-	// Skip permission check for commission members
-	if user.Role != auth.RoleCommissionMember {
-		if !h.canViewRepository(user, studentID) {
-			http.Error(w, "Access denied", http.StatusForbidden)
-			return
-		}
+	if !h.authorizeRepository(w, r, user, studentID, accessInfo, false) {
+		return
 	}
 
 	student, err := h.getStudentRecord(studentID)
@@ -2038,15 +1901,8 @@ func (h *RepositoryHandler) GetFileContentAPI(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Skip permission check for commission members
-	if user.Role != auth.RoleCommissionMember {
-		if !h.canViewRepository(user, studentID) {
-			w.WriteHeader(http.StatusForbidden)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"error": "Access denied",
-			})
-			return
-		}
+	if !h.authorizeRepository(w, r, user, studentID, h.extractAccessInfo(r), false) {
+		return
 	}
 
 	repoInfo, err := h.getStudentRepository(studentID)
@@ -2092,8 +1948,7 @@ func (h *RepositoryHandler) GetRepositoryPathAPI(w http.ResponseWriter, r *http.
 		dirPath = chi.URLParam(r, "*")
 	}
 
-	if !h.canViewRepository(user, studentID) {
-		w.WriteHeader(http.StatusForbidden)
+	if !h.authorizeRepository(w, r, user, studentID, h.extractAccessInfo(r), false) {
 		return
 	}
 
@@ -2143,7 +1998,7 @@ func (h *RepositoryHandler) getRepositoryContentsForPath(repoInfo *database.Docu
 		return nil, fmt.Errorf("failed to get repository files: %w", err)
 	}
 
-	log.Printf("DEBUG: Successfully fetched %d files from GitHub", len(files))
+	log.Printf("DEBUG: Successfully fetched %d files from %s", len(files), h.provider.Name())
 
 	// Get recent commits (same as before)
 	commits, err := h.getRepositoryCommits(repoName)
@@ -2163,57 +2018,28 @@ func (h *RepositoryHandler) getRepositoryContentsForPath(repoInfo *database.Docu
 }
 
 func (h *RepositoryHandler) getRepositoryFilesForPath(repoName, dirPath string) ([]types.RepositoryFile, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s",
-		h.githubConfig.Organization, repoName, dirPath)
-
-	req, _ := http.NewRequest("GET", url, nil)
-	req.Header.Set("Authorization", "Bearer "+h.githubConfig.PAT)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "Thesis-Management-System/1.0")
-
-	resp, err := h.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("GitHub API request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 404 {
+	entries, err := h.provider.ListContents(context.Background(), repoName, "", dirPath)
+	if err == githosting.ErrNotFound {
 		return nil, fmt.Errorf("directory not found or access denied")
 	}
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var githubFiles []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&githubFiles); err != nil {
-		return nil, fmt.Errorf("failed to decode GitHub response: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("%s API request failed: %w", h.provider.Name(), err)
 	}
 
 	var files []types.RepositoryFile
-	for _, file := range githubFiles {
-		fileType := "file"
-		if t, ok := file["type"].(string); ok {
-			fileType = t
-		}
-
-		size := int64(0)
-		if s, ok := file["size"].(float64); ok {
-			size = int64(s)
-		}
-
+	for _, entry := range entries {
 		files = append(files, types.RepositoryFile{
-			Name: h.getStringValue(file, "name"),
-			Path: h.getStringValue(file, "path"),
-			Type: fileType,
-			Size: size,
-			URL:  h.getStringValue(file, "html_url"),
+			Name: entry.Name,
+			Path: entry.Path,
+			Type: entry.Type,
+			Size: entry.Size,
+			URL:  entry.URL,
 		})
 	}
 
 	return files, nil
 }
+
 func (h *RepositoryHandler) renderRepositoryPageWithPath(w http.ResponseWriter, r *http.Request, user *auth.AuthenticatedUser, student *database.StudentRecord, repoInfo *database.Document, contents *types.RepositoryContents, dirPath string, accessInfo database.AccessInfo) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	html := h.generateRepositoryHTMLWithPath(user, student, repoInfo, contents, dirPath, accessInfo)
@@ -2309,7 +2135,7 @@ func (h *RepositoryHandler) generateRepositoryHTMLWithPath(user *auth.Authentica
 		.status-pending { background-color: #fef3c7; color: #92400e; }
 		.status-unknown { background-color: #f3f4f6; color: #374151; }
 		.repo-header { 
-			background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); 
+			background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); 
 			color: white; 
 			padding: 2rem; 
 			border-radius: 12px 12px 0 0; 
@@ -0,0 +1,489 @@
+// handlers/grading_rubric.go - Per study program grading rubrics for reviewer reports
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// rubricSections are the reviewer report sections a criterion can be mapped to
+var rubricSections = []string{
+	"review_goals", "review_theory", "review_practical", "review_theory_practical_link",
+	"review_results", "review_practical_significance", "review_language",
+}
+
+// GradingRubricHandler manages grading rubrics
+type GradingRubricHandler struct {
+	db *sqlx.DB
+}
+
+func NewGradingRubricHandler(db *sqlx.DB) *GradingRubricHandler {
+	return &GradingRubricHandler{db: db}
+}
+
+// getRubricForProgram returns the active rubric of a study program, falling back to the default rubric
+func getRubricForProgram(q sqlx.Queryer, studyProgram string) (*database.GradingRubric, error) {
+	var rubric database.GradingRubric
+	err := sqlx.Get(q, &rubric, `
+        SELECT * FROM grading_rubrics
+        WHERE is_active = TRUE AND (study_program = ? OR study_program IS NULL)
+        ORDER BY study_program IS NULL, id DESC
+        LIMIT 1`, studyProgram)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadRubricCriteria(q, &rubric); err != nil {
+		return nil, err
+	}
+	return &rubric, nil
+}
+
+func loadRubricCriteria(q sqlx.Queryer, rubric *database.GradingRubric) error {
+	return sqlx.Select(q, &rubric.Criteria, `
+        SELECT * FROM grading_rubric_criteria
+        WHERE rubric_id = ?
+        ORDER BY display_order, id`, rubric.ID)
+}
+
+// getReviewerReportScores returns the stored criterion scores of a reviewer report
+func getReviewerReportScores(q sqlx.Queryer, reportID int) (map[int]float64, error) {
+	var rows []database.ReviewerReportScore
+	if err := sqlx.Select(q, &rows, "SELECT * FROM reviewer_report_scores WHERE reviewer_report_id = ?", reportID); err != nil {
+		return nil, err
+	}
+	scores := make(map[int]float64, len(rows))
+	for _, row := range rows {
+		scores[row.CriterionID] = row.Score
+	}
+	return scores, nil
+}
+
+// loadReviewerRubric prepares the rubric and stored scores for the reviewer form
+func loadReviewerRubric(db *sqlx.DB, student *database.StudentRecord, reportID int, formData *database.ReviewerReportFormData) *database.GradingRubric {
+	rubric, err := getRubricForProgram(db, student.StudyProgram)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Error loading grading rubric for %q: %v", student.StudyProgram, err)
+		}
+		return nil
+	}
+	if reportID != 0 {
+		scores, err := getReviewerReportScores(db, reportID)
+		if err != nil {
+			log.Printf("Error loading rubric scores for reviewer report %d: %v", reportID, err)
+		}
+		formData.RubricScores = scores
+	}
+	return rubric
+}
+
+// parseRubricScores reads rubric_score_<criterion id> form fields.
+// Final submissions must score every criterion; drafts may leave some empty.
+func parseRubricScores(r *http.Request, rubric *database.GradingRubric, requireAll bool) (map[int]float64, error) {
+	scores := make(map[int]float64)
+	if rubric == nil {
+		return scores, nil
+	}
+
+	for _, c := range rubric.Criteria {
+		value := strings.TrimSpace(r.FormValue(fmt.Sprintf("rubric_score_%d", c.ID)))
+		if value == "" {
+			if requireAll {
+				return nil, fmt.Errorf("criterion %q is not scored", c.Title)
+			}
+			continue
+		}
+		score, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+		if err != nil || score < c.MinScore || score > c.MaxScore {
+			return nil, fmt.Errorf("score of criterion %q must be between %.1f and %.1f", c.Title, c.MinScore, c.MaxScore)
+		}
+		scores[c.ID] = score
+	}
+	return scores, nil
+}
+
+// saveRubricScores stores the criterion scores next to the reviewer report saved in the transaction.
+// The scores are part of the signed content, so a signed report keeps them until it is unlocked.
+func saveRubricScores(tx *sqlx.Tx, studentID int, scores map[int]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	state, err := getReportState(tx, reportKindReviewer, studentID, false)
+	if err != nil {
+		return err
+	}
+	if state.IsSigned {
+		return errReportLocked
+	}
+	for criterionID, score := range scores {
+		_, err := tx.Exec(`
+            INSERT INTO reviewer_report_scores (reviewer_report_id, criterion_id, score)
+            VALUES (?, ?, ?)
+            ON DUPLICATE KEY UPDATE score = VALUES(score)`,
+			state.ID, criterionID, score)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ShowRubrics renders the rubric management page
+func (h *GradingRubricHandler) ShowRubrics(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	var rubrics []database.GradingRubric
+	err := h.db.Select(&rubrics, `
+        SELECT * FROM grading_rubrics
+        WHERE is_active = TRUE
+        ORDER BY study_program IS NOT NULL, study_program`)
+	if err != nil {
+		log.Printf("Error loading grading rubrics: %v", err)
+		http.Error(w, "Failed to load rubrics", http.StatusInternalServerError)
+		return
+	}
+	for i := range rubrics {
+		if err := loadRubricCriteria(h.db, &rubrics[i]); err != nil {
+			log.Printf("Error loading criteria of rubric %d: %v", rubrics[i].ID, err)
+		}
+	}
+
+	programs, err := h.managedStudyPrograms(user)
+	if err != nil {
+		log.Printf("Error loading study programs: %v", err)
+	}
+
+	locale := getLocale(r)
+	templates.GradingRubricManagement(user, locale, rubrics, programs, rubricSections).Render(r.Context(), w)
+}
+
+// CreateRubric creates a rubric for a study program, starting from the default criteria
+func (h *GradingRubricHandler) CreateRubric(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	program := strings.TrimSpace(r.FormValue("study_program"))
+	if program == "" || !h.canManageProgram(user, program) {
+		http.Error(w, "You cannot manage rubrics of this study program", http.StatusForbidden)
+		return
+	}
+
+	base, err := getRubricForProgram(h.db, program)
+	if err != nil {
+		http.Error(w, "Default rubric not found", http.StatusInternalServerError)
+		return
+	}
+	if base.StudyProgram != nil {
+		http.Error(w, "This study program already has a rubric", http.StatusConflict)
+		return
+	}
+
+	tx, err := h.db.Beginx()
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		name = program
+	}
+	result, err := tx.Exec(`
+        INSERT INTO grading_rubrics (study_program, name, deviation_threshold, created_by)
+        VALUES (?, ?, ?, ?)`, program, name, base.DeviationThreshold, user.Email)
+	if err != nil {
+		log.Printf("Error creating rubric for %q: %v", program, err)
+		http.Error(w, "Failed to create rubric", http.StatusInternalServerError)
+		return
+	}
+	rubricID, _ := result.LastInsertId()
+
+	for _, c := range base.Criteria {
+		_, err := tx.Exec(`
+            INSERT INTO grading_rubric_criteria (rubric_id, section, title, title_en, weight, min_score, max_score, display_order)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			rubricID, c.Section, c.Title, c.TitleEn, c.Weight, c.MinScore, c.MaxScore, c.DisplayOrder)
+		if err != nil {
+			log.Printf("Error copying rubric criteria: %v", err)
+			http.Error(w, "Failed to create rubric", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to create rubric", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderRubric(w, r, int(rubricID))
+}
+
+// UpdateRubric saves edited criteria, weights and score ranges of a rubric
+func (h *GradingRubricHandler) UpdateRubric(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	rubric, ok := h.getManagedRubric(w, r, user)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	threshold, err := strconv.ParseFloat(r.FormValue("deviation_threshold"), 64)
+	if err != nil || threshold <= 0 || threshold > 9 {
+		renderTemplateError(w, fmt.Sprintf("#rubric-error-%d", rubric.ID), "Deviation threshold must be between 0 and 9")
+		return
+	}
+
+	criteria, err := parseRubricCriteria(r)
+	if err != nil {
+		renderTemplateError(w, fmt.Sprintf("#rubric-error-%d", rubric.ID), err.Error())
+		return
+	}
+
+	tx, err := h.db.Beginx()
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE grading_rubrics SET name = ?, deviation_threshold = ? WHERE id = ?",
+		strings.TrimSpace(r.FormValue("name")), threshold, rubric.ID)
+	if err != nil {
+		http.Error(w, "Failed to save rubric", http.StatusInternalServerError)
+		return
+	}
+
+	kept := make(map[int]bool)
+	for i, c := range criteria {
+		if c.ID != 0 {
+			kept[c.ID] = true
+			_, err = tx.Exec(`
+                UPDATE grading_rubric_criteria
+                SET section = ?, title = ?, title_en = ?, weight = ?, min_score = ?, max_score = ?, display_order = ?
+                WHERE id = ? AND rubric_id = ?`,
+				c.Section, c.Title, c.TitleEn, c.Weight, c.MinScore, c.MaxScore, i+1, c.ID, rubric.ID)
+		} else {
+			_, err = tx.Exec(`
+                INSERT INTO grading_rubric_criteria (rubric_id, section, title, title_en, weight, min_score, max_score, display_order)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+				rubric.ID, c.Section, c.Title, c.TitleEn, c.Weight, c.MinScore, c.MaxScore, i+1)
+		}
+		if err != nil {
+			log.Printf("Error saving criterion of rubric %d: %v", rubric.ID, err)
+			http.Error(w, "Failed to save rubric", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	for _, existing := range rubric.Criteria {
+		if kept[existing.ID] {
+			continue
+		}
+		var used int
+		if err := tx.Get(&used, "SELECT COUNT(*) FROM reviewer_report_scores WHERE criterion_id = ?", existing.ID); err != nil {
+			http.Error(w, "Failed to save rubric", http.StatusInternalServerError)
+			return
+		}
+		if used > 0 {
+			renderTemplateError(w, fmt.Sprintf("#rubric-error-%d", rubric.ID), fmt.Sprintf("Criterion %q is already used in reviewer reports and cannot be removed", existing.Title))
+			return
+		}
+		if _, err := tx.Exec("DELETE FROM grading_rubric_criteria WHERE id = ?", existing.ID); err != nil {
+			http.Error(w, "Failed to save rubric", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to save rubric", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderRubric(w, r, rubric.ID)
+}
+
+// DeactivateRubric retires a study program rubric; its reports fall back to the default rubric
+func (h *GradingRubricHandler) DeactivateRubric(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	rubric, ok := h.getManagedRubric(w, r, user)
+	if !ok {
+		return
+	}
+	if rubric.StudyProgram == nil {
+		http.Error(w, "The default rubric cannot be removed", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE grading_rubrics SET is_active = FALSE WHERE id = ?", rubric.ID); err != nil {
+		http.Error(w, "Failed to remove rubric", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *GradingRubricHandler) renderRubric(w http.ResponseWriter, r *http.Request, rubricID int) {
+	var rubric database.GradingRubric
+	if err := h.db.Get(&rubric, "SELECT * FROM grading_rubrics WHERE id = ?", rubricID); err != nil {
+		http.Error(w, "Rubric not found", http.StatusNotFound)
+		return
+	}
+	if err := loadRubricCriteria(h.db, &rubric); err != nil {
+		http.Error(w, "Failed to load rubric", http.StatusInternalServerError)
+		return
+	}
+	templates.GradingRubricCard(rubric, rubricSections, getLocale(r)).Render(r.Context(), w)
+}
+
+// getManagedRubric loads the rubric from the URL and checks the user may edit it
+func (h *GradingRubricHandler) getManagedRubric(w http.ResponseWriter, r *http.Request, user *auth.AuthenticatedUser) (*database.GradingRubric, bool) {
+	rubricID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid rubric ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	var rubric database.GradingRubric
+	if err := h.db.Get(&rubric, "SELECT * FROM grading_rubrics WHERE id = ? AND is_active = TRUE", rubricID); err != nil {
+		http.Error(w, "Rubric not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	program := ""
+	if rubric.StudyProgram != nil {
+		program = *rubric.StudyProgram
+	}
+	if (program == "" && user.Role != auth.RoleAdmin) || (program != "" && !h.canManageProgram(user, program)) {
+		http.Error(w, "You cannot manage this rubric", http.StatusForbidden)
+		return nil, false
+	}
+
+	if err := loadRubricCriteria(h.db, &rubric); err != nil {
+		http.Error(w, "Failed to load rubric", http.StatusInternalServerError)
+		return nil, false
+	}
+	return &rubric, true
+}
+
+// managedStudyPrograms lists the study programs the user may configure rubrics for
+func (h *GradingRubricHandler) managedStudyPrograms(user *auth.AuthenticatedUser) ([]string, error) {
+	var programs []string
+	query := `
+        SELECT DISTINCT study_program FROM student_records
+        WHERE study_program IS NOT NULL AND study_program != ''`
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		return nil, err
+	}
+	var args []interface{}
+	if department != "" {
+		query += " AND department = ?"
+		args = append(args, department)
+	}
+	err = h.db.Select(&programs, query+" ORDER BY study_program", args...)
+	return programs, err
+}
+
+func (h *GradingRubricHandler) canManageProgram(user *auth.AuthenticatedUser, program string) bool {
+	programs, err := h.managedStudyPrograms(user)
+	if err != nil {
+		return false
+	}
+	for _, p := range programs {
+		if p == program {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRubricCriteria reads the criteria rows of the rubric editor form
+func parseRubricCriteria(r *http.Request) ([]database.GradingRubricCriterion, error) {
+	ids := r.Form["criterion_id"]
+	sections := r.Form["criterion_section"]
+	titles := r.Form["criterion_title"]
+	titlesEn := r.Form["criterion_title_en"]
+	weights := r.Form["criterion_weight"]
+	minScores := r.Form["criterion_min"]
+	maxScores := r.Form["criterion_max"]
+
+	if len(sections) != len(ids) || len(titles) != len(ids) || len(titlesEn) != len(ids) ||
+		len(weights) != len(ids) || len(minScores) != len(ids) || len(maxScores) != len(ids) {
+		return nil, fmt.Errorf("incomplete criteria data")
+	}
+
+	var criteria []database.GradingRubricCriterion
+	for i := range ids {
+		title := strings.TrimSpace(titles[i])
+		if title == "" {
+			// Empty rows in the editor are ignored
+			continue
+		}
+		id, _ := strconv.Atoi(ids[i])
+		weight, err := strconv.ParseFloat(weights[i], 64)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("weight of %q must be positive", title)
+		}
+		minScore, err1 := strconv.ParseFloat(minScores[i], 64)
+		maxScore, err2 := strconv.ParseFloat(maxScores[i], 64)
+		if err1 != nil || err2 != nil || minScore < 0 || maxScore <= minScore {
+			return nil, fmt.Errorf("score range of %q is invalid", title)
+		}
+		if !isRubricSection(sections[i]) {
+			return nil, fmt.Errorf("unknown report section %q", sections[i])
+		}
+		criteria = append(criteria, database.GradingRubricCriterion{
+			ID:       id,
+			Section:  sections[i],
+			Title:    title,
+			TitleEn:  strings.TrimSpace(titlesEn[i]),
+			Weight:   weight,
+			MinScore: minScore,
+			MaxScore: maxScore,
+		})
+	}
+	if len(criteria) == 0 {
+		return nil, fmt.Errorf("a rubric needs at least one criterion")
+	}
+	return criteria, nil
+}
+
+func isRubricSection(section string) bool {
+	for _, s := range rubricSections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
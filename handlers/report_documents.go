@@ -0,0 +1,406 @@
+// handlers/report_documents.go - Official PDF documents of signed supervisor and reviewer reports
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/docgen"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	reportKindSupervisor = "supervisor"
+	reportKindReviewer   = "reviewer"
+
+	DocumentTypeSupervisorReportPDF = "supervisor_report_pdf"
+	DocumentTypeReviewerReportPDF   = "reviewer_report_pdf"
+
+	reportDocumentsDir = "uploads/reports"
+)
+
+// ReportDocumentHandler serves the PDF documents of signed reports
+type ReportDocumentHandler struct {
+	db *sqlx.DB
+}
+
+// NewReportDocumentHandler creates a new handler instance
+func NewReportDocumentHandler(db *sqlx.DB) *ReportDocumentHandler {
+	return &ReportDocumentHandler{db: db}
+}
+
+// DownloadReportPDF returns the stored PDF of a signed report, generating it on first request
+func (h *ReportDocumentHandler) DownloadReportPDF(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	kind := chi.URLParam(r, "kind")
+	if kind != reportKindSupervisor && kind != reportKindReviewer {
+		http.Error(w, "Unknown report type", http.StatusBadRequest)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	var student database.StudentRecord
+	if err := h.db.Get(&student, "SELECT * FROM student_records WHERE id = ?", studentID); err != nil {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+
+	if !h.canAccessReportDocument(user, &student) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	// Students get the reviewer report only once it is published by the department policy
+	if user.Role == auth.RoleStudent && kind == reportKindReviewer {
+		var report database.ReviewerReport
+		err := h.db.Get(&report, "SELECT * FROM reviewer_reports WHERE student_record_id = ?", studentID)
+		if err != nil || !isReviewerReportPublished(h.db, &student, &report) {
+			http.Error(w, "Signed report not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	h.serveReportPDF(w, r, kind, studentID)
+}
+
+// DownloadReviewerReportPDFWithToken lets a reviewer with an access token download their own signed report
+func (h *ReportDocumentHandler) DownloadReviewerReportPDFWithToken(w http.ResponseWriter, r *http.Request) {
+	accessToken := chi.URLParam(r, "accessToken")
+
+	var token database.ReviewerAccessToken
+	err := h.db.Get(&token, "SELECT * FROM reviewer_access_tokens WHERE access_token = ? AND is_active = true", accessToken)
+	if err != nil || !token.CanAccess() {
+		http.Error(w, "Invalid access token", http.StatusUnauthorized)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	var count int
+	err = h.db.Get(&count, "SELECT COUNT(*) FROM student_records WHERE id = ? AND reviewer_email = ?",
+		studentID, token.ReviewerEmail)
+	if err != nil || count == 0 {
+		http.Error(w, "Student not found or access denied", http.StatusNotFound)
+		return
+	}
+
+	h.serveReportPDF(w, r, reportKindReviewer, studentID)
+}
+
+func (h *ReportDocumentHandler) serveReportPDF(w http.ResponseWriter, r *http.Request, kind string, studentID int) {
+	doc, err := getLatestReportDocument(h.db, kind, studentID)
+	if err == nil {
+		if _, statErr := os.Stat(doc.FilePath); statErr != nil {
+			log.Printf("Report document %d is missing on disk, regenerating: %v", doc.ID, statErr)
+			err = statErr
+		}
+	}
+	if err != nil {
+		doc, err = storeReportPDF(h.db, kind, studentID, getReportLanguage(r))
+		if err != nil {
+			log.Printf("Error generating %s report PDF for student %d: %v", kind, studentID, err)
+			http.Error(w, "Signed report not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	filename := database.StringValue(doc.OriginalFilename)
+	if filename == "" {
+		filename = filepath.Base(doc.FilePath)
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	http.ServeFile(w, r, doc.FilePath)
+}
+
+// canAccessReportDocument allows the student, the commission, admins and staff assigned to the student
+func (h *ReportDocumentHandler) canAccessReportDocument(user *auth.AuthenticatedUser, student *database.StudentRecord) bool {
+	switch user.Role {
+	case auth.RoleAdmin:
+		return true
+	case auth.RoleCommissionMember:
+		member, err := commissionMemberOf(h.db, user)
+		return err == nil && CommissionCoversStudent(h.db, member, student.ID)
+	case auth.RoleStudent:
+		return strings.EqualFold(student.StudentEmail, user.Email)
+	case auth.RoleSupervisor:
+		return strings.EqualFold(student.SupervisorEmail, user.Email)
+	case auth.RoleReviewer:
+		return student.ReviewerEmail.Valid && strings.EqualFold(student.ReviewerEmail.String, user.Email)
+	case auth.RoleDepartmentHead:
+		department, err := departmentHeadScope(h.db, user)
+		return err == nil && department == student.Department
+	}
+	return false
+}
+
+// getReportLanguage picks the primary language of a generated report: the lang parameter,
+// then the Accept-Language header, defaulting to Lithuanian
+func getReportLanguage(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang == "en" || lang == "lt" {
+		return lang
+	}
+	if accept := r.Header.Get("Accept-Language"); len(accept) >= 2 && accept[:2] == "en" {
+		return "en"
+	}
+	return "lt"
+}
+
+func reportDocumentType(kind string) string {
+	if kind == reportKindReviewer {
+		return DocumentTypeReviewerReportPDF
+	}
+	return DocumentTypeSupervisorReportPDF
+}
+
+func getLatestReportDocument(db *sqlx.DB, kind string, studentID int) (*database.Document, error) {
+	var doc database.Document
+	err := db.Get(&doc, `
+        SELECT * FROM documents
+        WHERE student_record_id = ? AND document_type = ?
+        ORDER BY uploaded_date DESC, id DESC
+        LIMIT 1`,
+		studentID, reportDocumentType(kind))
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// storeReportPDF renders a signed report, writes it to disk and registers it as a student document
+func storeReportPDF(db *sqlx.DB, kind string, studentID int, language string) (*database.Document, error) {
+	var student database.StudentRecord
+	if err := db.Get(&student, "SELECT * FROM student_records WHERE id = ?", studentID); err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+
+	var doc *docgen.Document
+	switch kind {
+	case reportKindSupervisor:
+		var report database.SupervisorReport
+		err := db.Get(&report, "SELECT"+supervisorReportColumns+" FROM supervisor_reports WHERE student_record_id = ?", studentID)
+		if err != nil {
+			return nil, fmt.Errorf("supervisor report not found: %w", err)
+		}
+		if !report.IsSigned {
+			return nil, fmt.Errorf("supervisor report is not signed")
+		}
+		doc = buildSupervisorReportDocument(&student, &report, language)
+	case reportKindReviewer:
+		var report database.ReviewerReport
+		if err := db.Get(&report, "SELECT * FROM reviewer_reports WHERE student_record_id = ?", studentID); err != nil {
+			return nil, fmt.Errorf("reviewer report not found: %w", err)
+		}
+		if !report.IsSigned {
+			return nil, fmt.Errorf("reviewer report is not signed")
+		}
+		doc = buildReviewerReportDocument(&student, &report, language)
+	default:
+		return nil, fmt.Errorf("unknown report type %q", kind)
+	}
+
+	data, err := doc.Bytes(docgen.FormatPDF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render PDF: %w", err)
+	}
+
+	if err := os.MkdirAll(reportDocumentsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+	filePath := filepath.Join(reportDocumentsDir,
+		fmt.Sprintf("%s_%d_%d.pdf", reportDocumentType(kind), studentID, time.Now().UnixNano()))
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to save PDF: %w", err)
+	}
+
+	originalFilename := reportFilename(kind, &student, language)
+	result, err := db.Exec(`
+        INSERT INTO documents (student_record_id, document_type, file_path, original_filename, file_size, mime_type)
+        VALUES (?, ?, ?, ?, ?, ?)`,
+		studentID, reportDocumentType(kind), filePath, originalFilename, len(data), "application/pdf")
+	if err != nil {
+		os.Remove(filePath)
+		return nil, fmt.Errorf("failed to register document: %w", err)
+	}
+
+	id, _ := result.LastInsertId()
+	size := int64(len(data))
+	mimeType := "application/pdf"
+	return &database.Document{
+		ID:               int(id),
+		DocumentType:     reportDocumentType(kind),
+		FilePath:         filePath,
+		UploadedDate:     time.Now(),
+		StudentRecordID:  studentID,
+		FileSize:         &size,
+		MimeType:         &mimeType,
+		OriginalFilename: &originalFilename,
+	}, nil
+}
+
+// storeSignedReportPDF is called after a report is signed; failures are logged and do not undo the signature
+func storeSignedReportPDF(db *sqlx.DB, kind string, studentID int, language string) {
+	doc, err := storeReportPDF(db, kind, studentID, language)
+	if err != nil {
+		log.Printf("Error storing %s report PDF for student %d: %v", kind, studentID, err)
+		return
+	}
+	log.Printf("Stored %s report PDF for student %d as document %d", kind, studentID, doc.ID)
+}
+
+// reportLabel joins both languages with the primary one first
+func reportLabel(language, lt, en string) string {
+	if language == "en" {
+		return en + " / " + lt
+	}
+	return lt + " / " + en
+}
+
+func buildSupervisorReportDocument(student *database.StudentRecord, report *database.SupervisorReport, language string) *docgen.Document {
+	var doc *docgen.Document
+	if language == "en" {
+		doc = docgen.New("SUPERVISOR'S REPORT ON THE FINAL THESIS", "Baigiamojo darbo vadovo atsiliepimas")
+	} else {
+		doc = docgen.New("BAIGIAMOJO DARBO VADOVO ATSILIEPIMAS", "Supervisor's Report on the Final Thesis")
+	}
+
+	addReportStudentFields(doc, student, language)
+
+	doc.Heading(reportLabel(language, "Atsiliepimas", "Evaluation"))
+	doc.Paragraph(report.SupervisorComments)
+
+	doc.Heading(reportLabel(language, "Gynimo tinkamumas", "Defense eligibility"))
+	if report.IsPassOrFailed {
+		doc.Paragraph(reportLabel(language,
+			"Baigiamasis darbas tinkamas ginti Baigiamųjų darbų gynimo komisijos posėdyje.",
+			"Thesis is suitable for defense at the Final Thesis Defense Commission meeting."))
+	} else {
+		doc.Paragraph(reportLabel(language,
+			"Baigiamasis darbas netinkamas ginti Baigiamųjų darbų gynimo komisijos posėdyje dėl plagiato fakto nustatymo.",
+			"Thesis is not suitable for defense at the Final Thesis Defense Commission meeting due to plagiarism detection."))
+	}
+
+	doc.Heading(reportLabel(language, "Plagiato analizė", "Plagiarism analysis"))
+	doc.Field(reportLabel(language, "Bendra sutaptis", "Total similarity"), formatPercent(report.OtherMatch))
+	doc.Field(reportLabel(language, "Sutaptis su vienu šaltiniu", "Similarity with one source"), formatPercent(report.OneMatch))
+	doc.Field(reportLabel(language, "Savi ankstesni darbai", "Own previous works"), formatPercent(report.OwnMatch))
+	doc.Field(reportLabel(language, "Bendri autoriai", "Joint work authors"), formatPercent(report.JoinMatch))
+	if report.Grade != nil {
+		doc.Field(reportLabel(language, "Įvertinimas", "Grade"), strconv.Itoa(*report.Grade))
+	}
+	if report.HasFinalComments() {
+		doc.Field(reportLabel(language, "Baigiamosios pastabos", "Final comments"), report.GetFinalComments())
+	}
+
+	doc.Heading(reportLabel(language, "Vadovas", "Supervisor"))
+	doc.Field(reportLabel(language, "Vardas, pavardė", "Name, surname"), report.GetSupervisorName())
+	doc.Field(reportLabel(language, "Darbovietė", "Workplace"), report.SupervisorWorkplace)
+	doc.Field(reportLabel(language, "Pareigos", "Position"), report.GetSupervisorPosition())
+	doc.Field(reportLabel(language, "Pasirašyta", "Signed"), report.UpdatedDate.Format("2006-01-02 15:04"))
+
+	doc.Spacer()
+	doc.Signature(reportLabel(language, "Vadovas", "Supervisor"), report.GetSupervisorName())
+
+	doc.Footer = reportLabel(language, "Sugeneruota", "Generated") + " " + time.Now().Format("2006-01-02 15:04")
+	return doc
+}
+
+func buildReviewerReportDocument(student *database.StudentRecord, report *database.ReviewerReport, language string) *docgen.Document {
+	var doc *docgen.Document
+	if language == "en" {
+		doc = docgen.New("FINAL THESIS REVIEW", "Baigiamojo darbo recenzija")
+	} else {
+		doc = docgen.New("BAIGIAMOJO DARBO RECENZIJA", "Final Thesis Review")
+	}
+
+	addReportStudentFields(doc, student, language)
+
+	doc.Heading(reportLabel(language, "Recenzentas", "Reviewer"))
+	doc.Field(reportLabel(language, "Recenzento duomenys", "Reviewer details"), report.ReviewerPersonalDetails)
+
+	sections := []struct{ lt, en, text string }{
+		{"Tikslai ir uždaviniai", "Goals and tasks", report.ReviewGoals},
+		{"Teorija", "Theory", report.ReviewTheory},
+		{"Praktika", "Practice", report.ReviewPractical},
+		{"Teorijos ir praktikos ryšys", "Theory-practice link", report.ReviewTheoryPracticalLink},
+		{"Rezultatai", "Results", report.ReviewResults},
+		{"Praktinė reikšmė", "Practical significance", database.StringValue(report.ReviewPracticalSignificance)},
+		{"Kalba", "Language", report.ReviewLanguage},
+		{"Privalumai", "Pros", report.ReviewPros},
+		{"Trūkumai", "Cons", report.ReviewCons},
+		{"Klausimai", "Questions", report.ReviewQuestions},
+	}
+	for _, section := range sections {
+		doc.Heading(reportLabel(language, section.lt, section.en))
+		if strings.TrimSpace(section.text) == "" {
+			doc.Paragraph("-")
+		} else {
+			doc.Paragraph(section.text)
+		}
+	}
+
+	doc.Heading(reportLabel(language, "Įvertinimas", "Assessment"))
+	doc.Field(reportLabel(language, "Pažymys", "Grade"), strconv.FormatFloat(report.Grade, 'f', -1, 64))
+	doc.Field(reportLabel(language, "Pasirašyta", "Signed"), report.UpdatedDate.Format("2006-01-02 15:04"))
+
+	doc.Spacer()
+	doc.Signature(reportLabel(language, "Recenzentas", "Reviewer"), student.ReviewerName.String)
+
+	doc.Footer = reportLabel(language, "Sugeneruota", "Generated") + " " + time.Now().Format("2006-01-02 15:04")
+	return doc
+}
+
+func addReportStudentFields(doc *docgen.Document, student *database.StudentRecord, language string) {
+	doc.Heading(reportLabel(language, "Baigiamasis darbas", "Final thesis"))
+	doc.Field(reportLabel(language, "Studentas", "Student"), student.GetFullName())
+	doc.Field(reportLabel(language, "Grupė", "Group"), student.StudentGroup)
+	doc.Field(reportLabel(language, "Studijų programa", "Study programme"), strings.TrimSpace(student.StudyProgram+" "+student.ProgramCode))
+	doc.Field(reportLabel(language, "Katedra", "Department"), student.Department)
+	if language == "en" {
+		doc.Field(reportLabel(language, "Tema", "Title"), student.GetLocalizedTitle("en"))
+		doc.Field(reportLabel(language, "Tema lietuvių kalba", "Title in Lithuanian"), student.GetLocalizedTitle("lt"))
+	} else {
+		doc.Field(reportLabel(language, "Tema", "Title"), student.GetLocalizedTitle("lt"))
+		doc.Field(reportLabel(language, "Tema anglų kalba", "Title in English"), student.GetLocalizedTitle("en"))
+	}
+}
+
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(value, 'f', 1, 64) + " %"
+}
+
+func reportFilename(kind string, student *database.StudentRecord, language string) string {
+	prefix := "vadovo_atsiliepimas"
+	if kind == reportKindReviewer {
+		prefix = "recenzija"
+	}
+	if language == "en" {
+		prefix = kind + "_report"
+	}
+	name := fmt.Sprintf("%s_%s_%s_%s", prefix, student.StudentGroup, student.StudentLastname, student.StudentName)
+	return docgen.SafeFilename(name) + docgen.FormatPDF.Extension()
+}
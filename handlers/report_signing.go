@@ -0,0 +1,394 @@
+// handlers/report_signing.go - Electronic signing, locking and verification of reports
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+var errReportLocked = errors.New("report is signed and locked")
+
+// reportSigner identifies who signs a report and from where
+type reportSigner struct {
+	Email     string
+	Name      string
+	Role      string
+	IPAddress string
+	UserAgent string
+}
+
+func newReportSigner(r *http.Request, email, name, role string) reportSigner {
+	return reportSigner{
+		Email:     email,
+		Name:      name,
+		Role:      role,
+		IPAddress: requestIP(r),
+		UserAgent: r.UserAgent(),
+	}
+}
+
+// requestIP returns the client address; RealIP middleware has already applied forwarding headers
+func requestIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// reportState is the signing-related state of a report together with its canonical content
+type reportState struct {
+	ID          int
+	Revision    int
+	IsSigned    bool
+	ContentHash *string
+	Canonical   string
+	Snapshot    interface{}
+}
+
+const supervisorReportColumns = `
+        id, student_record_id, supervisor_comments, supervisor_name,
+        supervisor_position, supervisor_workplace, is_pass_or_failed,
+        is_signed, other_match, one_match, own_match, join_match,
+        created_date, updated_date, grade, final_comments,
+        revision, content_hash, signed_at, similarity_report_id, similarity_mismatch`
+
+func reportTable(kind string) string {
+	if kind == reportKindReviewer {
+		return "reviewer_reports"
+	}
+	return "supervisor_reports"
+}
+
+// getReportState loads a report; with lock set the row is locked until the transaction ends
+func getReportState(q sqlx.Queryer, kind string, studentID int, lock bool) (*reportState, error) {
+	suffix := ""
+	if lock {
+		suffix = " FOR UPDATE"
+	}
+
+	switch kind {
+	case reportKindSupervisor:
+		var report database.SupervisorReport
+		err := sqlx.Get(q, &report, "SELECT"+supervisorReportColumns+" FROM supervisor_reports WHERE student_record_id = ?"+suffix, studentID)
+		if err != nil {
+			return nil, err
+		}
+		return &reportState{
+			ID:          report.ID,
+			Revision:    report.Revision,
+			IsSigned:    report.IsSigned,
+			ContentHash: report.ContentHash,
+			Canonical:   report.CanonicalContent(),
+			Snapshot:    &report,
+		}, nil
+	case reportKindReviewer:
+		var report database.ReviewerReport
+		err := sqlx.Get(q, &report, "SELECT * FROM reviewer_reports WHERE student_record_id = ?"+suffix, studentID)
+		if err != nil {
+			return nil, err
+		}
+		if report.RubricScores, err = getReviewerReportScores(q, report.ID); err != nil {
+			return nil, err
+		}
+		return &reportState{
+			ID:          report.ID,
+			Revision:    report.Revision,
+			IsSigned:    report.IsSigned,
+			ContentHash: report.ContentHash,
+			Canonical:   report.CanonicalContent(),
+			Snapshot:    &report,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown report type %q", kind)
+}
+
+// signReport hashes the saved report content, appends a signature record and locks the report.
+// It must run in the same transaction that saved the content.
+func signReport(tx *sqlx.Tx, kind string, studentID int, signer reportSigner) (*database.ReportSignature, error) {
+	state, err := getReportState(tx, kind, studentID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load report: %w", err)
+	}
+	if state.IsSigned {
+		return nil, errReportLocked
+	}
+
+	signature := &database.ReportSignature{
+		ReportType:      kind,
+		ReportID:        state.ID,
+		StudentRecordID: studentID,
+		Revision:        state.Revision,
+		ContentHash:     database.ReportContentHash(state.Canonical),
+		HashAlgorithm:   "sha256",
+		SignerEmail:     signer.Email,
+		SignerName:      signer.Name,
+		SignerRole:      signer.Role,
+		IPAddress:       database.NullableString(signer.IPAddress),
+		UserAgent:       database.NullableString(signer.UserAgent),
+		SignedAt:        time.Now(),
+	}
+
+	result, err := tx.NamedExec(`
+        INSERT INTO report_signatures (
+            report_type, report_id, student_record_id, revision, content_hash, hash_algorithm,
+            signer_email, signer_name, signer_role, ip_address, user_agent, signed_at
+        ) VALUES (
+            :report_type, :report_id, :student_record_id, :revision, :content_hash, :hash_algorithm,
+            :signer_email, :signer_name, :signer_role, :ip_address, :user_agent, :signed_at
+        )`, signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record signature: %w", err)
+	}
+	if id, err := result.LastInsertId(); err == nil {
+		signature.ID = int(id)
+	}
+
+	res, err := tx.Exec(`UPDATE `+reportTable(kind)+`
+        SET is_signed = TRUE, content_hash = ?, signed_at = ?
+        WHERE id = ? AND is_signed = FALSE`,
+		signature.ContentHash, signature.SignedAt, state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock report: %w", err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return nil, errReportLocked
+	}
+
+	return signature, nil
+}
+
+// verifyReport recomputes the report hash and compares it with the signature of the current revision
+func verifyReport(db *sqlx.DB, kind string, studentID int) (*database.ReportVerification, error) {
+	state, err := getReportState(db, kind, studentID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &database.ReportVerification{
+		ReportType:   kind,
+		ReportID:     state.ID,
+		Revision:     state.Revision,
+		IsSigned:     state.IsSigned,
+		ComputedHash: database.ReportContentHash(state.Canonical),
+	}
+	if state.ContentHash != nil {
+		result.StoredHash = *state.ContentHash
+	}
+
+	var signature database.ReportSignature
+	err = db.Get(&signature, `
+        SELECT * FROM report_signatures
+        WHERE report_type = ? AND report_id = ? AND revision = ?`,
+		kind, state.ID, state.Revision)
+	switch {
+	case err == nil:
+		result.Signature = &signature
+	case err != sql.ErrNoRows:
+		return nil, err
+	}
+
+	if !state.IsSigned {
+		if result.Signature != nil {
+			result.Issues = append(result.Issues, "report is unlocked but a signature exists for the current revision")
+		} else {
+			result.Issues = append(result.Issues, "report is not signed")
+		}
+		return result, nil
+	}
+
+	if result.Signature == nil {
+		result.Issues = append(result.Issues, "no signature recorded for the current revision")
+	} else if result.Signature.ContentHash != result.ComputedHash {
+		result.Issues = append(result.Issues, "report content does not match the signed hash")
+	}
+	if result.StoredHash != result.ComputedHash {
+		result.Issues = append(result.Issues, "stored content hash does not match report content")
+	}
+
+	result.Valid = len(result.Issues) == 0
+	return result, nil
+}
+
+// VerifyReportSignature reports whether a signed report still matches its signature
+func (h *ReportDocumentHandler) VerifyReportSignature(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	kind := chi.URLParam(r, "kind")
+	if kind != reportKindSupervisor && kind != reportKindReviewer {
+		http.Error(w, "Unknown report type", http.StatusBadRequest)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	var student database.StudentRecord
+	if err := h.db.Get(&student, "SELECT * FROM student_records WHERE id = ?", studentID); err != nil {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+	if !h.canAccessReportDocument(user, &student) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	verification, err := verifyReport(h.db, kind, studentID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "Report not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Error verifying %s report of student %d: %v", kind, studentID, err)
+		http.Error(w, "Failed to verify report", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(renderReportVerification(verification)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(verification)
+}
+
+// UnlockReport lets an administrator reopen a signed report; the next signature creates a new revision
+func (h *ReportDocumentHandler) UnlockReport(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || user.Role != auth.RoleAdmin {
+		http.Error(w, "Only administrators can unlock signed reports", http.StatusForbidden)
+		return
+	}
+
+	kind := chi.URLParam(r, "kind")
+	if kind != reportKindSupervisor && kind != reportKindReviewer {
+		http.Error(w, "Unknown report type", http.StatusBadRequest)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if reason == "" {
+		// hx-prompt sends the answer in a header
+		reason = strings.TrimSpace(r.Header.Get("HX-Prompt"))
+	}
+	if reason == "" {
+		http.Error(w, "Unlock reason is required", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.Beginx()
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	state, err := getReportState(tx, kind, studentID, true)
+	if err != nil {
+		http.Error(w, "Report not found", http.StatusNotFound)
+		return
+	}
+	if !state.IsSigned {
+		http.Error(w, "Report is not signed", http.StatusConflict)
+		return
+	}
+
+	_, err = tx.Exec(`
+        INSERT INTO report_unlocks (report_type, report_id, student_record_id, from_revision, reason, unlocked_by, ip_address)
+        VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		kind, state.ID, studentID, state.Revision, reason, user.Email, requestIP(r))
+	if err != nil {
+		log.Printf("Error recording unlock of %s report %d: %v", kind, state.ID, err)
+		http.Error(w, "Failed to unlock report", http.StatusInternalServerError)
+		return
+	}
+
+	_, err = tx.Exec(`UPDATE `+reportTable(kind)+`
+        SET is_signed = FALSE, content_hash = NULL, signed_at = NULL, revision = revision + 1
+        WHERE id = ?`, state.ID)
+	if err != nil {
+		log.Printf("Error unlocking %s report %d: %v", kind, state.ID, err)
+		http.Error(w, "Failed to unlock report", http.StatusInternalServerError)
+		return
+	}
+
+	if err := saveReportVersion(tx, kind, studentID, user.Email, user.Role, "Unlocked: "+reason); err != nil {
+		log.Printf("Error saving version of %s report %d: %v", kind, state.ID, err)
+		http.Error(w, "Failed to unlock report", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to unlock report", http.StatusInternalServerError)
+		return
+	}
+
+	details := fmt.Sprintf(`{"student_id":%d,"from_revision":%d,"reason":%q}`, studentID, state.Revision, reason)
+	_, err = h.db.Exec(`
+        INSERT INTO audit_logs (user_email, user_role, action, resource_type, resource_id, details, ip_address, user_agent, success, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.Email, user.Role, "unlock_"+kind+"_report", reportTable(kind), strconv.Itoa(studentID),
+		details, requestIP(r), r.UserAgent(), true, time.Now())
+	if err != nil {
+		log.Printf("Error writing audit log for report unlock: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("HX-Trigger", kind+"ReportUnlocked")
+	fmt.Fprintf(w, `<div class="text-sm text-green-700">Report unlocked. Revision %d is open for editing.</div>`, state.Revision+1)
+}
+
+func renderReportVerification(v *database.ReportVerification) string {
+	var b strings.Builder
+	if v.Valid {
+		b.WriteString(`<div class="bg-green-50 border border-green-200 text-green-800 px-3 py-2 rounded text-sm">`)
+		fmt.Fprintf(&b, `<div class="font-medium">✓ Signature valid (revision %d)</div>`, v.Revision)
+	} else {
+		b.WriteString(`<div class="bg-red-50 border border-red-200 text-red-800 px-3 py-2 rounded text-sm">`)
+		fmt.Fprintf(&b, `<div class="font-medium">✗ Signature check failed (revision %d)</div>`, v.Revision)
+		b.WriteString(`<ul class="list-disc ml-5">`)
+		for _, issue := range v.Issues {
+			fmt.Fprintf(&b, "<li>%s</li>", html.EscapeString(issue))
+		}
+		b.WriteString(`</ul>`)
+	}
+	if v.Signature != nil {
+		fmt.Fprintf(&b, `<div class="text-xs mt-1">%s (%s), %s, IP %s</div>`,
+			html.EscapeString(v.Signature.SignerName),
+			html.EscapeString(v.Signature.SignerEmail),
+			v.Signature.SignedAt.Format("2006-01-02 15:04:05"),
+			html.EscapeString(database.StringValue(v.Signature.IPAddress)))
+	}
+	fmt.Fprintf(&b, `<div class="text-xs font-mono break-all mt-1">SHA-256 %s</div>`, v.ComputedHash)
+	b.WriteString(`</div>`)
+	return b.String()
+}
@@ -0,0 +1,230 @@
+// handlers/report_versions.go - Version history and diffs of supervisor and reviewer reports
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/textdiff"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+var supervisorReportDiffFields = []string{
+	"supervisor_comments", "is_pass_or_failed", "other_match", "one_match", "own_match", "join_match",
+	"grade", "final_comments", "supervisor_workplace", "supervisor_position",
+}
+
+var reviewerReportDiffFields = []string{
+	"reviewer_personal_details", "review_goals", "review_theory", "review_practical",
+	"review_theory_practical_link", "review_results", "review_practical_significance",
+	"review_language", "review_pros", "review_cons", "review_questions", "grade",
+}
+
+// saveReportVersion snapshots the report as stored in the transaction.
+// Repeated saves without any change (e.g. auto-save) do not create a new version.
+func saveReportVersion(tx *sqlx.Tx, kind string, studentID int, changedBy, role, changeSummary string) error {
+	state, err := getReportState(tx, kind, studentID, false)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(state.Snapshot)
+	if err != nil {
+		return err
+	}
+	contentHash := database.ReportContentHash(state.Canonical)
+
+	var latest database.ReportVersion
+	err = tx.Get(&latest, `
+        SELECT * FROM report_versions
+        WHERE report_type = ? AND report_id = ?
+        ORDER BY version_number DESC
+        LIMIT 1`, kind, state.ID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == nil && latest.ContentHash == contentHash && latest.IsSigned == state.IsSigned {
+		return nil
+	}
+
+	_, err = tx.Exec(`
+        INSERT INTO report_versions
+        (report_type, report_id, student_record_id, version_number, revision, version_data,
+         content_hash, is_signed, created_by, created_by_role, change_summary)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		kind, state.ID, studentID, latest.VersionNumber+1, state.Revision, string(data),
+		contentHash, state.IsSigned, changedBy, role, database.NullableString(changeSummary))
+	return err
+}
+
+// ShowReportVersions lists saved versions of a report and compares two of them
+func (h *ReportDocumentHandler) ShowReportVersions(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleAdmin && user.Role != auth.RoleDepartmentHead) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	kind := chi.URLParam(r, "kind")
+	if kind != reportKindSupervisor && kind != reportKindReviewer {
+		http.Error(w, "Unknown report type", http.StatusBadRequest)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	var student database.StudentRecord
+	if err := h.db.Get(&student, "SELECT * FROM student_records WHERE id = ?", studentID); err != nil {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+	if !h.canAccessReportDocument(user, &student) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	current, err := getReportState(h.db, kind, studentID, false)
+	if err != nil {
+		http.Error(w, "Report not found", http.StatusNotFound)
+		return
+	}
+
+	var versions []database.ReportVersion
+	err = h.db.Select(&versions, `
+        SELECT * FROM report_versions
+        WHERE report_type = ? AND report_id = ?
+        ORDER BY version_number DESC`, kind, current.ID)
+	if err != nil {
+		log.Printf("Error loading %s report versions for student %d: %v", kind, studentID, err)
+		http.Error(w, "Failed to load versions", http.StatusInternalServerError)
+		return
+	}
+
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if to == "" {
+		to = "current"
+	}
+	if from == "" {
+		// Default to the change made by the latest save
+		from = "current"
+		if len(versions) > 1 {
+			from = strconv.Itoa(versions[1].VersionNumber)
+		} else if len(versions) == 1 {
+			from = strconv.Itoa(versions[0].VersionNumber)
+		}
+	}
+
+	fromValues, err := reportSnapshotValues(kind, current, versions, from)
+	if err != nil {
+		http.Error(w, "Version not found", http.StatusNotFound)
+		return
+	}
+	toValues, err := reportSnapshotValues(kind, current, versions, to)
+	if err != nil {
+		http.Error(w, "Version not found", http.StatusNotFound)
+		return
+	}
+
+	view := r.URL.Query().Get("view")
+	if view != "side" {
+		view = "inline"
+	}
+
+	fields := supervisorReportDiffFields
+	if kind == reportKindReviewer {
+		fields = reviewerReportDiffFields
+	}
+	diffs := make([]textdiff.FieldDiff, 0, len(fields))
+	for _, field := range fields {
+		diffs = append(diffs, textdiff.NewFieldDiff(field, fromValues[field], toValues[field]))
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = templates.ReportVersionHistory(kind, studentID, versions, from, to, diffs, view, getLocale(r)).Render(r.Context(), w)
+	if err != nil {
+		log.Printf("Error rendering report versions: %v", err)
+	}
+}
+
+// reportSnapshotValues resolves a version reference ("current" or a version number) to field values
+func reportSnapshotValues(kind string, current *reportState, versions []database.ReportVersion, ref string) (map[string]string, error) {
+	if ref == "current" {
+		return reportFieldValues(current.Snapshot), nil
+	}
+
+	versionNumber, err := strconv.Atoi(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version reference %q", ref)
+	}
+
+	for i := range versions {
+		if versions[i].VersionNumber != versionNumber {
+			continue
+		}
+		if kind == reportKindReviewer {
+			report, err := versions[i].GetReviewerReport()
+			if err != nil {
+				return nil, err
+			}
+			return reportFieldValues(report), nil
+		}
+		report, err := versions[i].GetSupervisorReport()
+		if err != nil {
+			return nil, err
+		}
+		return reportFieldValues(report), nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+// reportFieldValues returns the compared report fields as plain text
+func reportFieldValues(snapshot interface{}) map[string]string {
+	switch report := snapshot.(type) {
+	case *database.SupervisorReport:
+		grade := ""
+		if report.Grade != nil {
+			grade = strconv.Itoa(*report.Grade)
+		}
+		return map[string]string{
+			"supervisor_comments":  report.SupervisorComments,
+			"is_pass_or_failed":    strconv.FormatBool(report.IsPassOrFailed),
+			"other_match":          formatPercent(report.OtherMatch),
+			"one_match":            formatPercent(report.OneMatch),
+			"own_match":            formatPercent(report.OwnMatch),
+			"join_match":           formatPercent(report.JoinMatch),
+			"grade":                grade,
+			"final_comments":       report.FinalComments.String,
+			"supervisor_workplace": report.SupervisorWorkplace,
+			"supervisor_position":  report.SupervisorPosition.String,
+		}
+	case *database.ReviewerReport:
+		return map[string]string{
+			"reviewer_personal_details":     report.ReviewerPersonalDetails,
+			"review_goals":                  report.ReviewGoals,
+			"review_theory":                 report.ReviewTheory,
+			"review_practical":              report.ReviewPractical,
+			"review_theory_practical_link":  report.ReviewTheoryPracticalLink,
+			"review_results":                report.ReviewResults,
+			"review_practical_significance": database.StringValue(report.ReviewPracticalSignificance),
+			"review_language":               report.ReviewLanguage,
+			"review_pros":                   report.ReviewPros,
+			"review_cons":                   report.ReviewCons,
+			"review_questions":              report.ReviewQuestions,
+			"grade":                         strconv.FormatFloat(report.Grade, 'f', 1, 64),
+		}
+	}
+	return map[string]string{}
+}
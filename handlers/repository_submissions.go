@@ -0,0 +1,364 @@
+// handlers/repository_submissions.go - Submission history timeline, snapshot browsing and diffs between submissions
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/repository"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/githosting"
+	"FinalProjectManagementApp/textdiff"
+	"FinalProjectManagementApp/types"
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+)
+
+// submissionBranchPattern matches the per-upload branches created by uploadToGit
+var submissionBranchPattern = regexp.MustCompile(`^submission-(\d{8}-\d{6})$`)
+
+// maxDiffFileSize is the largest file shown in a line diff
+const maxDiffFileSize = 1024 * 1024
+
+// submissionContext is the student and repository every submission history view works on
+type submissionContext struct {
+	user     *auth.AuthenticatedUser
+	student  *database.StudentRecord
+	repoInfo *database.Document
+	repoName string
+	access   database.AccessInfo
+}
+
+// loadSubmissionContext applies the same access rules as the repository viewer
+func (h *RepositoryHandler) loadSubmissionContext(w http.ResponseWriter, r *http.Request) (*submissionContext, bool) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusFound)
+		return nil, false
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	accessInfo := h.extractAccessInfo(r)
+	if !h.authorizeRepository(w, r, user, studentID, accessInfo, false) {
+		return nil, false
+	}
+
+	student, err := h.getStudentRecord(studentID)
+	if err != nil {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return nil, false
+	}
+	repoInfo, err := h.getStudentRepository(studentID)
+	if err != nil || repoInfo.RepositoryURL == nil || *repoInfo.RepositoryURL == "" {
+		http.Error(w, "Repository not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	return &submissionContext{
+		user:     user,
+		student:  student,
+		repoInfo: repoInfo,
+		repoName: h.extractRepoName(*repoInfo.RepositoryURL),
+		access:   accessInfo,
+	}, true
+}
+
+// ViewSubmissionHistory shows every submission of the student, newest first
+func (h *RepositoryHandler) ViewSubmissionHistory(w http.ResponseWriter, r *http.Request) {
+	sc, ok := h.loadSubmissionContext(w, r)
+	if !ok {
+		return
+	}
+
+	snapshots, err := h.listSubmissions(r.Context(), sc.repoName, sc.student.ID)
+	if err != nil {
+		log.Printf("Error listing submissions of %s: %v", sc.repoName, err)
+		http.Error(w, "Failed to load submission history", http.StatusBadGateway)
+		return
+	}
+
+	if r.Header.Get("Accept") == "application/json" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(snapshots)
+		return
+	}
+
+	component := repository.SubmissionHistoryPage(sc.user, sc.student, sc.repoInfo, snapshots, h.getCurrentLocale(r), sc.access)
+	templ.Handler(component).ServeHTTP(w, r)
+}
+
+// ViewSubmissionSnapshot lists the files of one submission
+func (h *RepositoryHandler) ViewSubmissionSnapshot(w http.ResponseWriter, r *http.Request) {
+	sc, ok := h.loadSubmissionContext(w, r)
+	if !ok {
+		return
+	}
+
+	snapshot, ok := h.findSubmission(w, r, sc, chi.URLParam(r, "branch"))
+	if !ok {
+		return
+	}
+
+	entries, err := h.provider.Tree(r.Context(), sc.repoName, snapshot.Branch)
+	if err != nil {
+		h.renderSubmissionError(w, sc.repoName, snapshot.Branch, err)
+		return
+	}
+
+	files := make([]types.RepositoryFile, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == "file" {
+			files = append(files, types.RepositoryFile{Name: entry.Name, Path: entry.Path, Type: entry.Type, Size: entry.Size})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	component := repository.SubmissionSnapshotPage(sc.user, sc.student, sc.repoInfo, snapshot, files, h.getCurrentLocale(r), sc.access)
+	templ.Handler(component).ServeHTTP(w, r)
+}
+
+// ViewSubmissionFile renders one file as it was in a submission, as an HTMX fragment
+func (h *RepositoryHandler) ViewSubmissionFile(w http.ResponseWriter, r *http.Request) {
+	sc, ok := h.loadSubmissionContext(w, r)
+	if !ok {
+		return
+	}
+
+	branch := chi.URLParam(r, "branch")
+	if !submissionBranchPattern.MatchString(branch) {
+		http.Error(w, "Submission not found", http.StatusNotFound)
+		return
+	}
+
+	filePath := chi.URLParam(r, "*")
+	fileContent, err := h.getFileContentAt(*sc.repoInfo.RepositoryURL, branch, filePath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	component := repository.FileViewer(sc.student.ID, filePath, fileContent, sc.access)
+	templ.Handler(component).ServeHTTP(w, r)
+}
+
+// CompareSubmissions lists the files added, removed and modified between two submissions
+func (h *RepositoryHandler) CompareSubmissions(w http.ResponseWriter, r *http.Request) {
+	sc, ok := h.loadSubmissionContext(w, r)
+	if !ok {
+		return
+	}
+
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if !submissionBranchPattern.MatchString(from) || !submissionBranchPattern.MatchString(to) {
+		http.Error(w, "Select two submissions to compare", http.StatusBadRequest)
+		return
+	}
+	// Always show changes from the older to the newer submission
+	if from > to {
+		from, to = to, from
+	}
+
+	oldTree, err := h.provider.Tree(r.Context(), sc.repoName, from)
+	if err != nil {
+		h.renderSubmissionError(w, sc.repoName, from, err)
+		return
+	}
+	newTree, err := h.provider.Tree(r.Context(), sc.repoName, to)
+	if err != nil {
+		h.renderSubmissionError(w, sc.repoName, to, err)
+		return
+	}
+
+	comparison := newSubmissionComparison(from, to, githosting.CompareTrees(oldTree, newTree))
+	if r.Header.Get("Accept") == "application/json" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(comparison)
+		return
+	}
+	component := repository.SubmissionComparePage(sc.user, sc.student, sc.repoInfo, comparison, h.getCurrentLocale(r), sc.access)
+	templ.Handler(component).ServeHTTP(w, r)
+}
+
+func (h *RepositoryHandler) renderSubmissionError(w http.ResponseWriter, repoName, branch string, err error) {
+	if err == githosting.ErrNotFound {
+		http.Error(w, "Submission not found", http.StatusNotFound)
+		return
+	}
+	log.Printf("Error loading submission %s of %s: %v", branch, repoName, err)
+	http.Error(w, "Failed to load submission", http.StatusBadGateway)
+}
+
+// ViewSubmissionFileDiff renders the line diff of one file between two submissions
+func (h *RepositoryHandler) ViewSubmissionFileDiff(w http.ResponseWriter, r *http.Request) {
+	sc, ok := h.loadSubmissionContext(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	from, to, filePath := query.Get("from"), query.Get("to"), query.Get("path")
+	if !submissionBranchPattern.MatchString(from) || !submissionBranchPattern.MatchString(to) || filePath == "" {
+		http.Error(w, "Invalid comparison", http.StatusBadRequest)
+		return
+	}
+	if from > to {
+		from, to = to, from
+	}
+
+	diff := &types.SubmissionFileDiff{From: from, To: to, Path: filePath}
+	oldContent, oldOK := h.readDiffSide(r.Context(), sc.repoName, from, filePath, diff)
+	newContent, newOK := h.readDiffSide(r.Context(), sc.repoName, to, filePath, diff)
+	if !oldOK && !newOK {
+		http.Error(w, "File not found in either submission", http.StatusNotFound)
+		return
+	}
+
+	if !diff.Binary && !diff.TooLarge {
+		lines, ok := textdiff.DiffLines(oldContent, newContent)
+		if ok {
+			diff.Lines = lines
+			diff.Inserted, diff.Deleted = textdiff.LineStats(lines)
+		} else {
+			diff.TooLarge = true
+		}
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		templ.Handler(repository.SubmissionFileDiff(diff)).ServeHTTP(w, r)
+		return
+	}
+	component := repository.SubmissionFileDiffPage(sc.user, sc.student, sc.repoInfo, diff, h.getCurrentLocale(r), sc.access)
+	templ.Handler(component).ServeHTTP(w, r)
+}
+
+// readDiffSide loads one side of a file diff; a file missing on that side diffs as empty
+func (h *RepositoryHandler) readDiffSide(ctx context.Context, repoName, ref, filePath string, diff *types.SubmissionFileDiff) (string, bool) {
+	file, err := h.provider.ReadFile(ctx, repoName, ref, filePath)
+	if err != nil {
+		if err != githosting.ErrNotFound {
+			log.Printf("Error reading %s at %s of %s: %v", filePath, ref, repoName, err)
+		}
+		return "", false
+	}
+	switch {
+	case file.Size > maxDiffFileSize || file.Content == nil:
+		diff.TooLarge = true
+	case bytes.IndexByte(file.Content, 0) >= 0:
+		diff.Binary = true
+	}
+	return string(file.Content), true
+}
+
+// findSubmission resolves a submission branch of the student, writing a 404 when it does not exist
+func (h *RepositoryHandler) findSubmission(w http.ResponseWriter, r *http.Request, sc *submissionContext, branch string) (*types.SubmissionSnapshot, bool) {
+	if submissionBranchPattern.MatchString(branch) {
+		snapshots, err := h.listSubmissions(r.Context(), sc.repoName, sc.student.ID)
+		if err != nil {
+			log.Printf("Error listing submissions of %s: %v", sc.repoName, err)
+			http.Error(w, "Failed to load submission history", http.StatusBadGateway)
+			return nil, false
+		}
+		for i := range snapshots {
+			if snapshots[i].Branch == branch {
+				return &snapshots[i], true
+			}
+		}
+	}
+	http.Error(w, "Submission not found", http.StatusNotFound)
+	return nil, false
+}
+
+// listSubmissions combines the submission branches on the git host with the upload records in the database.
+// Branches pushed before uploads recorded their branch only carry the time encoded in their name.
+func (h *RepositoryHandler) listSubmissions(ctx context.Context, repoName string, studentID int) ([]types.SubmissionSnapshot, error) {
+	branches, err := h.provider.ListBranches(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Branch           string  `db:"submission_branch"`
+		OriginalFilename *string `db:"original_filename"`
+		UploadedBy       *string `db:"uploaded_by_email"`
+		ResultJSON       *string `db:"result_json"`
+	}
+	err = h.db.Select(&rows, `
+		SELECT d.submission_branch, d.original_filename, d.uploaded_by_email, j.result_json
+		FROM documents d
+		LEFT JOIN source_upload_jobs j ON j.submission_id = d.submission_id
+		WHERE d.student_record_id = ? AND d.document_type = 'thesis_source_code'
+		  AND d.submission_branch IS NOT NULL`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	uploads := make(map[string]int, len(rows))
+	for i, row := range rows {
+		uploads[row.Branch] = i
+	}
+
+	var snapshots []types.SubmissionSnapshot
+	for _, branch := range branches {
+		match := submissionBranchPattern.FindStringSubmatch(branch.Name)
+		if match == nil {
+			continue
+		}
+		submittedAt, _ := time.ParseInLocation("20060102-150405", match[1], time.Local)
+		snapshot := types.SubmissionSnapshot{Branch: branch.Name, SubmittedAt: submittedAt, CommitSHA: branch.SHA}
+
+		if i, ok := uploads[branch.Name]; ok {
+			row := rows[i]
+			snapshot.OriginalFilename = database.StringValue(row.OriginalFilename)
+			snapshot.UploadedBy = database.StringValue(row.UploadedBy)
+			if row.ResultJSON != nil {
+				var result database.SubmissionResult
+				if json.Unmarshal([]byte(*row.ResultJSON), &result) == nil {
+					snapshot.Filter = result.FilterInfo
+					if result.CommitInfo != nil {
+						snapshot.FilesCount = result.CommitInfo.FilesCount
+					}
+				}
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Branch > snapshots[j].Branch })
+	if len(snapshots) > 0 {
+		snapshots[0].IsLatest = true
+	}
+	return snapshots, nil
+}
+
+func newSubmissionComparison(from, to string, changes []githosting.FileChange) *types.SubmissionComparison {
+	comparison := &types.SubmissionComparison{From: from, To: to, Changes: make([]types.SubmissionFileChange, 0, len(changes))}
+	for _, change := range changes {
+		comparison.Changes = append(comparison.Changes, types.SubmissionFileChange{
+			Path:    change.Path,
+			Status:  change.Status,
+			OldSize: change.OldSize,
+			NewSize: change.NewSize,
+		})
+		switch change.Status {
+		case githosting.ChangeAdded:
+			comparison.Added++
+		case githosting.ChangeRemoved:
+			comparison.Removed++
+		default:
+			comparison.Modified++
+		}
+	}
+	return comparison
+}
@@ -0,0 +1,307 @@
+// handlers/reviewer_answers.go - Published reviewer reports for students and their answers to the questions
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"github.com/jmoiron/sqlx"
+)
+
+// maxAnswerLength limits a single answer to keep the commission view readable
+const maxAnswerLength = 5000
+
+type ReviewerAnswersHandler struct {
+	db *sqlx.DB
+}
+
+func NewReviewerAnswersHandler(db *sqlx.DB) *ReviewerAnswersHandler {
+	return &ReviewerAnswersHandler{db: db}
+}
+
+// getDepartmentReviewPolicy returns the department policy or the defaults when none is configured
+func getDepartmentReviewPolicy(q sqlx.Queryer, department string) *database.DepartmentReviewPolicy {
+	var policy database.DepartmentReviewPolicy
+	err := sqlx.Get(q, &policy, "SELECT * FROM department_review_policies WHERE department = ?", department)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Error loading review policy of %s: %v", department, err)
+		}
+		return database.DefaultDepartmentReviewPolicy(department)
+	}
+	return &policy
+}
+
+// isReviewerReportPublished reports whether the student may already see the signed reviewer report
+func isReviewerReportPublished(q sqlx.Queryer, student *database.StudentRecord, report *database.ReviewerReport) bool {
+	return getDepartmentReviewPolicy(q, student.Department).IsPublished(report)
+}
+
+// getQuestionAnswers loads the answers of several students keyed by student
+func getQuestionAnswers(q sqlx.Queryer, studentIDs []int) (map[int][]database.ReviewerQuestionAnswer, error) {
+	answers := make(map[int][]database.ReviewerQuestionAnswer)
+	if len(studentIDs) == 0 {
+		return answers, nil
+	}
+	query, args, err := sqlx.In(`
+        SELECT * FROM reviewer_question_answers
+        WHERE student_record_id IN (?)
+        ORDER BY student_record_id, question_number`, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+	var rows []database.ReviewerQuestionAnswer
+	if err := sqlx.Select(q, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		answers[row.StudentRecordID] = append(answers[row.StudentRecordID], row)
+	}
+	return answers, nil
+}
+
+// ShowStudentReview renders the published reviewer report with the answer form
+func (h *ReviewerAnswersHandler) ShowStudentReview(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || user.Role != auth.RoleStudent {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	locale := getLocale(r)
+	data, err := h.getStudentReviewData(user.Email)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Error loading reviewer report of %s: %v", user.Email, err)
+		}
+		data = nil
+	}
+
+	templates.StudentReviewerReportPage(user, locale, data, r.URL.Query().Get("saved") == "1").Render(r.Context(), w)
+}
+
+// SaveAnswers stores the student's answers until the deadline
+func (h *ReviewerAnswersHandler) SaveAnswers(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || user.Role != auth.RoleStudent {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	locale := getLocale(r)
+
+	data, err := h.getStudentReviewData(user.Email)
+	if err != nil {
+		http.Error(w, "Reviewer report not found", http.StatusNotFound)
+		return
+	}
+	if !data.CanAnswer {
+		renderTemplateError(w, "#answer-error", reportLabel(locale,
+			"Atsakymų pateikimo terminas pasibaigė", "The deadline for answers has passed"))
+		return
+	}
+
+	tx, err := h.db.Beginx()
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	saved := 0
+	for i, question := range data.Questions {
+		number := i + 1
+		answer := strings.TrimSpace(r.FormValue(fmt.Sprintf("answer_%d", number)))
+		if len(answer) > maxAnswerLength {
+			renderTemplateError(w, "#answer-error", fmt.Sprintf(reportLabel(locale,
+				"%d klausimo atsakymas per ilgas", "The answer to question %d is too long"), number))
+			return
+		}
+		if answer == "" {
+			_, err = tx.Exec("DELETE FROM reviewer_question_answers WHERE student_record_id = ? AND question_number = ?",
+				data.Student.ID, number)
+		} else {
+			_, err = tx.Exec(`
+                INSERT INTO reviewer_question_answers (student_record_id, question_number, question_text, answer)
+                VALUES (?, ?, ?, ?)
+                ON DUPLICATE KEY UPDATE question_text = VALUES(question_text), answer = VALUES(answer)`,
+				data.Student.ID, number, question, answer)
+			saved++
+		}
+		if err != nil {
+			log.Printf("Error saving answer %d of student %d: %v", number, data.Student.ID, err)
+			http.Error(w, "Failed to save answers", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Questions removed after an unlock and re-sign no longer have a place on the form
+	_, err = tx.Exec("DELETE FROM reviewer_question_answers WHERE student_record_id = ? AND question_number > ?",
+		data.Student.ID, len(data.Questions))
+	if err != nil {
+		http.Error(w, "Failed to save answers", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to save answers", http.StatusInternalServerError)
+		return
+	}
+
+	details := fmt.Sprintf(`{"student_id":%d,"answers":%d}`, data.Student.ID, saved)
+	database.CreateAuditLog(database.AuditLog{
+		UserEmail:    user.Email,
+		UserRole:     user.Role,
+		Action:       "save_reviewer_question_answers",
+		ResourceType: "reviewer_report",
+		ResourceID:   database.NullableString(strconv.Itoa(data.Report.ID)),
+		Details:      &details,
+		IPAddress:    database.NullableString(requestIP(r)),
+		UserAgent:    database.NullableString(r.UserAgent()),
+		Success:      true,
+		CreatedAt:    time.Now(),
+	})
+
+	w.Header().Set("HX-Redirect", "/student/reviewer-report?saved=1&locale="+locale)
+}
+
+func (h *ReviewerAnswersHandler) getStudentReviewData(email string) (*database.StudentReviewPageData, error) {
+	var student database.StudentRecord
+	if err := h.db.Get(&student, "SELECT * FROM student_records WHERE student_email = ?", email); err != nil {
+		return nil, err
+	}
+
+	var report database.ReviewerReport
+	if err := h.db.Get(&report, "SELECT * FROM reviewer_reports WHERE student_record_id = ?", student.ID); err != nil {
+		return nil, err
+	}
+
+	policy := getDepartmentReviewPolicy(h.db, student.Department)
+	publishedAt, ok := policy.PublishedAt(&report)
+	if !ok || time.Now().Before(publishedAt) {
+		return nil, sql.ErrNoRows
+	}
+
+	answers, err := getQuestionAnswers(h.db, []int{student.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	data := &database.StudentReviewPageData{
+		Student:     &student,
+		Report:      &report,
+		Questions:   database.SplitReviewQuestions(report.ReviewQuestions),
+		Answers:     make(map[int]database.ReviewerQuestionAnswer),
+		PublishedAt: publishedAt,
+		CanAnswer:   true,
+	}
+	for _, answer := range answers[student.ID] {
+		data.Answers[answer.QuestionNumber] = answer
+	}
+	if deadline, ok := policy.AnswerDeadline(student.DefenseDate); ok {
+		data.Deadline = &deadline
+		data.CanAnswer = time.Now().Before(deadline)
+	}
+	if len(data.Questions) == 0 {
+		data.CanAnswer = false
+	}
+	return data, nil
+}
+
+// ShowPolicies renders the publication and answer deadline settings of the departments
+func (h *ReviewerAnswersHandler) ShowPolicies(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	policies, err := h.managedPolicies(user)
+	if err != nil {
+		log.Printf("Error loading review policies: %v", err)
+		http.Error(w, "Failed to load policies", http.StatusInternalServerError)
+		return
+	}
+
+	locale := getLocale(r)
+	templates.ReviewPolicyPage(user, locale, policies, r.URL.Query().Get("saved") == "1").Render(r.Context(), w)
+}
+
+// SavePolicy stores the policy of one department
+func (h *ReviewerAnswersHandler) SavePolicy(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	locale := getLocale(r)
+
+	department := r.FormValue("department")
+	if scope, err := departmentHeadScope(h.db, user); err != nil || (scope != "" && department != scope) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	delay, err := strconv.Atoi(r.FormValue("publish_delay_hours"))
+	if err != nil || delay < 0 {
+		delay = 0
+	}
+	deadline, err := strconv.Atoi(r.FormValue("answer_deadline_hours"))
+	if err != nil || deadline < 0 {
+		deadline = 24
+	}
+
+	_, err = h.db.Exec(`
+        INSERT INTO department_review_policies
+        (department, publish_to_students, publish_delay_hours, answer_deadline_hours, updated_by)
+        VALUES (?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE publish_to_students = VALUES(publish_to_students),
+            publish_delay_hours = VALUES(publish_delay_hours),
+            answer_deadline_hours = VALUES(answer_deadline_hours), updated_by = VALUES(updated_by)`,
+		department, r.FormValue("publish_to_students") == "true", delay, deadline, user.Email)
+	if err != nil {
+		log.Printf("Error saving review policy of %s: %v", department, err)
+		http.Error(w, "Failed to save policy", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/admin/review-policy?saved=1&locale="+locale)
+}
+
+// managedPolicies lists the policy of every department the user manages, with defaults filled in
+func (h *ReviewerAnswersHandler) managedPolicies(user *auth.AuthenticatedUser) ([]database.DepartmentReviewPolicy, error) {
+	scope, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		return nil, err
+	}
+	departments := []string{scope}
+	if scope == "" {
+		err := h.db.Select(&departments, `
+            SELECT DISTINCT department FROM student_records
+            WHERE department IS NOT NULL AND department != '' ORDER BY department`)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	policies := make([]database.DepartmentReviewPolicy, 0, len(departments))
+	for _, department := range departments {
+		policies = append(policies, *getDepartmentReviewPolicy(h.db, department))
+	}
+	return policies, nil
+}
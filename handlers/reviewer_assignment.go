@@ -0,0 +1,525 @@
+// handlers/reviewer_assignment.go - Manual and automatic reviewer assignment for department heads
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/assignment"
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/notifications"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+type ReviewerAssignmentHandler struct {
+	db                  *sqlx.DB
+	notificationService *notifications.NotificationService
+}
+
+func NewReviewerAssignmentHandler(db *sqlx.DB, notificationService *notifications.NotificationService) *ReviewerAssignmentHandler {
+	return &ReviewerAssignmentHandler{db: db, notificationService: notificationService}
+}
+
+// ShowAssignmentPage lists students waiting for a reviewer; ?auto=1 pre-selects balanced proposals
+func (h *ReviewerAssignmentHandler) ShowAssignmentPage(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		log.Printf("Error resolving department of %s: %v", user.Email, err)
+		http.Error(w, "Department not found", http.StatusForbidden)
+		return
+	}
+
+	pool, err := h.getPool(department)
+	if err != nil {
+		log.Printf("Error loading reviewer pool: %v", err)
+		http.Error(w, "Failed to load reviewer pool", http.StatusInternalServerError)
+		return
+	}
+
+	candidates, err := h.getCandidates(department)
+	if err != nil {
+		log.Printf("Error loading assignment candidates: %v", err)
+		http.Error(w, "Failed to load students", http.StatusInternalServerError)
+		return
+	}
+
+	excluded, err := h.getExcludedReviewers(candidates)
+	if err != nil {
+		log.Printf("Error loading excluded reviewers: %v", err)
+		http.Error(w, "Failed to load students", http.StatusInternalServerError)
+		return
+	}
+
+	options := make(map[int][]database.ReviewerPoolMember, len(candidates))
+	for _, candidate := range candidates {
+		student := assignmentStudent(candidate, excluded)
+		for _, member := range pool {
+			if member.Department == candidate.Department && assignment.Eligible(student, assignmentReviewer(member)) {
+				options[candidate.StudentRecordID] = append(options[candidate.StudentRecordID], member)
+			}
+		}
+	}
+
+	var unassigned []int
+	if r.URL.Query().Get("auto") == "1" {
+		unassigned = proposeReviewers(candidates, pool, excluded)
+	}
+
+	locale := getLocale(r)
+	templates.ReviewerAssignmentPage(user, locale, pool, candidates, options, unassigned, h.getDepartments(department)).Render(r.Context(), w)
+}
+
+// proposeReviewers runs the balancing per department and stores proposals on the candidates
+func proposeReviewers(candidates []database.ReviewerAssignmentCandidate, pool []database.ReviewerPoolMember, excluded map[int][]string) []int {
+	byDepartment := make(map[string][]assignment.Student)
+	for _, candidate := range candidates {
+		byDepartment[candidate.Department] = append(byDepartment[candidate.Department], assignmentStudent(candidate, excluded))
+	}
+
+	proposed := make(map[int]assignment.Proposal)
+	var unassigned []int
+	for department, students := range byDepartment {
+		var reviewers []assignment.Reviewer
+		for _, member := range pool {
+			if member.Department == department {
+				reviewers = append(reviewers, assignmentReviewer(member))
+			}
+		}
+		proposals, missing := assignment.Assign(students, reviewers)
+		for _, proposal := range proposals {
+			proposed[proposal.StudentID] = proposal
+		}
+		unassigned = append(unassigned, missing...)
+	}
+
+	for i := range candidates {
+		if proposal, ok := proposed[candidates[i].StudentRecordID]; ok {
+			candidates[i].ProposedReviewer = proposal.ReviewerEmail
+			candidates[i].MatchedKeywords = proposal.MatchedKeywords
+		}
+	}
+	return unassigned
+}
+
+func assignmentStudent(candidate database.ReviewerAssignmentCandidate, excluded map[int][]string) assignment.Student {
+	student := assignment.Student{
+		ID:                  candidate.StudentRecordID,
+		SupervisorEmail:     candidate.SupervisorEmail,
+		SupervisorWorkplace: database.StringValue(candidate.SupervisorWorkplace),
+		Topic:               candidate.TopicText(),
+		Excluded:            excluded[candidate.StudentRecordID],
+	}
+	if candidate.CurrentReviewer != nil {
+		// Only students whose current reviewer was rejected are listed with a reviewer
+		student.Excluded = append(student.Excluded, *candidate.CurrentReviewer)
+	}
+	return student
+}
+
+func assignmentReviewer(member database.ReviewerPoolMember) assignment.Reviewer {
+	return assignment.Reviewer{
+		Email:     member.ReviewerEmail,
+		Name:      member.ReviewerName,
+		Workplace: member.Workplace,
+		Keywords:  assignment.ParseKeywords(database.StringValue(member.Keywords)),
+		Capacity:  member.MaxLoad,
+		Load:      member.CurrentLoad,
+	}
+}
+
+// SavePoolMember adds a reviewer to the department pool or updates an existing entry
+func (h *ReviewerAssignmentHandler) SavePoolMember(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	locale := getLocale(r)
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		http.Error(w, "Department not found", http.StatusForbidden)
+		return
+	}
+	if department == "" {
+		department = strings.TrimSpace(r.FormValue("department"))
+	}
+
+	email := strings.ToLower(strings.TrimSpace(r.FormValue("reviewer_email")))
+	name := strings.TrimSpace(r.FormValue("reviewer_name"))
+	if email == "" || name == "" || department == "" || !strings.Contains(email, "@") {
+		renderTemplateError(w, "#pool-error", reportLabel(locale,
+			"Nurodykite recenzento el. paštą, vardą ir katedrą", "Reviewer e-mail, name and department are required"))
+		return
+	}
+	maxLoad, err := strconv.Atoi(r.FormValue("max_load"))
+	if err != nil || maxLoad < 0 {
+		maxLoad = 5
+	}
+
+	_, err = h.db.Exec(`
+        INSERT INTO reviewer_pool (department, reviewer_email, reviewer_name, workplace, keywords, max_load, created_by)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE reviewer_name = VALUES(reviewer_name), workplace = VALUES(workplace),
+            keywords = VALUES(keywords), max_load = VALUES(max_load), is_active = TRUE`,
+		department, email, name, strings.TrimSpace(r.FormValue("workplace")),
+		database.NullableString(strings.TrimSpace(r.FormValue("keywords"))), maxLoad, user.Email)
+	if err != nil {
+		log.Printf("Error saving reviewer pool member %s: %v", email, err)
+		http.Error(w, "Failed to save reviewer", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/admin/reviewer-assignment?locale="+locale)
+}
+
+// RemovePoolMember deactivates a reviewer in the pool; existing assignments stay untouched
+func (h *ReviewerAssignmentHandler) RemovePoolMember(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid reviewer ID", http.StatusBadRequest)
+		return
+	}
+
+	member, err := h.getPoolMember(user, id)
+	if err != nil {
+		http.Error(w, "Reviewer not found", http.StatusNotFound)
+		return
+	}
+
+	if _, err := h.db.Exec("UPDATE reviewer_pool SET is_active = FALSE WHERE id = ?", member.ID); err != nil {
+		http.Error(w, "Failed to remove reviewer", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConfirmAssignments stores the selected reviewers, issues access tokens and notifies the reviewers
+func (h *ReviewerAssignmentHandler) ConfirmAssignments(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	locale := getLocale(r)
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		http.Error(w, "Department not found", http.StatusForbidden)
+		return
+	}
+
+	candidates, err := h.getCandidates(department)
+	if err != nil {
+		log.Printf("Error loading assignment candidates: %v", err)
+		http.Error(w, "Failed to load students", http.StatusInternalServerError)
+		return
+	}
+	excluded, err := h.getExcludedReviewers(candidates)
+	if err != nil {
+		http.Error(w, "Failed to load students", http.StatusInternalServerError)
+		return
+	}
+
+	daysValid, err := strconv.Atoi(r.FormValue("days_valid"))
+	if err != nil || daysValid <= 0 {
+		daysValid = 30
+	}
+
+	tokens := make(map[string]string)
+	var results []database.ReviewerAssignmentResult
+	var notices []reviewerAssignmentNotice
+	for _, candidate := range candidates {
+		memberID, err := strconv.Atoi(r.FormValue(fmt.Sprintf("reviewer_%d", candidate.StudentRecordID)))
+		if err != nil || memberID == 0 {
+			continue
+		}
+
+		studentName := candidate.StudentName + " " + candidate.StudentLastname
+		result := database.ReviewerAssignmentResult{StudentName: studentName}
+
+		member, err := h.getPoolMember(user, memberID)
+		if err != nil || member.Department != candidate.Department {
+			result.Error = reportLabel(locale, "Recenzentas nerastas", "Reviewer not found")
+			results = append(results, result)
+			continue
+		}
+		result.ReviewerEmail = member.ReviewerEmail
+
+		if !assignment.Eligible(assignmentStudent(candidate, excluded), assignmentReviewer(*member)) {
+			result.Error = reportLabel(locale, "Interesų konfliktas", "Conflict of interest")
+			results = append(results, result)
+			continue
+		}
+
+		accessToken, err := h.assignReviewer(candidate.StudentRecordID, member, tokens, daysValid, user.Email)
+		if err != nil {
+			log.Printf("Error assigning reviewer %s to student %d: %v", member.ReviewerEmail, candidate.StudentRecordID, err)
+			result.Error = reportLabel(locale, "Nepavyko išsaugoti", "Failed to save")
+			results = append(results, result)
+			continue
+		}
+		result.AccessURL = "/reviewer/" + accessToken
+		results = append(results, result)
+
+		details := fmt.Sprintf(`{"student_id":%d,"reviewer":%q,"previous_reviewer":%q}`,
+			candidate.StudentRecordID, member.ReviewerEmail, database.StringValue(candidate.CurrentReviewer))
+		database.CreateAuditLog(database.AuditLog{
+			UserEmail:    user.Email,
+			UserRole:     user.Role,
+			Action:       "assign_reviewer",
+			ResourceType: "student_record",
+			ResourceID:   database.NullableString(strconv.Itoa(candidate.StudentRecordID)),
+			Details:      &details,
+			IPAddress:    database.NullableString(requestIP(r)),
+			UserAgent:    database.NullableString(r.UserAgent()),
+			Success:      true,
+			CreatedAt:    time.Now(),
+		})
+
+		notices = append(notices, reviewerAssignmentNotice{
+			reviewerEmail: member.ReviewerEmail,
+			reviewerName:  member.ReviewerName,
+			studentName:   studentName,
+			topicTitle:    candidate.GetTitle(),
+		})
+	}
+
+	h.sendAssignmentNotifications(notices)
+
+	templates.ReviewerAssignmentResults(results, locale).Render(r.Context(), w)
+}
+
+// assignReviewer updates the student record and reuses or creates the reviewer's access token
+func (h *ReviewerAssignmentHandler) assignReviewer(studentID int, member *database.ReviewerPoolMember, tokens map[string]string, daysValid int, createdBy string) (string, error) {
+	tx, err := h.db.Beginx()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE student_records SET reviewer_email = ?, reviewer_name = ? WHERE id = ?",
+		member.ReviewerEmail, member.ReviewerName, studentID)
+	if err != nil {
+		return "", err
+	}
+
+	accessToken, ok := tokens[member.ReviewerEmail]
+	if !ok {
+		err = tx.Get(&accessToken, `
+            SELECT access_token FROM reviewer_access_tokens
+            WHERE reviewer_email = ? AND is_active = TRUE AND expires_at > ? AND (max_access = 0 OR access_count < max_access)
+            ORDER BY expires_at DESC LIMIT 1`,
+			member.ReviewerEmail, time.Now().Unix())
+		if err != nil {
+			accessToken, err = (&ReviewerAccessHandler{}).generateAccessToken()
+			if err != nil {
+				return "", err
+			}
+			_, err = tx.Exec(`
+                INSERT INTO reviewer_access_tokens (
+                    reviewer_email, reviewer_name, access_token, department,
+                    created_at, expires_at, max_access, is_active, created_by
+                ) VALUES (?, ?, ?, ?, ?, ?, 0, TRUE, ?)`,
+				member.ReviewerEmail, member.ReviewerName, accessToken, member.Department,
+				time.Now().Unix(), time.Now().AddDate(0, 0, daysValid).Unix(), createdBy)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	tokens[member.ReviewerEmail] = accessToken
+	return accessToken, nil
+}
+
+type reviewerAssignmentNotice struct {
+	reviewerEmail string
+	reviewerName  string
+	studentName   string
+	topicTitle    string
+}
+
+func (h *ReviewerAssignmentHandler) sendAssignmentNotifications(notices []reviewerAssignmentNotice) {
+	if len(notices) == 0 {
+		return
+	}
+	if h.notificationService == nil || !h.notificationService.IsEnabled() {
+		log.Printf("Reviewer assignment finished - notifications disabled, %d notification(s) not sent", len(notices))
+		return
+	}
+
+	service := h.notificationService
+	go func() {
+		for _, notice := range notices {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			err := service.SendReviewerAssignmentNotification(ctx, notice.reviewerEmail, notice.reviewerName, notice.studentName, notice.topicTitle)
+			if err != nil {
+				log.Printf("Failed to notify reviewer %s: %v", notice.reviewerEmail, err)
+			}
+			cancel()
+		}
+	}()
+}
+
+// currentYearCondition limits queries to the latest imported academic year
+const currentYearCondition = "s.current_year = (SELECT MAX(current_year) FROM student_records)"
+
+func (h *ReviewerAssignmentHandler) getPool(department string) ([]database.ReviewerPoolMember, error) {
+	query := `
+        SELECT p.*,
+               (SELECT COUNT(*) FROM student_records s
+                WHERE s.reviewer_email = p.reviewer_email AND ` + currentYearCondition + `) AS current_load
+        FROM reviewer_pool p
+        WHERE p.is_active = TRUE`
+	var args []interface{}
+	if department != "" {
+		query += " AND p.department = ?"
+		args = append(args, department)
+	}
+	query += " ORDER BY p.department, p.reviewer_name"
+
+	var pool []database.ReviewerPoolMember
+	err := h.db.Select(&pool, query, args...)
+	return pool, err
+}
+
+func (h *ReviewerAssignmentHandler) getPoolMember(user *auth.AuthenticatedUser, id int) (*database.ReviewerPoolMember, error) {
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT p.*,
+               (SELECT COUNT(*) FROM student_records s
+                WHERE s.reviewer_email = p.reviewer_email AND ` + currentYearCondition + `) AS current_load
+        FROM reviewer_pool p
+        WHERE p.id = ? AND p.is_active = TRUE`
+	args := []interface{}{id}
+	if department != "" {
+		query += " AND p.department = ?"
+		args = append(args, department)
+	}
+
+	var member database.ReviewerPoolMember
+	if err := h.db.Get(&member, query, args...); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// getCandidates lists students without a reviewer and students whose reviewer had a conflict confirmed
+func (h *ReviewerAssignmentHandler) getCandidates(department string) ([]database.ReviewerAssignmentCandidate, error) {
+	query := `
+        SELECT s.id AS student_record_id, s.student_name, s.student_lastname, s.student_group,
+               s.study_program, s.department, s.supervisor_email, s.final_project_title,
+               ptr.title AS topic_title, ptr.title_en AS topic_title_en, ptr.problem AS topic_problem,
+               NULLIF(s.reviewer_email, '') AS current_reviewer,
+               (SELECT sup.supervisor_workplace FROM supervisor_reports sup
+                JOIN student_records s2 ON s2.id = sup.student_record_id
+                WHERE s2.supervisor_email = s.supervisor_email AND sup.supervisor_workplace != ''
+                ORDER BY sup.updated_date DESC LIMIT 1) AS supervisor_workplace
+        FROM student_records s
+        LEFT JOIN project_topic_registrations ptr ON ptr.student_record_id = s.id AND ptr.status = 'approved'
+        WHERE ` + currentYearCondition + `
+          AND (s.reviewer_email IS NULL OR s.reviewer_email = ''
+               OR EXISTS (SELECT 1 FROM reviewer_conflict_declarations d
+                          WHERE d.student_record_id = s.id AND d.reviewer_email = s.reviewer_email
+                            AND d.status = 'rejected'))`
+	var args []interface{}
+	if department != "" {
+		query += " AND s.department = ?"
+		args = append(args, department)
+	}
+	query += " ORDER BY s.department, s.student_group, s.student_lastname, s.student_name"
+
+	var candidates []database.ReviewerAssignmentCandidate
+	err := h.db.Select(&candidates, query, args...)
+	return candidates, err
+}
+
+// getExcludedReviewers collects earlier reviewers of the same students and blocking conflict declarations
+func (h *ReviewerAssignmentHandler) getExcludedReviewers(candidates []database.ReviewerAssignmentCandidate) (map[int][]string, error) {
+	excluded := make(map[int][]string)
+	if len(candidates) == 0 {
+		return excluded, nil
+	}
+
+	ids := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.StudentRecordID
+	}
+
+	query, args, err := sqlx.In(`
+        SELECT s.id AS student_record_id, prev.reviewer_email
+        FROM student_records s
+        JOIN student_records prev ON prev.id != s.id
+             AND prev.reviewer_email IS NOT NULL AND prev.reviewer_email != ''
+             AND ((s.student_number != '' AND prev.student_number = s.student_number)
+                  OR LOWER(prev.student_email) = LOWER(s.student_email))
+        WHERE s.id IN (?)
+        UNION
+        SELECT d.student_record_id, d.reviewer_email
+        FROM reviewer_conflict_declarations d
+        WHERE d.student_record_id IN (?)
+          AND (d.status = 'rejected' OR (d.status != 'approved' AND d.has_conflict = TRUE)
+               OR FIND_IN_SET('same_as_supervisor', d.auto_flags))`, ids, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		StudentRecordID int    `db:"student_record_id"`
+		ReviewerEmail   string `db:"reviewer_email"`
+	}
+	if err := h.db.Select(&rows, h.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		excluded[row.StudentRecordID] = append(excluded[row.StudentRecordID], row.ReviewerEmail)
+	}
+	return excluded, nil
+}
+
+// getDepartments lists the departments an admin can add pool reviewers to
+func (h *ReviewerAssignmentHandler) getDepartments(department string) []string {
+	if department != "" {
+		return []string{department}
+	}
+	var departments []string
+	err := h.db.Select(&departments, `
+        SELECT DISTINCT department FROM student_records
+        WHERE department IS NOT NULL AND department != '' ORDER BY department`)
+	if err != nil {
+		log.Printf("Error loading departments: %v", err)
+	}
+	return departments
+}
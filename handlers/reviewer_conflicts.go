@@ -0,0 +1,396 @@
+// handlers/reviewer_conflicts.go - Reviewer conflict-of-interest declarations and automatic checks
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/assignment"
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+type ReviewerConflictHandler struct {
+	db *sqlx.DB
+}
+
+func NewReviewerConflictHandler(db *sqlx.DB) *ReviewerConflictHandler {
+	return &ReviewerConflictHandler{db: db}
+}
+
+// getConflictDeclaration returns the reviewer's declaration for a student, if any
+func getConflictDeclaration(q sqlx.Queryer, studentID int, reviewerEmail string) (*database.ReviewerConflictDeclaration, error) {
+	var declaration database.ReviewerConflictDeclaration
+	err := sqlx.Get(q, &declaration, `
+        SELECT * FROM reviewer_conflict_declarations
+        WHERE student_record_id = ? AND reviewer_email = ?`,
+		studentID, reviewerEmail)
+	if err != nil {
+		return nil, err
+	}
+	return &declaration, nil
+}
+
+// detectConflicts runs the automatic conflict checks for a reviewer assignment
+func detectConflicts(q sqlx.Queryer, student *database.StudentRecord, reviewerEmail, workplace string) ([]string, error) {
+	var flags []string
+
+	if strings.EqualFold(strings.TrimSpace(reviewerEmail), strings.TrimSpace(student.SupervisorEmail)) {
+		flags = append(flags, database.ConflictFlagSameAsSupervisor)
+	}
+
+	var supervisorWorkplaces []string
+	err := sqlx.Select(q, &supervisorWorkplaces, `
+        SELECT DISTINCT sup.supervisor_workplace
+        FROM supervisor_reports sup
+        JOIN student_records s ON s.id = sup.student_record_id
+        WHERE s.supervisor_email = ? AND sup.supervisor_workplace != ''`,
+		student.SupervisorEmail)
+	if err != nil {
+		return nil, err
+	}
+	for _, supervisorWorkplace := range supervisorWorkplaces {
+		if assignment.SameWorkplace(workplace, supervisorWorkplace) {
+			flags = append(flags, database.ConflictFlagSameWorkplace)
+			break
+		}
+	}
+
+	var reviewedBefore int
+	err = sqlx.Get(q, &reviewedBefore, `
+        SELECT COUNT(*) FROM student_records
+        WHERE id != ? AND LOWER(reviewer_email) = LOWER(?)
+          AND ((student_number != '' AND student_number = ?) OR LOWER(student_email) = LOWER(?))`,
+		student.ID, reviewerEmail, student.StudentNumber, student.StudentEmail)
+	if err != nil {
+		return nil, err
+	}
+	if reviewedBefore > 0 {
+		flags = append(flags, database.ConflictFlagReviewedBefore)
+	}
+
+	return flags, nil
+}
+
+// saveConflictDeclaration stores the submitted declaration together with the automatic check results
+func saveConflictDeclaration(db *sqlx.DB, r *http.Request, student *database.StudentRecord, reviewerEmail, reviewerName string) (*database.ReviewerConflictDeclaration, error) {
+	if existing, err := getConflictDeclaration(db, student.ID, reviewerEmail); err == nil {
+		return existing, nil
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	declaration := &database.ReviewerConflictDeclaration{
+		StudentRecordID:   student.ID,
+		ReviewerEmail:     reviewerEmail,
+		ReviewerName:      reviewerName,
+		ReviewerWorkplace: strings.TrimSpace(r.FormValue("reviewer_workplace")),
+		HasConflict:       r.FormValue("has_conflict") == "true",
+		Status:            database.ConflictStatusClear,
+		IPAddress:         database.NullableString(requestIP(r)),
+		DeclaredAt:        time.Now(),
+	}
+	if details := strings.TrimSpace(r.FormValue("conflict_details")); details != "" {
+		declaration.ConflictDetails = &details
+	}
+
+	flags, err := detectConflicts(db, student, reviewerEmail, declaration.ReviewerWorkplace)
+	if err != nil {
+		return nil, err
+	}
+	declaration.AutoFlags = strings.Join(flags, ",")
+	if len(declaration.Flags()) > 0 {
+		declaration.Status = database.ConflictStatusFlagged
+	}
+
+	result, err := db.Exec(`
+        INSERT INTO reviewer_conflict_declarations
+        (student_record_id, reviewer_email, reviewer_name, reviewer_workplace, has_conflict,
+         conflict_details, auto_flags, status, ip_address)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		declaration.StudentRecordID, declaration.ReviewerEmail, declaration.ReviewerName, declaration.ReviewerWorkplace,
+		declaration.HasConflict, declaration.ConflictDetails, declaration.AutoFlags, declaration.Status, declaration.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := result.LastInsertId()
+	declaration.ID = int(id)
+
+	details := fmt.Sprintf(`{"student_id":%d,"flags":%q,"status":%q}`, student.ID, declaration.AutoFlags, declaration.Status)
+	database.CreateAuditLog(database.AuditLog{
+		UserEmail:    reviewerEmail,
+		UserRole:     auth.RoleReviewer,
+		Action:       "declare_reviewer_conflict",
+		ResourceType: "reviewer_conflict_declaration",
+		ResourceID:   database.NullableString(strconv.Itoa(declaration.ID)),
+		Details:      &details,
+		IPAddress:    declaration.IPAddress,
+		UserAgent:    database.NullableString(r.UserAgent()),
+		Success:      true,
+		CreatedAt:    time.Now(),
+	})
+
+	return declaration, nil
+}
+
+// validateConflictDeclarationForm checks the required fields of the declaration form
+func validateConflictDeclarationForm(r *http.Request, locale string) string {
+	if r.FormValue("confirm") != "true" {
+		return reportLabel(locale, "Patvirtinkite deklaraciją", "Please confirm the declaration")
+	}
+	if strings.TrimSpace(r.FormValue("reviewer_workplace")) == "" {
+		return reportLabel(locale, "Nurodykite darbovietę", "Please enter your workplace")
+	}
+	if r.FormValue("has_conflict") == "true" && strings.TrimSpace(r.FormValue("conflict_details")) == "" {
+		return reportLabel(locale, "Aprašykite interesų konfliktą", "Please describe the conflict of interest")
+	}
+	return ""
+}
+
+// reviewerConflictGate renders the declaration form or its status when the reviewer may not open the review yet
+func (h *StudentListHandler) reviewerConflictGate(w http.ResponseWriter, r *http.Request, student *database.StudentRecord, reviewerEmail, accessToken, locale string) bool {
+	declaration, err := getConflictDeclaration(h.db, student.ID, reviewerEmail)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("Error loading conflict declaration for student %d: %v", student.ID, err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return false
+	}
+	if declaration != nil && declaration.CanReview() {
+		return true
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ReviewerConflictDeclarationModal(student, declaration, accessToken, locale, "").Render(r.Context(), w); err != nil {
+		log.Printf("Error rendering conflict declaration: %v", err)
+	}
+	return false
+}
+
+// reviewerHasClearance is the server-side guard for saving a review
+func (h *StudentListHandler) reviewerHasClearance(w http.ResponseWriter, studentID int, reviewerEmail string) bool {
+	declaration, err := getConflictDeclaration(h.db, studentID, reviewerEmail)
+	if err != nil || !declaration.CanReview() {
+		http.Error(w, "Conflict of interest declaration required", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// SubmitConflictDeclaration stores the declaration of a signed-in reviewer and opens the review form
+func (h *StudentListHandler) SubmitConflictDeclaration(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	var student database.StudentRecord
+	if err := h.db.Get(&student, "SELECT * FROM student_records WHERE id = ?", studentID); err != nil {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+	if user.Role != auth.RoleReviewer || !student.ReviewerEmail.Valid || student.ReviewerEmail.String != user.Email {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	if h.storeConflictDeclaration(w, r, &student, user.Email, user.Name, "") {
+		h.ReviewerReportModalHandler(w, r)
+	}
+}
+
+// SubmitConflictDeclarationWithToken stores the declaration of a reviewer using an access link
+func (h *StudentListHandler) SubmitConflictDeclarationWithToken(w http.ResponseWriter, r *http.Request) {
+	accessToken := chi.URLParam(r, "accessToken")
+
+	reviewerToken, err := h.validateReviewerAccessToken(accessToken)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	var student database.StudentRecord
+	err = h.db.Get(&student, "SELECT * FROM student_records WHERE id = ? AND reviewer_email = ?",
+		studentID, reviewerToken.ReviewerEmail)
+	if err != nil {
+		http.Error(w, "Student not found or access denied", http.StatusNotFound)
+		return
+	}
+
+	if h.storeConflictDeclaration(w, r, &student, reviewerToken.ReviewerEmail, reviewerToken.ReviewerName, accessToken) {
+		h.ReviewerReportModalHandlerWithToken(w, r)
+	}
+}
+
+// storeConflictDeclaration validates and saves the form; it reports whether the review form may follow
+func (h *StudentListHandler) storeConflictDeclaration(w http.ResponseWriter, r *http.Request, student *database.StudentRecord, reviewerEmail, reviewerName, accessToken string) bool {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return false
+	}
+
+	locale := "lt"
+	if r.URL.Query().Get("lang") == "en" {
+		locale = "en"
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if message := validateConflictDeclarationForm(r, locale); message != "" {
+		templates.ReviewerConflictDeclarationModal(student, nil, accessToken, locale, message).Render(r.Context(), w)
+		return false
+	}
+
+	declaration, err := saveConflictDeclaration(h.db, r, student, reviewerEmail, reviewerName)
+	if err != nil {
+		log.Printf("Error saving conflict declaration for student %d: %v", student.ID, err)
+		http.Error(w, "Failed to save declaration", http.StatusInternalServerError)
+		return false
+	}
+	if !declaration.CanReview() {
+		templates.ReviewerConflictDeclarationModal(student, declaration, accessToken, locale, "").Render(r.Context(), w)
+		return false
+	}
+	return true
+}
+
+// ShowFlaggedAssignments lists flagged reviewer assignments of the department
+func (h *ReviewerConflictHandler) ShowFlaggedAssignments(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleAdmin && user.Role != auth.RoleDepartmentHead) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	query := flaggedAssignmentsQuery + " WHERE d.status != ?"
+	args := []interface{}{database.ConflictStatusClear}
+	if department != "" {
+		query += " AND s.department = ?"
+		args = append(args, department)
+	}
+	query += " ORDER BY d.status = 'flagged' DESC, d.declared_at DESC LIMIT 200"
+
+	var assignments []database.FlaggedReviewerAssignment
+	if err := h.db.Select(&assignments, query, args...); err != nil {
+		log.Printf("Error loading flagged reviewer assignments: %v", err)
+		http.Error(w, "Failed to load assignments", http.StatusInternalServerError)
+		return
+	}
+
+	locale := getLocale(r)
+	templates.ReviewerConflictList(user, locale, assignments).Render(r.Context(), w)
+}
+
+// ResolveConflict records the department head decision on a flagged assignment
+func (h *ReviewerConflictHandler) ResolveConflict(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleAdmin && user.Role != auth.RoleDepartmentHead) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid declaration ID", http.StatusBadRequest)
+		return
+	}
+
+	locale := getLocale(r)
+	assignment, err := h.getFlaggedAssignment(id)
+	if err != nil {
+		http.Error(w, "Declaration not found", http.StatusNotFound)
+		return
+	}
+	if department, err := departmentHeadScope(h.db, user); err != nil || (department != "" && assignment.Department != department) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	status := database.ConflictStatusRejected
+	switch r.FormValue("decision") {
+	case "approve":
+		if assignment.HasFlag(database.ConflictFlagSameAsSupervisor) {
+			renderTemplateError(w, fmt.Sprintf("#conflict-error-%d", id), reportLabel(locale,
+				"Vadovas negali būti savo studento recenzentu", "The supervisor cannot review their own student"))
+			return
+		}
+		status = database.ConflictStatusApproved
+	case "reject":
+	default:
+		http.Error(w, "Invalid decision", http.StatusBadRequest)
+		return
+	}
+
+	note := strings.TrimSpace(r.FormValue("note"))
+	_, err = h.db.Exec(`
+        UPDATE reviewer_conflict_declarations
+        SET status = ?, reviewed_by = ?, reviewed_at = NOW(), review_note = ?
+        WHERE id = ?`,
+		status, user.Email, database.NullableString(note), id)
+	if err != nil {
+		log.Printf("Error resolving reviewer conflict %d: %v", id, err)
+		http.Error(w, "Failed to save decision", http.StatusInternalServerError)
+		return
+	}
+
+	details := fmt.Sprintf(`{"student_id":%d,"reviewer":%q,"status":%q}`, assignment.StudentRecordID, assignment.ReviewerEmail, status)
+	database.CreateAuditLog(database.AuditLog{
+		UserEmail:    user.Email,
+		UserRole:     user.Role,
+		Action:       "resolve_reviewer_conflict",
+		ResourceType: "reviewer_conflict_declaration",
+		ResourceID:   database.NullableString(strconv.Itoa(id)),
+		Details:      &details,
+		IPAddress:    database.NullableString(requestIP(r)),
+		UserAgent:    database.NullableString(r.UserAgent()),
+		Success:      true,
+		CreatedAt:    time.Now(),
+	})
+
+	assignment, err = h.getFlaggedAssignment(id)
+	if err != nil {
+		http.Error(w, "Declaration not found", http.StatusNotFound)
+		return
+	}
+	templates.ReviewerConflictRow(*assignment, locale).Render(r.Context(), w)
+}
+
+const flaggedAssignmentsQuery = `
+        SELECT d.*, s.student_name, s.student_lastname, s.student_group, s.study_program,
+               s.department, s.supervisor_email, s.reviewer_email AS current_reviewer, s.final_project_title
+        FROM reviewer_conflict_declarations d
+        JOIN student_records s ON s.id = d.student_record_id`
+
+func (h *ReviewerConflictHandler) getFlaggedAssignment(id int) (*database.FlaggedReviewerAssignment, error) {
+	var assignment database.FlaggedReviewerAssignment
+	err := h.db.Get(&assignment, flaggedAssignmentsQuery+" WHERE d.id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
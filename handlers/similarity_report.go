@@ -0,0 +1,250 @@
+// handlers/similarity_report.go - Similarity (plagiarism) report attachments for supervisor reports
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/similarity"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	DocumentTypeSimilarityReport = "similarity_report"
+	similarityReportsDir         = "uploads/similarity_reports"
+)
+
+// UploadSimilarityReport stores an attached similarity report, parses its percentages
+// and returns them to the modal for pre-filling the form
+func (h *SupervisorReportHandler) UploadSimilarityReport(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	student, err := h.getStudentRecord(studentID)
+	if err != nil {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+	if user.Role != auth.RoleAdmin && (user.Role != auth.RoleSupervisor || student.SupervisorEmail != user.Email) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	language := h.getLanguageFromRequest(r)
+
+	if state, err := getReportState(h.db, reportKindSupervisor, studentID, false); err == nil && state.IsSigned {
+		renderTemplateError(w, "#similarity-report-result", reportLabel(language, "Pasirašyto atsiliepimo keisti negalima", "The report is already signed"))
+		return
+	}
+
+	if err := r.ParseMultipartForm(similarity.MaxFileSize); err != nil {
+		renderTemplateError(w, "#similarity-report-result", reportLabel(language, "Failas per didelis", "File too large"))
+		return
+	}
+	file, header, err := r.FormFile("similarity_report")
+	if err != nil {
+		renderTemplateError(w, "#similarity-report-result", reportLabel(language, "Nepasirinktas failas", "No file selected"))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, similarity.MaxFileSize+1))
+	if err != nil {
+		http.Error(w, "Failed to read file", http.StatusInternalServerError)
+		return
+	}
+
+	parsed, err := similarity.Parse(header.Filename, data)
+	if err != nil {
+		log.Printf("Similarity report %q for student %d not parsed: %v", header.Filename, studentID, err)
+		renderTemplateError(w, "#similarity-report-result", reportLabel(language, "Nepavyko nuskaityti ataskaitos: ", "Could not read the report: ")+err.Error())
+		return
+	}
+
+	tx, err := h.db.Beginx()
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	report, filePath, err := storeSimilarityReport(tx, studentID, header.Filename, header.Header.Get("Content-Type"), data, parsed, user.Email)
+	if err != nil {
+		log.Printf("Error storing similarity report for student %d: %v", studentID, err)
+		http.Error(w, "Failed to store report", http.StatusInternalServerError)
+		return
+	}
+
+	var mismatches []string
+	if existing, err := getReportState(tx, reportKindSupervisor, studentID, true); err == nil {
+		mismatches, err = updateSimilarityCheck(tx, existing.Snapshot.(*database.SupervisorReport))
+		if err != nil {
+			os.Remove(filePath)
+			log.Printf("Error updating similarity check for student %d: %v", studentID, err)
+			http.Error(w, "Failed to store report", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		os.Remove(filePath)
+		http.Error(w, "Failed to store report", http.StatusInternalServerError)
+		return
+	}
+
+	details := fmt.Sprintf(`{"student_id":%d,"document_id":%d,"format":%q}`, studentID, report.DocumentID, report.SourceFormat)
+	database.CreateAuditLog(database.AuditLog{
+		UserEmail:    user.Email,
+		UserRole:     user.Role,
+		Action:       "upload_similarity_report",
+		ResourceType: "supervisor_report",
+		ResourceID:   database.NullableString(strconv.Itoa(studentID)),
+		Details:      &details,
+		IPAddress:    database.NullableString(h.getClientIP(r)),
+		UserAgent:    database.NullableString(r.UserAgent()),
+		Success:      true,
+		CreatedAt:    time.Now(),
+	})
+
+	// The modal script fills the similarity fields from this event
+	trigger, _ := json.Marshal(map[string]interface{}{"similarityReportParsed": parsed.Values})
+	w.Header().Set("HX-Trigger", string(trigger))
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.SimilarityReportSummary(report, mismatches, language).Render(r.Context(), w); err != nil {
+		log.Printf("Error rendering similarity report summary: %v", err)
+	}
+}
+
+// storeSimilarityReport saves the file, registers it as a document and records the parsed values
+func storeSimilarityReport(tx *sqlx.Tx, studentID int, filename, mimeType string, data []byte, parsed *similarity.Report, uploadedBy string) (*database.SimilarityReport, string, error) {
+	if err := os.MkdirAll(similarityReportsDir, 0755); err != nil {
+		return nil, "", err
+	}
+	filePath := filepath.Join(similarityReportsDir,
+		fmt.Sprintf("similarity_%d_%d%s", studentID, time.Now().UnixNano(), strings.ToLower(filepath.Ext(filename))))
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return nil, "", err
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	result, err := tx.Exec(`
+        INSERT INTO documents (student_record_id, document_type, file_path, original_filename, file_size, mime_type)
+        VALUES (?, ?, ?, ?, ?, ?)`,
+		studentID, DocumentTypeSimilarityReport, filePath, filename, len(data), mimeType)
+	if err != nil {
+		os.Remove(filePath)
+		return nil, "", err
+	}
+	documentID, _ := result.LastInsertId()
+
+	report := &database.SimilarityReport{
+		StudentRecordID:  studentID,
+		DocumentID:       int(documentID),
+		SourceFormat:     parsed.Format,
+		UploadedBy:       uploadedBy,
+		CreatedAt:        time.Now(),
+		OriginalFilename: &filename,
+	}
+	for field, target := range map[string]**float64{
+		similarity.FieldOtherMatch: &report.OtherMatch,
+		similarity.FieldOneMatch:   &report.OneMatch,
+		similarity.FieldOwnMatch:   &report.OwnMatch,
+		similarity.FieldJoinMatch:  &report.JoinMatch,
+	} {
+		if value, ok := parsed.Value(field); ok {
+			*target = &value
+		}
+	}
+
+	result, err = tx.Exec(`
+        INSERT INTO similarity_reports
+        (student_record_id, document_id, source_format, other_match, one_match, own_match, join_match, uploaded_by)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		studentID, documentID, report.SourceFormat, report.OtherMatch, report.OneMatch, report.OwnMatch, report.JoinMatch, uploadedBy)
+	if err != nil {
+		os.Remove(filePath)
+		return nil, "", err
+	}
+	id, _ := result.LastInsertId()
+	report.ID = int(id)
+	return report, filePath, nil
+}
+
+// getLatestSimilarityReport returns the most recently attached similarity report of a student
+func getLatestSimilarityReport(q sqlx.Queryer, studentID int) (*database.SimilarityReport, error) {
+	var report database.SimilarityReport
+	err := sqlx.Get(q, &report, `
+        SELECT sr.*, d.original_filename
+        FROM similarity_reports sr
+        JOIN documents d ON d.id = sr.document_id
+        WHERE sr.student_record_id = ?
+        ORDER BY sr.id DESC
+        LIMIT 1`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// similarityMismatches compares the typed percentages with an attached report
+func similarityMismatches(attached *database.SimilarityReport, report *database.SupervisorReport) []string {
+	parsed := similarity.Report{Values: attached.Values()}
+	return parsed.Mismatches(map[string]float64{
+		similarity.FieldOtherMatch: report.OtherMatch,
+		similarity.FieldOneMatch:   report.OneMatch,
+		similarity.FieldOwnMatch:   report.OwnMatch,
+		similarity.FieldJoinMatch:  report.JoinMatch,
+	})
+}
+
+// updateSimilarityCheck links the report to the latest attachment and stores the mismatch flag
+func updateSimilarityCheck(tx *sqlx.Tx, report *database.SupervisorReport) ([]string, error) {
+	attached, err := getLatestSimilarityReport(tx, report.StudentRecordID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mismatches := similarityMismatches(attached, report)
+	_, err = tx.Exec(`
+        UPDATE supervisor_reports SET similarity_report_id = ?, similarity_mismatch = ?
+        WHERE id = ? AND is_signed = FALSE`,
+		attached.ID, len(mismatches) > 0, report.ID)
+	return mismatches, err
+}
+
+// refreshSimilarityCheck re-evaluates the mismatch flag after the report content was saved
+func refreshSimilarityCheck(tx *sqlx.Tx, studentID int) error {
+	state, err := getReportState(tx, reportKindSupervisor, studentID, false)
+	if err != nil {
+		return err
+	}
+	_, err = updateSimilarityCheck(tx, state.Snapshot.(*database.SupervisorReport))
+	return err
+}
@@ -2,65 +2,56 @@
 package handlers
 
 import (
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/codeanalysis"
 	"FinalProjectManagementApp/database"
-	"archive/zip"
-	"bytes"
+	"FinalProjectManagementApp/extract"
+	"FinalProjectManagementApp/githosting"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"io"
 	"log"
-	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
-	"sync"
 	"time"
 )
 
-// Add these structs at the top after your existing imports
-type UploadRequest struct {
-	ID              string
-	ResponseChan    chan *database.SubmissionResult
-	StudentInfo     *database.StudentInfo
-	File            multipart.File
-	Header          *multipart.FileHeader
-	StudentRecordID int
-}
-
-// Update your existing SourceCodeHandler struct
 type SourceCodeHandler struct {
-	db           *sqlx.DB
-	githubConfig *database.GitHubConfig
-	client       *http.Client
+	db       *sqlx.DB
+	provider githosting.Provider
 
-	// ADD these new fields:
-	uploadQueue   chan *UploadRequest
-	activeUploads sync.Map
+	// Upload jobs live in source_upload_jobs; wake signals idle workers that a job was queued
+	wake          chan struct{}
 	maxConcurrent int
+
+	extractLimits extract.Limits
 }
 
-// UPDATE your existing NewSourceCodeHandler function
-func NewSourceCodeHandler(db *sqlx.DB, githubConfig *database.GitHubConfig) *SourceCodeHandler {
+func NewSourceCodeHandler(db *sqlx.DB, provider githosting.Provider) *SourceCodeHandler {
 	handler := &SourceCodeHandler{
-		db:           db,
-		githubConfig: githubConfig,
-		client:       &http.Client{Timeout: 60 * time.Second},
-
-		// ADD these:
-		uploadQueue:   make(chan *UploadRequest, 50),
+		db:            db,
+		provider:      provider,
+		wake:          make(chan struct{}, 1),
 		maxConcurrent: 5,
+		extractLimits: extract.DefaultLimits(),
+	}
+
+	if err := os.MkdirAll(uploadSpoolDir, 0755); err != nil {
+		log.Printf("Warning: Failed to create upload spool directory: %v", err)
 	}
 
-	// ADD: Start upload workers
+	// Workers also pick up jobs left queued or half-processed by a previous run
 	for i := 0; i < handler.maxConcurrent; i++ {
 		go handler.uploadWorker(i)
 	}
@@ -68,37 +59,26 @@ func NewSourceCodeHandler(db *sqlx.DB, githubConfig *database.GitHubConfig) *Sou
 	return handler
 }
 
-// ADD this new method
-func (h *SourceCodeHandler) uploadWorker(workerID int) {
-	log.Printf("Upload worker %d started", workerID)
-
-	for req := range h.uploadQueue {
-		log.Printf("Worker %d processing upload for student %s", workerID, req.StudentInfo.StudentID)
-
-		// Simple delay to avoid overwhelming GitHub
-		time.Sleep(10 * time.Second)
-
-		result := h.processSourceCodeUpload(req.StudentRecordID, req.ID, req.File, req.Header, req.StudentInfo)
-
-		req.ResponseChan <- result
-		close(req.ResponseChan)
-		h.activeUploads.Delete(req.ID)
+// SetExtractionLimits replaces the default limits for uploaded archives
+func (h *SourceCodeHandler) SetExtractionLimits(limits extract.Limits) {
+	h.extractLimits = limits
+}
 
-		log.Printf("Worker %d completed upload for student %s", workerID, req.StudentInfo.StudentID)
-	}
+// uploadRequest is an authorized upload: who makes it and the student it is stored under
+type uploadRequest struct {
+	User      *auth.AuthenticatedUser
+	Target    *uploadTarget
+	IP        *string
+	UserAgent *string
 }
 
-// REPLACE your existing UploadSourceCode function with this:
+// UploadSourceCode validates the upload, spools the archive and queues it for processing
 func (h *SourceCodeHandler) UploadSourceCode(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-
-	// Check system load
-	queueLen := len(h.uploadQueue)
-	if queueLen >= 45 { // Near capacity
-		h.renderJSONError(w, fmt.Sprintf("System busy, please try again in 10 minutes. Queue length: %d", queueLen))
+	if h.queueFull(w) {
 		return
 	}
 
@@ -108,25 +88,11 @@ func (h *SourceCodeHandler) UploadSourceCode(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	studentInfo := &database.StudentInfo{
-		Name:        strings.TrimSpace(r.FormValue("name")),
-		StudentID:   strings.TrimSpace(r.FormValue("student_id")),
-		Email:       strings.TrimSpace(r.FormValue("email")),
-		ThesisTitle: strings.TrimSpace(r.FormValue("thesis_title")),
-	}
-
-	if errors := h.validateStudentInfo(studentInfo); len(errors) > 0 {
-		h.renderJSONError(w, strings.Join(errors, "; "))
-		return
-	}
-
-	// Check for duplicate upload
-	uploadKey := fmt.Sprintf("%s-%s", studentInfo.StudentID, studentInfo.Email)
-	if _, exists := h.activeUploads.LoadOrStore(uploadKey, true); exists {
-		h.renderJSONError(w, "Upload already in progress for this student. Please wait.")
+	req, ok := h.authorizeUpload(w, r)
+	if !ok {
 		return
 	}
-	defer h.activeUploads.Delete(uploadKey) // Clean up on any early return
+	studentRecordID := req.Target.Record.ID
 
 	file, header, err := r.FormFile("source_code")
 	if err != nil {
@@ -135,121 +101,186 @@ func (h *SourceCodeHandler) UploadSourceCode(w http.ResponseWriter, r *http.Requ
 	}
 	defer file.Close()
 
-	if !strings.HasSuffix(strings.ToLower(header.Filename), ".zip") {
-		h.renderJSONError(w, "Only ZIP files are accepted")
+	extension := extract.Extension(header.Filename)
+	if extension == "" {
+		h.renderJSONError(w, "Only ZIP, TAR, TAR.GZ and TGZ archives are accepted")
 		return
 	}
 
-	studentRecordID, err := h.findStudentRecordID(studentInfo)
-	if err != nil {
-		h.renderError(w, "Student not found", err)
+	if h.uploadPending(w, studentRecordID) {
 		return
 	}
 
-	uploadID := uuid.New().String()
-
-	// For small queues, process immediately
-	if queueLen < 3 {
-		result := h.processSourceCodeUpload(studentRecordID, uploadID, file, header, studentInfo)
-		h.renderJSONResult(w, result)
+	job, err := h.enqueueUpload(studentRecordID, req.Target.StudentInfo(), req.User, file, header, extension, req.IP, req.UserAgent)
+	if err != nil {
+		h.renderError(w, "Failed to queue upload", err)
 		return
 	}
+	h.auditUpload(req.User.Email, req.User.Role, "upload_source_code", job.SubmissionID, map[string]interface{}{
+		"student_record_id": studentRecordID,
+		"student_email":     req.Target.Record.StudentEmail,
+		"on_behalf":         req.Target.OnBehalf,
+		"filename":          header.Filename,
+		"file_size":         header.Size,
+	}, req.IP, req.UserAgent, true)
 
-	// For larger queues, queue the request
-	responseChan := make(chan *database.SubmissionResult, 1)
+	h.renderQueued(w, job)
+}
 
-	uploadReq := &UploadRequest{
-		ID:              uploadID,
-		ResponseChan:    responseChan,
-		StudentInfo:     studentInfo,
-		File:            file,
-		Header:          header,
-		StudentRecordID: studentRecordID,
+// queueFull rejects new uploads while the queue is near capacity
+func (h *SourceCodeHandler) queueFull(w http.ResponseWriter) bool {
+	queueLen := h.uploadJobCounts()[database.UploadJobQueued]
+	if queueLen >= uploadQueueLimit { // Near capacity
+		h.renderJSONError(w, fmt.Sprintf("System busy, please try again in 10 minutes. Queue length: %d", queueLen))
+		return true
 	}
+	return false
+}
 
-	select {
-	case h.uploadQueue <- uploadReq:
-		queuePos := len(h.uploadQueue)
+// authorizeUpload resolves the signed-in uploader and the target student, answering the request when it is denied
+func (h *SourceCodeHandler) authorizeUpload(w http.ResponseWriter, r *http.Request) (*uploadRequest, bool) {
+	// The upload identity comes from the session; form fields naming a student are ignored
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+	ip, userAgent := database.NullableString(requestIP(r)), database.NullableString(r.UserAgent())
 
+	target, status, err := h.resolveUploadTarget(r, user)
+	if err != nil {
+		if status == http.StatusForbidden {
+			h.auditUpload(user.Email, user.Role, "upload_source_code_denied", r.FormValue("student_record_id"),
+				map[string]interface{}{"reason": err.Error()}, ip, userAgent, false)
+		}
+		log.Printf("Source code upload rejected for %s: %v", user.Email, err)
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success":        true,
-			"message":        fmt.Sprintf("Upload queued successfully. Position: %d", queuePos),
-			"submission_id":  uploadID,
-			"queue_position": queuePos,
-			"estimated_wait": fmt.Sprintf("%d minutes", queuePos*2),
-			"status":         "queued",
-		})
-
-		// Process in background
-		go func() {
-			select {
-			case result := <-responseChan:
-				log.Printf("Background upload completed for %s: success=%v", studentInfo.StudentID, result.Success)
-			case <-time.After(30 * time.Minute):
-				log.Printf("Upload timeout for student %s", studentInfo.StudentID)
-			}
-		}()
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": false, "error": err.Error()})
+		return nil, false
+	}
+	return &uploadRequest{User: user, Target: target, IP: ip, UserAgent: userAgent}, true
+}
 
-	case <-time.After(5 * time.Second):
-		h.renderJSONError(w, "System overloaded. Please try again later.")
+// uploadPending rejects a second upload while one for the same student is queued or processing
+func (h *SourceCodeHandler) uploadPending(w http.ResponseWriter, studentRecordID int) bool {
+	pending, err := h.hasPendingUpload(studentRecordID)
+	if err != nil {
+		h.renderError(w, "Failed to check upload queue", err)
+		return true
+	}
+	if pending {
+		h.renderJSONError(w, "Upload already in progress for this student. Please wait.")
+		return true
 	}
+	return false
+}
+
+// renderQueued answers an accepted upload with its queue position and status URL
+func (h *SourceCodeHandler) renderQueued(w http.ResponseWriter, job *database.SourceUploadJob) {
+	queuePos := h.queuePosition(job)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":        true,
+		"message":        fmt.Sprintf("Upload queued successfully. Position: %d", queuePos),
+		"submission_id":  job.SubmissionID,
+		"queue_position": queuePos,
+		"estimated_wait": fmt.Sprintf("%d minutes", queuePos*2),
+		"status":         job.Status,
+		"status_url":     "/api/source-code/status?id=" + job.SubmissionID,
+	})
 }
 
 // ===== MAIN PROCESSING LOGIC =====
-func (h *SourceCodeHandler) processSourceCodeUpload(studentRecordID int, submissionID string, file multipart.File, header *multipart.FileHeader, studentInfo *database.StudentInfo) *database.SubmissionResult {
-	// Create temp directories
-	// ADD: Create unique directories to avoid conflicts
-	timestamp := time.Now().UnixNano()
-	uniqueID := fmt.Sprintf("%s_%d", submissionID[:8], timestamp) // Shorter unique ID
 
-	uploadDir := filepath.Join("uploads", "upload_"+uniqueID)
+// processSourceCodeUpload extracts, validates and pushes a spooled upload. The second result is true when the
+// failure is transient (git host or network) and the job may be retried.
+func (h *SourceCodeHandler) processSourceCodeUpload(job *database.SourceUploadJob, progress func(stage string, percent int)) (*database.SubmissionResult, bool) {
+	studentInfo := job.StudentInfo()
+	submissionID := job.SubmissionID
+
+	// Unique directory per attempt to avoid conflicts
+	uniqueID := fmt.Sprintf("%s_%d", submissionID[:8], time.Now().UnixNano())
 	extractDir := filepath.Join("uploads", "extract_"+uniqueID)
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		return &database.SubmissionResult{Success: false, Error: "Failed to create upload directory"}
-	}
-	defer os.RemoveAll(uploadDir)
 	defer os.RemoveAll(extractDir)
 
-	// Save ZIP file
-	zipPath := filepath.Join(uploadDir, header.Filename)
-	if err := h.saveFile(file, zipPath); err != nil {
-		return &database.SubmissionResult{Success: false, Error: "Failed to save file"}
+	// Extract and validate with filtering; imported repositories go through the same filter as archives
+	var filterInfo *database.FilterInfo
+	var imported *gitImport
+	var err error
+	if job.IsGitImport() {
+		progress("importing", 5)
+		ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+		defer cancel()
+		importDir := filepath.Join("uploads", "import_"+uniqueID)
+		defer os.RemoveAll(importDir)
+		imported, err = h.cloneImport(ctx, job, importDir)
+		if err != nil {
+			return &database.SubmissionResult{Success: false, Error: "Failed to import repository: " + err.Error()}, isRetryableImportError(err)
+		}
+		progress("extracting", 10)
+		filterInfo, err = filterInfoFrom(extract.Walk(extractDir, h.extractLimits, isIgnoredSourceFile, imported.walk(ctx)))
+	} else {
+		progress("extracting", 10)
+		filterInfo, err = filterInfoFrom(extract.Archive(job.SpoolPath, extractDir, h.extractLimits, isIgnoredSourceFile))
 	}
-
-	// Extract and validate with filtering
-	filterInfo, err := h.extractZip(zipPath, extractDir)
 	if err != nil {
-		return &database.SubmissionResult{Success: false, Error: "Failed to extract ZIP: " + err.Error()}
+		var rejected *extract.Error
+		if errors.As(err, &rejected) {
+			return &database.SubmissionResult{
+				Success:    false,
+				Error:      "Archive rejected: " + rejected.Error(),
+				Validation: archiveRejection(rejected),
+			}, false
+		}
+		return &database.SubmissionResult{Success: false, Error: "Failed to extract archive: " + err.Error()}, false
 	}
 
+	progress("validating", 30)
 	validation := h.validateSubmission(extractDir)
 	if !validation.Valid {
 		return &database.SubmissionResult{
 			Success:    false,
 			Error:      "Validation failed",
 			Validation: validation,
-		}
+		}, false
 	}
 
-	// Create or update GitHub repository (one per student)
+	progress("analyzing", 35)
+	analysis := h.analyzeSubmission(extractDir, filterInfo, validation)
+
+	// Create or update the hosted repository (one per student)
+	progress("repository", 45)
 	repoInfo, err := h.createOrUpdateRepository(studentInfo)
 	if err != nil {
-		return &database.SubmissionResult{Success: false, Error: "Failed to create/update repository: " + err.Error()}
+		return &database.SubmissionResult{Success: false, Error: "Failed to create/update repository: " + err.Error()}, true
 	}
 
 	// Upload code using Git
-	commitInfo, err := h.uploadToGit(repoInfo, extractDir, studentInfo)
+	progress("pushing", 60)
+	commitInfo, err := h.uploadToGit(repoInfo, extractDir, studentInfo, imported)
 	if err != nil {
-		return &database.SubmissionResult{Success: false, Error: "Failed to upload code: " + err.Error()}
+		return &database.SubmissionResult{Success: false, Error: "Failed to upload code: " + err.Error()}, true
 	}
 
 	// Save to database
-	documentID, err := h.saveToDatabase(studentRecordID, submissionID, header.Filename, header.Size, repoInfo, commitInfo)
+	progress("saving", 90)
+	documentID, err := h.saveToDatabase(job, repoInfo, commitInfo, analysis)
 	if err != nil {
 		log.Printf("Warning: Failed to save to database: %v", err)
 	}
 
+	// Fingerprints for code similarity checks; older submissions are fingerprinted from their archive on demand
+	if documentID > 0 {
+		prints, err := fingerprintDirectory(extractDir)
+		if err == nil {
+			err = storeFingerprints(h.db, documentID, job.StudentRecordID, prints)
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to fingerprint submission %s: %v", submissionID, err)
+		}
+	}
+
 	return &database.SubmissionResult{
 		Success:        true,
 		Message:        "Thesis source code uploaded successfully",
@@ -259,7 +290,26 @@ func (h *SourceCodeHandler) processSourceCodeUpload(studentRecordID int, submiss
 		CommitInfo:     commitInfo,
 		DocumentID:     documentID,
 		FilterInfo:     filterInfo,
+		Analysis:       analysis,
+	}, false
+}
+
+// analyzeSubmission runs the code analyzers over the extracted files and the filtered out entries.
+// The report is informational: a failing analysis never blocks the upload.
+func (h *SourceCodeHandler) analyzeSubmission(extractDir string, filterInfo *database.FilterInfo, validation *database.ValidationResult) *codeanalysis.Report {
+	report, err := codeanalysis.Run(extractDir, filterInfo.Skipped, codeanalysis.DefaultAnalyzers()...)
+	if err != nil {
+		log.Printf("Warning: code analysis failed: %v", err)
+		return nil
 	}
+
+	for _, secret := range report.Secrets {
+		if !secret.Excluded {
+			validation.Warnings = append(validation.Warnings,
+				fmt.Sprintf("Possible committed secret (%s): %s", secret.Kind, secret.Location()))
+		}
+	}
+	return report
 }
 
 // ===== REPOSITORY MANAGEMENT =====
@@ -295,102 +345,54 @@ func (h *SourceCodeHandler) createOrUpdateRepository(studentInfo *database.Stude
 }
 
 func (h *SourceCodeHandler) getExistingRepository(repoName string) (*database.RepositoryInfo, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", h.githubConfig.Organization, repoName)
-
-	req, _ := http.NewRequest("GET", url, nil)
-	req.Header.Set("Authorization", "Bearer "+h.githubConfig.PAT)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-
-	resp, err := h.client.Do(req)
+	repo, err := h.provider.GetRepository(context.Background(), repoName)
 	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("repository does not exist")
-	}
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get repository info: %s", string(body))
-	}
-
-	var repoResponse map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&repoResponse); err != nil {
-		return nil, err
+		if err == githosting.ErrNotFound {
+			return nil, fmt.Errorf("repository does not exist")
+		}
+		return nil, fmt.Errorf("failed to get repository info: %w", err)
 	}
-
-	return &database.RepositoryInfo{
-		ID:        fmt.Sprintf("%.0f", repoResponse["id"].(float64)),
-		Name:      repoResponse["name"].(string),
-		WebURL:    repoResponse["html_url"].(string),
-		RemoteURL: repoResponse["clone_url"].(string),
-		CloneURL:  repoResponse["clone_url"].(string),
-	}, nil
+	return toRepositoryInfo(repo), nil
 }
 
 func (h *SourceCodeHandler) createNewRepository(repoName string, studentInfo *database.StudentInfo) (*database.RepositoryInfo, error) {
-	payload := map[string]interface{}{
-		"name":    repoName,
-		"private": true,
-		"description": fmt.Sprintf("Final thesis: %s by %s (%s)",
-			studentInfo.ThesisTitle, studentInfo.Name, studentInfo.StudentID),
-		"auto_init":      true,
-		"default_branch": "main",
-	}
-
-	jsonData, _ := json.Marshal(payload)
-	url := fmt.Sprintf("https://api.github.com/orgs/%s/repos", h.githubConfig.Organization)
-
-	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	req.Header.Set("Authorization", "Bearer "+h.githubConfig.PAT)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "Thesis-Management-System/1.0")
+	description := fmt.Sprintf("Final thesis: %s by %s (%s)",
+		studentInfo.ThesisTitle, studentInfo.Name, studentInfo.StudentID)
 
-	resp, err := h.client.Do(req)
+	repo, err := h.provider.CreateRepository(context.Background(), repoName, description)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make GitHub API request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create GitHub repository (status %d): %s", resp.StatusCode, string(body))
+		return nil, err
 	}
 
-	var repoResponse map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&repoResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode GitHub API response: %w", err)
-	}
+	repoInfo := toRepositoryInfo(repo)
+	log.Printf("Created %s repository: %s", h.provider.Name(), repoInfo.WebURL)
+	return repoInfo, nil
+}
 
-	repoInfo := &database.RepositoryInfo{
-		ID:        fmt.Sprintf("%.0f", repoResponse["id"].(float64)),
-		Name:      repoResponse["name"].(string),
-		WebURL:    repoResponse["html_url"].(string),
-		RemoteURL: repoResponse["clone_url"].(string),
-		CloneURL:  repoResponse["clone_url"].(string),
+func toRepositoryInfo(repo *githosting.Repository) *database.RepositoryInfo {
+	return &database.RepositoryInfo{
+		ID:        repo.ID,
+		Name:      repo.Name,
+		WebURL:    repo.WebURL,
+		RemoteURL: repo.CloneURL,
+		CloneURL:  repo.CloneURL,
 	}
-
-	log.Printf("Created GitHub repository: %s", repoInfo.WebURL)
-	return repoInfo, nil
 }
 
 // ===== GIT OPERATIONS =====
-func (h *SourceCodeHandler) uploadToGit(repoInfo *database.RepositoryInfo, sourcePath string, studentInfo *database.StudentInfo) (*database.CommitInfo, error) {
+// uploadToGit commits the filtered sources to a new submission branch and to main. An imported repository's
+// filtered history becomes the base of the submission branch and is merged into main, so its commits stay reachable.
+func (h *SourceCodeHandler) uploadToGit(repoInfo *database.RepositoryInfo, sourcePath string, studentInfo *database.StudentInfo, imported *gitImport) (*database.CommitInfo, error) {
 	tempDir := filepath.Join("uploads", "git_"+uuid.New().String())
 	defer os.RemoveAll(tempDir)
 
-	// Clone existing repository
-	cloneURL := strings.Replace(repoInfo.CloneURL, "https://", fmt.Sprintf("https://%s@", h.githubConfig.PAT), 1)
+	ctx := context.Background()
+	hosted := &githosting.Repository{ID: repoInfo.ID, Name: repoInfo.Name, WebURL: repoInfo.WebURL, CloneURL: repoInfo.CloneURL}
 
-	repo, err := git.PlainClone(tempDir, false, &git.CloneOptions{
-		URL:  cloneURL,
-		Auth: &githttp.BasicAuth{Username: h.githubConfig.PAT, Password: ""},
-	})
+	// Clone existing repository
+	repo, err := h.provider.Clone(ctx, hosted, tempDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to clone repository: %w", err)
+		return nil, err
 	}
 
 	worktree, err := repo.Worktree()
@@ -402,21 +404,25 @@ func (h *SourceCodeHandler) uploadToGit(repoInfo *database.RepositoryInfo, sourc
 	submissionTime := time.Now().Format("20060102-150405")
 	branchName := fmt.Sprintf("submission-%s", submissionTime)
 
-	// Create new branch from current main
+	// Create new branch from current main, or from the imported commit
 	branchRef := plumbing.NewBranchReferenceName(branchName)
 	headRef, err := repo.Head()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get HEAD: %w", err)
 	}
 
-	ref := plumbing.NewHashReference(branchRef, headRef.Hash())
-	err = repo.Storer.SetReference(ref)
+	if imported != nil {
+		err = fetchImportedHistory(ctx, repo, imported, branchRef)
+	} else {
+		err = repo.Storer.SetReference(plumbing.NewHashReference(branchRef, headRef.Hash()))
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create branch reference: %w", err)
 	}
 
 	err = worktree.Checkout(&git.CheckoutOptions{
 		Branch: branchRef,
+		Force:  imported != nil,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to checkout branch: %w", err)
@@ -449,23 +455,20 @@ func (h *SourceCodeHandler) uploadToGit(repoInfo *database.RepositoryInfo, sourc
 	commitMessage := fmt.Sprintf("Thesis submission %s - %s (%s)",
 		submissionTime, studentInfo.Name, studentInfo.StudentID)
 
-	_, err = worktree.Commit(commitMessage, &git.CommitOptions{
+	submissionCommit, err := worktree.Commit(commitMessage, &git.CommitOptions{
 		Author: &object.Signature{
 			Name:  studentInfo.Name,
 			Email: studentInfo.Email,
 			When:  time.Now(),
 		},
+		AllowEmptyCommits: imported != nil,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to commit: %w", err)
 	}
 
 	// Push the submission branch (for history)
-	err = repo.Push(&git.PushOptions{
-		RemoteName: "origin",
-		Auth:       &githttp.BasicAuth{Username: h.githubConfig.PAT, Password: ""},
-		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branchName, branchName))},
-	})
+	err = h.provider.Push(ctx, hosted, repo, branchName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to push submission branch: %w", err)
 	}
@@ -473,7 +476,7 @@ func (h *SourceCodeHandler) uploadToGit(repoInfo *database.RepositoryInfo, sourc
 	// NOW UPDATE MAIN BRANCH WITH LATEST CONTENT
 	// Checkout main branch
 	err = worktree.Checkout(&git.CheckoutOptions{
-		Branch: plumbing.NewBranchReferenceName("main"),
+		Branch: plumbing.NewBranchReferenceName(githosting.DefaultBranch),
 		Force:  true,
 	})
 	if err != nil {
@@ -505,22 +508,24 @@ func (h *SourceCodeHandler) uploadToGit(repoInfo *database.RepositoryInfo, sourc
 	mainCommitMessage := fmt.Sprintf("Latest submission: %s (%s) - %s",
 		studentInfo.Name, studentInfo.StudentID, time.Now().Format("Jan 2, 2006 15:04"))
 
-	mainCommit, err := worktree.Commit(mainCommitMessage, &git.CommitOptions{
+	mainOptions := &git.CommitOptions{
 		Author: &object.Signature{
 			Name:  studentInfo.Name,
 			Email: studentInfo.Email,
 			When:  time.Now(),
 		},
-	})
+	}
+	if imported != nil {
+		mainOptions.Parents = []plumbing.Hash{headRef.Hash(), submissionCommit}
+		mainOptions.AllowEmptyCommits = true
+	}
+	mainCommit, err := worktree.Commit(mainCommitMessage, mainOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to commit to main: %w", err)
 	}
 
 	// Push main branch
-	err = repo.Push(&git.PushOptions{
-		RemoteName: "origin",
-		Auth:       &githttp.BasicAuth{Username: h.githubConfig.PAT, Password: ""},
-	})
+	err = h.provider.Push(ctx, hosted, repo, githosting.DefaultBranch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to push main: %w", err)
 	}
@@ -532,6 +537,7 @@ func (h *SourceCodeHandler) uploadToGit(repoInfo *database.RepositoryInfo, sourc
 		Timestamp:  time.Now(),
 		FilesCount: fileCount,
 		CommitID:   mainCommit.String(),
+		Branch:     branchName,
 	}, nil
 }
 
@@ -588,9 +594,9 @@ For questions about this thesis implementation:
 		studentInfo.ThesisTitle,
 		studentInfo.Name,
 		studentInfo.Email,
-		h.githubConfig.Organization,
+		h.provider.Owner(),
 		h.generateRepoName(studentInfo),
-		h.githubConfig.Organization,
+		h.provider.Owner(),
 		time.Now().Format("January 2, 2006 at 15:04"))
 }
 
@@ -632,7 +638,8 @@ func (h *SourceCodeHandler) clearRepositoryContent(repoPath string) {
 }
 
 // ===== FILE FILTERING LOGIC =====
-func (h *SourceCodeHandler) shouldIgnoreFile(path string, info os.FileInfo) bool {
+// isIgnoredSourceFile reports whether an upload entry is build output, a dependency directory, a hidden file or a large media file
+func isIgnoredSourceFile(path string, size int64) bool {
 	parts := strings.Split(filepath.ToSlash(path), "/")
 
 	ignoreDirs := map[string]bool{
@@ -683,7 +690,7 @@ func (h *SourceCodeHandler) shouldIgnoreFile(path string, info os.FileInfo) bool
 		}
 	}
 
-	if info.Size() > 1024*1024 {
+	if size > 1024*1024 {
 		largeMediaExtensions := map[string]bool{
 			".mp4": true, ".avi": true, ".mov": true, ".wmv": true, ".flv": true,
 			".mp3": true, ".wav": true, ".flac": true, ".ape": true,
@@ -703,26 +710,6 @@ func (h *SourceCodeHandler) shouldIgnoreFile(path string, info os.FileInfo) bool
 }
 
 // ===== VALIDATION FUNCTIONS =====
-func (h *SourceCodeHandler) validateStudentInfo(info *database.StudentInfo) []string {
-	var errors []string
-	if strings.TrimSpace(info.Name) == "" {
-		errors = append(errors, "Student name is required")
-	}
-	if strings.TrimSpace(info.StudentID) == "" {
-		errors = append(errors, "Student ID is required")
-	}
-	if strings.TrimSpace(info.Email) == "" {
-		errors = append(errors, "Email is required")
-	}
-	if strings.TrimSpace(info.ThesisTitle) == "" {
-		errors = append(errors, "Thesis title is required")
-	}
-	if info.Email != "" && !strings.Contains(info.Email, "@") {
-		errors = append(errors, "Invalid email format")
-	}
-	return errors
-}
-
 func (h *SourceCodeHandler) validateSubmission(sourcePath string) *database.ValidationResult {
 	result := &database.ValidationResult{
 		Valid:    true,
@@ -801,62 +788,22 @@ func (h *SourceCodeHandler) saveFile(src io.Reader, dst string) error {
 	return err
 }
 
-func (h *SourceCodeHandler) extractZip(src, dst string) (*database.FilterInfo, error) {
-	reader, err := zip.OpenReader(src)
+// filterInfoFrom summarizes an extraction of an upload, leaving out ignored files
+func filterInfoFrom(result *extract.Result, err error) (*database.FilterInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer reader.Close()
 
-	if err := os.MkdirAll(dst, 0755); err != nil {
-		return nil, err
+	filterInfo := &database.FilterInfo{
+		TotalFilesInZip:  result.Entries,
+		FilesSkipped:     len(result.Skipped),
+		FilesAfterFilter: result.Extracted,
+		OriginalSize:     result.DeclaredSize,
+		SizeAfterFilter:  result.ExtractedSize,
 	}
-
-	filterInfo := &database.FilterInfo{}
-
-	for _, file := range reader.File {
-		path := filepath.Join(dst, file.Name)
-
-		if !strings.HasPrefix(path, filepath.Clean(dst)+string(os.PathSeparator)) {
-			return nil, fmt.Errorf("invalid file path: %s", file.Name)
-		}
-
-		filterInfo.TotalFilesInZip++
-		filterInfo.OriginalSize += file.FileInfo().Size()
-
-		if h.shouldIgnoreFile(file.Name, file.FileInfo()) {
-			filterInfo.FilesSkipped++
-			continue
-		}
-
-		filterInfo.FilesAfterFilter++
-		filterInfo.SizeAfterFilter += file.FileInfo().Size()
-
-		if file.FileInfo().IsDir() {
-			os.MkdirAll(path, file.FileInfo().Mode())
-			continue
-		}
-
-		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-			return nil, err
-		}
-
-		fileReader, err := file.Open()
-		if err != nil {
-			return nil, err
-		}
-
-		targetFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.FileInfo().Mode())
-		if err != nil {
-			fileReader.Close()
-			return nil, err
-		}
-
-		_, err = io.Copy(targetFile, fileReader)
-		fileReader.Close()
-		targetFile.Close()
-		if err != nil {
-			return nil, err
+	for _, skipped := range result.Skipped {
+		if !strings.HasSuffix(skipped.Path, "/") {
+			filterInfo.Skipped = append(filterInfo.Skipped, codeanalysis.ExcludedFile{Path: skipped.Path, Size: skipped.Size})
 		}
 	}
 
@@ -867,6 +814,22 @@ func (h *SourceCodeHandler) extractZip(src, dst string) (*database.FilterInfo, e
 	return filterInfo, nil
 }
 
+// archiveRejection reports a rejected archive in the validation result shown to the uploader
+func archiveRejection(rejected *extract.Error) *database.ValidationResult {
+	return &database.ValidationResult{
+		Valid:    false,
+		Warnings: []string{},
+		Errors:   []string{rejected.Error()},
+		Issues: []database.ValidationIssue{{
+			Code:    rejected.Code,
+			Path:    rejected.Entry,
+			Message: rejected.Error(),
+			Limit:   rejected.Limit,
+			Actual:  rejected.Actual,
+		}},
+	}
+}
+
 func (h *SourceCodeHandler) copyFiles(src, dst string) error {
 	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
 		if err != nil || (info.IsDir() && info.Name() == ".git") {
@@ -914,7 +877,7 @@ func (h *SourceCodeHandler) countFiles(dirPath string) int {
 	return count
 }
 
-// ADD these new endpoint methods
+// GetUploadStatus reports the state, progress and, once finished, the result of one submission
 func (h *SourceCodeHandler) GetUploadStatus(w http.ResponseWriter, r *http.Request) {
 	submissionID := r.URL.Query().Get("id")
 	if submissionID == "" {
@@ -922,52 +885,61 @@ func (h *SourceCodeHandler) GetUploadStatus(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	queueLength := len(h.uploadQueue)
-	activeCount := 0
-	h.activeUploads.Range(func(key, value interface{}) bool {
-		activeCount++
-		return true
-	})
-
-	status := map[string]interface{}{
-		"submission_id":  submissionID,
-		"queue_length":   queueLength,
-		"active_uploads": activeCount,
-		"system_status":  "operational",
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
 	}
 
-	if queueLength > 40 {
-		status["system_status"] = "busy"
+	// Jobs of other students are reported as unknown
+	job, err := h.getUploadJob(submissionID)
+	if err == sql.ErrNoRows || (err == nil && !canViewUploadJob(user, job)) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Submission not found",
+		})
+		return
+	}
+	if err != nil {
+		h.renderError(w, "Failed to load upload status", err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(status)
+	json.NewEncoder(w).Encode(h.uploadStatusResponse(job))
 }
 
 func (h *SourceCodeHandler) GetSystemHealth(w http.ResponseWriter, r *http.Request) {
-	activeCount := 0
-	h.activeUploads.Range(func(key, value interface{}) bool {
-		activeCount++
-		return true
-	})
-
-	queueLen := len(h.uploadQueue)
+	counts := h.uploadJobCounts()
+	activeCount := counts[database.UploadJobProcessing]
+	queueLen := counts[database.UploadJobQueued]
 
 	health := map[string]interface{}{
 		"status":          "healthy",
 		"active_uploads":  activeCount,
 		"queue_length":    queueLen,
+		"failed_uploads":  counts[database.UploadJobFailed],
 		"max_concurrent":  h.maxConcurrent,
-		"queue_capacity":  cap(h.uploadQueue),
+		"queue_capacity":  uploadQueueLimit,
 		"load_percentage": fmt.Sprintf("%.1f%%", float64(activeCount)/float64(h.maxConcurrent)*100),
 		"timestamp":       time.Now().Format("2006-01-02 15:04:05"),
 	}
 
+	// Hit and miss counts of the repository browsing cache and its conditional requests to the git host,
+	// for administrators only
+	if user := auth.GetUserFromContext(r.Context()); user != nil && user.Role == auth.RoleAdmin {
+		if cached, ok := h.provider.(*githosting.Cached); ok {
+			health["repository_cache"] = cached.Stats()
+		}
+	}
+
 	if activeCount >= h.maxConcurrent {
 		health["status"] = "busy"
 	}
 
-	if queueLen >= 40 {
+	if queueLen >= uploadQueueBusy {
 		health["status"] = "overloaded"
 	}
 
@@ -1025,9 +997,9 @@ For questions about this thesis implementation:
 		studentInfo.ThesisTitle,
 		studentInfo.Name,
 		studentInfo.Email,
-		h.githubConfig.Organization,
+		h.provider.Owner(),
 		h.generateRepoName(studentInfo),
-		h.githubConfig.Organization,
+		h.provider.Owner(),
 		time.Now().Format("January 2, 2006 at 15:04"))
 }
 
@@ -1064,33 +1036,36 @@ This submission represents the original work of the student listed above. The re
 		studentInfo.ThesisTitle,
 		uuid.New().String(),
 		time.Now().Format("January 2, 2006 at 15:04:05"),
-		h.githubConfig.Organization,
+		h.provider.Owner(),
 		h.generateRepoName(studentInfo),
 		submissionTime,
-		h.githubConfig.Organization)
+		h.provider.Owner())
 }
 
 // ===== DATABASE OPERATIONS =====
-func (h *SourceCodeHandler) findStudentRecordID(studentInfo *database.StudentInfo) (int, error) {
-	var studentRecordID int
-	query := "SELECT id FROM student_records WHERE student_email = ? OR student_number = ?"
-	err := h.db.Get(&studentRecordID, query, studentInfo.Email, studentInfo.StudentID)
-	return studentRecordID, err
-}
+func (h *SourceCodeHandler) saveToDatabase(job *database.SourceUploadJob, repoInfo *database.RepositoryInfo, commitInfo *database.CommitInfo, analysis *codeanalysis.Report) (int, error) {
+	var analysisJSON *string
+	if analysis != nil {
+		if data, err := json.Marshal(analysis); err == nil {
+			analysisJSON = database.NullableString(string(data))
+		}
+	}
 
-func (h *SourceCodeHandler) saveToDatabase(studentRecordID int, submissionID, filename string, fileSize int64, repoInfo *database.RepositoryInfo, commitInfo *database.CommitInfo) (int, error) {
 	query := `
         INSERT INTO documents (
             student_record_id, document_type, file_path, original_filename,
             file_size, mime_type, repository_url, repository_id, commit_id,
-            submission_id, validation_status, upload_status, is_confidential
-        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+            submission_id, validation_status, upload_status, is_confidential,
+            uploaded_by_email, uploaded_by_role, submission_branch, analysis_report
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
 	result, err := h.db.Exec(query,
-		studentRecordID, "thesis_source_code", repoInfo.WebURL, filename,
-		fileSize, "application/zip", repoInfo.WebURL, repoInfo.ID,
-		commitInfo.CommitID, submissionID, "valid", "completed", true)
+		job.StudentRecordID, "thesis_source_code", repoInfo.WebURL, job.OriginalFilename,
+		job.FileSize, uploadMimeType(job), repoInfo.WebURL, repoInfo.ID,
+		commitInfo.CommitID, job.SubmissionID, "valid", "completed", true,
+		database.NullableString(job.UploadedByEmail), database.NullableString(job.UploadedByRole),
+		database.NullableString(commitInfo.Branch), analysisJSON)
 
 	if err != nil {
 		return 0, err
@@ -0,0 +1,521 @@
+// handlers/source_import.go - Importing thesis source code from a public Git repository, keeping its history
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/extract"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+const (
+	// importTimeout bounds cloning, filtering and extracting an imported repository
+	importTimeout = 5 * time.Minute
+	// importMaxDepth is how many commits of the requested ref's history are cloned
+	importMaxDepth = 100
+	// importMaxCommits bounds the history that is rewritten, which merges can make longer than the depth
+	importMaxCommits = 1000
+	// importMaxCloneSize is the budget of a clone when the extraction limits set no total size
+	importMaxCloneSize = 1 << 30
+)
+
+// importRefPattern accepts branch names, tags and commit hashes, but no revision expressions
+var importRefPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]{0,254}$`)
+
+// gitImport is a bare clone of an imported repository. Commit is the requested commit as cloned, History the
+// same commit with its history rewritten through the upload filter, which is what reaches the thesis repository.
+type gitImport struct {
+	Repo    *git.Repository
+	Commit  plumbing.Hash
+	History plumbing.Hash
+}
+
+// ImportSourceCode queues a clone of a public Git repository at the given ref
+func (h *SourceCodeHandler) ImportSourceCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.queueFull(w) {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderError(w, "Failed to parse form data", err)
+		return
+	}
+
+	req, ok := h.authorizeUpload(w, r)
+	if !ok {
+		return
+	}
+	studentRecordID := req.Target.Record.ID
+
+	repoURL, err := validateImportURL(r.FormValue("git_url"))
+	if err != nil {
+		h.renderJSONError(w, err.Error())
+		return
+	}
+	ref := strings.TrimSpace(r.FormValue("git_ref"))
+	if ref != "" && (!importRefPattern.MatchString(ref) || strings.Contains(ref, "..")) {
+		h.renderJSONError(w, "Invalid branch, tag or commit")
+		return
+	}
+
+	if h.uploadPending(w, studentRecordID) {
+		return
+	}
+
+	job, err := h.enqueueImport(studentRecordID, req.Target.StudentInfo(), req.User, repoURL, ref, req.IP, req.UserAgent)
+	if err != nil {
+		h.renderError(w, "Failed to queue import", err)
+		return
+	}
+	h.auditUpload(req.User.Email, req.User.Role, "import_source_code", job.SubmissionID, map[string]interface{}{
+		"student_record_id": studentRecordID,
+		"student_email":     req.Target.Record.StudentEmail,
+		"on_behalf":         req.Target.OnBehalf,
+		"git_url":           repoURL,
+		"git_ref":           ref,
+	}, req.IP, req.UserAgent, true)
+
+	h.renderQueued(w, job)
+}
+
+// validateImportURL accepts public HTTPS repositories only: no credentials, local paths or internal hosts.
+// The host is checked again when the clone connects, since its name may resolve differently by then.
+func validateImportURL(raw string) (string, error) {
+	parsed, err := parseImportURL(raw)
+	if err != nil {
+		return "", err
+	}
+
+	addresses, err := net.LookupIP(parsed.Hostname())
+	if err != nil || len(addresses) == 0 {
+		return "", fmt.Errorf("Git host %s could not be resolved", parsed.Hostname())
+	}
+	for _, ip := range addresses {
+		if !isPublicAddress(ip) {
+			return "", fmt.Errorf("Git host %s is not a public address", parsed.Hostname())
+		}
+	}
+	return parsed.String(), nil
+}
+
+// parseImportURL checks the form of a repository URL without resolving its host
+func parseImportURL(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, errors.New("Git repository URL is required")
+	}
+	if len(raw) > 500 {
+		return nil, errors.New("Git repository URL is too long")
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, errors.New("only https:// Git repository URLs are supported")
+	}
+	if parsed.User != nil {
+		return nil, errors.New("Git repository URL must not contain credentials; only public repositories can be imported")
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return nil, errors.New("Git repository URL must not contain a query or fragment")
+	}
+	return parsed, nil
+}
+
+// importDisplayName is the repository and ref shown where an archive's file name would be
+func importDisplayName(repoURL, ref string) string {
+	name := strings.TrimSuffix(strings.TrimPrefix(repoURL, "https://"), ".git")
+	if ref != "" {
+		name += "@" + ref
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+// cloneImport clones the job's repository with a limited history, within the size budget and the network rules
+// of importTransport, and rewrites that history through the upload filter
+func (h *SourceCodeHandler) cloneImport(ctx context.Context, job *database.SourceUploadJob, dir string) (*gitImport, error) {
+	repoURL, err := parseImportURL(database.StringValue(job.ImportURL))
+	if err != nil {
+		return nil, err
+	}
+
+	maxBytes := h.extractLimits.MaxTotalSize
+	if maxBytes <= 0 {
+		maxBytes = importMaxCloneSize
+	}
+	installImportClient()
+	guard := newImportGuard(maxBytes)
+
+	repo, err := git.PlainCloneContext(withImportGuard(ctx, guard), dir, true, &git.CloneOptions{
+		URL:   repoURL.String(),
+		Depth: importMaxDepth,
+		Tags:  git.AllTags,
+	})
+	if violation := guard.violation(); violation != nil {
+		return nil, violation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := resolveImportRef(repo, database.StringValue(job.ImportRef))
+	if err != nil {
+		return nil, err
+	}
+	history, err := newHistoryFilter(repo.Storer, h.extractLimits, isIgnoredSourceFile).rewrite(ctx, commit)
+	if err != nil {
+		return nil, err
+	}
+	return &gitImport{Repo: repo, Commit: commit, History: history}, nil
+}
+
+// errImportRefNotFound is a ref that is neither a branch, a tag nor a commit of the imported repository
+var errImportRefNotFound = errors.New("branch, tag or commit not found in the repository")
+
+// resolveImportRef finds the commit for a branch, tag or commit hash; an empty ref is the default branch
+func resolveImportRef(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	if ref == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return head.Hash(), nil
+	}
+
+	// A fresh clone only has remote tracking branches besides the default one
+	for _, candidate := range []string{ref, "origin/" + ref} {
+		if hash, err := repo.ResolveRevision(plumbing.Revision(candidate)); err == nil {
+			return *hash, nil
+		}
+	}
+	return plumbing.ZeroHash, fmt.Errorf("%w: %s", errImportRefNotFound, ref)
+}
+
+// isRetryableImportError tells network failures apart from repositories that will never clone
+func isRetryableImportError(err error) bool {
+	switch {
+	case errors.Is(err, transport.ErrRepositoryNotFound),
+		errors.Is(err, transport.ErrEmptyRemoteRepository),
+		errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed),
+		errors.Is(err, transport.ErrInvalidAuthMethod),
+		errors.Is(err, errImportRefNotFound),
+		errors.Is(err, errImportAddress),
+		errors.Is(err, errImportRedirect),
+		errors.Is(err, errImportTooLarge),
+		errors.Is(err, errImportHistoryTooLong):
+		return false
+	}
+	return true
+}
+
+// fetchImportedHistory copies every object reachable from the filtered history into repo and points branch at it.
+// Objects are copied between the two object stores, so no git binary or file transport is needed.
+func fetchImportedHistory(ctx context.Context, repo *git.Repository, imported *gitImport, branch plumbing.ReferenceName) error {
+	hashes, err := revlist.Objects(imported.Repo.Storer, []plumbing.Hash{imported.History}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list imported objects: %w", err)
+	}
+	for _, hash := range hashes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		obj, err := imported.Repo.Storer.EncodedObject(plumbing.AnyObject, hash)
+		if err != nil {
+			return err
+		}
+		if _, err := repo.Storer.SetEncodedObject(obj); err != nil {
+			return err
+		}
+	}
+	return repo.Storer.SetReference(plumbing.NewHashReference(branch, imported.History))
+}
+
+// walk passes the files of the requested commit to the extractor, so the import is extracted under the same
+// limits as an archive without checking out a worktree first
+func (imported *gitImport) walk(ctx context.Context) extract.WalkFunc {
+	return func(visit func(extract.Entry) error) error {
+		commit, err := imported.Repo.CommitObject(imported.Commit)
+		if err != nil {
+			return err
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			return err
+		}
+		return walkImportedTree(ctx, imported.Repo.Storer, tree, "", visit)
+	}
+}
+
+func walkImportedTree(ctx context.Context, s storer.EncodedObjectStorer, tree *object.Tree, prefix string, visit func(extract.Entry) error) error {
+	for _, entry := range tree.Entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		name := prefix + entry.Name
+
+		switch entry.Mode {
+		case filemode.Dir:
+			err := visit(extract.Entry{Name: name, Mode: fs.ModeDir | 0755})
+			if errors.Is(err, fs.SkipDir) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			subtree, err := object.GetTree(s, entry.Hash)
+			if err != nil {
+				return err
+			}
+			if err := walkImportedTree(ctx, s, subtree, name+"/", visit); err != nil {
+				return err
+			}
+		case filemode.Submodule:
+			// Submodules are not cloned, just as a checkout leaves them empty
+			continue
+		default:
+			mode, err := entry.Mode.ToOSFileMode()
+			if err != nil {
+				return err
+			}
+			size, err := s.EncodedObjectSize(entry.Hash)
+			if err != nil {
+				return err
+			}
+			hash := entry.Hash
+			err = visit(extract.Entry{Name: name, Size: size, Mode: mode, Open: func() (io.ReadCloser, error) {
+				blob, err := object.GetBlob(s, hash)
+				if err != nil {
+					return nil, err
+				}
+				return blob.Reader()
+			}})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// errImportHistoryTooLong is a history that merges made longer than importMaxCommits despite the clone depth
+var errImportHistoryTooLong = fmt.Errorf("repository history has more than %d commits", importMaxCommits)
+
+// historyFilter rewrites imported commits so that they only hold files an upload would keep. Ignored entries,
+// links, submodules and entries over the file size, depth or path length limits are dropped; the commits keep
+// their authors, dates and messages.
+type historyFilter struct {
+	storer  storer.EncodedObjectStorer
+	limits  extract.Limits
+	skip    extract.SkipFunc
+	trees   map[filteredTreeKey]plumbing.Hash // ZeroHash for a tree left empty
+	entries int                               // entries looked at for the current commit
+}
+
+// filteredTreeKey identifies a tree by where it is, since what the filter drops depends on the path
+type filteredTreeKey struct {
+	hash   plumbing.Hash
+	prefix string
+}
+
+func newHistoryFilter(s storer.EncodedObjectStorer, limits extract.Limits, skip extract.SkipFunc) *historyFilter {
+	return &historyFilter{storer: s, limits: limits, skip: skip, trees: make(map[filteredTreeKey]plumbing.Hash)}
+}
+
+// rewrite stores the filtered copy of head and its history and returns the copy of head. Parents cut off
+// by the shallow clone are left out, so the oldest cloned commits become roots.
+func (f *historyFilter) rewrite(ctx context.Context, head plumbing.Hash) (plumbing.Hash, error) {
+	order, err := f.commitsOldestFirst(head)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	rewritten := make(map[plumbing.Hash]plumbing.Hash, len(order))
+	for _, commit := range order {
+		if err := ctx.Err(); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		f.entries = 0
+		tree, err := f.tree(ctx, commit.TreeHash, "")
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if tree == plumbing.ZeroHash {
+			if tree, err = f.store(&object.Tree{}); err != nil {
+				return plumbing.ZeroHash, err
+			}
+		}
+
+		var parents []plumbing.Hash
+		for _, parent := range commit.ParentHashes {
+			if hash, ok := rewritten[parent]; ok {
+				parents = append(parents, hash)
+			}
+		}
+		// Signatures and merge tags no longer match the rewritten content, so they are not copied
+		hash, err := f.store(&object.Commit{
+			Author:       commit.Author,
+			Committer:    commit.Committer,
+			Message:      commit.Message,
+			TreeHash:     tree,
+			ParentHashes: parents,
+			Encoding:     commit.Encoding,
+		})
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		rewritten[commit.Hash] = hash
+	}
+	return rewritten[head], nil
+}
+
+// commitsOldestFirst lists head and its cloned ancestors with every parent before its children
+func (f *historyFilter) commitsOldestFirst(head plumbing.Hash) ([]*object.Commit, error) {
+	var order []*object.Commit
+	visited := map[plumbing.Hash]bool{}
+	type frame struct {
+		commit *object.Commit
+		next   int // index of the next parent to visit
+	}
+
+	first, err := object.GetCommit(f.storer, head)
+	if err != nil {
+		return nil, err
+	}
+	visited[head] = true
+	stack := []*frame{{commit: first}}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		if top.next == len(top.commit.ParentHashes) {
+			order = append(order, top.commit)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		parent := top.commit.ParentHashes[top.next]
+		top.next++
+		if visited[parent] {
+			continue
+		}
+		visited[parent] = true
+		commit, err := object.GetCommit(f.storer, parent)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			continue // beyond the clone depth
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(visited) > importMaxCommits {
+			return nil, errImportHistoryTooLong
+		}
+		stack = append(stack, &frame{commit: commit})
+	}
+	return order, nil
+}
+
+// tree returns the filtered copy of a tree, or ZeroHash when nothing in it is kept
+func (f *historyFilter) tree(ctx context.Context, hash plumbing.Hash, prefix string) (plumbing.Hash, error) {
+	key := filteredTreeKey{hash: hash, prefix: prefix}
+	if filtered, ok := f.trees[key]; ok {
+		return filtered, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	tree, err := object.GetTree(f.storer, hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	var kept []object.TreeEntry
+	for _, entry := range tree.Entries {
+		f.entries++
+		if f.limits.MaxEntries > 0 && f.entries > f.limits.MaxEntries {
+			return plumbing.ZeroHash, &extract.Error{Code: extract.CodeTooManyEntries, Limit: int64(f.limits.MaxEntries), Actual: int64(f.entries)}
+		}
+		name := prefix + entry.Name
+		if !f.keepsPath(entry.Name, name) {
+			continue
+		}
+
+		switch entry.Mode {
+		case filemode.Dir:
+			if f.skip(name+"/", 0) {
+				continue
+			}
+			subtree, err := f.tree(ctx, entry.Hash, name+"/")
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+			if subtree != plumbing.ZeroHash {
+				kept = append(kept, object.TreeEntry{Name: entry.Name, Mode: filemode.Dir, Hash: subtree})
+			}
+		case filemode.Regular, filemode.Executable, filemode.Deprecated:
+			size, err := f.storer.EncodedObjectSize(entry.Hash)
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+			if f.skip(name, size) || (f.limits.MaxFileSize > 0 && size > f.limits.MaxFileSize) {
+				continue
+			}
+			kept = append(kept, entry)
+		}
+	}
+
+	// Kept entries stay in the original order, which is the order Git requires
+	filtered := plumbing.ZeroHash
+	if len(kept) > 0 {
+		if filtered, err = f.store(&object.Tree{Entries: kept}); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	f.trees[key] = filtered
+	return filtered, nil
+}
+
+// keepsPath drops entry names an extraction would reject, and paths over the depth and length limits
+func (f *historyFilter) keepsPath(entryName, name string) bool {
+	if entryName == "" || entryName == "." || entryName == ".." || strings.ContainsAny(entryName, "/\\\x00") {
+		return false
+	}
+	if f.limits.MaxPathLength > 0 && len(name) > f.limits.MaxPathLength {
+		return false
+	}
+	return f.limits.MaxDepth <= 0 || strings.Count(name, "/")+1 <= f.limits.MaxDepth
+}
+
+// objectEncoder is a tree or a commit
+type objectEncoder interface {
+	Encode(plumbing.EncodedObject) error
+}
+
+// store encodes a tree or commit into the clone's object store
+func (f *historyFilter) store(obj objectEncoder) (plumbing.Hash, error) {
+	encoded := f.storer.NewEncodedObject()
+	if err := obj.Encode(encoded); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return f.storer.SetEncodedObject(encoded)
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/extract"
+	"FinalProjectManagementApp/githosting"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jmoiron/sqlx"
+)
+
+// recordingDriver accepts every statement and remembers the ones executed, so the job runner can be
+// followed without a MySQL server. Queries return no rows.
+type recordingDriver struct {
+	mu    sync.Mutex
+	execs []recordedExec
+}
+
+type recordedExec struct {
+	query string
+	args  []driver.NamedValue
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{d: d}, nil }
+
+func (d *recordingDriver) lastExec(prefix string) *recordedExec {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := len(d.execs) - 1; i >= 0; i-- {
+		if strings.HasPrefix(strings.TrimSpace(d.execs[i].query), prefix) {
+			return &d.execs[i]
+		}
+	}
+	return nil
+}
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *recordingConn) Commit() error             { return nil }
+func (c *recordingConn) Rollback() error           { return nil }
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.execs = append(c.d.execs, recordedExec{query: query, args: args})
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+func newRecordingDB(t *testing.T) (*sqlx.DB, *recordingDriver) {
+	t.Helper()
+	recorder := &recordingDriver{}
+	name := "recording_" + strings.ReplaceAll(t.Name(), "/", "_")
+	sql.Register(name, recorder)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return sqlx.NewDb(db, "mysql"), recorder
+}
+
+// serveGitRepository serves a repository over smart HTTPS with git http-backend and returns its clone URL
+func serveGitRepository(t *testing.T, files ...map[string]string) string {
+	t.Helper()
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary not available")
+	}
+
+	work := filepath.Join(t.TempDir(), "work")
+	repo, err := git.PlainInit(work, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, commitFiles := range files {
+		for name, content := range commitFiles {
+			path := filepath.Join(work, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := worktree.Add("."); err != nil {
+			t.Fatal(err)
+		}
+		_, err := worktree.Commit("Student commit "+string(rune('A'+i)), &git.CommitOptions{
+			Author: &object.Signature{Name: "Student", Email: "student@example.com", When: time.Now()},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := t.TempDir()
+	if out, err := exec.Command(gitPath, "clone", "--bare", "--quiet", work, filepath.Join(root, "thesis.git")).CombinedOutput(); err != nil {
+		t.Fatalf("git clone --bare: %v\n%s", err, out)
+	}
+
+	server := httptest.NewTLSServer(&cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	t.Cleanup(server.Close)
+
+	// The test server runs on loopback with its own certificate
+	previousAllowed, previousTLS := importAddressAllowed, importTransport.TLSClientConfig
+	importAddressAllowed = func(ip net.IP) bool { return ip.IsLoopback() }
+	importTransport.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	t.Cleanup(func() {
+		importAddressAllowed, importTransport.TLSClientConfig = previousAllowed, previousTLS
+	})
+	return server.URL + "/thesis.git"
+}
+
+func newImportJob(repoURL string) *database.SourceUploadJob {
+	return &database.SourceUploadJob{
+		ID:               1,
+		SubmissionID:     "5b1f0a62-7c1e-4a4e-9a51-0f3c2f0d9e11",
+		StudentRecordID:  7,
+		StudentName:      "Test Student",
+		StudentNumber:    "s123456",
+		StudentEmail:     "student@example.com",
+		UploadedByEmail:  "student@example.com",
+		UploadedByRole:   "student",
+		OriginalFilename: importDisplayName(repoURL, ""),
+		SourceType:       database.UploadSourceGit,
+		ImportURL:        &repoURL,
+		Status:           database.UploadJobProcessing,
+		Attempts:         1,
+		MaxAttempts:      uploadMaxAttempts,
+		LeaseOwner:       database.NullableString("test-worker"),
+	}
+}
+
+func TestRunUploadJobImportsFilteredGitHistory(t *testing.T) {
+	repoURL := serveGitRepository(t,
+		map[string]string{
+			"main.go":                 "package main\n\nfunc main() {}\n",
+			".env":                    "DB_PASSWORD=secret\n",
+			"node_modules/lib/x.js":   "module.exports = 1\n",
+			"build/app.exe":           "MZ",
+			"docs/notes.txt":          "notes\n",
+			"internal/parse/parse.go": "package parse\n",
+		},
+		map[string]string{"internal/parse/parse.go": "package parse\n\nfunc Parse() {}\n"},
+	)
+	t.Chdir(t.TempDir())
+
+	db, recorder := newRecordingDB(t)
+	provider, err := githosting.NewLocal(t.TempDir(), "test-org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &SourceCodeHandler{db: db, provider: provider, wake: make(chan struct{}, 1), extractLimits: extract.DefaultLimits()}
+
+	job := newImportJob(repoURL)
+	h.runUploadJob(job)
+
+	finish := recorder.lastExec("UPDATE source_upload_jobs")
+	if finish == nil || !strings.Contains(finish.query, "status = 'done'") {
+		var lastError interface{}
+		if finish != nil && len(finish.args) > 1 {
+			lastError = finish.args[1].Value
+		}
+		t.Fatalf("job did not finish as done, last error = %v", lastError)
+	}
+	var result database.SubmissionResult
+	if err := json.Unmarshal([]byte(finish.args[0].Value.(string)), &result); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || result.CommitInfo == nil {
+		t.Fatalf("result = %+v, want success with a commit", result)
+	}
+
+	hosted, err := provider.GetRepository(context.Background(), h.generateRepoName(job.StudentInfo()))
+	if err != nil {
+		t.Fatalf("GetRepository() error = %v", err)
+	}
+	clone, err := provider.Clone(context.Background(), hosted, filepath.Join(t.TempDir(), "thesis"))
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	head, err := clone.CommitObject(plumbing.NewHash(result.CommitInfo.CommitID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every commit pushed to the thesis repository, imported ones included, holds only filtered files
+	messages := map[string]bool{}
+	err = object.NewCommitPreorderIter(head, nil, nil).ForEach(func(commit *object.Commit) error {
+		messages[commit.Message] = true
+		tree, err := commit.Tree()
+		if err != nil {
+			return err
+		}
+		return tree.Files().ForEach(func(file *object.File) error {
+			if isIgnoredSourceFile(file.Name, file.Size) {
+				t.Errorf("commit %q contains ignored file %s", strings.TrimSpace(commit.Message), file.Name)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, message := range []string{"Student commit A", "Student commit B"} {
+		if !messages[message] {
+			t.Errorf("imported commit %q is missing from the thesis history", message)
+		}
+	}
+
+	tree, err := head.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := tree.File("internal/parse/parse.go")
+	if err != nil {
+		t.Fatalf("imported source missing from main: %v", err)
+	}
+	if content, _ := file.Contents(); !strings.Contains(content, "func Parse") {
+		t.Errorf("parse.go = %q, want the requested commit's content", content)
+	}
+}
+
+func TestCloneImportRejectsInternalAddresses(t *testing.T) {
+	repoURL := serveGitRepository(t, map[string]string{"main.go": "package main\n"})
+	importAddressAllowed = isPublicAddress
+	t.Chdir(t.TempDir())
+
+	h := &SourceCodeHandler{extractLimits: extract.DefaultLimits()}
+	_, err := h.cloneImport(context.Background(), newImportJob(repoURL), "clone")
+	if !errors.Is(err, errImportAddress) {
+		t.Fatalf("cloneImport() error = %v, want %v", err, errImportAddress)
+	}
+	if isRetryableImportError(err) {
+		t.Error("a blocked address must not be retried")
+	}
+}
+
+func TestCloneImportStopsAtSizeBudget(t *testing.T) {
+	// Random content does not compress, so the pack is about as large as the file
+	padding := make([]byte, 64<<10)
+	rand.New(rand.NewSource(1)).Read(padding)
+	repoURL := serveGitRepository(t, map[string]string{"main.go": "package main\n\n// " + hex.EncodeToString(padding) + "\n"})
+	t.Chdir(t.TempDir())
+
+	limits := extract.DefaultLimits()
+	limits.MaxTotalSize = 4 << 10
+	h := &SourceCodeHandler{extractLimits: limits}
+	_, err := h.cloneImport(context.Background(), newImportJob(repoURL), "clone")
+	if !errors.Is(err, errImportTooLarge) {
+		t.Fatalf("cloneImport() error = %v, want %v", err, errImportTooLarge)
+	}
+}
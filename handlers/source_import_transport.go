@@ -0,0 +1,169 @@
+// handlers/source_import_transport.go - Network rules for cloning imported repositories: public addresses only, checked redirects and a size budget
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+const importMaxRedirects = 3
+
+var (
+	errImportAddress  = errors.New("Git host is not a public address")
+	errImportRedirect = errors.New("Git host redirected to a location that cannot be imported")
+	errImportTooLarge = errors.New("repository is too large to import")
+)
+
+// importAddressAllowed decides which addresses an import may connect to; tests allow their loopback server
+var importAddressAllowed = isPublicAddress
+
+var installImportProtocol sync.Once
+
+// importTransport carries only import clones. Connections are never reused, so every request is dialed,
+// and so checked, again, and no proxy resolves the host on the clone's behalf.
+var importTransport = newImportTransport()
+
+// importGuard is the budget of one import's clone, passed to the transport in the request context
+type importGuard struct {
+	remaining atomic.Int64
+
+	mu  sync.Mutex
+	err error // first rule the clone broke
+}
+
+type importGuardKey struct{}
+
+func newImportGuard(maxBytes int64) *importGuard {
+	guard := &importGuard{}
+	guard.remaining.Store(maxBytes)
+	return guard
+}
+
+func withImportGuard(ctx context.Context, guard *importGuard) context.Context {
+	return context.WithValue(ctx, importGuardKey{}, guard)
+}
+
+func importGuardFrom(ctx context.Context) *importGuard {
+	guard, _ := ctx.Value(importGuardKey{}).(*importGuard)
+	return guard
+}
+
+// fail records the first violation; go-git does not always keep the error chain, so cloneImport asks the guard
+func (g *importGuard) fail(err error) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.err == nil {
+		g.err = err
+	}
+	return err
+}
+
+func (g *importGuard) violation() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.err
+}
+
+// isPublicAddress rejects loopback, private, link-local, multicast and unspecified addresses
+func isPublicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+func newImportTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DisableKeepAlives = true
+
+	// The address is checked after DNS resolution, right before connecting, so rebinding the name
+	// between the check at queue time and the clone does not reach internal hosts
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !importAddressAllowed(ip) {
+				return fmt.Errorf("%w: %s", errImportAddress, host)
+			}
+			return nil
+		},
+	}
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		guard := importGuardFrom(ctx)
+		if guard == nil {
+			return nil, errors.New("import transport used without an import guard")
+		}
+		conn, err := dialer.DialContext(ctx, network, address)
+		if err != nil {
+			if errors.Is(err, errImportAddress) {
+				guard.fail(errImportAddress)
+			}
+			return nil, err
+		}
+		return &importConn{Conn: conn, guard: guard}, nil
+	}
+	return transport
+}
+
+// importConn stops reading once the clone used up its budget, whatever the server sends
+type importConn struct {
+	net.Conn
+	guard *importGuard
+}
+
+func (c *importConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if c.guard.remaining.Add(-int64(n)) < 0 {
+		return 0, c.guard.fail(errImportTooLarge)
+	}
+	return n, err
+}
+
+// importRoundTripper sends import clones through importTransport and every other https Git request,
+// such as pushes to the hosting provider, through the default transport
+type importRoundTripper struct{}
+
+func (importRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if importGuardFrom(req.Context()) != nil {
+		return importTransport.RoundTrip(req)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// checkImportRedirect follows a few https redirects of an import; their targets are dialed through the same checks
+func checkImportRedirect(req *http.Request, via []*http.Request) error {
+	guard := importGuardFrom(req.Context())
+	if guard == nil {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	if len(via) > importMaxRedirects || req.URL.Scheme != "https" || req.URL.User != nil {
+		return guard.fail(errImportRedirect)
+	}
+	return nil
+}
+
+// installImportClient replaces go-git's https client with one that applies the import rules to guarded requests
+func installImportClient() {
+	installImportProtocol.Do(func() {
+		client.InstallProtocol("https", githttp.NewClient(&http.Client{
+			Transport:     importRoundTripper{},
+			CheckRedirect: checkImportRedirect,
+		}))
+	})
+}
@@ -0,0 +1,144 @@
+// handlers/source_upload_identity.go - Resolving the student an upload belongs to and auditing who made it
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/database"
+)
+
+// uploadTarget is the student record an upload is stored under, and whether someone else uploads it
+type uploadTarget struct {
+	Record   *database.StudentRecord
+	OnBehalf bool
+}
+
+// StudentInfo builds the repository metadata from the student record, never from form input
+func (t *uploadTarget) StudentInfo() *database.StudentInfo {
+	title := strings.TrimSpace(t.Record.FinalProjectTitle)
+	if title == "" {
+		title = "Final Thesis Project"
+	}
+	return &database.StudentInfo{
+		Name:        t.Record.GetFullName(),
+		StudentID:   t.Record.StudentNumber,
+		Email:       t.Record.StudentEmail,
+		ThesisTitle: title,
+	}
+}
+
+// resolveUploadTarget binds students to their own record; other users must name a student_record_id and
+// hold the explicit on-behalf permission for that student
+func (h *SourceCodeHandler) resolveUploadTarget(r *http.Request, user *auth.AuthenticatedUser) (*uploadTarget, int, error) {
+	if user.Role == auth.RoleStudent {
+		record, err := h.getUploadStudentRecord("student_email = ?", user.Email)
+		if err == sql.ErrNoRows {
+			return nil, http.StatusNotFound, fmt.Errorf("no student record found for %s", user.Email)
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return &uploadTarget{Record: record}, http.StatusOK, nil
+	}
+
+	studentRecordID, err := strconv.Atoi(r.FormValue("student_record_id"))
+	if err != nil || studentRecordID <= 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("student_record_id is required when uploading on behalf of a student")
+	}
+	record, err := h.getUploadStudentRecord("id = ?", studentRecordID)
+	if err == sql.ErrNoRows {
+		return nil, http.StatusNotFound, fmt.Errorf("student record %d not found", studentRecordID)
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !h.canUploadOnBehalf(user, record) {
+		return nil, http.StatusForbidden, fmt.Errorf("you are not permitted to upload source code for this student")
+	}
+	return &uploadTarget{Record: record, OnBehalf: true}, http.StatusOK, nil
+}
+
+func (h *SourceCodeHandler) getUploadStudentRecord(condition string, arg interface{}) (*database.StudentRecord, error) {
+	var record database.StudentRecord
+	query := `
+        SELECT id, student_name, student_lastname, student_email, student_number,
+               final_project_title, study_program, department, supervisor_email
+        FROM student_records WHERE ` + condition + ` LIMIT 1`
+	if err := h.db.Get(&record, query, arg); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// canUploadOnBehalf requires an upload_source_on_behalf grant for the user's role and that the student is in the user's scope
+func (h *SourceCodeHandler) canUploadOnBehalf(user *auth.AuthenticatedUser, record *database.StudentRecord) bool {
+	if !user.HasPermission(auth.PermissionFullAccess) && !h.roleHasPermission(user, auth.PermissionUploadSourceOnBehalf) {
+		return false
+	}
+
+	switch user.Role {
+	case auth.RoleAdmin:
+		return true
+	case auth.RoleDepartmentHead:
+		department, err := departmentHeadScope(h.db, user)
+		return err == nil && department == record.Department
+	case auth.RoleSupervisor:
+		return strings.EqualFold(record.SupervisorEmail, user.Email)
+	}
+	return false
+}
+
+// roleHasPermission looks the permission up in role_permissions; department head roles are keyed by their role ID
+func (h *SourceCodeHandler) roleHasPermission(user *auth.AuthenticatedUser, permission string) bool {
+	roleName := user.Role
+	if user.Role == auth.RoleDepartmentHead {
+		roleName = fmt.Sprintf("department_head_%d", user.RoleID)
+	}
+
+	var count int
+	err := h.db.Get(&count, `
+        SELECT COUNT(*) FROM role_permissions
+        WHERE role_name = ? AND permission = ? AND resource_type = 'documents'`,
+		roleName, permission)
+	if err != nil {
+		log.Printf("Error checking %s permission for role %s: %v", permission, roleName, err)
+		return false
+	}
+	return count > 0
+}
+
+// canViewUploadJob allows the student, the uploader and administrators to follow an upload
+func canViewUploadJob(user *auth.AuthenticatedUser, job *database.SourceUploadJob) bool {
+	return user.HasPermission(auth.PermissionFullAccess) ||
+		strings.EqualFold(user.Email, job.StudentEmail) ||
+		strings.EqualFold(user.Email, job.UploadedByEmail)
+}
+
+// auditUpload records an upload attempt, denial or outcome in the audit log
+func (h *SourceCodeHandler) auditUpload(email, role, action, resourceID string, details map[string]interface{}, ip, userAgent *string, success bool) {
+	detailsJSON, _ := json.Marshal(details)
+	detailsText := string(detailsJSON)
+	err := database.CreateAuditLog(database.AuditLog{
+		UserEmail:    email,
+		UserRole:     role,
+		Action:       action,
+		ResourceType: "source_code",
+		ResourceID:   database.NullableString(resourceID),
+		Details:      &detailsText,
+		IPAddress:    ip,
+		UserAgent:    userAgent,
+		Success:      success,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		log.Printf("Error writing audit log for %s: %v", action, err)
+	}
+}
@@ -0,0 +1,361 @@
+// handlers/source_upload_jobs.go - Durable source code upload queue: spooling, leasing, retries and status
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/extract"
+	"github.com/google/uuid"
+)
+
+const (
+	uploadSpoolDir      = "uploads/queue"
+	uploadLeaseDuration = 5 * time.Minute
+	uploadPollInterval  = 2 * time.Second
+	uploadMaxAttempts   = 3
+	uploadQueueLimit    = 45
+	// Status pages report the queue as busy a few jobs before uploads are refused
+	uploadQueueBusy = uploadQueueLimit - 5
+)
+
+// enqueueUpload spools the archive to disk and records a queued job for it
+func (h *SourceCodeHandler) enqueueUpload(studentRecordID int, studentInfo *database.StudentInfo, uploader *auth.AuthenticatedUser, file multipart.File, header *multipart.FileHeader, extension string, ip, userAgent *string) (*database.SourceUploadJob, error) {
+	submissionID := uuid.New().String()
+	spoolPath := filepath.Join(uploadSpoolDir, submissionID+extension)
+	if err := h.saveFile(file, spoolPath); err != nil {
+		return nil, fmt.Errorf("failed to spool upload: %w", err)
+	}
+
+	_, err := h.db.Exec(`
+        INSERT INTO source_upload_jobs (
+            submission_id, student_record_id, student_name, student_number, student_email,
+            thesis_title, uploaded_by_email, uploaded_by_role, upload_ip, upload_user_agent,
+            original_filename, file_size, spool_path, max_attempts
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		submissionID, studentRecordID, studentInfo.Name, studentInfo.StudentID, studentInfo.Email,
+		database.NullableString(studentInfo.ThesisTitle), uploader.Email, uploader.Role, ip, userAgent,
+		header.Filename, header.Size, spoolPath, uploadMaxAttempts)
+	if err != nil {
+		os.Remove(spoolPath)
+		return nil, err
+	}
+
+	h.wakeWorker()
+	return h.getUploadJob(submissionID)
+}
+
+// enqueueImport records a queued job that clones a Git repository at ref
+func (h *SourceCodeHandler) enqueueImport(studentRecordID int, studentInfo *database.StudentInfo, uploader *auth.AuthenticatedUser, repoURL, ref string, ip, userAgent *string) (*database.SourceUploadJob, error) {
+	submissionID := uuid.New().String()
+	_, err := h.db.Exec(`
+        INSERT INTO source_upload_jobs (
+            submission_id, student_record_id, student_name, student_number, student_email,
+            thesis_title, uploaded_by_email, uploaded_by_role, upload_ip, upload_user_agent,
+            original_filename, spool_path, source_type, import_url, import_ref, max_attempts
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, '', ?, ?, ?, ?)`,
+		submissionID, studentRecordID, studentInfo.Name, studentInfo.StudentID, studentInfo.Email,
+		database.NullableString(studentInfo.ThesisTitle), uploader.Email, uploader.Role, ip, userAgent,
+		importDisplayName(repoURL, ref), database.UploadSourceGit, repoURL, database.NullableString(ref), uploadMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	h.wakeWorker()
+	return h.getUploadJob(submissionID)
+}
+
+// wakeWorker signals an idle worker instead of waiting for the next poll
+func (h *SourceCodeHandler) wakeWorker() {
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (h *SourceCodeHandler) getUploadJob(submissionID string) (*database.SourceUploadJob, error) {
+	var job database.SourceUploadJob
+	if err := h.db.Get(&job, "SELECT * FROM source_upload_jobs WHERE submission_id = ?", submissionID); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// hasPendingUpload reports whether the student already has a queued or running upload
+func (h *SourceCodeHandler) hasPendingUpload(studentRecordID int) (bool, error) {
+	var pending int
+	err := h.db.Get(&pending, `
+        SELECT COUNT(*) FROM source_upload_jobs
+        WHERE student_record_id = ? AND status IN ('queued', 'processing')`, studentRecordID)
+	return pending > 0, err
+}
+
+// queuePosition is 1 for the next job to be claimed, 0 once the job left the queue
+func (h *SourceCodeHandler) queuePosition(job *database.SourceUploadJob) int {
+	if job.Status != database.UploadJobQueued {
+		return 0
+	}
+	var ahead int
+	h.db.Get(&ahead, `
+        SELECT COUNT(*) FROM source_upload_jobs
+        WHERE status = 'queued' AND (next_attempt_at < ? OR (next_attempt_at = ? AND id < ?))`,
+		job.NextAttemptAt, job.NextAttemptAt, job.ID)
+	return ahead + 1
+}
+
+// uploadJobCounts returns the number of jobs per status
+func (h *SourceCodeHandler) uploadJobCounts() map[string]int {
+	var rows []struct {
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+	counts := map[string]int{}
+	if err := h.db.Select(&rows, "SELECT status, COUNT(*) AS count FROM source_upload_jobs GROUP BY status"); err != nil {
+		log.Printf("Error counting upload jobs: %v", err)
+		return counts
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts
+}
+
+// uploadWorker claims jobs until the process exits; jobs of a crashed worker are reclaimed once their lease expires
+func (h *SourceCodeHandler) uploadWorker(workerID int) {
+	log.Printf("Upload worker %d started", workerID)
+
+	for {
+		job, err := h.claimUploadJob()
+		if err != nil {
+			log.Printf("Upload worker %d failed to claim a job: %v", workerID, err)
+		}
+		if job == nil {
+			select {
+			case <-h.wake:
+			case <-time.After(uploadPollInterval):
+			}
+			continue
+		}
+
+		log.Printf("Worker %d processing upload %s for student %s (attempt %d/%d)",
+			workerID, job.SubmissionID, job.StudentNumber, job.Attempts, job.MaxAttempts)
+		h.runUploadJob(job)
+	}
+}
+
+// claimUploadJob leases the next due job, or a processing job whose lease expired
+func (h *SourceCodeHandler) claimUploadJob() (*database.SourceUploadJob, error) {
+	hostname, _ := os.Hostname()
+	lease := fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), uuid.New().String()[:8])
+
+	result, err := h.db.Exec(`
+        UPDATE source_upload_jobs
+        SET status = 'processing', stage = 'starting', lease_owner = ?,
+            lease_expires_at = DATE_ADD(NOW(), INTERVAL ? SECOND),
+            attempts = attempts + 1, started_at = COALESCE(started_at, NOW())
+        WHERE (status = 'queued' AND next_attempt_at <= NOW())
+           OR (status = 'processing' AND lease_expires_at < NOW())
+        ORDER BY next_attempt_at, id
+        LIMIT 1`,
+		lease, int(uploadLeaseDuration.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, nil
+	}
+
+	var job database.SourceUploadJob
+	if err := h.db.Get(&job, "SELECT * FROM source_upload_jobs WHERE lease_owner = ? AND status = 'processing'", lease); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// runUploadJob processes one leased job and records its outcome
+func (h *SourceCodeHandler) runUploadJob(job *database.SourceUploadJob) {
+	stop := make(chan struct{})
+	go h.renewUploadLease(job, stop)
+	defer close(stop)
+
+	var result *database.SubmissionResult
+	retryable := false
+	func() {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("Upload %s panicked: %v", job.SubmissionID, p)
+				result = &database.SubmissionResult{Success: false, Error: "Internal error while processing the upload"}
+				retryable = true
+			}
+		}()
+
+		switch {
+		case job.Attempts > job.MaxAttempts:
+			// The job kept losing its lease, e.g. the server restarted during every attempt
+			result = &database.SubmissionResult{Success: false, Error: fmt.Sprintf("Upload abandoned after %d attempts", job.MaxAttempts)}
+		case !job.IsGitImport() && !fileExists(job.SpoolPath):
+			result = &database.SubmissionResult{Success: false, Error: "Uploaded file is no longer available, please upload again"}
+		default:
+			result, retryable = h.processSourceCodeUpload(job, func(stage string, percent int) {
+				h.setUploadProgress(job, stage, percent)
+			})
+		}
+	}()
+	result.SubmissionID = job.SubmissionID
+
+	h.finishUploadJob(job, result, retryable)
+}
+
+func (h *SourceCodeHandler) renewUploadLease(job *database.SourceUploadJob, stop <-chan struct{}) {
+	ticker := time.NewTicker(uploadLeaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_, err := h.db.Exec(`
+                UPDATE source_upload_jobs SET lease_expires_at = DATE_ADD(NOW(), INTERVAL ? SECOND)
+                WHERE id = ? AND lease_owner = ?`,
+				int(uploadLeaseDuration.Seconds()), job.ID, database.StringValue(job.LeaseOwner))
+			if err != nil {
+				log.Printf("Error renewing lease of upload %s: %v", job.SubmissionID, err)
+			}
+		}
+	}
+}
+
+func (h *SourceCodeHandler) setUploadProgress(job *database.SourceUploadJob, stage string, percent int) {
+	_, err := h.db.Exec(`
+        UPDATE source_upload_jobs SET stage = ?, progress = ?
+        WHERE id = ? AND lease_owner = ?`,
+		stage, percent, job.ID, database.StringValue(job.LeaseOwner))
+	if err != nil {
+		log.Printf("Error updating progress of upload %s: %v", job.SubmissionID, err)
+	}
+}
+
+// finishUploadJob marks the job done or failed, or schedules a retry with backoff for transient failures
+func (h *SourceCodeHandler) finishUploadJob(job *database.SourceUploadJob, result *database.SubmissionResult, retryable bool) {
+	resultJSON, _ := json.Marshal(result)
+	leaseOwner := database.StringValue(job.LeaseOwner)
+
+	var err error
+	switch {
+	case result.Success:
+		var documentID *int
+		if result.DocumentID > 0 {
+			documentID = &result.DocumentID
+		}
+		_, err = h.db.Exec(`
+            UPDATE source_upload_jobs
+            SET status = 'done', stage = 'done', progress = 100, result_json = ?, document_id = ?,
+                last_error = NULL, lease_owner = NULL, lease_expires_at = NULL, finished_at = NOW()
+            WHERE id = ? AND lease_owner = ?`,
+			string(resultJSON), documentID, job.ID, leaseOwner)
+		os.Remove(job.SpoolPath)
+		log.Printf("Upload %s completed for student %s", job.SubmissionID, job.StudentNumber)
+
+	case retryable && job.Attempts < job.MaxAttempts:
+		backoff := time.Duration(job.Attempts*job.Attempts) * 30 * time.Second
+		_, err = h.db.Exec(`
+            UPDATE source_upload_jobs
+            SET status = 'queued', stage = 'retry_scheduled', progress = 0, last_error = ?,
+                next_attempt_at = DATE_ADD(NOW(), INTERVAL ? SECOND),
+                lease_owner = NULL, lease_expires_at = NULL
+            WHERE id = ? AND lease_owner = ?`,
+			result.Error, int(backoff.Seconds()), job.ID, leaseOwner)
+		log.Printf("Upload %s failed (attempt %d/%d), retrying in %s: %s",
+			job.SubmissionID, job.Attempts, job.MaxAttempts, backoff, result.Error)
+
+	default:
+		_, err = h.db.Exec(`
+            UPDATE source_upload_jobs
+            SET status = 'failed', stage = 'failed', result_json = ?, last_error = ?,
+                lease_owner = NULL, lease_expires_at = NULL, finished_at = NOW()
+            WHERE id = ? AND lease_owner = ?`,
+			string(resultJSON), result.Error, job.ID, leaseOwner)
+		os.Remove(job.SpoolPath)
+		log.Printf("Upload %s failed for student %s: %s", job.SubmissionID, job.StudentNumber, result.Error)
+	}
+	if err != nil {
+		log.Printf("Error recording outcome of upload %s: %v", job.SubmissionID, err)
+	}
+
+	if result.Success || !retryable || job.Attempts >= job.MaxAttempts {
+		details := map[string]interface{}{
+			"student_record_id": job.StudentRecordID,
+			"student_email":     job.StudentEmail,
+			"on_behalf":         job.IsOnBehalf(),
+			"attempts":          job.Attempts,
+		}
+		if result.Success {
+			details["document_id"] = result.DocumentID
+		} else {
+			details["error"] = result.Error
+		}
+		h.auditUpload(job.UploadedByEmail, job.UploadedByRole, "process_source_code", job.SubmissionID,
+			details, job.UploadIP, job.UploadUserAgent, result.Success)
+	}
+}
+
+// uploadStatusResponse is the per-submission status returned by GetUploadStatus
+func (h *SourceCodeHandler) uploadStatusResponse(job *database.SourceUploadJob) map[string]interface{} {
+	counts := h.uploadJobCounts()
+	status := map[string]interface{}{
+		"submission_id":  job.SubmissionID,
+		"status":         job.Status,
+		"stage":          job.Stage,
+		"progress":       job.Progress,
+		"attempts":       job.Attempts,
+		"max_attempts":   job.MaxAttempts,
+		"queue_position": h.queuePosition(job),
+		"filename":       job.OriginalFilename,
+		"created_at":     job.CreatedAt,
+		"updated_at":     job.UpdatedAt,
+		"queue_length":   counts[database.UploadJobQueued],
+		"active_uploads": counts[database.UploadJobProcessing],
+		"system_status":  "operational",
+	}
+	if job.LastError != nil {
+		status["last_error"] = *job.LastError
+	}
+	if job.Status == database.UploadJobQueued && job.Stage == "retry_scheduled" {
+		status["next_attempt_at"] = job.NextAttemptAt
+	}
+	if job.FinishedAt != nil {
+		status["finished_at"] = job.FinishedAt
+	}
+	if result := job.Result(); result != nil {
+		status["result"] = result
+	}
+	if counts[database.UploadJobQueued] >= uploadQueueBusy {
+		status["system_status"] = "busy"
+	}
+	return status
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// uploadMimeType is the document MIME type of what the job was created from
+func uploadMimeType(job *database.SourceUploadJob) string {
+	switch {
+	case job.IsGitImport():
+		return "application/x-git"
+	case extract.Extension(job.OriginalFilename) == ".zip":
+		return "application/zip"
+	case extract.Extension(job.OriginalFilename) == ".tar":
+		return "application/x-tar"
+	}
+	return "application/gzip"
+}
@@ -0,0 +1,165 @@
+// handlers/student_documents.go - Document lists and files of a student for the supervisor and commission pages
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// StudentDocumentsHandler lists the documents of a student to a signed-in user who may see them
+func (h *UploadHandlers) StudentDocumentsHandler(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+	if !h.canAccessStudentDocuments(user, studentID) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	if !EnforceUserAccessWindow(h.db, w, r, user, studentID, "document") {
+		return
+	}
+
+	WriteStudentDocuments(h.db, w, studentID)
+}
+
+// WriteStudentDocuments answers with the student's documents as JSON; the caller checks access
+func WriteStudentDocuments(db *sqlx.DB, w http.ResponseWriter, studentID int) {
+	var documents []struct {
+		ID               int     `db:"id"`
+		DocumentType     string  `db:"document_type"`
+		FileSize         *int64  `db:"file_size"`
+		MimeType         *string `db:"mime_type"`
+		OriginalFilename *string `db:"original_filename"`
+	}
+	err := db.Select(&documents, `
+        SELECT id, document_type, file_size, mime_type, original_filename
+        FROM documents
+        WHERE student_record_id = ?
+        ORDER BY uploaded_date DESC`, studentID)
+	if err != nil {
+		log.Printf("Error loading documents of student %d: %v", studentID, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":     "Failed to fetch documents",
+			"documents": []interface{}{},
+		})
+		return
+	}
+
+	responseDocuments := make([]map[string]interface{}, 0, len(documents))
+	for _, doc := range documents {
+		docType := doc.DocumentType
+		if docType == "thesis" {
+			docType = "thesis_pdf"
+		}
+		responseDocuments = append(responseDocuments, map[string]interface{}{
+			"id":         doc.ID,
+			"type":       docType,
+			"hasPreview": doc.MimeType != nil && strings.HasPrefix(*doc.MimeType, "application/pdf"),
+			"filename":   doc.OriginalFilename,
+			"size":       doc.FileSize,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"documents": responseDocuments,
+	})
+}
+
+// ServeStudentDocument sends a stored document of the student, as an inline PDF preview or as a download.
+// The document must belong to the student, so access checked for the student covers it.
+func ServeStudentDocument(db *sqlx.DB, w http.ResponseWriter, r *http.Request, studentID, documentID int, download bool) {
+	var doc database.Document
+	err := db.Get(&doc, `SELECT * FROM documents WHERE id = ? AND student_record_id = ?`, documentID, studentID)
+	if err != nil {
+		http.Error(w, "Document not found", http.StatusNotFound)
+		return
+	}
+
+	mimeType := database.StringValue(doc.MimeType)
+	filename := database.StringValue(doc.OriginalFilename)
+	if filename == "" {
+		filename = "document"
+	}
+	if download {
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	} else {
+		if !strings.HasPrefix(mimeType, "application/pdf") {
+			http.Error(w, "Document cannot be previewed", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Disposition", "inline; filename=\""+filename+"\"")
+	}
+	if mimeType != "" {
+		w.Header().Set("Content-Type", mimeType)
+	}
+
+	if !fileExists(doc.FilePath) {
+		log.Printf("Document %d of student %d is missing at %s", documentID, studentID, doc.FilePath)
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	http.ServeFile(w, r, doc.FilePath)
+}
+
+// CommissionCoversStudent reports whether the student is in the department, study program and year the
+// commission access code was issued for, the same students its student list shows
+func CommissionCoversStudent(db *sqlx.DB, member *database.CommissionMember, studentID int) bool {
+	var count int
+	err := db.Get(&count, `
+        SELECT COUNT(*) FROM student_records
+        WHERE id = ? AND department = ? AND study_program = ? AND current_year = ?`,
+		studentID, member.Department, member.StudyProgram.String, member.Year.Int64)
+	if err != nil {
+		log.Printf("Error checking commission coverage of student %d: %v", studentID, err)
+		return false
+	}
+	return count > 0
+}
+
+// commissionMemberOf loads the active access code a commission member's request was made with; commission
+// pages sign the member in as "commission_<access code>"
+func commissionMemberOf(db *sqlx.DB, user *auth.AuthenticatedUser) (*database.CommissionMember, error) {
+	accessCode, ok := strings.CutPrefix(user.Email, "commission_")
+	if !ok {
+		return nil, errors.New("no commission access code")
+	}
+	return commissionMemberByCode(db, accessCode)
+}
+
+// commissionMemberByCode loads an active, unexpired commission access code
+func commissionMemberByCode(db *sqlx.DB, accessCode string) (*database.CommissionMember, error) {
+	if accessCode == "" {
+		return nil, errors.New("no commission access code")
+	}
+
+	var member database.CommissionMember
+	err := db.Get(&member, `SELECT * FROM commission_members WHERE access_code = ? AND is_active = true`, accessCode)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().Unix() > member.ExpiresAt {
+		return nil, errors.New("access code has expired")
+	}
+	return &member, nil
+}
@@ -1677,6 +1677,9 @@ func (h *StudentListHandler) ReviewerReportSubmitHandler(w http.ResponseWriter,
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
+	if !h.reviewerHasClearance(w, studentID, user.Email) {
+		return
+	}
 
 	// Parse form data
 	err = r.ParseForm()
@@ -1700,9 +1703,20 @@ func (h *StudentListHandler) ReviewerReportSubmitHandler(w http.ResponseWriter,
 		return
 	}
 
+	rubric, err := getRubricForProgram(h.db, student.StudyProgram)
+	if err != nil && err != sql.ErrNoRows {
+		http.Error(w, "Failed to load grading rubric", http.StatusInternalServerError)
+		return
+	}
+	rubricScores, err := parseRubricScores(r, rubric, true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Check if report already exists
 	var existingReport database.ReviewerReport
-	err = h.db.Get(&existingReport,
+	existingErr := h.db.Get(&existingReport,
 		"SELECT * FROM reviewer_reports WHERE student_record_id = ?", studentID)
 
 	tx, err := h.db.Beginx()
@@ -1712,7 +1726,7 @@ func (h *StudentListHandler) ReviewerReportSubmitHandler(w http.ResponseWriter,
 	}
 	defer tx.Rollback()
 
-	if err == sql.ErrNoRows {
+	if existingErr == sql.ErrNoRows {
 		// Create new report
 		_, err = tx.Exec(`
             INSERT INTO reviewer_reports (
@@ -1745,9 +1759,9 @@ func (h *StudentListHandler) ReviewerReportSubmitHandler(w http.ResponseWriter,
 			r.FormValue("review_pros"),
 			r.FormValue("review_cons"),
 			r.FormValue("review_questions"),
-			true, // Auto-sign on submission
+			false, // Signed below once the content is stored
 		)
-	} else if err == nil && !existingReport.IsSigned {
+	} else if existingErr == nil && !existingReport.IsSigned {
 		// Update existing unsigned report
 		_, err = tx.Exec(`
             UPDATE reviewer_reports SET
@@ -1765,7 +1779,7 @@ func (h *StudentListHandler) ReviewerReportSubmitHandler(w http.ResponseWriter,
                 review_questions = ?,
                 is_signed = ?,
                 updated_date = NOW()
-            WHERE id = ?`,
+            WHERE id = ? AND is_signed = FALSE`,
 			r.FormValue("reviewer_personal_details"),
 			grade,
 			r.FormValue("review_goals"),
@@ -1778,7 +1792,7 @@ func (h *StudentListHandler) ReviewerReportSubmitHandler(w http.ResponseWriter,
 			r.FormValue("review_pros"),
 			r.FormValue("review_cons"),
 			r.FormValue("review_questions"),
-			true,
+			false,
 			existingReport.ID,
 		)
 	} else {
@@ -1793,14 +1807,44 @@ func (h *StudentListHandler) ReviewerReportSubmitHandler(w http.ResponseWriter,
 		return
 	}
 
+	if err := saveRubricScores(tx, studentID, rubricScores); err != nil {
+		if err == errReportLocked {
+			http.Error(w, "Report already signed", http.StatusConflict)
+			return
+		}
+		log.Printf("Error saving rubric scores for student %d: %v", studentID, err)
+		http.Error(w, "Failed to save report", http.StatusInternalServerError)
+		return
+	}
+
+	signature, err := signReport(tx, reportKindReviewer, studentID,
+		newReportSigner(r, user.Email, user.Name, auth.RoleReviewer))
+	if err != nil {
+		if err == errReportLocked {
+			http.Error(w, "Report already signed", http.StatusConflict)
+			return
+		}
+		log.Printf("Error signing reviewer report for student %d: %v", studentID, err)
+		http.Error(w, "Failed to sign report", http.StatusInternalServerError)
+		return
+	}
+
+	if err := saveReportVersion(tx, reportKindReviewer, studentID, user.Email, auth.RoleReviewer, "Submitted and signed"); err != nil {
+		log.Printf("Error saving reviewer report version for student %d: %v", studentID, err)
+		http.Error(w, "Failed to save report", http.StatusInternalServerError)
+		return
+	}
+
 	// Create audit log
+	details := fmt.Sprintf("Submitted review for student %s (revision %d, hash %s)",
+		student.GetFullName(), signature.Revision, signature.ContentHash)
 	auditLog := database.AuditLog{
 		UserEmail:    user.Email,
 		UserRole:     user.Role,
 		Action:       "submit_reviewer_report",
 		ResourceType: "reviewer_reports",
 		ResourceID:   &studentIDStr,
-		Details:      &[]string{fmt.Sprintf("Submitted review for student %s", student.GetFullName())}[0],
+		Details:      &details,
 		Success:      true,
 	}
 	database.CreateAuditLog(auditLog)
@@ -1811,6 +1855,8 @@ func (h *StudentListHandler) ReviewerReportSubmitHandler(w http.ResponseWriter,
 		return
 	}
 
+	storeSignedReportPDF(h.db, reportKindReviewer, studentID, getReportLanguage(r))
+
 	// Return success message
 	w.Header().Set("HX-Trigger", "reviewerReportSaved")
 	w.Write([]byte(`
@@ -1858,6 +1904,9 @@ func (h *StudentListHandler) ReviewerReportSaveDraftHandler(w http.ResponseWrite
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
+	if !h.reviewerHasClearance(w, studentID, user.Email) {
+		return
+	}
 
 	// Parse form data
 	err = r.ParseForm()
@@ -1872,9 +1921,20 @@ func (h *StudentListHandler) ReviewerReportSaveDraftHandler(w http.ResponseWrite
 		grade, _ = strconv.ParseFloat(gradeStr, 64)
 	}
 
+	rubric, err := getRubricForProgram(h.db, student.StudyProgram)
+	if err != nil && err != sql.ErrNoRows {
+		http.Error(w, "Failed to load grading rubric", http.StatusInternalServerError)
+		return
+	}
+	rubricScores, err := parseRubricScores(r, rubric, false)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Check if report already exists
 	var existingReport database.ReviewerReport
-	err = h.db.Get(&existingReport,
+	existingErr := h.db.Get(&existingReport,
 		"SELECT * FROM reviewer_reports WHERE student_record_id = ?", studentID)
 
 	tx, err := h.db.Beginx()
@@ -1884,7 +1944,7 @@ func (h *StudentListHandler) ReviewerReportSaveDraftHandler(w http.ResponseWrite
 	}
 	defer tx.Rollback()
 
-	if err == sql.ErrNoRows {
+	if existingErr == sql.ErrNoRows {
 		// Create new draft report
 		_, err = tx.Exec(`
             INSERT INTO reviewer_reports (
@@ -1919,7 +1979,7 @@ func (h *StudentListHandler) ReviewerReportSaveDraftHandler(w http.ResponseWrite
 			r.FormValue("review_questions"),
 			false, // Not signed for draft
 		)
-	} else if err == nil && !existingReport.IsSigned {
+	} else if existingErr == nil && !existingReport.IsSigned {
 		// Update existing unsigned report
 		_, err = tx.Exec(`
             UPDATE reviewer_reports SET
@@ -1936,7 +1996,7 @@ func (h *StudentListHandler) ReviewerReportSaveDraftHandler(w http.ResponseWrite
                 review_cons = ?,
                 review_questions = ?,
                 updated_date = NOW()
-            WHERE id = ?`,
+            WHERE id = ? AND is_signed = FALSE`,
 			r.FormValue("reviewer_personal_details"),
 			grade,
 			r.FormValue("review_goals"),
@@ -1963,6 +2023,24 @@ func (h *StudentListHandler) ReviewerReportSaveDraftHandler(w http.ResponseWrite
 		return
 	}
 
+	if err := saveRubricScores(tx, studentID, rubricScores); err != nil {
+		tx.Rollback()
+		if err == errReportLocked {
+			http.Error(w, "Report already signed", http.StatusConflict)
+			return
+		}
+		log.Printf("Error saving rubric scores for student %d: %v", studentID, err)
+		http.Error(w, "Failed to save draft", http.StatusInternalServerError)
+		return
+	}
+
+	if err := saveReportVersion(tx, reportKindReviewer, studentID, user.Email, user.Role, "Draft saved"); err != nil {
+		tx.Rollback()
+		log.Printf("Error saving reviewer report version for student %d: %v", studentID, err)
+		http.Error(w, "Failed to save draft", http.StatusInternalServerError)
+		return
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		http.Error(w, "Failed to save draft", http.StatusInternalServerError)
@@ -2082,6 +2160,11 @@ func (h *StudentListHandler) ReviewerReportModalHandlerWithToken(w http.Response
 		formVariant = "en"
 	}
 
+	// The conflict-of-interest declaration must precede editing the review
+	if !isReadOnly && !h.reviewerConflictGate(w, r, &student, reviewerToken.ReviewerEmail, accessToken, formVariant) {
+		return
+	}
+
 	// Pass access token to form props
 	props := database.ReviewerReportFormProps{
 		StudentRecord: &student,
@@ -2089,6 +2172,8 @@ func (h *StudentListHandler) ReviewerReportModalHandlerWithToken(w http.Response
 		FormVariant:   formVariant,
 		ReviewerName:  student.ReviewerName.String,
 		AccessToken:   accessToken, // Add this line
+		IsSigned:      existingReport.IsSigned,
+		Rubric:        loadReviewerRubric(h.db, &student, existingReport.ID, formData),
 	}
 
 	err = templates.CompactReviewerForm(props, formData).Render(r.Context(), w)
@@ -2126,6 +2211,9 @@ func (h *StudentListHandler) ReviewerReportSubmitHandlerWithToken(w http.Respons
 		http.Error(w, "Student not found or access denied", http.StatusNotFound)
 		return
 	}
+	if !h.reviewerHasClearance(w, studentID, reviewerToken.ReviewerEmail) {
+		return
+	}
 
 	// Parse form data
 	err = r.ParseForm()
@@ -2156,6 +2244,17 @@ func (h *StudentListHandler) ReviewerReportSubmitHandlerWithToken(w http.Respons
 		}
 	}
 
+	rubric, err := getRubricForProgram(h.db, student.StudyProgram)
+	if err != nil && err != sql.ErrNoRows {
+		http.Error(w, "Failed to load grading rubric", http.StatusInternalServerError)
+		return
+	}
+	rubricScores, err := parseRubricScores(r, rubric, !isDraft)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Check if report already exists
 	var existingReport database.ReviewerReport
 	err = h.db.Get(&existingReport,
@@ -2207,7 +2306,7 @@ func (h *StudentListHandler) ReviewerReportSubmitHandlerWithToken(w http.Respons
 			r.FormValue("review_pros"),
 			r.FormValue("review_cons"),
 			r.FormValue("review_questions"),
-			!isDraft, // Sign if not draft
+			false, // Signed below once the content is stored
 		)
 
 		if err != nil {
@@ -2241,7 +2340,7 @@ func (h *StudentListHandler) ReviewerReportSubmitHandlerWithToken(w http.Respons
                 review_questions = ?,
                 is_signed = ?,
                 updated_date = NOW()
-            WHERE id = ?`,
+            WHERE id = ? AND is_signed = FALSE`,
 			r.FormValue("reviewer_personal_details"),
 			grade,
 			r.FormValue("review_goals"),
@@ -2254,7 +2353,7 @@ func (h *StudentListHandler) ReviewerReportSubmitHandlerWithToken(w http.Respons
 			r.FormValue("review_pros"),
 			r.FormValue("review_cons"),
 			r.FormValue("review_questions"),
-			!isDraft,
+			false,
 			existingReport.ID,
 		)
 
@@ -2275,6 +2374,37 @@ func (h *StudentListHandler) ReviewerReportSubmitHandlerWithToken(w http.Respons
 		return
 	}
 
+	if err := saveRubricScores(tx, studentID, rubricScores); err != nil {
+		if err == errReportLocked {
+			http.Error(w, "Report already signed", http.StatusConflict)
+			return
+		}
+		log.Printf("Error saving rubric scores for student %d: %v", studentID, err)
+		http.Error(w, "Failed to save report", http.StatusInternalServerError)
+		return
+	}
+
+	if !isDraft {
+		signer := newReportSigner(r, reviewerToken.ReviewerEmail, reviewerToken.ReviewerName, auth.RoleReviewer)
+		signature, err := signReport(tx, reportKindReviewer, studentID, signer)
+		if err != nil {
+			log.Printf("Error signing reviewer report for student %d: %v", studentID, err)
+			http.Error(w, "Failed to sign report", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("Reviewer report for student %d signed (revision %d, hash %s)", studentID, signature.Revision, signature.ContentHash)
+	}
+
+	changeSummary := "Submitted and signed"
+	if isDraft {
+		changeSummary = "Draft saved"
+	}
+	if err := saveReportVersion(tx, reportKindReviewer, studentID, reviewerToken.ReviewerEmail, auth.RoleReviewer, changeSummary); err != nil {
+		log.Printf("Error saving reviewer report version for student %d: %v", studentID, err)
+		http.Error(w, "Failed to save report", http.StatusInternalServerError)
+		return
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		log.Printf("Error committing transaction: %v", err)
@@ -2284,6 +2414,10 @@ func (h *StudentListHandler) ReviewerReportSubmitHandlerWithToken(w http.Respons
 
 	log.Printf("Transaction committed successfully")
 
+	if !isDraft {
+		storeSignedReportPDF(h.db, reportKindReviewer, studentID, getReportLanguage(r))
+	}
+
 	// Update access count in reviewer token
 	updateQuery := `UPDATE reviewer_access_tokens SET access_count = access_count + 1, last_accessed_at = ? WHERE id = ?`
 	_, err = h.db.Exec(updateQuery, time.Now().Unix(), reviewerToken.ID)
@@ -2427,6 +2561,12 @@ func (h *StudentListHandler) ReviewerReportModalHandler(w http.ResponseWriter, r
 	err = h.db.Get(&existingReport,
 		"SELECT * FROM reviewer_reports WHERE student_record_id = ?", studentID)
 
+	// Students see the reviewer report only after it is published by the department policy
+	if user.Role == auth.RoleStudent && (err != nil || !isReviewerReportPublished(h.db, &student, &existingReport)) {
+		http.Error(w, "Report not found", http.StatusNotFound)
+		return
+	}
+
 	formData := &database.ReviewerReportFormData{}
 	if err == nil {
 		// Report exists
@@ -2469,6 +2609,11 @@ func (h *StudentListHandler) ReviewerReportModalHandler(w http.ResponseWriter, r
 		formVariant = "en"
 	}
 
+	// The conflict-of-interest declaration must precede editing the review
+	if user.Role == auth.RoleReviewer && !isReadOnly && !h.reviewerConflictGate(w, r, &student, user.Email, "", formVariant) {
+		return
+	}
+
 	// Get reviewer name for display
 	reviewerName := ""
 	if student.ReviewerName.Valid {
@@ -2476,11 +2621,15 @@ func (h *StudentListHandler) ReviewerReportModalHandler(w http.ResponseWriter, r
 	}
 
 	props := database.ReviewerReportFormProps{
-		StudentRecord: &student,
-		IsReadOnly:    isReadOnly,
-		FormVariant:   formVariant,
-		ReviewerName:  reviewerName,
-		AccessToken:   "", // Empty for authenticated users
+		StudentRecord:  &student,
+		IsReadOnly:     isReadOnly,
+		FormVariant:    formVariant,
+		ReviewerName:   reviewerName,
+		AccessToken:    "", // Empty for authenticated users
+		IsSigned:       existingReport.IsSigned,
+		CanUnlock:      user.Role == auth.RoleAdmin,
+		CanViewHistory: existingReport.ID != 0 && (user.Role == auth.RoleAdmin || user.Role == auth.RoleDepartmentHead),
+		Rubric:         loadReviewerRubric(h.db, &student, existingReport.ID, formData),
 	}
 
 	err = templates.CompactReviewerForm(props, formData).Render(r.Context(), w)
@@ -100,6 +100,32 @@ func (h *SupervisorReportHandler) GetCompactSupervisorModal(w http.ResponseWrite
 		CurrentSupervisorName:  supervisorName,
 		CurrentSupervisorEmail: supervisorEmail,
 		IsReadOnly:             isReadOnly,
+		CanUnlock:              user != nil && user.Role == auth.RoleAdmin,
+		CanViewHistory:         existingReport != nil && user != nil && (user.Role == auth.RoleAdmin || user.Role == auth.RoleDepartmentHead),
+	}
+	if existingReport == nil && user != nil {
+		// New reports start from the supervisor's personal defaults
+		if profile := getSupervisorProfile(h.db, user.Email); profile != nil {
+			formData.SupervisorPosition = profile.SupervisorPosition
+			formData.SupervisorWorkplace = profile.SupervisorWorkplace
+		}
+	}
+	if !isReadOnly && user != nil {
+		if snippets, err := getCommentSnippets(h.db, user.Email); err != nil {
+			log.Printf("Error loading comment snippets of %s: %v", user.Email, err)
+		} else {
+			props.CommentSnippets = snippets
+		}
+	}
+	if attached, err := getLatestSimilarityReport(h.db, studentID); err == nil {
+		props.SimilarityReport = attached
+		if existingReport == nil {
+			values := attached.Values()
+			formData.OtherMatch = values["other_match"]
+			formData.OneMatch = values["one_match"]
+			formData.OwnMatch = values["own_match"]
+			formData.JoinMatch = values["join_match"]
+		}
 	}
 
 	w.Header().Set("Content-Type", "text/html")
@@ -176,14 +202,49 @@ func (h *SupervisorReportHandler) SubmitSupervisorReport(w http.ResponseWriter,
 		return
 	}
 
-	// Save to database
-	if err := h.saveSupervisorReport(reportData); err != nil {
+	// Save and sign in one transaction so the signed hash always matches the stored content
+	tx, err := h.db.Beginx()
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := h.saveSupervisorReport(tx, reportData); err != nil {
+		if err == errReportLocked {
+			http.Error(w, "Report is already signed", http.StatusConflict)
+			return
+		}
 		log.Printf("ERROR: Failed to save report: %v", err)
 		http.Error(w, "Failed to save report: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	log.Printf("DEBUG: Report saved successfully")
+	signature, err := signReport(tx, reportKindSupervisor, studentID,
+		newReportSigner(r, user.Email, supervisorName, auth.RoleSupervisor))
+	if err != nil {
+		if err == errReportLocked {
+			http.Error(w, "Report is already signed", http.StatusConflict)
+			return
+		}
+		log.Printf("ERROR: Failed to sign report: %v", err)
+		http.Error(w, "Failed to sign report", http.StatusInternalServerError)
+		return
+	}
+
+	if err := saveReportVersion(tx, reportKindSupervisor, studentID, user.Email, auth.RoleSupervisor, "Submitted and signed"); err != nil {
+		log.Printf("ERROR: Failed to save report version: %v", err)
+		http.Error(w, "Failed to save report", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ERROR: Failed to commit report: %v", err)
+		http.Error(w, "Failed to save report", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("DEBUG: Report saved and signed (revision %d, hash %s)", signature.Revision, signature.ContentHash)
 
 	// Create audit log
 	h.createAuditLog(database.AuditLog{
@@ -193,7 +254,8 @@ func (h *SupervisorReportHandler) SubmitSupervisorReport(w http.ResponseWriter,
 		ResourceType: "supervisor_report",
 		ResourceID:   database.NullableString(fmt.Sprintf("%d", studentID)),
 		Details: func() *string {
-			detailsStr := fmt.Sprintf(`{"student_id":%d,"supervisor":"%s"}`, studentID, supervisorName)
+			detailsStr := fmt.Sprintf(`{"student_id":%d,"supervisor":"%s","revision":%d,"content_hash":"%s"}`,
+				studentID, supervisorName, signature.Revision, signature.ContentHash)
 			return &detailsStr
 		}(),
 		IPAddress: database.NullableString(h.getClientIP(r)),
@@ -201,6 +263,9 @@ func (h *SupervisorReportHandler) SubmitSupervisorReport(w http.ResponseWriter,
 		Success:   true,
 	})
 
+	// Keep the official PDF of the signed report among the student's documents
+	storeSignedReportPDF(h.db, reportKindSupervisor, studentID, getReportLanguage(r))
+
 	// Return success response with proper modal closing and refresh
 	//w.Header().Set("Content-Type", "text/html")
 	//w.Header().Set("HX-Trigger", "closeModal,refreshStudentList") // Multiple triggers
@@ -278,7 +343,8 @@ func (h *SupervisorReportHandler) getSupervisorReport(studentID int) (*database.
 		SELECT id, student_record_id, supervisor_comments, supervisor_name,
 		       supervisor_position, supervisor_workplace, is_pass_or_failed,
 		       is_signed, other_match, one_match, own_match, join_match,
-		       created_date, updated_date, grade, final_comments
+		       created_date, updated_date, grade, final_comments,
+		       revision, content_hash, signed_at, similarity_report_id, similarity_mismatch
 		FROM supervisor_reports
 		WHERE student_record_id = ?
 	`
@@ -291,8 +357,9 @@ func (h *SupervisorReportHandler) getSupervisorReport(studentID int) (*database.
 	return &report, nil
 }
 
-func (h *SupervisorReportHandler) saveSupervisorReport(data *database.SupervisorReportData) error {
-	_, err := h.getSupervisorReport(data.StudentRecordID)
+// saveSupervisorReport stores the report content unsigned; signReport locks it in the same transaction
+func (h *SupervisorReportHandler) saveSupervisorReport(tx *sqlx.Tx, data *database.SupervisorReportData) error {
+	state, err := getReportState(tx, reportKindSupervisor, data.StudentRecordID, true)
 	if err == sql.ErrNoRows {
 		// Create new report
 		query := `
@@ -323,14 +390,18 @@ func (h *SupervisorReportHandler) saveSupervisorReport(data *database.Supervisor
 			"join_match":           data.JoinMatch,
 			"grade":                data.Grade,
 			"final_comments":       sql.NullString{String: data.FinalComments, Valid: data.FinalComments != ""},
-			"is_signed":            true,
+			"is_signed":            false,
 		}
 
-		_, err = h.db.NamedExec(query, params)
-		return err
+		if _, err = tx.NamedExec(query, params); err != nil {
+			return err
+		}
+		return refreshSimilarityCheck(tx, data.StudentRecordID)
 
 	} else if err != nil {
 		return err
+	} else if state.IsSigned {
+		return errReportLocked
 	} else {
 		// Update existing report
 		query := `
@@ -347,7 +418,7 @@ func (h *SupervisorReportHandler) saveSupervisorReport(data *database.Supervisor
                 final_comments = :final_comments,
                 updated_date = :updated_date,
                 is_signed = :is_signed
-            WHERE student_record_id = :student_record_id
+            WHERE student_record_id = :student_record_id AND is_signed = FALSE
         `
 
 		params := map[string]interface{}{
@@ -363,11 +434,13 @@ func (h *SupervisorReportHandler) saveSupervisorReport(data *database.Supervisor
 			"grade":                data.Grade,
 			"final_comments":       sql.NullString{String: data.FinalComments, Valid: data.FinalComments != ""},
 			"updated_date":         time.Now(),
-			"is_signed":            true,
+			"is_signed":            false,
 		}
 
-		_, err = h.db.NamedExec(query, params)
-		return err
+		if _, err = tx.NamedExec(query, params); err != nil {
+			return err
+		}
+		return refreshSimilarityCheck(tx, data.StudentRecordID)
 	}
 }
 func (h *SupervisorReportHandler) createAuditLog(log database.AuditLog) error {
@@ -425,7 +498,8 @@ func (h *SupervisorReportHandler) getSupervisorReportsBySupervisor(supervisorEma
 		SELECT sr.id, sr.student_record_id, sr.supervisor_comments, sr.supervisor_name,
 		       sr.supervisor_position, sr.supervisor_workplace, sr.is_pass_or_failed,
 		       sr.is_signed, sr.other_match, sr.one_match, sr.own_match, sr.join_match,
-		       sr.created_date, sr.updated_date, sr.grade, sr.final_comments
+		       sr.created_date, sr.updated_date, sr.grade, sr.final_comments,
+		       sr.revision, sr.content_hash, sr.signed_at, sr.similarity_report_id, sr.similarity_mismatch
 		FROM supervisor_reports sr
 		JOIN student_records st ON sr.student_record_id = st.id
 		WHERE st.supervisor_email = ?
@@ -554,20 +628,8 @@ func (h *SupervisorReportHandler) getLanguageFromRequest(r *http.Request) string
 		return lang
 	}
 
-	// Try URL parameter
-	if lang := r.URL.Query().Get("lang"); lang == "en" || lang == "lt" {
-		return lang
-	}
-
-	// Try Accept-Language header
-	if r.Header.Get("Accept-Language") != "" &&
-		len(r.Header.Get("Accept-Language")) >= 2 &&
-		r.Header.Get("Accept-Language")[:2] == "en" {
-		return "en"
-	}
-
-	// Default to Lithuanian
-	return "lt"
+	// Fall back to the URL parameter and Accept-Language header
+	return getReportLanguage(r)
 }
 
 func (h *SupervisorReportHandler) getSupervisorNameFromRequest(r *http.Request) string {
@@ -786,7 +848,7 @@ func (h *SupervisorReportHandler) SaveSupervisorDraft(w http.ResponseWriter, r *
                 own_match = ?,
                 join_match = ?,
                 updated_date = NOW()
-            WHERE id = ?`,
+            WHERE id = ? AND is_signed = FALSE`,
 			formData.SupervisorComments,
 			formData.SupervisorPosition,
 			formData.SupervisorWorkplace,
@@ -805,6 +867,18 @@ func (h *SupervisorReportHandler) SaveSupervisorDraft(w http.ResponseWriter, r *
 		}
 	}
 
+	if err := refreshSimilarityCheck(tx, studentID); err != nil {
+		log.Printf("ERROR: Failed to check similarity report: %v", err)
+		http.Error(w, "Failed to save draft", http.StatusInternalServerError)
+		return
+	}
+
+	if err := saveReportVersion(tx, reportKindSupervisor, studentID, user.Email, user.Role, "Draft saved"); err != nil {
+		log.Printf("ERROR: Failed to save report version: %v", err)
+		http.Error(w, "Failed to save draft", http.StatusInternalServerError)
+		return
+	}
+
 	// Create audit log
 	auditLog := database.AuditLog{
 		UserEmail:    user.Email,
@@ -0,0 +1,251 @@
+// handlers/supervisor_report_batch.go - Batch overview, sequential draft review and bulk signing of supervisor reports
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+)
+
+// ShowBatchOverview lists the reports of all the supervisor's students
+func (h *SupervisorReportHandler) ShowBatchOverview(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	items, err := h.loadBatch(user)
+	if err != nil {
+		log.Printf("Error loading report batch of %s: %v", user.Email, err)
+		http.Error(w, "Failed to load reports", http.StatusInternalServerError)
+		return
+	}
+
+	locale := getLocale(r)
+	templates.SupervisorReportBatchPage(user, locale, items, database.NewSupervisorBatchSummary(items)).Render(r.Context(), w)
+}
+
+// ShowBatchReview shows one draft with links to the previous and next draft of the batch
+func (h *SupervisorReportHandler) ShowBatchReview(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	studentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.loadBatch(user)
+	if err != nil {
+		log.Printf("Error loading report batch of %s: %v", user.Email, err)
+		http.Error(w, "Failed to load reports", http.StatusInternalServerError)
+		return
+	}
+
+	var drafts []database.SupervisorBatchItem
+	position := -1
+	for _, item := range items {
+		if item.Status() != "draft" {
+			continue
+		}
+		if item.Student.ID == studentID {
+			position = len(drafts)
+		}
+		drafts = append(drafts, item)
+	}
+	if position == -1 {
+		http.Error(w, "Draft not found", http.StatusNotFound)
+		return
+	}
+
+	prevID, nextID := 0, 0
+	if position > 0 {
+		prevID = drafts[position-1].Student.ID
+	}
+	if position < len(drafts)-1 {
+		nextID = drafts[position+1].Student.ID
+	}
+
+	locale := getLocale(r)
+	templates.SupervisorBatchReviewPage(user, locale, drafts[position], prevID, nextID, position+1, len(drafts)).Render(r.Context(), w)
+}
+
+// SignBatch signs the selected drafts one by one; each report is validated and signed in its own transaction
+func (h *SupervisorReportHandler) SignBatch(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	locale := getLocale(r)
+
+	items, err := h.loadBatch(user)
+	if err != nil {
+		log.Printf("Error loading report batch of %s: %v", user.Email, err)
+		http.Error(w, "Failed to load reports", http.StatusInternalServerError)
+		return
+	}
+	byStudent := make(map[int]database.SupervisorBatchItem, len(items))
+	for _, item := range items {
+		byStudent[item.Student.ID] = item
+	}
+
+	var results []database.SupervisorBatchSignResult
+	for _, value := range r.Form["student_id"] {
+		studentID, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		item, ok := byStudent[studentID]
+		result := database.SupervisorBatchSignResult{StudentID: studentID}
+		switch {
+		case !ok:
+			// Only the supervisor's own students are loaded into the batch
+			result.Error = reportLabel(locale, "Studentas nerastas", "Student not found")
+		case item.Status() == "missing":
+			result.StudentName = item.Student.GetFullName()
+			result.Error = reportLabel(locale, "Atsiliepimas neužpildytas", "Report not filled in")
+		case item.Status() == "signed":
+			result.StudentName = item.Student.GetFullName()
+			result.Error = reportLabel(locale, "Jau pasirašyta", "Already signed")
+		case item.ValidationError != "":
+			result.StudentName = item.Student.GetFullName()
+			result.Error = item.ValidationError
+		default:
+			result.StudentName = item.Student.GetFullName()
+			revision, err := h.signBatchItem(r, user, item)
+			if err != nil {
+				log.Printf("Error signing supervisor report of student %d: %v", studentID, err)
+				result.Error = reportLabel(locale, "Nepavyko pasirašyti", "Failed to sign")
+				if err == errReportLocked {
+					result.Error = reportLabel(locale, "Jau pasirašyta", "Already signed")
+				}
+			} else {
+				result.Revision = revision
+			}
+		}
+		results = append(results, result)
+	}
+
+	signed := 0
+	for _, result := range results {
+		if result.Error == "" {
+			signed++
+		}
+	}
+	details := fmt.Sprintf(`{"requested":%d,"signed":%d}`, len(results), signed)
+	h.createAuditLog(database.AuditLog{
+		UserEmail:    user.Email,
+		UserRole:     user.Role,
+		Action:       "bulk_sign_supervisor_reports",
+		ResourceType: "supervisor_report",
+		Details:      &details,
+		IPAddress:    database.NullableString(h.getClientIP(r)),
+		UserAgent:    database.NullableString(r.UserAgent()),
+		Success:      signed > 0,
+		CreatedAt:    time.Now(),
+	})
+
+	w.Header().Set("Content-Type", "text/html")
+	templates.SupervisorBatchSignResults(results, locale).Render(r.Context(), w)
+}
+
+// signBatchItem signs one draft exactly as the single report submission does
+func (h *SupervisorReportHandler) signBatchItem(r *http.Request, user *auth.AuthenticatedUser, item database.SupervisorBatchItem) (int, error) {
+	tx, err := h.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	signature, err := signReport(tx, reportKindSupervisor, item.Student.ID,
+		newReportSigner(r, user.Email, user.Name, auth.RoleSupervisor))
+	if err != nil {
+		return 0, err
+	}
+	if err := saveReportVersion(tx, reportKindSupervisor, item.Student.ID, user.Email, auth.RoleSupervisor, "Signed in bulk"); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	h.createAuditLog(database.AuditLog{
+		UserEmail:    user.Email,
+		UserRole:     auth.RoleSupervisor,
+		Action:       "sign_supervisor_report",
+		ResourceType: "supervisor_report",
+		ResourceID:   database.NullableString(strconv.Itoa(item.Student.ID)),
+		Details: func() *string {
+			detailsStr := fmt.Sprintf(`{"student_id":%d,"revision":%d,"content_hash":"%s","bulk":true}`,
+				item.Student.ID, signature.Revision, signature.ContentHash)
+			return &detailsStr
+		}(),
+		IPAddress: database.NullableString(h.getClientIP(r)),
+		UserAgent: database.NullableString(r.UserAgent()),
+		Success:   true,
+	})
+
+	storeSignedReportPDF(h.db, reportKindSupervisor, item.Student.ID, getReportLanguage(r))
+	return signature.Revision, nil
+}
+
+// loadBatch joins the supervisor's students with their reports and validates the drafts
+func (h *SupervisorReportHandler) loadBatch(user *auth.AuthenticatedUser) ([]database.SupervisorBatchItem, error) {
+	var studentIDs []int
+	if err := h.db.Select(&studentIDs, "SELECT id FROM student_records WHERE supervisor_email = ?", user.Email); err != nil {
+		return nil, err
+	}
+	students, err := h.getMultipleStudentRecords(studentIDs)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := h.getSupervisorReportsBySupervisor(user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	reportByStudent := make(map[int]*database.SupervisorReport, len(reports))
+	for i := range reports {
+		reportByStudent[reports[i].StudentRecordID] = &reports[i]
+	}
+
+	sort.Slice(students, func(a, b int) bool {
+		if students[a].StudentLastname != students[b].StudentLastname {
+			return students[a].StudentLastname < students[b].StudentLastname
+		}
+		return students[a].StudentName < students[b].StudentName
+	})
+
+	items := make([]database.SupervisorBatchItem, 0, len(students))
+	for _, student := range students {
+		item := database.SupervisorBatchItem{Student: student, Report: reportByStudent[student.ID]}
+		if item.Status() == "draft" {
+			data := item.Report.ToReportData()
+			if data.SupervisorName == "" {
+				data.SupervisorName = user.Name
+			}
+			if err := data.Validate(); err != nil {
+				item.ValidationError = err.Error()
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,208 @@
+// handlers/supervisor_templates.go - Supervisor profile defaults and reusable comment snippets
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+const maxSnippetLength = 5000
+
+type SupervisorTemplateHandler struct {
+	db *sqlx.DB
+}
+
+func NewSupervisorTemplateHandler(db *sqlx.DB) *SupervisorTemplateHandler {
+	return &SupervisorTemplateHandler{db: db}
+}
+
+// getSupervisorProfile returns the saved defaults of the supervisor, or nil when there are none
+func getSupervisorProfile(q sqlx.Queryer, email string) *database.SupervisorProfile {
+	var profile database.SupervisorProfile
+	err := sqlx.Get(q, &profile, "SELECT * FROM supervisor_profiles WHERE supervisor_email = ?", email)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Error loading supervisor profile of %s: %v", email, err)
+		}
+		return nil
+	}
+	return &profile
+}
+
+// getCommentSnippets lists the supervisor's snippets by title
+func getCommentSnippets(q sqlx.Queryer, email string) ([]database.CommentSnippet, error) {
+	var snippets []database.CommentSnippet
+	err := sqlx.Select(q, &snippets,
+		"SELECT * FROM supervisor_comment_snippets WHERE supervisor_email = ? ORDER BY title, id", email)
+	return snippets, err
+}
+
+// canUseReportTemplates allows everyone who writes supervisor reports
+func canUseReportTemplates(user *auth.AuthenticatedUser) bool {
+	return user != nil && (user.Role == auth.RoleSupervisor || user.Role == auth.RoleAdmin || user.Role == auth.RoleDepartmentHead)
+}
+
+// ShowTemplates renders the profile defaults and the snippet library of the current user
+func (h *SupervisorTemplateHandler) ShowTemplates(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if !canUseReportTemplates(user) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	profile := getSupervisorProfile(h.db, user.Email)
+	if profile == nil {
+		profile = &database.SupervisorProfile{SupervisorEmail: user.Email, SupervisorPosition: user.JobTitle}
+	}
+
+	snippets, err := getCommentSnippets(h.db, user.Email)
+	if err != nil {
+		log.Printf("Error loading comment snippets of %s: %v", user.Email, err)
+		http.Error(w, "Failed to load snippets", http.StatusInternalServerError)
+		return
+	}
+
+	locale := getLocale(r)
+	templates.SupervisorTemplatesPage(user, locale, profile, snippets, r.URL.Query().Get("saved") == "1").Render(r.Context(), w)
+}
+
+// SaveProfile stores the position and workplace defaults
+func (h *SupervisorTemplateHandler) SaveProfile(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if !canUseReportTemplates(user) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	locale := getLocale(r)
+
+	position := strings.TrimSpace(r.FormValue("supervisor_position"))
+	workplace := strings.TrimSpace(r.FormValue("supervisor_workplace"))
+	if len(position) > 255 || len(workplace) > 255 {
+		renderTemplateError(w, "#profile-error", reportLabel(locale, "Per ilga reikšmė", "Value is too long"))
+		return
+	}
+
+	_, err := h.db.Exec(`
+        INSERT INTO supervisor_profiles (supervisor_email, supervisor_position, supervisor_workplace)
+        VALUES (?, ?, ?)
+        ON DUPLICATE KEY UPDATE supervisor_position = VALUES(supervisor_position),
+            supervisor_workplace = VALUES(supervisor_workplace)`,
+		user.Email, position, workplace)
+	if err != nil {
+		log.Printf("Error saving supervisor profile of %s: %v", user.Email, err)
+		http.Error(w, "Failed to save profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/report-templates?saved=1&locale="+locale)
+}
+
+// SaveSnippet creates a snippet, or updates one when an id is posted
+func (h *SupervisorTemplateHandler) SaveSnippet(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if !canUseReportTemplates(user) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	locale := getLocale(r)
+
+	title := strings.TrimSpace(r.FormValue("title"))
+	body := strings.TrimSpace(r.FormValue("body"))
+	id, _ := strconv.Atoi(r.FormValue("id"))
+	target := fmt.Sprintf("#snippet-error-%d", id)
+	if title == "" || body == "" {
+		renderTemplateError(w, target, reportLabel(locale, "Įveskite pavadinimą ir tekstą", "Enter a title and text"))
+		return
+	}
+	if len(title) > 100 || len(body) > maxSnippetLength {
+		renderTemplateError(w, target, reportLabel(locale, "Per ilga reikšmė", "Value is too long"))
+		return
+	}
+
+	var err error
+	if id > 0 {
+		var result sql.Result
+		result, err = h.db.Exec(`
+            UPDATE supervisor_comment_snippets SET title = ?, body = ?
+            WHERE id = ? AND supervisor_email = ?`,
+			title, body, id, user.Email)
+		if err == nil {
+			if rows, _ := result.RowsAffected(); rows == 0 {
+				// MySQL reports 0 rows for unchanged values too, so check ownership separately
+				var owner string
+				if h.db.Get(&owner, "SELECT supervisor_email FROM supervisor_comment_snippets WHERE id = ?", id) != nil || owner != user.Email {
+					http.Error(w, "Snippet not found", http.StatusNotFound)
+					return
+				}
+			}
+		}
+	} else {
+		_, err = h.db.Exec(`
+            INSERT INTO supervisor_comment_snippets (supervisor_email, title, body)
+            VALUES (?, ?, ?)`,
+			user.Email, title, body)
+	}
+	if err != nil {
+		log.Printf("Error saving comment snippet of %s: %v", user.Email, err)
+		http.Error(w, "Failed to save snippet", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/report-templates?saved=1&locale="+locale)
+}
+
+// DeleteSnippet removes one of the user's snippets
+func (h *SupervisorTemplateHandler) DeleteSnippet(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if !canUseReportTemplates(user) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid snippet ID", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM supervisor_comment_snippets WHERE id = ? AND supervisor_email = ?", id, user.Email)
+	if err != nil {
+		log.Printf("Error deleting comment snippet %d: %v", id, err)
+		http.Error(w, "Failed to delete snippet", http.StatusInternalServerError)
+		return
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		http.Error(w, "Snippet not found", http.StatusNotFound)
+		return
+	}
+
+	// hx-swap="outerHTML" removes the snippet card
+	w.WriteHeader(http.StatusOK)
+}
+
+// renderTemplateError shows the message in the error slot of the submitted form
+func renderTemplateError(w http.ResponseWriter, target, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("HX-Retarget", target)
+	w.Header().Set("HX-Reswap", "innerHTML")
+	fmt.Fprintf(w, `<div class="bg-red-50 border border-red-200 text-red-800 px-3 py-2 rounded text-sm">❌ %s</div>`,
+		html.EscapeString(message))
+}
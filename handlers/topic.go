@@ -15,12 +15,15 @@ import (
 	"FinalProjectManagementApp/auth"
 	"FinalProjectManagementApp/components/templates"
 	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/notifications"
+	"FinalProjectManagementApp/textdiff"
 	"github.com/go-chi/chi/v5"
 )
 
 // Update the struct definition
 type TopicHandlers struct {
-	db *sqlx.DB // Change from *sql.DB to *sqlx.DB
+	db                  *sqlx.DB // Change from *sql.DB to *sqlx.DB
+	notificationService *notifications.NotificationService
 }
 
 // Update the constructor
@@ -51,9 +54,10 @@ func (h *TopicHandlers) ShowTopicRegistrationForm(w http.ResponseWriter, r *http
 	// Get comments if topic exists
 	var comments []database.TopicRegistrationComment
 	var versions []database.ProjectTopicRegistrationVersion // ADD THIS
+	var changeRequests []database.TopicChangeRequest
 
 	if topic != nil {
-		comments, err = h.getTopicComments(topic.ID)
+		comments, err = h.getTopicComments(topic.ID, user.Email)
 		if err != nil {
 			comments = []database.TopicRegistrationComment{}
 		}
@@ -63,11 +67,16 @@ func (h *TopicHandlers) ShowTopicRegistrationForm(w http.ResponseWriter, r *http
 		if err != nil {
 			versions = []database.ProjectTopicRegistrationVersion{}
 		}
+
+		changeRequests, err = h.getTopicChangeRequests(topic.ID)
+		if err != nil {
+			changeRequests = []database.TopicChangeRequest{}
+		}
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	// PASS VERSIONS TO THE TEMPLATE - UPDATE THIS
-	err = templates.TopicRegistrationModal(user, topic, comments, versions, locale).Render(r.Context(), w)
+	err = templates.TopicRegistrationModal(user, topic, comments, versions, changeRequests, false, locale).Render(r.Context(), w)
 
 	if err != nil {
 		http.Error(w, "Failed to render template", http.StatusInternalServerError)
@@ -217,6 +226,18 @@ func (h *TopicHandlers) SubmitTopicForReview(w http.ResponseWriter, r *http.Requ
 			h.renderFormError(w, fmt.Sprintf("Topic cannot be submitted in current status: %s", existingTopic.Status))
 			return
 		}
+
+		// Required-change comments must be resolved or answered before resubmission
+		blocking, err := h.countBlockingComments(existingTopic.ID)
+		if err != nil {
+			log.Printf("SubmitTopicForReview: Failed to check open review comments: %v", err)
+			h.renderFormError(w, "Database error")
+			return
+		}
+		if blocking > 0 {
+			h.renderFormError(w, fmt.Sprintf("%d required change(s) must be resolved or answered before resubmitting", blocking))
+			return
+		}
 		topicID = existingTopic.ID
 		err = h.updateTopicAndSubmit(topicID, topicData, user)
 		if err != nil {
@@ -452,7 +473,7 @@ func (h *TopicHandlers) RejectTopic(w http.ResponseWriter, r *http.Request) {
 	h.renderApprovalSuccess(w, "Topic rejected")
 }
 
-// AddComment handles adding comments to topics
+// AddComment handles adding comments, field-anchored review comments and replies to topics
 func (h *TopicHandlers) AddComment(w http.ResponseWriter, r *http.Request) {
 	user := auth.GetUserFromContext(r.Context())
 	if user == nil {
@@ -467,6 +488,10 @@ func (h *TopicHandlers) AddComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := h.authorizeTopicComments(w, user, topicID); !ok {
+		return
+	}
+
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
 		return
@@ -474,7 +499,7 @@ func (h *TopicHandlers) AddComment(w http.ResponseWriter, r *http.Request) {
 
 	comment := database.TopicRegistrationComment{
 		TopicRegistrationID: topicID,
-		CommentText:         r.FormValue("comment"),
+		CommentText:         strings.TrimSpace(r.FormValue("comment")),
 		AuthorRole:          user.Role,
 		AuthorName:          user.Name,
 		AuthorEmail:         user.Email,
@@ -483,19 +508,58 @@ func (h *TopicHandlers) AddComment(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:           time.Now(),
 	}
 
-	if comment.CommentType == "" {
-		comment.CommentType = "comment"
+	if comment.CommentText == "" {
+		h.renderFormError(w, "Comment text is required")
+		return
+	}
+
+	switch comment.CommentType {
+	case "":
+		comment.CommentType = database.CommentTypeComment
+	case database.CommentTypeRequiredChange:
+		// Only reviewers of the topic can demand changes from the student
+		if user.Role == auth.RoleStudent {
+			h.renderFormError(w, "Students cannot create required-change comments")
+			return
+		}
+	}
+
+	if parentIDStr := r.FormValue("parent_comment_id"); parentIDStr != "" {
+		parentID, err := strconv.Atoi(parentIDStr)
+		if err != nil {
+			h.renderFormError(w, "Invalid parent comment")
+			return
+		}
+
+		parent, err := h.getCommentByID(parentID)
+		if err != nil || parent.TopicRegistrationID != topicID {
+			h.renderFormError(w, "Parent comment not found")
+			return
+		}
+
+		// Threads are one level deep - replies to replies attach to the root comment
+		if parent.ParentCommentID != nil {
+			parentID = *parent.ParentCommentID
+		}
+		comment.ParentCommentID = &parentID
+		comment.FieldName = parent.FieldName
+		comment.CommentType = database.CommentTypeComment
+	} else if fieldName := r.FormValue("field_name"); fieldName != "" {
+		if !database.IsValidTopicCommentField(fieldName) {
+			h.renderFormError(w, "Invalid topic field")
+			return
+		}
+		comment.FieldName = &fieldName
 	}
 
 	err = h.addTopicComment(&comment)
 	if err != nil {
+		log.Printf("Error adding comment to topic %d: %v", topicID, err)
 		http.Error(w, "Failed to add comment", http.StatusInternalServerError)
 		return
 	}
 
-	// Return the new comment HTML
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	templates.CommentCard(comment, "lt").Render(r.Context(), w)
+	h.renderCommentsSection(w, r, user, topicID)
 }
 
 // New methods for role-specific views
@@ -536,18 +600,6 @@ func (h *TopicHandlers) ShowDepartmentTopics(w http.ResponseWriter, r *http.Requ
 	w.Write([]byte("<h1>Department Topics - Coming Soon</h1>"))
 }
 
-func (h *TopicHandlers) ShowPendingDepartmentTopics(w http.ResponseWriter, r *http.Request) {
-	user := auth.GetUserFromContext(r.Context())
-	if user == nil || user.Role != auth.RoleDepartmentHead {
-		http.Error(w, "Unauthorized", http.StatusForbidden)
-		return
-	}
-
-	// TODO: Implement pending department topics view
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write([]byte("<h1>Pending Department Topics - Coming Soon</h1>"))
-}
-
 func (h *TopicHandlers) ShowAllTopics(w http.ResponseWriter, r *http.Request) {
 	user := auth.GetUserFromContext(r.Context())
 	if user == nil || user.Role != auth.RoleAdmin {
@@ -988,62 +1040,49 @@ func (h *TopicHandlers) ShowTopicVersionHistory(w http.ResponseWriter, r *http.R
 //	templates.VersionDiffModal(previousVersionData, &versionData, changes, locale).Render(r.Context(), w)
 //}
 
-// Helper method to compare two versions
-func (h *TopicHandlers) compareVersions(old, new *database.ProjectTopicRegistration) map[string][]string {
-	changes := make(map[string][]string)
-
-	// Compare title
-	if old.Title != new.Title {
-		changes["Tema (Lietuvių k.) / Title (Lithuanian)"] = []string{old.Title, new.Title}
-	}
-
-	// Compare English title
-	if old.TitleEn != new.TitleEn {
-		changes["Tema (Anglų k.) / Title (English)"] = []string{old.TitleEn, new.TitleEn}
-	}
-
-	// Compare problem
-	if old.Problem != new.Problem {
-		changes["Problemos aprašymas / Problem Description"] = []string{old.Problem, new.Problem}
-	}
-
-	// Compare objective
-	if old.Objective != new.Objective {
-		changes["Tikslas / Objective"] = []string{old.Objective, new.Objective}
-	}
-
-	// Compare tasks
-	if old.Tasks != new.Tasks {
-		changes["Uždaviniai / Tasks"] = []string{old.Tasks, new.Tasks}
-	}
+// topicDiffFields lists the compared topic fields in display order
+var topicDiffFields = []string{"title", "title_en", "problem", "objective", "tasks", "supervisor", "completion_date"}
 
-	// Compare supervisor
-	if old.Supervisor != new.Supervisor {
-		changes["Vadovas / Supervisor"] = []string{old.Supervisor, new.Supervisor}
-	}
+// buildTopicFieldDiffs computes word-level diffs for every changed topic field
+func (h *TopicHandlers) buildTopicFieldDiffs(old, new *database.ProjectTopicRegistration, includeUnchanged bool) []textdiff.FieldDiff {
+	var diffs []textdiff.FieldDiff
 
-	// Compare completion date
-	oldDate := ""
-	newDate := ""
-	if old.CompletionDate != nil {
-		oldDate = *old.CompletionDate
-	}
-	if new.CompletionDate != nil {
-		newDate = *new.CompletionDate
-	}
-	if oldDate != newDate {
-		changes["Užbaigimo data / Completion Date"] = []string{oldDate, newDate}
+	for _, field := range topicDiffFields {
+		diff := textdiff.NewFieldDiff(field, topicFieldValue(old, field), topicFieldValue(new, field))
+		if diff.Changed() || includeUnchanged {
+			diffs = append(diffs, diff)
+		}
 	}
 
-	// Compare status
+	// Status is compared as a whole value, word diffs make no sense for it
 	if old.Status != new.Status {
-		changes["Būsena / Status"] = []string{
+		diffs = append(diffs, textdiff.NewFieldDiff("status",
 			h.getStatusDisplayForDiff(old.Status),
-			h.getStatusDisplayForDiff(new.Status),
-		}
+			h.getStatusDisplayForDiff(new.Status)))
 	}
 
-	return changes
+	return diffs
+}
+
+// topicFieldValue returns a topic field as plain text
+func topicFieldValue(topic *database.ProjectTopicRegistration, field string) string {
+	switch field {
+	case "title":
+		return topic.Title
+	case "title_en":
+		return topic.TitleEn
+	case "problem":
+		return topic.Problem
+	case "objective":
+		return topic.Objective
+	case "tasks":
+		return topic.Tasks
+	case "supervisor":
+		return topic.Supervisor
+	case "completion_date":
+		return database.StringValue(topic.CompletionDate)
+	}
+	return ""
 }
 
 // Helper to get status display for diff
@@ -1112,17 +1151,19 @@ func (h *TopicHandlers) updateTopicStatus(topicID int, status, approverEmail, re
 	return err
 }
 
-func (h *TopicHandlers) getTopicComments(topicID int) ([]database.TopicRegistrationComment, error) {
+func (h *TopicHandlers) getTopicComments(topicID int, viewerEmail string) ([]database.TopicRegistrationComment, error) {
 	query := `
-        SELECT id, topic_registration_id, field_name, comment_text, author_role,
-               author_name, author_email, created_at, parent_comment_id, is_read,
-               comment_type
-        FROM topic_registration_comments
-        WHERE topic_registration_id = ?
-        ORDER BY created_at ASC
+        SELECT c.id, c.topic_registration_id, c.field_name, c.comment_text, c.author_role,
+               c.author_name, c.author_email, c.created_at, c.parent_comment_id, c.is_read,
+               c.comment_type, COALESCE(c.is_resolved, FALSE), c.resolved_by, c.resolved_at,
+               (c.author_email <> ? AND r.id IS NULL) AS is_unread
+        FROM topic_registration_comments c
+        LEFT JOIN topic_comment_reads r ON r.comment_id = c.id AND r.user_email = ?
+        WHERE c.topic_registration_id = ?
+        ORDER BY c.created_at ASC, c.id ASC
     `
 
-	rows, err := h.db.Query(query, topicID)
+	rows, err := h.db.Query(query, viewerEmail, viewerEmail, topicID)
 	if err != nil {
 		return nil, err
 	}
@@ -1131,20 +1172,22 @@ func (h *TopicHandlers) getTopicComments(topicID int) ([]database.TopicRegistrat
 	var comments []database.TopicRegistrationComment
 	for rows.Next() {
 		var comment database.TopicRegistrationComment
-		var fieldName sql.NullString
+		var fieldName, resolvedBy sql.NullString
 		var parentCommentID sql.NullInt32
+		var resolvedAt sql.NullInt64
 
 		err := rows.Scan(
 			&comment.ID, &comment.TopicRegistrationID, &fieldName,
 			&comment.CommentText, &comment.AuthorRole, &comment.AuthorName,
 			&comment.AuthorEmail, &comment.CreatedAt, &parentCommentID,
-			&comment.IsRead, &comment.CommentType,
+			&comment.IsRead, &comment.CommentType, &comment.IsResolved,
+			&resolvedBy, &resolvedAt, &comment.IsUnread,
 		)
 		if err != nil {
 			continue
 		}
 
-		if fieldName.Valid {
+		if fieldName.Valid && fieldName.String != "" {
 			comment.FieldName = &fieldName.String
 		}
 
@@ -1155,25 +1198,41 @@ func (h *TopicHandlers) getTopicComments(topicID int) ([]database.TopicRegistrat
 			comment.ParentCommentID = nil
 		}
 
+		if resolvedBy.Valid {
+			comment.ResolvedBy = &resolvedBy.String
+		}
+		if resolvedAt.Valid {
+			comment.ResolvedAt = &resolvedAt.Int64
+		}
+
 		comments = append(comments, comment)
 	}
 
-	return comments, nil
+	return database.BuildCommentThreads(comments), nil
 }
 
 func (h *TopicHandlers) addTopicComment(comment *database.TopicRegistrationComment) error {
 	query := `
        INSERT INTO topic_registration_comments (
-           topic_registration_id, comment_text, author_role, author_name,
-           author_email, comment_type, is_read
-       ) VALUES (?, ?, ?, ?, ?, ?, ?)
+           topic_registration_id, field_name, parent_comment_id, comment_text,
+           author_role, author_name, author_email, comment_type, is_read
+       ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
    `
 
-	_, err := h.db.Exec(query,
-		comment.TopicRegistrationID, comment.CommentText, comment.AuthorRole,
-		comment.AuthorName, comment.AuthorEmail, comment.CommentType, comment.IsRead)
+	result, err := h.db.Exec(query,
+		comment.TopicRegistrationID, comment.FieldName, comment.ParentCommentID,
+		comment.CommentText, comment.AuthorRole, comment.AuthorName,
+		comment.AuthorEmail, comment.CommentType, comment.IsRead)
+	if err != nil {
+		return err
+	}
 
-	return err
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	comment.ID = int(id)
+	return nil
 }
 
 // ShowTopicRegistrationModal displays the topic registration form in a modal
@@ -1219,9 +1278,12 @@ func (h *TopicHandlers) ShowTopicRegistrationModal(w http.ResponseWriter, r *htt
 	// Get comments if topic exists
 	var comments []database.TopicRegistrationComment
 	var versions []database.ProjectTopicRegistrationVersion // ADD THIS
+	var changeRequests []database.TopicChangeRequest
 
+	supervisesTopic := false
 	if topic != nil {
-		comments, err = h.getTopicComments(topic.ID)
+		_, supervisesTopic = h.topicCommentAccess(user, topic)
+		comments, err = h.getTopicComments(topic.ID, user.Email)
 		if err != nil {
 			comments = []database.TopicRegistrationComment{}
 		}
@@ -1231,11 +1293,16 @@ func (h *TopicHandlers) ShowTopicRegistrationModal(w http.ResponseWriter, r *htt
 		if err != nil {
 			versions = []database.ProjectTopicRegistrationVersion{}
 		}
+
+		changeRequests, err = h.getTopicChangeRequests(topic.ID)
+		if err != nil {
+			changeRequests = []database.TopicChangeRequest{}
+		}
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	// PASS VERSIONS TO THE TEMPLATE - UPDATE THIS
-	err = templates.TopicRegistrationModal(user, topic, comments, versions, locale).Render(r.Context(), w)
+	err = templates.TopicRegistrationModal(user, topic, comments, versions, changeRequests, supervisesTopic, locale).Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render template", http.StatusInternalServerError)
 	}
@@ -1504,23 +1571,19 @@ func (h *TopicHandlers) ShowVersionChanges(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Get the previous version for comparison (if exists)
-	changes := make(map[string][]string)
-
+	// Compare with the previous version, version 1 is compared with an empty topic
+	previousData := &database.ProjectTopicRegistration{}
 	if versionNumber > 1 {
 		var previousVersion database.ProjectTopicRegistrationVersion
 		err = h.db.Get(&previousVersion, query, topicID, versionNumber-1)
 		if err == nil {
 			var prevData database.ProjectTopicRegistration
 			if err := json.Unmarshal([]byte(previousVersion.VersionData), &prevData); err == nil {
-				changes = h.compareVersions(&prevData, &versionData)
+				previousData = &prevData
 			}
 		}
-	} else {
-		// For version 1, compare with empty state
-		emptyTopic := &database.ProjectTopicRegistration{}
-		changes = h.compareVersions(emptyTopic, &versionData)
 	}
+	changes := h.buildTopicFieldDiffs(previousData, &versionData, false)
 
 	// Get locale
 	locale := r.URL.Query().Get("locale")
@@ -1563,61 +1626,102 @@ func (h *TopicHandlers) GetTopicContent(w http.ResponseWriter, r *http.Request)
 	templates.TopicContentDisplay(topic, nil, false, locale).Render(r.Context(), w)
 }
 
-// CompareTopicVersions returns comparison view
+// CompareTopicVersions returns comparison view of a stored version against the current topic
 func (h *TopicHandlers) CompareTopicVersions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	query.Set("from", chi.URLParam(r, "versionId"))
+	if query.Get("to") == "" {
+		query.Set("to", "current")
+	}
+	r.URL.RawQuery = query.Encode()
+
+	h.ShowTopicVersionDiff(w, r)
+}
+
+// ShowTopicVersionDiff renders a word-level diff between any two topic versions.
+// "from" and "to" are version numbers or "current" for the live topic,
+// "view" selects inline or side-by-side rendering.
+func (h *TopicHandlers) ShowTopicVersionDiff(w http.ResponseWriter, r *http.Request) {
 	user := auth.GetUserFromContext(r.Context())
 	if user == nil {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	topicIDStr := chi.URLParam(r, "id")
-	versionIDStr := chi.URLParam(r, "versionId")
-
-	topicID, err := strconv.Atoi(topicIDStr)
+	topicID, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
 		http.Error(w, "Invalid topic ID", http.StatusBadRequest)
 		return
 	}
 
-	versionNumber, err := strconv.Atoi(versionIDStr)
+	currentTopic, err := h.getTopicByID(topicID)
 	if err != nil {
-		http.Error(w, "Invalid version ID", http.StatusBadRequest)
+		http.Error(w, "Topic not found", http.StatusNotFound)
 		return
 	}
 
-	// Get current topic
-	currentTopic, err := h.getTopicByID(topicID)
+	// The diff shows the same drafts as the comment thread
+	if participates, _ := h.topicCommentAccess(user, currentTopic); !participates {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	versions, err := h.getTopicVersions(topicID)
 	if err != nil {
-		http.Error(w, "Topic not found", http.StatusNotFound)
+		http.Error(w, "Failed to load versions", http.StatusInternalServerError)
 		return
 	}
 
-	// Get comparison version
-	var comparisonVersion database.ProjectTopicRegistrationVersion
-	query := `
-		SELECT * FROM project_topic_registration_versions 
-		WHERE topic_registration_id = ? AND version_number = ?
-	`
-	err = h.db.Get(&comparisonVersion, query, topicID, versionNumber)
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if to == "" {
+		to = "current"
+	}
+	if from == "" {
+		// Default to the latest stored version
+		from = "current"
+		if len(versions) > 0 {
+			from = strconv.Itoa(versions[0].VersionNumber)
+		}
+	}
+
+	fromTopic, err := h.getTopicSnapshot(currentTopic, versions, from)
 	if err != nil {
 		http.Error(w, "Version not found", http.StatusNotFound)
 		return
 	}
-
-	// Parse comparison version data
-	var comparisonTopic database.ProjectTopicRegistration
-	err = json.Unmarshal([]byte(comparisonVersion.VersionData), &comparisonTopic)
+	toTopic, err := h.getTopicSnapshot(currentTopic, versions, to)
 	if err != nil {
-		http.Error(w, "Failed to parse version data", http.StatusInternalServerError)
+		http.Error(w, "Version not found", http.StatusNotFound)
 		return
 	}
 
-	locale := r.URL.Query().Get("locale")
-	if locale == "" {
-		locale = "lt"
+	view := r.URL.Query().Get("view")
+	if view != "side" {
+		view = "inline"
 	}
 
+	diffs := h.buildTopicFieldDiffs(fromTopic, toTopic, true)
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	templates.TopicContentDisplay(currentTopic, &comparisonTopic, true, locale).Render(r.Context(), w)
+	templates.TopicVersionDiffView(topicID, versions, from, to, diffs, view, getLocale(r)).Render(r.Context(), w)
+}
+
+// getTopicSnapshot resolves a version reference to the topic data stored for it
+func (h *TopicHandlers) getTopicSnapshot(current *database.ProjectTopicRegistration, versions []database.ProjectTopicRegistrationVersion, ref string) (*database.ProjectTopicRegistration, error) {
+	if ref == "current" {
+		return current, nil
+	}
+
+	versionNumber, err := strconv.Atoi(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version reference %q", ref)
+	}
+
+	for i := range versions {
+		if versions[i].VersionNumber == versionNumber {
+			return versions[i].GetVersionData()
+		}
+	}
+	return nil, sql.ErrNoRows
 }
@@ -0,0 +1,291 @@
+// handlers/topic_bulk_review.go - Bulk department review of pending topics
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/notifications"
+)
+
+const (
+	bulkActionApprove  = "approve"
+	bulkActionRevision = "revision"
+)
+
+// SetNotificationService enables e-mail notifications sent directly by topic handlers
+func (h *TopicHandlers) SetNotificationService(service *notifications.NotificationService) {
+	h.notificationService = service
+}
+
+// ShowPendingDepartmentTopics lists topics awaiting department review with bulk actions
+func (h *TopicHandlers) ShowPendingDepartmentTopics(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleDepartmentHead && user.Role != auth.RoleAdmin) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		log.Printf("Error getting department of %s: %v", user.Email, err)
+		http.Error(w, "Department head information not found", http.StatusForbidden)
+		return
+	}
+
+	topics, err := h.getPendingDepartmentTopics(department)
+	if err != nil {
+		log.Printf("Error loading pending department topics: %v", err)
+		http.Error(w, "Failed to load pending topics", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.PendingDepartmentTopicsPage(user, topics, getLocale(r)).Render(r.Context(), w); err != nil {
+		log.Printf("Error rendering pending department topics: %v", err)
+	}
+}
+
+// BulkReviewTopics approves or returns for revision many topics at once.
+// Every topic is decided in its own transaction so one failure does not block the rest.
+func (h *TopicHandlers) BulkReviewTopics(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleDepartmentHead && user.Role != auth.RoleAdmin) {
+		h.renderApprovalError(w, "Unauthorized access")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderApprovalError(w, "Invalid form data")
+		return
+	}
+
+	action := r.FormValue("action")
+	if action != bulkActionApprove && action != bulkActionRevision {
+		h.renderApprovalError(w, "Invalid action")
+		return
+	}
+
+	topicIDs := r.Form["topic_ids"]
+	if len(topicIDs) == 0 {
+		h.renderApprovalError(w, "Select at least one topic")
+		return
+	}
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		h.renderApprovalError(w, "Department head information not found")
+		return
+	}
+
+	sharedReason := strings.TrimSpace(r.FormValue("shared_reason"))
+
+	results := make([]database.BulkTopicReviewResult, 0, len(topicIDs))
+	for _, idStr := range topicIDs {
+		topicID, err := strconv.Atoi(idStr)
+		if err != nil {
+			results = append(results, database.BulkTopicReviewResult{Message: "Invalid topic ID: " + idStr})
+			continue
+		}
+
+		reason := strings.TrimSpace(r.FormValue("reason_" + idStr))
+		if reason == "" {
+			reason = sharedReason
+		}
+
+		results = append(results, h.reviewTopicInBulk(user, department, topicID, action, reason))
+	}
+
+	h.sendBulkReviewDigests(results, action == bulkActionApprove)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.BulkTopicReviewResults(results, getLocale(r)).Render(r.Context(), w); err != nil {
+		log.Printf("Error rendering bulk review results: %v", err)
+	}
+}
+
+// reviewTopicInBulk decides a single topic and reports the outcome instead of failing the request
+func (h *TopicHandlers) reviewTopicInBulk(user *auth.AuthenticatedUser, department string, topicID int, action, reason string) database.BulkTopicReviewResult {
+	result := database.BulkTopicReviewResult{TopicID: topicID, Reason: reason}
+
+	summary, err := h.getPendingTopicSummary(topicID)
+	if err != nil {
+		result.Message = "Topic not found"
+		return result
+	}
+	result.StudentName = summary.GetStudentFullName()
+	result.Title = summary.Title
+	result.SupervisorEmail = summary.SupervisorEmail
+
+	if department != "" && !strings.EqualFold(summary.Department, department) {
+		result.Message = "Topic belongs to another department"
+		return result
+	}
+	if !summary.IsAwaitingDepartment() {
+		result.Message = "Topic is not awaiting department review"
+		return result
+	}
+	if action == bulkActionRevision && reason == "" {
+		result.Message = "Revision reason is required"
+		return result
+	}
+
+	if action == bulkActionApprove {
+		err = h.bulkApproveTopic(user, topicID)
+	} else {
+		err = h.bulkRequestRevision(user, topicID, reason)
+	}
+	if err != nil {
+		log.Printf("Bulk review of topic %d failed: %v", topicID, err)
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Success = true
+	if action == bulkActionApprove {
+		result.Message = "Topic approved"
+	} else {
+		result.Message = "Revision requested"
+	}
+	return result
+}
+
+func (h *TopicHandlers) bulkApproveTopic(user *auth.AuthenticatedUser, topicID int) error {
+	tx, err := h.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("database error")
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+        UPDATE project_topic_registrations
+        SET status = 'approved', approved_by = ?, approved_at = ?, updated_at = CURRENT_TIMESTAMP
+        WHERE id = ? AND status IN ('submitted', 'supervisor_approved')`,
+		user.Email, time.Now().Unix(), topicID)
+	if err != nil {
+		return fmt.Errorf("failed to approve topic")
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return fmt.Errorf("topic was changed by someone else")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save changes")
+	}
+	return nil
+}
+
+func (h *TopicHandlers) bulkRequestRevision(user *auth.AuthenticatedUser, topicID int, reason string) error {
+	tx, err := h.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("database error")
+	}
+	defer tx.Rollback()
+
+	changeSummary := fmt.Sprintf("Department requested revision: %s", reason)
+	if err := h.saveTopicVersion(tx, topicID, user.Email, changeSummary); err != nil {
+		return fmt.Errorf("failed to save topic version")
+	}
+
+	res, err := tx.Exec(`
+        UPDATE project_topic_registrations
+        SET status = 'revision_requested', rejection_reason = ?,
+            updated_at = CURRENT_TIMESTAMP, current_version = current_version + 1
+        WHERE id = ? AND status IN ('submitted', 'supervisor_approved')`,
+		reason, topicID)
+	if err != nil {
+		return fmt.Errorf("failed to request revision")
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return fmt.Errorf("topic was changed by someone else")
+	}
+
+	_, err = tx.Exec(`
+        INSERT INTO topic_registration_comments
+        (topic_registration_id, author_role, author_name, author_email, comment_text, comment_type, is_read)
+        VALUES (?, ?, ?, ?, ?, 'revision', true)`,
+		topicID, user.Role, user.Name, user.Email, changeSummary)
+	if err != nil {
+		return fmt.Errorf("failed to add revision comment")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save changes")
+	}
+	return nil
+}
+
+// sendBulkReviewDigests sends one summary per supervisor instead of one e-mail per topic
+func (h *TopicHandlers) sendBulkReviewDigests(results []database.BulkTopicReviewResult, approved bool) {
+	digests := make(map[string][]notifications.TopicReviewDigestItem)
+	for _, result := range results {
+		if !result.Success || result.SupervisorEmail == "" {
+			continue
+		}
+		digests[result.SupervisorEmail] = append(digests[result.SupervisorEmail], notifications.TopicReviewDigestItem{
+			StudentName: result.StudentName,
+			TopicTitle:  result.Title,
+			Approved:    approved,
+			Reason:      result.Reason,
+		})
+	}
+
+	if len(digests) == 0 {
+		return
+	}
+	if h.notificationService == nil || !h.notificationService.IsEnabled() {
+		log.Printf("Bulk review finished - notifications disabled, %d supervisor digest(s) not sent", len(digests))
+		return
+	}
+
+	service := h.notificationService
+	go func() {
+		for supervisorEmail, items := range digests {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if err := service.SendTopicReviewDigest(ctx, supervisorEmail, items); err != nil {
+				log.Printf("Failed to send review digest to %s: %v", supervisorEmail, err)
+			}
+			cancel()
+		}
+	}()
+}
+
+const pendingTopicSummaryQuery = `
+        SELECT ptr.id AS topic_id, ptr.student_record_id, ptr.title, ptr.title_en, ptr.status,
+               sr.student_name, sr.student_lastname, sr.student_group, sr.study_program,
+               sr.department, sr.supervisor_email, ptr.supervisor_approved_at
+        FROM project_topic_registrations ptr
+        JOIN student_records sr ON ptr.student_record_id = sr.id`
+
+func (h *TopicHandlers) getPendingDepartmentTopics(department string) ([]database.PendingTopicSummary, error) {
+	query := pendingTopicSummaryQuery + `
+        WHERE ptr.status IN ('supervisor_approved', 'submitted')`
+	var args []interface{}
+	if department != "" {
+		query += " AND sr.department = ?"
+		args = append(args, department)
+	}
+	query += `
+        ORDER BY ptr.status = 'supervisor_approved' DESC, sr.student_group, sr.student_lastname`
+
+	var topics []database.PendingTopicSummary
+	err := h.db.Select(&topics, query, args...)
+	return topics, err
+}
+
+func (h *TopicHandlers) getPendingTopicSummary(topicID int) (*database.PendingTopicSummary, error) {
+	var summary database.PendingTopicSummary
+	err := h.db.Get(&summary, pendingTopicSummaryQuery+" WHERE ptr.id = ?", topicID)
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
@@ -0,0 +1,353 @@
+// handlers/topic_change_request.go - Change requests for already approved topics
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateChangeRequest lets a student propose a new title/objective for an approved topic
+func (h *TopicHandlers) CreateChangeRequest(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || user.Role != auth.RoleStudent {
+		h.renderFormError(w, "Only students can request topic changes")
+		return
+	}
+
+	topicID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderFormError(w, "Invalid topic ID")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderFormError(w, "Invalid form data")
+		return
+	}
+
+	topic, err := h.getTopicByID(topicID)
+	if err != nil {
+		h.renderFormError(w, "Topic not found")
+		return
+	}
+
+	studentRecord, err := h.getStudentRecord(user.Email)
+	if err != nil || studentRecord.ID != topic.StudentRecordID {
+		h.renderFormError(w, "You can only request changes to your own topic")
+		return
+	}
+
+	if !topic.IsApproved() {
+		h.renderFormError(w, "Change requests are only possible for approved topics")
+		return
+	}
+
+	open, err := h.hasOpenChangeRequest(topicID)
+	if err != nil {
+		log.Printf("Error checking open change requests for topic %d: %v", topicID, err)
+		h.renderFormError(w, "Database error")
+		return
+	}
+	if open {
+		h.renderFormError(w, "A change request for this topic is already being reviewed")
+		return
+	}
+
+	data := &database.TopicChangeRequestData{
+		Title:         strings.TrimSpace(r.FormValue("title")),
+		TitleEn:       strings.TrimSpace(r.FormValue("title_en")),
+		Objective:     strings.TrimSpace(r.FormValue("objective")),
+		Justification: strings.TrimSpace(r.FormValue("justification")),
+	}
+	if err := data.Validate(topic); err != nil {
+		h.renderFormError(w, err.Error())
+		return
+	}
+
+	query := `
+        INSERT INTO topic_change_requests (
+            topic_registration_id, student_record_id,
+            proposed_title, proposed_title_en, proposed_objective,
+            previous_title, previous_title_en, previous_objective,
+            justification, status, requested_by
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 'pending', ?)
+    `
+	_, err = h.db.Exec(query,
+		topicID, topic.StudentRecordID,
+		data.Title, data.TitleEn, data.Objective,
+		topic.Title, topic.TitleEn, topic.Objective,
+		data.Justification, user.Email)
+	if err != nil {
+		log.Printf("Error creating change request for topic %d: %v", topicID, err)
+		h.renderFormError(w, "Failed to create change request")
+		return
+	}
+
+	h.renderFormSuccess(w, "Change request sent to your supervisor", topicID)
+}
+
+// WithdrawChangeRequest lets the student cancel a change request still under review
+func (h *TopicHandlers) WithdrawChangeRequest(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || user.Role != auth.RoleStudent {
+		h.renderApprovalError(w, "Unauthorized access")
+		return
+	}
+
+	request, err := h.getChangeRequestFromURL(r)
+	if err != nil {
+		h.renderApprovalError(w, "Change request not found")
+		return
+	}
+
+	if request.RequestedBy != user.Email || !request.IsOpen() {
+		h.renderApprovalError(w, "This change request cannot be withdrawn")
+		return
+	}
+
+	_, err = h.db.Exec(`UPDATE topic_change_requests SET status = 'withdrawn' WHERE id = ?`, request.ID)
+	if err != nil {
+		h.renderApprovalError(w, "Failed to withdraw change request")
+		return
+	}
+
+	h.renderApprovalSuccess(w, "Change request withdrawn")
+}
+
+// SupervisorDecideChangeRequest records the supervisor's approval or rejection
+func (h *TopicHandlers) SupervisorDecideChangeRequest(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleSupervisor && user.Role != auth.RoleAdmin) {
+		h.renderApprovalError(w, "Unauthorized access")
+		return
+	}
+
+	request, err := h.getChangeRequestFromURL(r)
+	if err != nil {
+		h.renderApprovalError(w, "Change request not found")
+		return
+	}
+
+	if !request.CanSupervisorDecide() {
+		h.renderApprovalError(w, "Change request is not awaiting supervisor review")
+		return
+	}
+
+	if user.Role == auth.RoleSupervisor {
+		studentRecord, err := h.getStudentRecordByID(request.StudentRecordID)
+		if err != nil || !strings.EqualFold(studentRecord.SupervisorEmail, user.Email) {
+			h.renderApprovalError(w, "You are not the supervisor of this student")
+			return
+		}
+	}
+
+	approve, comment, err := parseChangeRequestDecision(r)
+	if err != nil {
+		h.renderApprovalError(w, err.Error())
+		return
+	}
+
+	status := database.ChangeRequestSupervisorApproved
+	if !approve {
+		status = database.ChangeRequestRejected
+	}
+
+	query := `
+        UPDATE topic_change_requests
+        SET status = ?, supervisor_decision_by = ?, supervisor_decision_at = ?, supervisor_comment = ?
+        WHERE id = ? AND status = 'pending'
+    `
+	_, err = h.db.Exec(query, status, user.Email, time.Now().Unix(), database.NullableString(comment), request.ID)
+	if err != nil {
+		log.Printf("Error saving supervisor decision for change request %d: %v", request.ID, err)
+		h.renderApprovalError(w, "Failed to save decision")
+		return
+	}
+
+	if approve {
+		h.renderApprovalSuccess(w, "Change request approved and sent to department head")
+	} else {
+		h.renderApprovalSuccess(w, "Change request rejected")
+	}
+}
+
+// DepartmentDecideChangeRequest records the final decision; approval applies the change
+func (h *TopicHandlers) DepartmentDecideChangeRequest(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil || (user.Role != auth.RoleDepartmentHead && user.Role != auth.RoleAdmin) {
+		h.renderApprovalError(w, "Unauthorized access")
+		return
+	}
+
+	request, err := h.getChangeRequestFromURL(r)
+	if err != nil {
+		h.renderApprovalError(w, "Change request not found")
+		return
+	}
+
+	if !request.CanDepartmentDecide() {
+		h.renderApprovalError(w, "Change request is not awaiting department review")
+		return
+	}
+
+	department, err := departmentHeadScope(h.db, user)
+	if err != nil {
+		h.renderApprovalError(w, "Unauthorized access")
+		return
+	}
+	if department != "" {
+		studentRecord, err := h.getStudentRecordByID(request.StudentRecordID)
+		if err != nil || studentRecord.Department != department {
+			h.renderApprovalError(w, "This student is not in your department")
+			return
+		}
+	}
+
+	approve, comment, err := parseChangeRequestDecision(r)
+	if err != nil {
+		h.renderApprovalError(w, err.Error())
+		return
+	}
+
+	if !approve {
+		query := `
+            UPDATE topic_change_requests
+            SET status = 'rejected', department_decision_by = ?, department_decision_at = ?, department_comment = ?
+            WHERE id = ? AND status = 'supervisor_approved'
+        `
+		if _, err := h.db.Exec(query, user.Email, time.Now().Unix(), database.NullableString(comment), request.ID); err != nil {
+			log.Printf("Error rejecting change request %d: %v", request.ID, err)
+			h.renderApprovalError(w, "Failed to save decision")
+			return
+		}
+		h.renderApprovalSuccess(w, "Change request rejected")
+		return
+	}
+
+	if err := h.applyChangeRequest(request, user, comment); err != nil {
+		log.Printf("Error applying change request %d: %v", request.ID, err)
+		h.renderApprovalError(w, "Failed to apply topic change")
+		return
+	}
+
+	h.renderApprovalSuccess(w, "Topic change approved and applied")
+}
+
+// applyChangeRequest versions the topic, updates it and the student record in one transaction
+func (h *TopicHandlers) applyChangeRequest(request *database.TopicChangeRequest, user *auth.AuthenticatedUser, comment string) error {
+	tx, err := h.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Keep the approved state as a version before changing it
+	changeSummary := fmt.Sprintf("Approved topic change request #%d: %s", request.ID, request.Justification)
+	if err := h.saveTopicVersion(tx, request.TopicRegistrationID, user.Email, changeSummary); err != nil {
+		return fmt.Errorf("failed to save topic version: %w", err)
+	}
+
+	_, err = tx.Exec(`
+        UPDATE project_topic_registrations
+        SET title = ?, title_en = ?, objective = ?,
+            current_version = current_version + 1,
+            approved_by = ?, approved_at = ?, updated_at = CURRENT_TIMESTAMP
+        WHERE id = ?`,
+		request.ProposedTitle, request.ProposedTitleEn, request.ProposedObjective,
+		user.Email, time.Now().Unix(), request.TopicRegistrationID)
+	if err != nil {
+		return fmt.Errorf("failed to update topic: %w", err)
+	}
+
+	_, err = tx.Exec(`
+        UPDATE student_records
+        SET final_project_title = ?, final_project_title_en = ?
+        WHERE id = ?`,
+		request.ProposedTitle, request.ProposedTitleEn, request.StudentRecordID)
+	if err != nil {
+		return fmt.Errorf("failed to update student record: %w", err)
+	}
+
+	var appliedVersion int
+	if err := tx.Get(&appliedVersion, `SELECT current_version FROM project_topic_registrations WHERE id = ?`, request.TopicRegistrationID); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+        UPDATE topic_change_requests
+        SET status = 'approved', department_decision_by = ?, department_decision_at = ?,
+            department_comment = ?, applied_version = ?
+        WHERE id = ? AND status = 'supervisor_approved'`,
+		user.Email, time.Now().Unix(), database.NullableString(comment), appliedVersion, request.ID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("change request %d was decided concurrently", request.ID)
+	}
+
+	return tx.Commit()
+}
+
+func parseChangeRequestDecision(r *http.Request) (bool, string, error) {
+	if err := r.ParseForm(); err != nil {
+		return false, "", fmt.Errorf("invalid form data")
+	}
+
+	comment := strings.TrimSpace(r.FormValue("comment"))
+	switch r.FormValue("decision") {
+	case "approve":
+		return true, comment, nil
+	case "reject":
+		if comment == "" {
+			return false, "", fmt.Errorf("a reason is required when rejecting a change request")
+		}
+		return false, comment, nil
+	default:
+		return false, "", fmt.Errorf("invalid decision")
+	}
+}
+
+func (h *TopicHandlers) getChangeRequestFromURL(r *http.Request) (*database.TopicChangeRequest, error) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "requestId"))
+	if err != nil {
+		return nil, err
+	}
+
+	var request database.TopicChangeRequest
+	err = h.db.Get(&request, `SELECT * FROM topic_change_requests WHERE id = ?`, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (h *TopicHandlers) hasOpenChangeRequest(topicID int) (bool, error) {
+	var count int
+	err := h.db.Get(&count, `
+        SELECT COUNT(*) FROM topic_change_requests
+        WHERE topic_registration_id = ? AND status IN ('pending', 'supervisor_approved')`, topicID)
+	return count > 0, err
+}
+
+// getTopicChangeRequests returns the change request history of a topic, newest first
+func (h *TopicHandlers) getTopicChangeRequests(topicID int) ([]database.TopicChangeRequest, error) {
+	var requests []database.TopicChangeRequest
+	err := h.db.Select(&requests, `
+        SELECT * FROM topic_change_requests
+        WHERE topic_registration_id = ?
+        ORDER BY created_at DESC, id DESC`, topicID)
+	if err == sql.ErrNoRows {
+		return []database.TopicChangeRequest{}, nil
+	}
+	return requests, err
+}
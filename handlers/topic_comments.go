@@ -0,0 +1,245 @@
+// handlers/topic_comments.go - Review comment threads: resolution and read tracking
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/components/templates"
+	"FinalProjectManagementApp/database"
+	"github.com/go-chi/chi/v5"
+)
+
+// ResolveComment marks a review comment thread as resolved
+func (h *TopicHandlers) ResolveComment(w http.ResponseWriter, r *http.Request) {
+	h.setCommentResolved(w, r, true)
+}
+
+// UnresolveComment reopens a previously resolved review comment thread
+func (h *TopicHandlers) UnresolveComment(w http.ResponseWriter, r *http.Request) {
+	h.setCommentResolved(w, r, false)
+}
+
+// MarkCommentsRead marks all comments of a topic as read by the current user
+func (h *TopicHandlers) MarkCommentsRead(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	topicID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid topic ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := h.authorizeTopicComments(w, user, topicID); !ok {
+		return
+	}
+
+	if err := h.markCommentsRead(topicID, user.Email); err != nil {
+		log.Printf("Error marking comments read for topic %d: %v", topicID, err)
+		http.Error(w, "Failed to mark comments as read", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderCommentsSection(w, r, user, topicID)
+}
+
+func (h *TopicHandlers) setCommentResolved(w http.ResponseWriter, r *http.Request, resolved bool) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	topicID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid topic ID", http.StatusBadRequest)
+		return
+	}
+
+	commentID, err := strconv.Atoi(chi.URLParam(r, "commentId"))
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	supervises, ok := h.authorizeTopicComments(w, user, topicID)
+	if !ok {
+		return
+	}
+
+	comment, err := h.getCommentByID(commentID)
+	if err != nil || comment.TopicRegistrationID != topicID {
+		h.renderFormError(w, "Comment not found")
+		return
+	}
+
+	if comment.ParentCommentID != nil {
+		h.renderFormError(w, "Only comment threads can be resolved")
+		return
+	}
+
+	if !comment.CanBeResolvedBy(user.Role, user.Email, supervises) {
+		h.renderFormError(w, "You are not allowed to change the state of this comment")
+		return
+	}
+
+	if resolved {
+		_, err = h.db.Exec(`
+            UPDATE topic_registration_comments
+            SET is_resolved = TRUE, resolved_by = ?, resolved_at = ?
+            WHERE id = ?`, user.Email, time.Now().Unix(), commentID)
+	} else {
+		_, err = h.db.Exec(`
+            UPDATE topic_registration_comments
+            SET is_resolved = FALSE, resolved_by = NULL, resolved_at = NULL
+            WHERE id = ?`, commentID)
+	}
+	if err != nil {
+		log.Printf("Error updating resolution of comment %d: %v", commentID, err)
+		h.renderFormError(w, "Failed to update comment")
+		return
+	}
+
+	h.renderCommentsSection(w, r, user, topicID)
+}
+
+func (h *TopicHandlers) getCommentByID(commentID int) (*database.TopicRegistrationComment, error) {
+	query := `
+        SELECT id, topic_registration_id, field_name, comment_text, author_role,
+               author_name, author_email, created_at, parent_comment_id, is_read,
+               comment_type, COALESCE(is_resolved, FALSE)
+        FROM topic_registration_comments
+        WHERE id = ?
+    `
+
+	var comment database.TopicRegistrationComment
+	var fieldName sql.NullString
+	var parentCommentID sql.NullInt32
+
+	err := h.db.QueryRow(query, commentID).Scan(
+		&comment.ID, &comment.TopicRegistrationID, &fieldName,
+		&comment.CommentText, &comment.AuthorRole, &comment.AuthorName,
+		&comment.AuthorEmail, &comment.CreatedAt, &parentCommentID,
+		&comment.IsRead, &comment.CommentType, &comment.IsResolved,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if fieldName.Valid && fieldName.String != "" {
+		comment.FieldName = &fieldName.String
+	}
+	if parentCommentID.Valid {
+		parentID := int(parentCommentID.Int32)
+		comment.ParentCommentID = &parentID
+	}
+
+	return &comment, nil
+}
+
+// markCommentsRead records that the user has seen every comment of the topic
+func (h *TopicHandlers) markCommentsRead(topicID int, userEmail string) error {
+	query := `
+        INSERT IGNORE INTO topic_comment_reads (comment_id, user_email, read_at)
+        SELECT id, ?, ?
+        FROM topic_registration_comments
+        WHERE topic_registration_id = ?
+    `
+	_, err := h.db.Exec(query, userEmail, time.Now().Unix(), topicID)
+	return err
+}
+
+// countBlockingComments returns the number of open required-change threads
+func (h *TopicHandlers) countBlockingComments(topicID int) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM topic_registration_comments c
+        WHERE c.topic_registration_id = ?
+          AND c.parent_comment_id IS NULL
+          AND c.comment_type = ?
+          AND COALESCE(c.is_resolved, FALSE) = FALSE
+          AND NOT EXISTS (
+              SELECT 1 FROM topic_registration_comments reply
+              WHERE reply.parent_comment_id = c.id AND reply.author_role = ?
+          )
+    `
+	var count int
+	err := h.db.Get(&count, query, topicID, database.CommentTypeRequiredChange, auth.RoleStudent)
+	return count, err
+}
+
+// renderCommentsSection re-renders the whole comments card after a thread changes
+func (h *TopicHandlers) renderCommentsSection(w http.ResponseWriter, r *http.Request, user *auth.AuthenticatedUser, topicID int) {
+	topic, err := h.getTopicByID(topicID)
+	if err != nil {
+		http.Error(w, "Topic not found", http.StatusNotFound)
+		return
+	}
+
+	participates, supervises := h.topicCommentAccess(user, topic)
+	if !participates {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+
+	comments, err := h.getTopicComments(topicID, user.Email)
+	if err != nil {
+		log.Printf("Error loading comments for topic %d: %v", topicID, err)
+		comments = []database.TopicRegistrationComment{}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.CommentsSection(user, topic, comments, supervises, getLocale(r)).Render(r.Context(), w); err != nil {
+		log.Printf("Error rendering comments section: %v", err)
+	}
+}
+
+// topicCommentAccess tells whether the user takes part in the topic's review threads, as on the topic view:
+// the student who owns the topic, or someone who supervises it - the assigned supervisor, the head of the
+// topic's department or an admin. Only those who supervise the topic moderate its threads.
+func (h *TopicHandlers) topicCommentAccess(user *auth.AuthenticatedUser, topic *database.ProjectTopicRegistration) (participates, supervises bool) {
+	student, err := h.getStudentRecordByID(topic.StudentRecordID)
+	if err != nil {
+		return false, false
+	}
+
+	switch user.Role {
+	case auth.RoleAdmin:
+		return true, true
+	case auth.RoleDepartmentHead:
+		department, err := departmentHeadScope(h.db, user)
+		supervises = err == nil && strings.EqualFold(department, student.Department)
+		return supervises, supervises
+	case auth.RoleSupervisor:
+		supervises = strings.EqualFold(student.SupervisorEmail, user.Email)
+		return supervises, supervises
+	case auth.RoleStudent:
+		return strings.EqualFold(student.StudentEmail, user.Email), false
+	}
+	return false, false
+}
+
+// authorizeTopicComments answers the request when the user may not take part in the topic's review threads
+func (h *TopicHandlers) authorizeTopicComments(w http.ResponseWriter, user *auth.AuthenticatedUser, topicID int) (supervises, ok bool) {
+	topic, err := h.getTopicByID(topicID)
+	if err != nil {
+		http.Error(w, "Topic not found", http.StatusNotFound)
+		return false, false
+	}
+
+	participates, supervises := h.topicCommentAccess(user, topic)
+	if !participates {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return false, false
+	}
+	return supervises, true
+}
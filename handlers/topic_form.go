@@ -0,0 +1,251 @@
+// handlers/topic_form.go - Official topic registration form (PDF/DOCX) generation
+package handlers
+
+import (
+	"archive/zip"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/docgen"
+	"github.com/go-chi/chi/v5"
+)
+
+// DownloadTopicForm generates the bilingual topic registration form of a single topic
+func (h *TopicHandlers) DownloadTopicForm(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	topicID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid topic ID", http.StatusBadRequest)
+		return
+	}
+
+	format, err := docgen.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	topic, err := h.getTopicByID(topicID)
+	if err != nil {
+		http.Error(w, "Topic not found", http.StatusNotFound)
+		return
+	}
+
+	student, err := h.getStudentRecordByID(topic.StudentRecordID)
+	if err != nil {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	}
+
+	if !h.canDownloadTopicForm(user, student) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	if !EnforceUserAccessWindow(h.db, w, r, user, student.ID, "document") {
+		return
+	}
+
+	data, err := buildTopicRegistrationForm(student, topic).Bytes(format)
+	if err != nil {
+		log.Printf("Error generating topic form for topic %d: %v", topicID, err)
+		http.Error(w, "Failed to generate document", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+topicFormFilename(student, format)+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Write(data)
+}
+
+// DownloadGroupTopicForms returns the topic registration forms of a whole group as a ZIP archive
+func (h *TopicHandlers) DownloadGroupTopicForms(w http.ResponseWriter, r *http.Request) {
+	user := auth.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	group := strings.TrimSpace(r.URL.Query().Get("group"))
+	if group == "" {
+		http.Error(w, "Group is required", http.StatusBadRequest)
+		return
+	}
+
+	format, err := docgen.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	topicIDs, err := h.getGroupTopicIDs(user, group)
+	if err != nil {
+		log.Printf("Error loading topics of group %s: %v", group, err)
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	if len(topicIDs) == 0 {
+		http.Error(w, "No registered topics found for this group", http.StatusNotFound)
+		return
+	}
+
+	filename := fmt.Sprintf("topic_forms_%s_%s.zip", docgen.SafeFilename(group), time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+
+	archive := zip.NewWriter(w)
+	for _, topicID := range topicIDs {
+		topic, err := h.getTopicByID(topicID)
+		if err != nil {
+			log.Printf("Skipping topic %d in group export: %v", topicID, err)
+			continue
+		}
+		student, err := h.getStudentRecordByID(topic.StudentRecordID)
+		if err != nil {
+			log.Printf("Skipping topic %d in group export: %v", topicID, err)
+			continue
+		}
+
+		entry, err := archive.Create(topicFormFilename(student, format))
+		if err != nil {
+			log.Printf("Error adding topic %d to group export: %v", topicID, err)
+			break
+		}
+		if err := buildTopicRegistrationForm(student, topic).Render(entry, format); err != nil {
+			log.Printf("Error generating topic form for topic %d: %v", topicID, err)
+			break
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		log.Printf("Error finishing group export for %s: %v", group, err)
+	}
+}
+
+// canDownloadTopicForm allows the student, their supervisor, the head of their department and the commission
+// whose access code covers the student
+func (h *TopicHandlers) canDownloadTopicForm(user *auth.AuthenticatedUser, student *database.StudentRecord) bool {
+	switch user.Role {
+	case auth.RoleAdmin:
+		return true
+	case auth.RoleDepartmentHead:
+		department, err := departmentHeadScope(h.db, user)
+		return err == nil && strings.EqualFold(department, student.Department)
+	case auth.RoleCommissionMember:
+		member, err := commissionMemberOf(h.db, user)
+		return err == nil && CommissionCoversStudent(h.db, member, student.ID)
+	case auth.RoleSupervisor:
+		return strings.EqualFold(student.SupervisorEmail, user.Email)
+	case auth.RoleStudent:
+		return strings.EqualFold(student.StudentEmail, user.Email)
+	}
+	return false
+}
+
+// getGroupTopicIDs returns the topics of a group that are visible to the user
+func (h *TopicHandlers) getGroupTopicIDs(user *auth.AuthenticatedUser, group string) ([]int, error) {
+	query := `
+        SELECT ptr.id
+        FROM project_topic_registrations ptr
+        JOIN student_records sr ON ptr.student_record_id = sr.id
+        WHERE sr.student_group = ?`
+	args := []interface{}{group}
+
+	switch user.Role {
+	case auth.RoleAdmin:
+	case auth.RoleDepartmentHead:
+		department, err := departmentHeadScope(h.db, user)
+		if err != nil {
+			return nil, err
+		}
+		query += " AND sr.department = ?"
+		args = append(args, department)
+	case auth.RoleSupervisor:
+		query += " AND sr.supervisor_email = ?"
+		args = append(args, user.Email)
+	default:
+		return nil, fmt.Errorf("role %s cannot export group topic forms", user.Role)
+	}
+
+	query += " ORDER BY sr.student_lastname, sr.student_name"
+
+	var ids []int
+	err := h.db.Select(&ids, query, args...)
+	return ids, err
+}
+
+// buildTopicRegistrationForm lays out the official bilingual topic registration form
+func buildTopicRegistrationForm(student *database.StudentRecord, topic *database.ProjectTopicRegistration) *docgen.Document {
+	doc := docgen.New(
+		"BAIGIAMOJO DARBO TEMOS REGISTRACIJOS LAPAS",
+		"Final Project Topic Registration Form",
+	)
+
+	doc.Heading("Studentas / Student")
+	doc.Field("Vardas, pavardė / Name, surname", student.StudentName+" "+student.StudentLastname)
+	doc.Field("Studento kodas / Student number", student.StudentNumber)
+	doc.Field("Grupė / Group", student.StudentGroup)
+	doc.Field("Studijų programa / Study programme", strings.TrimSpace(student.StudyProgram+" "+student.ProgramCode))
+	doc.Field("Katedra / Department", student.Department)
+	doc.Field("Vadovas / Supervisor", topicFormSupervisor(student, topic))
+
+	doc.Heading("Tema / Topic")
+	doc.Field("Pavadinimas lietuvių kalba / Title in Lithuanian", topic.Title)
+	doc.Field("Pavadinimas anglų kalba / Title in English", topic.TitleEn)
+	doc.Field("Problema / Problem", topic.Problem)
+	doc.Field("Tikslas / Objective", topic.Objective)
+	doc.Field("Uždaviniai / Tasks", topic.Tasks)
+	doc.Field("Atlikimo terminas / Completion date", database.StringValue(topic.CompletionDate))
+
+	doc.Heading("Tvirtinimas / Approval")
+	doc.Field("Būsena / Status", topic.GetStatusDisplay("lt")+" / "+topic.GetStatusDisplay("en"))
+	doc.Field("Pateikta / Submitted", formatFormTimestamp(topic.SubmittedAt))
+	doc.Field("Vadovas patvirtino / Approved by supervisor", formatFormApproval(topic.SupervisorApprovedBy, topic.SupervisorApprovedAt))
+	doc.Field("Katedra patvirtino / Approved by department", formatFormApproval(topic.ApprovedBy, topic.ApprovedAt))
+	doc.Field("Versija / Version", strconv.Itoa(topic.CurrentVersion))
+
+	doc.Spacer()
+	doc.Signature("Studentas / Student", student.StudentName+" "+student.StudentLastname)
+	doc.Signature("Vadovas / Supervisor", topicFormSupervisor(student, topic))
+	doc.Signature("Katedros vedėjas / Head of department", database.StringValue(topic.ApprovedBy))
+
+	doc.Footer = "Sugeneruota / Generated " + time.Now().Format("2006-01-02 15:04")
+	return doc
+}
+
+func topicFormSupervisor(student *database.StudentRecord, topic *database.ProjectTopicRegistration) string {
+	if strings.TrimSpace(topic.Supervisor) != "" {
+		return topic.Supervisor
+	}
+	return student.SupervisorEmail
+}
+
+func formatFormTimestamp(ts *int64) string {
+	if ts == nil {
+		return ""
+	}
+	return time.Unix(*ts, 0).Format("2006-01-02 15:04")
+}
+
+func formatFormApproval(by *string, at *int64) string {
+	if by == nil && at == nil {
+		return ""
+	}
+	return strings.TrimSpace(database.StringValue(by) + " " + formatFormTimestamp(at))
+}
+
+func topicFormFilename(student *database.StudentRecord, format docgen.Format) string {
+	name := fmt.Sprintf("temos_lapas_%s_%s_%s", student.StudentGroup, student.StudentLastname, student.StudentName)
+	return docgen.SafeFilename(name) + format.Extension()
+}
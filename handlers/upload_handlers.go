@@ -208,6 +208,9 @@ func (h *UploadHandlers) DocumentPreviewHandler(w http.ResponseWriter, r *http.R
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
+	if !EnforceUserAccessWindow(h.db, w, r, user, doc.StudentRecordID, "document") {
+		return
+	}
 
 	// Serve file
 	http.ServeFile(w, r, doc.FilePath)
@@ -243,6 +246,9 @@ func (h *UploadHandlers) DocumentDownloadHandler(w http.ResponseWriter, r *http.
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
+	if !EnforceUserAccessWindow(h.db, w, r, user, doc.StudentRecordID, "document") {
+		return
+	}
 
 	// Set download headers
 	filename := database.StringValue(doc.OriginalFilename)
@@ -258,6 +264,12 @@ func (h *UploadHandlers) DocumentDownloadHandler(w http.ResponseWriter, r *http.
 }
 
 func (h *UploadHandlers) canAccessDocument(user *auth.AuthenticatedUser, doc *database.Document) bool {
+	return h.canAccessStudentDocuments(user, doc.StudentRecordID)
+}
+
+// canAccessStudentDocuments lets the student, their supervisor and reviewer, their department head and admins
+// see the student's documents
+func (h *UploadHandlers) canAccessStudentDocuments(user *auth.AuthenticatedUser, studentID int) bool {
 	// Admin can access everything
 	if user.Role == auth.RoleAdmin {
 		return true
@@ -267,7 +279,7 @@ func (h *UploadHandlers) canAccessDocument(user *auth.AuthenticatedUser, doc *da
 	var studentEmail string
 	err := h.db.Get(&studentEmail,
 		"SELECT student_email FROM student_records WHERE id = ?",
-		doc.StudentRecordID)
+		studentID)
 	if err != nil {
 		return false
 	}
@@ -281,14 +293,14 @@ func (h *UploadHandlers) canAccessDocument(user *auth.AuthenticatedUser, doc *da
 	var supervisorEmail, reviewerEmail string
 	err = h.db.Get(&supervisorEmail,
 		"SELECT supervisor_email FROM student_records WHERE id = ?",
-		doc.StudentRecordID)
+		studentID)
 	if err == nil && user.Role == auth.RoleSupervisor && user.Email == supervisorEmail {
 		return true
 	}
 
 	err = h.db.Get(&reviewerEmail,
 		"SELECT reviewer_email FROM student_records WHERE id = ?",
-		doc.StudentRecordID)
+		studentID)
 	if err == nil && user.Role == auth.RoleReviewer && user.Email == reviewerEmail {
 		return true
 	}
@@ -298,7 +310,7 @@ func (h *UploadHandlers) canAccessDocument(user *auth.AuthenticatedUser, doc *da
 		var department string
 		err = h.db.Get(&department,
 			"SELECT sr.department FROM student_records sr WHERE sr.id = ?",
-			doc.StudentRecordID)
+			studentID)
 		if err == nil {
 			var userDepartment string
 			err = h.db.Get(&userDepartment,
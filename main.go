@@ -13,6 +13,7 @@ import (
 
 	"FinalProjectManagementApp/auth"
 	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/githosting"
 	"FinalProjectManagementApp/routes"
 	_ "github.com/go-sql-driver/mysql"
 )
@@ -94,10 +95,21 @@ func main() {
 
 	// Initialize source code upload handler
 	var sourceCodeHandler *handlers.SourceCodeHandler
-	if appConfig.HasGitHub() {
-		sourceCodeHandler = handlers.NewSourceCodeHandler(db, appConfig.GitHub)
+	var gitProvider githosting.Provider
+	if appConfig.HasGitHosting() {
+		gitProvider, err = githosting.New(*appConfig.GitHosting)
+		if err != nil {
+			log.Printf("Git hosting configuration is invalid: %v", err)
+		} else if appConfig.GitHosting.Cache.MaxEntries > 0 {
+			// Repository pages are served from a cache keyed by commit; pushes invalidate it
+			gitProvider = githosting.NewCached(gitProvider, appConfig.GitHosting.Cache)
+		}
+	}
+	if gitProvider != nil {
+		sourceCodeHandler = handlers.NewSourceCodeHandler(db, gitProvider)
+		sourceCodeHandler.SetExtractionLimits(*appConfig.Extraction)
 	} else {
-		log.Println("Github configuration not found - source code upload will be disabled")
+		log.Println("Git hosting configuration not found - source code upload will be disabled")
 		sourceCodeHandler = nil
 	}
 
@@ -110,7 +122,7 @@ func main() {
 	}
 
 	// Setup routes
-	r := routes.SetupRoutes(db, authService, authMiddleware, notificationService, sourceCodeHandler)
+	r := routes.SetupRoutes(db, authService, authMiddleware, notificationService, sourceCodeHandler, gitProvider)
 
 	// Get port from environment or use config
 	port := appConfig.Server.Port
@@ -124,10 +136,10 @@ func main() {
 		appConfig.Database.Port, appConfig.Database.Database)
 
 	if sourceCodeHandler != nil {
-		log.Printf("Github API active integration: ENABLED (%s/%s)",
-			appConfig.GitHub.Organization, appConfig.GitHub.Project)
+		log.Printf("Git hosting integration: ENABLED (%s/%s)",
+			gitProvider.Name(), gitProvider.Owner())
 	} else {
-		log.Printf("Github API integration: DISABLED")
+		log.Printf("Git hosting integration: DISABLED")
 	}
 
 	if notificationService != nil {
@@ -0,0 +1,30 @@
+-- ================================================
+-- Migration UP: Topic Comment Threads
+-- File: 000008_topic_comment_threads.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Add resolution tracking to topic registration comments
+ALTER TABLE topic_registration_comments
+    ADD COLUMN is_resolved BOOLEAN DEFAULT FALSE,
+    ADD COLUMN resolved_by VARCHAR(255) NULL,
+    ADD COLUMN resolved_at BIGINT NULL,
+    ADD INDEX idx_field_name (field_name),
+    ADD INDEX idx_comment_type (comment_type),
+    ADD INDEX idx_is_resolved (is_resolved);
+
+-- Create per-user comment read tracking table
+CREATE TABLE IF NOT EXISTS topic_comment_reads (
+                                                   id INT AUTO_INCREMENT PRIMARY KEY,
+                                                   comment_id INT NOT NULL,
+                                                   user_email VARCHAR(255) NOT NULL,
+                                                   read_at BIGINT NOT NULL,
+
+                                                   FOREIGN KEY (comment_id) REFERENCES topic_registration_comments(id) ON DELETE CASCADE,
+                                                   UNIQUE KEY unique_comment_reader (comment_id, user_email),
+                                                   INDEX idx_user_email (user_email)
+);
+
+SET foreign_key_checks = 1;
@@ -0,0 +1,41 @@
+-- ================================================
+-- Migration UP: Topic Change Requests
+-- File: 000009_topic_change_requests.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Create topic change requests table (changes to already approved topics)
+CREATE TABLE IF NOT EXISTS topic_change_requests (
+                                                     id INT AUTO_INCREMENT PRIMARY KEY,
+                                                     topic_registration_id INT NOT NULL,
+                                                     student_record_id INT NOT NULL,
+                                                     proposed_title TEXT NOT NULL,
+                                                     proposed_title_en TEXT NOT NULL,
+                                                     proposed_objective TEXT NOT NULL,
+                                                     previous_title TEXT NOT NULL,
+                                                     previous_title_en TEXT NOT NULL,
+                                                     previous_objective TEXT NOT NULL,
+                                                     justification TEXT NOT NULL,
+                                                     status ENUM('pending', 'supervisor_approved', 'approved', 'rejected', 'withdrawn') DEFAULT 'pending',
+                                                     requested_by VARCHAR(255) NOT NULL,
+                                                     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                                                     supervisor_decision_by VARCHAR(255) NULL,
+                                                     supervisor_decision_at BIGINT NULL,
+                                                     supervisor_comment TEXT NULL,
+                                                     department_decision_by VARCHAR(255) NULL,
+                                                     department_decision_at BIGINT NULL,
+                                                     department_comment TEXT NULL,
+                                                     applied_version INT NULL,
+
+                                                     FOREIGN KEY (topic_registration_id) REFERENCES project_topic_registrations(id) ON DELETE CASCADE,
+                                                     FOREIGN KEY (student_record_id) REFERENCES student_records(id) ON DELETE CASCADE,
+                                                     INDEX idx_topic_registration (topic_registration_id),
+                                                     INDEX idx_student_record (student_record_id),
+                                                     INDEX idx_status (status),
+                                                     INDEX idx_created_at (created_at)
+);
+
+SET foreign_key_checks = 1;
@@ -0,0 +1,89 @@
+-- ================================================
+-- Migration UP: Report Signatures
+-- File: 000010_report_signatures.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Track the signed revision of supervisor and reviewer reports
+ALTER TABLE supervisor_reports
+    ADD COLUMN revision INT NOT NULL DEFAULT 1,
+    ADD COLUMN content_hash CHAR(64) NULL,
+    ADD COLUMN signed_at TIMESTAMP NULL;
+
+ALTER TABLE reviewer_reports
+    ADD COLUMN revision INT NOT NULL DEFAULT 1,
+    ADD COLUMN content_hash CHAR(64) NULL,
+    ADD COLUMN signed_at TIMESTAMP NULL;
+
+-- Append-only log of report signatures
+CREATE TABLE IF NOT EXISTS report_signatures (
+                                                 id INT AUTO_INCREMENT PRIMARY KEY,
+                                                 report_type ENUM('supervisor', 'reviewer') NOT NULL,
+                                                 report_id INT NOT NULL,
+                                                 student_record_id INT NOT NULL,
+                                                 revision INT NOT NULL,
+                                                 content_hash CHAR(64) NOT NULL,
+                                                 hash_algorithm VARCHAR(20) NOT NULL DEFAULT 'sha256',
+                                                 signer_email VARCHAR(255) NOT NULL,
+                                                 signer_name VARCHAR(255) NOT NULL,
+                                                 signer_role VARCHAR(50) NOT NULL,
+                                                 ip_address VARCHAR(45) NULL,
+                                                 user_agent TEXT NULL,
+                                                 signed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+                                                 FOREIGN KEY (student_record_id) REFERENCES student_records(id) ON DELETE CASCADE,
+                                                 UNIQUE KEY unique_report_revision (report_type, report_id, revision),
+                                                 INDEX idx_student_record (student_record_id),
+                                                 INDEX idx_signer_email (signer_email)
+);
+
+-- Append-only log of administrative unlocks
+CREATE TABLE IF NOT EXISTS report_unlocks (
+                                              id INT AUTO_INCREMENT PRIMARY KEY,
+                                              report_type ENUM('supervisor', 'reviewer') NOT NULL,
+                                              report_id INT NOT NULL,
+                                              student_record_id INT NOT NULL,
+                                              from_revision INT NOT NULL,
+                                              reason TEXT NOT NULL,
+                                              unlocked_by VARCHAR(255) NOT NULL,
+                                              ip_address VARCHAR(45) NULL,
+                                              unlocked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+                                              FOREIGN KEY (student_record_id) REFERENCES student_records(id) ON DELETE CASCADE,
+                                              INDEX idx_report (report_type, report_id),
+                                              INDEX idx_student_record (student_record_id)
+);
+
+-- Signatures and unlocks can never be changed or removed
+CREATE TRIGGER report_signatures_no_update BEFORE UPDATE ON report_signatures
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'report_signatures is append-only';
+
+CREATE TRIGGER report_signatures_no_delete BEFORE DELETE ON report_signatures
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'report_signatures is append-only';
+
+CREATE TRIGGER report_unlocks_no_update BEFORE UPDATE ON report_unlocks
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'report_unlocks is append-only';
+
+CREATE TRIGGER report_unlocks_no_delete BEFORE DELETE ON report_unlocks
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'report_unlocks is append-only';
+
+-- Signed reports stay locked until an administrator unlocks them (is_signed back to false)
+CREATE TRIGGER supervisor_reports_signed_lock BEFORE UPDATE ON supervisor_reports
+    FOR EACH ROW
+    BEGIN
+        IF OLD.is_signed = TRUE AND NEW.is_signed = TRUE THEN
+            SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'signed supervisor report is locked';
+        END IF;
+    END;
+
+CREATE TRIGGER reviewer_reports_signed_lock BEFORE UPDATE ON reviewer_reports
+    FOR EACH ROW
+    BEGIN
+        IF OLD.is_signed = TRUE AND NEW.is_signed = TRUE THEN
+            SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'signed reviewer report is locked';
+        END IF;
+    END;
+
+SET foreign_key_checks = 1;
@@ -0,0 +1,32 @@
+-- ================================================
+-- Migration UP: Report Versions
+-- File: 000011_report_versions.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Snapshot of a supervisor or reviewer report after every save
+CREATE TABLE IF NOT EXISTS report_versions (
+                                               id INT AUTO_INCREMENT PRIMARY KEY,
+                                               report_type ENUM('supervisor', 'reviewer') NOT NULL,
+                                               report_id INT NOT NULL,
+                                               student_record_id INT NOT NULL,
+                                               version_number INT NOT NULL,
+                                               revision INT NOT NULL DEFAULT 1,
+                                               version_data LONGTEXT NOT NULL,
+                                               content_hash CHAR(64) NOT NULL,
+                                               is_signed BOOLEAN NOT NULL DEFAULT FALSE,
+                                               created_by VARCHAR(255) NOT NULL,
+                                               created_by_role VARCHAR(50) NOT NULL,
+                                               change_summary TEXT NULL,
+                                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+                                               FOREIGN KEY (student_record_id) REFERENCES student_records(id) ON DELETE CASCADE,
+                                               UNIQUE KEY unique_report_version (report_type, report_id, version_number),
+                                               INDEX idx_student_record (student_record_id),
+                                               INDEX idx_created_by (created_by),
+                                               INDEX idx_created_at (created_at)
+);
+
+SET foreign_key_checks = 1;
@@ -0,0 +1,32 @@
+-- ================================================
+-- Migration UP: Similarity Reports
+-- File: 000012_similarity_reports.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Percentages parsed from similarity check reports attached by supervisors
+CREATE TABLE IF NOT EXISTS similarity_reports (
+                                                  id INT AUTO_INCREMENT PRIMARY KEY,
+                                                  student_record_id INT NOT NULL,
+                                                  document_id INT NOT NULL,
+                                                  source_format VARCHAR(10) NOT NULL,
+                                                  other_match DECIMAL(5,1) NULL,
+                                                  one_match DECIMAL(5,1) NULL,
+                                                  own_match DECIMAL(5,1) NULL,
+                                                  join_match DECIMAL(5,1) NULL,
+                                                  uploaded_by VARCHAR(255) NOT NULL,
+                                                  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+                                                  FOREIGN KEY (student_record_id) REFERENCES student_records(id) ON DELETE CASCADE,
+                                                  FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE,
+                                                  INDEX idx_student_record (student_record_id)
+);
+
+-- Link supervisor reports to the attached report and flag typed values that disagree with it
+ALTER TABLE supervisor_reports
+    ADD COLUMN similarity_report_id INT NULL,
+    ADD COLUMN similarity_mismatch BOOLEAN NOT NULL DEFAULT FALSE;
+
+SET foreign_key_checks = 1;
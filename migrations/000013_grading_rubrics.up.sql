@@ -0,0 +1,66 @@
+-- ================================================
+-- Migration UP: Grading Rubrics
+-- File: 000013_grading_rubrics.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Reviewer grading rubrics; a rubric without study program is the default for all programs
+CREATE TABLE IF NOT EXISTS grading_rubrics (
+                                               id INT AUTO_INCREMENT PRIMARY KEY,
+                                               study_program VARCHAR(255) NULL,
+                                               name VARCHAR(255) NOT NULL,
+                                               deviation_threshold DECIMAL(3,1) NOT NULL DEFAULT 1.5,
+                                               is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                                               created_by VARCHAR(255) NOT NULL,
+                                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                               updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+                                               INDEX idx_study_program (study_program, is_active)
+);
+
+-- Weighted criteria, each mapped to a section of the reviewer report
+CREATE TABLE IF NOT EXISTS grading_rubric_criteria (
+                                                       id INT AUTO_INCREMENT PRIMARY KEY,
+                                                       rubric_id INT NOT NULL,
+                                                       section VARCHAR(50) NOT NULL,
+                                                       title VARCHAR(255) NOT NULL,
+                                                       title_en VARCHAR(255) NOT NULL,
+                                                       weight DECIMAL(5,2) NOT NULL,
+                                                       min_score DECIMAL(4,1) NOT NULL DEFAULT 1,
+                                                       max_score DECIMAL(4,1) NOT NULL DEFAULT 10,
+                                                       display_order INT NOT NULL DEFAULT 0,
+
+                                                       FOREIGN KEY (rubric_id) REFERENCES grading_rubrics(id) ON DELETE CASCADE,
+                                                       INDEX idx_rubric (rubric_id, display_order)
+);
+
+-- Criterion scores given by the reviewer, stored alongside the reviewer report
+CREATE TABLE IF NOT EXISTS reviewer_report_scores (
+                                                      id INT AUTO_INCREMENT PRIMARY KEY,
+                                                      reviewer_report_id INT NOT NULL,
+                                                      criterion_id INT NOT NULL,
+                                                      score DECIMAL(4,1) NOT NULL,
+                                                      created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                      updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+                                                      FOREIGN KEY (reviewer_report_id) REFERENCES reviewer_reports(id) ON DELETE CASCADE,
+                                                      FOREIGN KEY (criterion_id) REFERENCES grading_rubric_criteria(id),
+                                                      UNIQUE KEY unique_report_criterion (reviewer_report_id, criterion_id)
+);
+
+-- Default rubric
+INSERT INTO grading_rubrics (id, study_program, name, deviation_threshold, created_by)
+VALUES (1, NULL, 'Numatytieji recenzijos kriterijai', 1.5, 'system');
+
+INSERT INTO grading_rubric_criteria (rubric_id, section, title, title_en, weight, min_score, max_score, display_order) VALUES
+    (1, 'review_goals', 'Tikslų ir uždavinių aiškumas', 'Clarity of goals and tasks', 10, 1, 10, 1),
+    (1, 'review_theory', 'Teorinės dalies kokybė', 'Quality of the theoretical part', 15, 1, 10, 2),
+    (1, 'review_practical', 'Praktinės dalies kokybė', 'Quality of the practical part', 25, 1, 10, 3),
+    (1, 'review_theory_practical_link', 'Teorijos ir praktikos sąsaja', 'Link between theory and practice', 10, 1, 10, 4),
+    (1, 'review_results', 'Rezultatai ir išvados', 'Results and conclusions', 20, 1, 10, 5),
+    (1, 'review_practical_significance', 'Praktinė reikšmė', 'Practical significance', 10, 1, 10, 6),
+    (1, 'review_language', 'Kalba ir įforminimas', 'Language and formatting', 10, 1, 10, 7);
+
+SET foreign_key_checks = 1;
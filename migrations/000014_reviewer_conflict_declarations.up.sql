@@ -0,0 +1,31 @@
+-- ================================================
+-- Migration UP: Reviewer Conflict Declarations
+-- File: 000014_reviewer_conflict_declarations.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Conflict-of-interest declarations made by reviewers before opening the review form
+CREATE TABLE IF NOT EXISTS reviewer_conflict_declarations (
+                                                              id INT AUTO_INCREMENT PRIMARY KEY,
+                                                              student_record_id INT NOT NULL,
+                                                              reviewer_email VARCHAR(255) NOT NULL,
+                                                              reviewer_name VARCHAR(255) NOT NULL DEFAULT '',
+                                                              reviewer_workplace VARCHAR(500) NOT NULL DEFAULT '',
+                                                              has_conflict BOOLEAN NOT NULL DEFAULT FALSE,
+                                                              conflict_details TEXT NULL,
+                                                              auto_flags VARCHAR(255) NOT NULL DEFAULT '',
+                                                              status ENUM('clear', 'flagged', 'approved', 'rejected') NOT NULL DEFAULT 'clear',
+                                                              reviewed_by VARCHAR(255) NULL,
+                                                              reviewed_at TIMESTAMP NULL,
+                                                              review_note TEXT NULL,
+                                                              ip_address VARCHAR(45) NULL,
+                                                              declared_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+                                                              FOREIGN KEY (student_record_id) REFERENCES student_records(id) ON DELETE CASCADE,
+                                                              UNIQUE KEY unique_student_reviewer (student_record_id, reviewer_email),
+                                                              INDEX idx_status (status)
+);
+
+SET foreign_key_checks = 1;
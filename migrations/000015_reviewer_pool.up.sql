@@ -0,0 +1,27 @@
+-- ================================================
+-- Migration UP: Reviewer Pool
+-- File: 000015_reviewer_pool.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Reviewers available to a department for (automatic) assignment
+CREATE TABLE IF NOT EXISTS reviewer_pool (
+                                             id INT AUTO_INCREMENT PRIMARY KEY,
+                                             department VARCHAR(100) NOT NULL,
+                                             reviewer_email VARCHAR(255) NOT NULL,
+                                             reviewer_name VARCHAR(255) NOT NULL,
+                                             workplace VARCHAR(500) NOT NULL DEFAULT '',
+                                             keywords TEXT NULL,
+                                             max_load INT NOT NULL DEFAULT 5,
+                                             is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                                             created_by VARCHAR(255) NOT NULL,
+                                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                             updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+                                             UNIQUE KEY unique_department_reviewer (department, reviewer_email),
+                                             INDEX idx_department_active (department, is_active)
+);
+
+SET foreign_key_checks = 1;
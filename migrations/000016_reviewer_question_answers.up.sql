@@ -0,0 +1,36 @@
+-- ================================================
+-- Migration UP: Reviewer Question Answers
+-- File: 000016_reviewer_question_answers.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- When signed reviewer reports are published to students and until when they can answer
+CREATE TABLE IF NOT EXISTS department_review_policies (
+                                                          id INT AUTO_INCREMENT PRIMARY KEY,
+                                                          department VARCHAR(100) NOT NULL,
+                                                          publish_to_students BOOLEAN NOT NULL DEFAULT TRUE,
+                                                          publish_delay_hours INT NOT NULL DEFAULT 0,
+                                                          answer_deadline_hours INT NOT NULL DEFAULT 24,
+                                                          updated_by VARCHAR(255) NOT NULL,
+                                                          updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+                                                          UNIQUE KEY unique_department (department)
+);
+
+-- Written answers of students to the questions of the reviewer report
+CREATE TABLE IF NOT EXISTS reviewer_question_answers (
+                                                         id INT AUTO_INCREMENT PRIMARY KEY,
+                                                         student_record_id INT NOT NULL,
+                                                         question_number INT NOT NULL,
+                                                         question_text TEXT NOT NULL,
+                                                         answer TEXT NOT NULL,
+                                                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+                                                         FOREIGN KEY (student_record_id) REFERENCES student_records(id) ON DELETE CASCADE,
+                                                         UNIQUE KEY unique_student_question (student_record_id, question_number)
+);
+
+SET foreign_key_checks = 1;
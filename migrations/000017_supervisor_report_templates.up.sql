@@ -0,0 +1,33 @@
+-- ================================================
+-- Migration UP: Supervisor Report Templates
+-- File: 000017_supervisor_report_templates.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Personal defaults that pre-fill new supervisor reports
+CREATE TABLE IF NOT EXISTS supervisor_profiles (
+                                                   id INT AUTO_INCREMENT PRIMARY KEY,
+                                                   supervisor_email VARCHAR(255) NOT NULL,
+                                                   supervisor_position VARCHAR(255) NOT NULL DEFAULT '',
+                                                   supervisor_workplace VARCHAR(255) NOT NULL DEFAULT '',
+                                                   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+                                                   UNIQUE KEY unique_supervisor (supervisor_email)
+);
+
+-- Reusable comment snippets; {student_name} and {thesis_title} are replaced on insert
+CREATE TABLE IF NOT EXISTS supervisor_comment_snippets (
+                                                           id INT AUTO_INCREMENT PRIMARY KEY,
+                                                           supervisor_email VARCHAR(255) NOT NULL,
+                                                           title VARCHAR(100) NOT NULL,
+                                                           body TEXT NOT NULL,
+                                                           created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                           updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+                                                           INDEX idx_supervisor (supervisor_email)
+);
+
+SET foreign_key_checks = 1;
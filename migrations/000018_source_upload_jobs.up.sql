@@ -0,0 +1,44 @@
+-- ================================================
+-- Migration UP: Source Upload Jobs
+-- File: 000018_source_upload_jobs.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Durable queue of source code uploads; the ZIP is spooled to disk at spool_path until the job finishes
+CREATE TABLE IF NOT EXISTS source_upload_jobs (
+                                                  id INT AUTO_INCREMENT PRIMARY KEY,
+                                                  submission_id VARCHAR(36) NOT NULL,
+                                                  student_record_id INT NOT NULL,
+                                                  student_name VARCHAR(255) NOT NULL,
+                                                  student_number VARCHAR(50) NOT NULL,
+                                                  student_email VARCHAR(255) NOT NULL,
+                                                  thesis_title TEXT,
+                                                  original_filename VARCHAR(255) NOT NULL,
+                                                  file_size BIGINT NOT NULL DEFAULT 0,
+                                                  spool_path VARCHAR(500) NOT NULL,
+                                                  status ENUM('queued', 'processing', 'done', 'failed') NOT NULL DEFAULT 'queued',
+                                                  stage VARCHAR(50) NOT NULL DEFAULT 'queued',
+                                                  progress INT NOT NULL DEFAULT 0,
+                                                  attempts INT NOT NULL DEFAULT 0,
+                                                  max_attempts INT NOT NULL DEFAULT 3,
+                                                  lease_owner VARCHAR(100) NULL,
+                                                  lease_expires_at TIMESTAMP NULL,
+                                                  next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                  last_error TEXT NULL,
+                                                  result_json LONGTEXT NULL,
+                                                  document_id INT NULL,
+                                                  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                                                  started_at TIMESTAMP NULL,
+                                                  finished_at TIMESTAMP NULL,
+
+                                                  UNIQUE KEY unique_submission (submission_id),
+                                                  INDEX idx_claim (status, next_attempt_at),
+                                                  INDEX idx_lease (status, lease_expires_at),
+                                                  INDEX idx_student (student_record_id, status),
+                                                  FOREIGN KEY (student_record_id) REFERENCES student_records(id) ON DELETE CASCADE
+);
+
+SET foreign_key_checks = 1;
@@ -0,0 +1,25 @@
+-- ================================================
+-- Migration UP: Source Upload Uploader
+-- File: 000019_source_upload_uploader.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Who actually uploaded the source code; differs from the student for uploads made on their behalf
+ALTER TABLE documents
+    ADD COLUMN uploaded_by_email VARCHAR(255) NULL,
+    ADD COLUMN uploaded_by_role VARCHAR(50) NULL;
+
+ALTER TABLE source_upload_jobs
+    ADD COLUMN uploaded_by_email VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN uploaded_by_role VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN upload_ip VARCHAR(45) NULL,
+    ADD COLUMN upload_user_agent TEXT NULL;
+
+-- Uploading on behalf of a student requires this explicit grant; add rows for other roles (e.g. supervisor) to allow it
+INSERT IGNORE INTO role_permissions (role_name, permission, resource_type) VALUES
+                                                                               ('admin', 'upload_source_on_behalf', 'documents'),
+                                                                               ('department_head_1', 'upload_source_on_behalf', 'documents');
+
+SET foreign_key_checks = 1;
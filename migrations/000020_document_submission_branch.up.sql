@@ -0,0 +1,14 @@
+-- ================================================
+-- Migration UP: Document Submission Branch
+-- File: 000020_document_submission_branch.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Every source code upload is kept on its own submission-YYYYMMDD-HHMMSS branch
+ALTER TABLE documents
+    ADD COLUMN submission_branch VARCHAR(100) NULL,
+    ADD INDEX idx_documents_submission_branch (student_record_id, submission_branch);
+
+SET foreign_key_checks = 1;
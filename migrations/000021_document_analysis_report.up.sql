@@ -0,0 +1,13 @@
+-- ================================================
+-- Migration UP: Document Analysis Report
+-- File: 000021_document_analysis_report.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Code report (languages, build systems, tests, secrets, vendored code, binaries) produced after extraction
+ALTER TABLE documents
+    ADD COLUMN analysis_report LONGTEXT NULL;
+
+SET foreign_key_checks = 1;
@@ -0,0 +1,63 @@
+-- ================================================
+-- Migration UP: Code Similarity
+-- File: 000022_code_similarity.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Winnowing fingerprints of a source code submission, computed at upload or lazily from the repository archive
+CREATE TABLE IF NOT EXISTS source_code_fingerprints (
+                                                        document_id INT PRIMARY KEY,
+                                                        student_record_id INT NOT NULL,
+                                                        version INT NOT NULL,
+                                                        files_count INT NOT NULL DEFAULT 0,
+                                                        fingerprints_count INT NOT NULL DEFAULT 0,
+                                                        fingerprints LONGTEXT NOT NULL,
+                                                        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+                                                        INDEX idx_student (student_record_id),
+                                                        FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE
+);
+
+-- A similarity check over the submissions of one program and year
+CREATE TABLE IF NOT EXISTS code_similarity_runs (
+                                                    id INT AUTO_INCREMENT PRIMARY KEY,
+                                                    department VARCHAR(255) NOT NULL,
+                                                    study_program VARCHAR(255) NOT NULL,
+                                                    current_year INT NOT NULL,
+                                                    include_previous_years BOOLEAN NOT NULL DEFAULT TRUE,
+                                                    status ENUM('pending', 'running', 'completed', 'failed') NOT NULL DEFAULT 'pending',
+                                                    submissions_count INT NOT NULL DEFAULT 0,
+                                                    previous_submissions_count INT NOT NULL DEFAULT 0,
+                                                    pairs_count INT NOT NULL DEFAULT 0,
+                                                    error_message TEXT NULL,
+                                                    created_by VARCHAR(255) NOT NULL,
+                                                    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                                    completed_at TIMESTAMP NULL,
+
+                                                    INDEX idx_scope (department, study_program, current_year)
+);
+
+-- Suspicious pairs of a run with their matched fragments
+CREATE TABLE IF NOT EXISTS code_similarity_pairs (
+                                                     id INT AUTO_INCREMENT PRIMARY KEY,
+                                                     run_id INT NOT NULL,
+                                                     document_a_id INT NOT NULL,
+                                                     student_a_id INT NOT NULL,
+                                                     document_b_id INT NOT NULL,
+                                                     student_b_id INT NOT NULL,
+                                                     previous_year BOOLEAN NOT NULL DEFAULT FALSE,
+                                                     score DECIMAL(5,2) NOT NULL,
+                                                     percent_a DECIMAL(5,2) NOT NULL,
+                                                     percent_b DECIMAL(5,2) NOT NULL,
+                                                     shared_fingerprints INT NOT NULL,
+                                                     matches_json LONGTEXT NULL,
+
+                                                     INDEX idx_run_score (run_id, score),
+                                                     FOREIGN KEY (run_id) REFERENCES code_similarity_runs(id) ON DELETE CASCADE,
+                                                     FOREIGN KEY (student_a_id) REFERENCES student_records(id) ON DELETE CASCADE,
+                                                     FOREIGN KEY (student_b_id) REFERENCES student_records(id) ON DELETE CASCADE
+);
+
+SET foreign_key_checks = 1;
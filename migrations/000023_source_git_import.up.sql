@@ -0,0 +1,15 @@
+-- ================================================
+-- Migration UP: Source Git Import
+-- File: 000023_source_git_import.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Uploads are either a spooled archive or a public Git repository cloned at import_ref with its history
+ALTER TABLE source_upload_jobs
+    ADD COLUMN source_type ENUM('archive', 'git') NOT NULL DEFAULT 'archive',
+    ADD COLUMN import_url VARCHAR(500) NULL,
+    ADD COLUMN import_ref VARCHAR(255) NULL;
+
+SET foreign_key_checks = 1;
@@ -0,0 +1,27 @@
+-- ================================================
+-- Migration UP: Defense Periods
+-- File: 000024_defense_periods.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Windows in which commission and reviewer links may open a student's repository and documents.
+-- A period without a study program covers the whole department; program periods take precedence.
+-- Reviewers are let in from review_starts_at (or starts_at when not set) until ends_at.
+CREATE TABLE IF NOT EXISTS defense_periods (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    department VARCHAR(255) NOT NULL,
+    study_program VARCHAR(255) NULL,
+    review_starts_at DATETIME NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    description VARCHAR(500) NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_department_program (department, study_program),
+    INDEX idx_ends_at (ends_at)
+);
+
+SET foreign_key_checks = 1;
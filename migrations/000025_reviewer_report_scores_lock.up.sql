@@ -0,0 +1,34 @@
+-- ================================================
+-- Migration UP: Reviewer Report Scores Lock
+-- File: 000025_reviewer_report_scores_lock.up.sql
+-- ================================================
+
+SET sql_mode = '';
+SET foreign_key_checks = 0;
+
+-- Rubric scores are part of the signed reviewer report, so they stay locked with it
+CREATE TRIGGER reviewer_report_scores_signed_insert BEFORE INSERT ON reviewer_report_scores
+    FOR EACH ROW
+    BEGIN
+        IF (SELECT is_signed FROM reviewer_reports WHERE id = NEW.reviewer_report_id) = TRUE THEN
+            SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'signed reviewer report is locked';
+        END IF;
+    END;
+
+CREATE TRIGGER reviewer_report_scores_signed_update BEFORE UPDATE ON reviewer_report_scores
+    FOR EACH ROW
+    BEGIN
+        IF (SELECT is_signed FROM reviewer_reports WHERE id = OLD.reviewer_report_id) = TRUE THEN
+            SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'signed reviewer report is locked';
+        END IF;
+    END;
+
+CREATE TRIGGER reviewer_report_scores_signed_delete BEFORE DELETE ON reviewer_report_scores
+    FOR EACH ROW
+    BEGIN
+        IF (SELECT is_signed FROM reviewer_reports WHERE id = OLD.reviewer_report_id) = TRUE THEN
+            SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'signed reviewer report is locked';
+        END IF;
+    END;
+
+SET foreign_key_checks = 1;
@@ -4,8 +4,10 @@ package notifications
 import (
 	"context"
 	"fmt"
+	"html"
 	"log"
 	"os"
+	"strings"
 	"time" // Add this import
 
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
@@ -211,6 +213,45 @@ Thesis Management System
 	return n.sendNotification(ctx, studentEmail, subject, body)
 }
 
+// TopicReviewDigestItem is one decided topic in a supervisor's review digest
+type TopicReviewDigestItem struct {
+	StudentName string
+	TopicTitle  string
+	Approved    bool
+	Reason      string
+}
+
+// SendTopicReviewDigest sends a supervisor a single summary of topics decided in one bulk review
+func (n *NotificationService) SendTopicReviewDigest(ctx context.Context, supervisorEmail string, items []TopicReviewDigestItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	subject := fmt.Sprintf("Department topic review summary (%d) - Katedros temų vertinimo suvestinė (%d)", len(items), len(items))
+
+	var lines strings.Builder
+	for _, item := range items {
+		if item.Approved {
+			fmt.Fprintf(&lines, "<li>%s: \"%s\" - Approved / Patvirtinta</li>\n",
+				html.EscapeString(item.StudentName), html.EscapeString(item.TopicTitle))
+		} else {
+			fmt.Fprintf(&lines, "<li>%s: \"%s\" - Revision requested / Reikalauja pataisymų: %s</li>\n",
+				html.EscapeString(item.StudentName), html.EscapeString(item.TopicTitle), html.EscapeString(item.Reason))
+		}
+	}
+
+	body := fmt.Sprintf(`
+<p>Dear supervisor / Gerb. vadove,</p>
+<p>The department has reviewed the following topics of your students:</p>
+<p>Katedra įvertino šias jūsų studentų temas:</p>
+<ul>
+%s</ul>
+<p>Best regards / Pagarbiai,<br>Thesis Management System</p>
+`, lines.String())
+
+	return n.sendNotification(ctx, supervisorEmail, subject, body)
+}
+
 // Add this method to your notifications/service.go
 func (n *NotificationService) SendTestNotificationWithDebug(ctx context.Context, toEmail string) error {
 	if n.graphClient == nil {
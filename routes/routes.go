@@ -7,19 +7,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/jmoiron/sqlx"
-	"io"
 	"log"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
 	"FinalProjectManagementApp/auth"
+	"FinalProjectManagementApp/githosting"
 	"FinalProjectManagementApp/handlers"
 	"FinalProjectManagementApp/notifications"
 )
@@ -29,7 +27,8 @@ func SetupRoutes(db *sqlx.DB,
 	authService *auth.AuthService,
 	authMiddleware *auth.AuthMiddleware,
 	notificationService *notifications.NotificationService,
-	sourceCodeHandler *handlers.SourceCodeHandler) *chi.Mux {
+	sourceCodeHandler *handlers.SourceCodeHandler,
+	gitProvider githosting.Provider) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middleware
@@ -43,23 +42,31 @@ func SetupRoutes(db *sqlx.DB,
 	dashboardHandlers := handlers.NewDashboardHandlers(db)
 	authHandlers := handlers.NewAuthHandlers(authMiddleware)
 	topicHandlers := handlers.NewTopicHandlers(db)
+	topicHandlers.SetNotificationService(notificationService)
 	supervisorReportHandler := handlers.NewSupervisorReportHandler(db)
 	studentListHandler := handlers.NewStudentListHandler(db)
 	uploadHandlers := handlers.NewUploadHandlers(db)
+	reportDocumentHandler := handlers.NewReportDocumentHandler(db)
 	commissionHandler := handlers.NewCommissionHandler(db)
+	defensePeriodHandler := handlers.NewDefensePeriodHandler(db)
 
 	reviewerAccessHandler := handlers.NewReviewerAccessHandler(db)
-
-	// Get app config for GitHub settings
-	appConfig := database.LoadAppConfig()
-
-	// Initialize repository handler only if GitHub is configured
+	reviewerConflictHandler := handlers.NewReviewerConflictHandler(db)
+	reviewerAssignmentHandler := handlers.NewReviewerAssignmentHandler(db, notificationService)
+	reviewerAnswersHandler := handlers.NewReviewerAnswersHandler(db)
+	supervisorTemplateHandler := handlers.NewSupervisorTemplateHandler(db)
+	gradingRubricHandler := handlers.NewGradingRubricHandler(db)
+
+	// Initialize repository handler only if git hosting is configured. The provider is shared with the
+	// upload handler, so a new submission invalidates what the repository pages have cached.
 	var repositoryHandler *handlers.RepositoryHandler
-	if appConfig.HasGitHub() {
-		repositoryHandler = handlers.NewRepositoryHandler(db, appConfig.GitHub)
+	var codeSimilarityHandler *handlers.CodeSimilarityHandler
+	if gitProvider != nil {
+		repositoryHandler = handlers.NewRepositoryHandler(db, gitProvider)
+		codeSimilarityHandler = handlers.NewCodeSimilarityHandler(db, gitProvider)
 		log.Println("Repository handler initialized successfully")
 	} else {
-		log.Println("Repository viewing disabled - GitHub not configured")
+		log.Println("Repository viewing disabled - git hosting not configured")
 	}
 
 	// Static files - serve both assets and static directories
@@ -116,6 +123,8 @@ func SetupRoutes(db *sqlx.DB,
 		r.Get("/", studentListHandler.ShowReviewerStudentsWithToken)
 		r.Get("/student/{studentId}/review", studentListHandler.ReviewerReportModalHandlerWithToken)
 		r.Post("/student/{studentId}/review/submit", studentListHandler.ReviewerReportSubmitHandlerWithToken)
+		r.Post("/student/{studentId}/conflict-declaration", studentListHandler.SubmitConflictDeclarationWithToken)
+		r.Get("/student/{studentId}/review/pdf", reportDocumentHandler.DownloadReviewerReportPDFWithToken)
 
 		// Add repository viewing route for reviewers
 		r.Get("/repository/student/{studentId}", func(w http.ResponseWriter, r *http.Request) {
@@ -369,19 +378,26 @@ func SetupRoutes(db *sqlx.DB,
 			}
 		})
 
+		// Submission history for the assigned reviewer
+		if repositoryHandler != nil {
+			r.Get("/repository/student/{studentId}/submissions", createReviewerSubmissionHandler(db, repositoryHandler.ViewSubmissionHistory))
+			r.Get("/repository/student/{studentId}/submissions/compare", createReviewerSubmissionHandler(db, repositoryHandler.CompareSubmissions))
+			r.Get("/repository/student/{studentId}/submissions/compare/file", createReviewerSubmissionHandler(db, repositoryHandler.ViewSubmissionFileDiff))
+			r.Get("/repository/student/{studentId}/submissions/{branch}", createReviewerSubmissionHandler(db, repositoryHandler.ViewSubmissionSnapshot))
+			r.Get("/repository/student/{studentId}/submissions/{branch}/file/*", createReviewerSubmissionHandler(db, repositoryHandler.ViewSubmissionFile))
+		}
 	})
 
-	// PUBLIC DOCUMENTS ROUTES
-	r.Get("/api/public/documents/{id}", createPublicDocumentsHandler(db))
-	r.Get("/api/public/students/{id}/documents", createPublicDocumentsHandler(db))
-	r.Get("/api/public/documents/{id}/preview", createPublicDocumentPreviewHandler(db))
-	r.Get("/api/public/documents/{id}/download", createPublicDocumentDownloadHandler(db))
-
 	// Public commission access (no auth required)
 
 	r.Route("/commission/{accessCode}", func(r chi.Router) {
 		r.Get("/", commissionHandler.ShowStudentList)
 		r.Get("/topic-registration/{studentId}", createCommissionTopicRegistrationHandler(db))
+		r.Get("/topic/{id}/form", createCommissionTopicFormHandler(db))
+		r.Get("/reports/{kind}/{studentId}/pdf", createCommissionReportDocumentHandler(db))
+		r.Get("/students/{studentId}/documents", createCommissionDocumentsHandler(db))
+		r.Get("/students/{studentId}/documents/{id}/preview", createCommissionDocumentFileHandler(db, false))
+		r.Get("/students/{studentId}/documents/{id}/download", createCommissionDocumentFileHandler(db, true))
 
 		// Add repository routes here
 		if repositoryHandler != nil {
@@ -391,6 +407,11 @@ func SetupRoutes(db *sqlx.DB,
 				r.Get("/student/{studentId}/browse/*", createCommissionBrowseHandler(repositoryHandler, db))
 				r.Get("/student/{studentId}/file/*", createCommissionFileHandler(repositoryHandler, db))
 				r.Get("/student/{studentId}/tree", createCommissionTreeHandler(repositoryHandler, db))
+				r.Get("/student/{studentId}/submissions", createCommissionSubmissionHandler(db, repositoryHandler.ViewSubmissionHistory))
+				r.Get("/student/{studentId}/submissions/compare", createCommissionSubmissionHandler(db, repositoryHandler.CompareSubmissions))
+				r.Get("/student/{studentId}/submissions/compare/file", createCommissionSubmissionHandler(db, repositoryHandler.ViewSubmissionFileDiff))
+				r.Get("/student/{studentId}/submissions/{branch}", createCommissionSubmissionHandler(db, repositoryHandler.ViewSubmissionSnapshot))
+				r.Get("/student/{studentId}/submissions/{branch}/file/*", createCommissionSubmissionHandler(db, repositoryHandler.ViewSubmissionFile))
 			})
 		}
 	})
@@ -401,9 +422,10 @@ func SetupRoutes(db *sqlx.DB,
 
 		// version modal
 
-		r.Get("/api/topic/{id}/version/{versionId}/changes", topicHandlers.ShowVersionChanges) // this no use anymore
+		r.Get("/api/topic/{id}/version/{versionId}/changes", topicHandlers.ShowVersionChanges)
 		r.Get("/api/topic/{id}/content", topicHandlers.GetTopicContent)
 		r.Get("/api/topic/{id}/compare/{versionId}", topicHandlers.CompareTopicVersions)
+		r.Get("/api/topic/{id}/diff", topicHandlers.ShowTopicVersionDiff)
 		// students for supervisor
 		r.Get("/my-students", studentListHandler.SupervisorMyStudentsHandler)
 
@@ -436,6 +458,13 @@ func SetupRoutes(db *sqlx.DB,
 				r.Get("/student/{studentId}/browse/*", repositoryHandler.ViewStudentRepositoryPath)
 				r.Get("/student/{studentId}/file/*", repositoryHandler.ViewFileContent)
 				r.Get("/student/{studentId}/tree", repositoryHandler.GetRepositoryTree)
+
+				// Submission history and diffs between submissions
+				r.Get("/student/{studentId}/submissions", repositoryHandler.ViewSubmissionHistory)
+				r.Get("/student/{studentId}/submissions/compare", repositoryHandler.CompareSubmissions)
+				r.Get("/student/{studentId}/submissions/compare/file", repositoryHandler.ViewSubmissionFileDiff)
+				r.Get("/student/{studentId}/submissions/{branch}", repositoryHandler.ViewSubmissionSnapshot)
+				r.Get("/student/{studentId}/submissions/{branch}/file/*", repositoryHandler.ViewSubmissionFile)
 			})
 
 			// API routes for repository data
@@ -457,6 +486,16 @@ func SetupRoutes(db *sqlx.DB,
 		r.Get("/supervisor-report/{id}/compact-modal", supervisorReportHandler.GetCompactSupervisorModal)
 		r.Post("/supervisor-report/{id}/submit", supervisorReportHandler.SubmitSupervisorReport)
 		r.Post("/supervisor-report/{id}/save-draft", supervisorReportHandler.SaveSupervisorDraft)
+		r.Post("/supervisor-report/{id}/similarity-report", supervisorReportHandler.UploadSimilarityReport)
+		r.Get("/supervisor-reports/batch", supervisorReportHandler.ShowBatchOverview)
+		r.Get("/supervisor-reports/batch/review/{id}", supervisorReportHandler.ShowBatchReview)
+		r.Post("/supervisor-reports/batch/sign", supervisorReportHandler.SignBatch)
+
+		// Supervisor report defaults and comment snippets
+		r.Get("/report-templates", supervisorTemplateHandler.ShowTemplates)
+		r.Post("/report-templates/profile", supervisorTemplateHandler.SaveProfile)
+		r.Post("/report-templates/snippets", supervisorTemplateHandler.SaveSnippet)
+		r.Delete("/report-templates/snippets/{id}", supervisorTemplateHandler.DeleteSnippet)
 
 		// Upload routes
 		r.Get("/upload", handlers.ShowUploadPage)
@@ -477,6 +516,8 @@ func SetupRoutes(db *sqlx.DB,
 			r.Get("/dashboard", dashboardHandlers.DashboardHandler)
 			r.Get("/topic", topicHandlers.ShowTopicRegistrationForm)
 			r.Post("/topic/submit", topicHandlers.SubmitTopic)
+			r.Get("/reviewer-report", reviewerAnswersHandler.ShowStudentReview)
+			r.Post("/reviewer-report/answers", reviewerAnswersHandler.SaveAnswers)
 		})
 
 		// Supervisor routes - ENHANCED WITH TOPIC WORKFLOW
@@ -521,6 +562,14 @@ func SetupRoutes(db *sqlx.DB,
 			// Document operations
 			r.Get("/documents/{id}/preview", uploadHandlers.DocumentPreviewHandler)
 			r.Get("/documents/{id}/download", uploadHandlers.DocumentDownloadHandler)
+			r.Get("/student-documents/{studentId}", uploadHandlers.StudentDocumentsHandler)
+			r.Get("/reports/{kind}/{studentId}/pdf", reportDocumentHandler.DownloadReportPDF)
+			r.Get("/reports/{kind}/{studentId}/verify", reportDocumentHandler.VerifyReportSignature)
+			r.Get("/reports/{kind}/{studentId}/versions", reportDocumentHandler.ShowReportVersions)
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.RequireRole(auth.RoleAdmin))
+				r.Post("/reports/{kind}/{studentId}/unlock", reportDocumentHandler.UnlockReport)
+			})
 
 			// Student-only upload routes
 			r.Group(func(r chi.Router) {
@@ -532,6 +581,7 @@ func SetupRoutes(db *sqlx.DB,
 			// Source code routes
 			r.Route("/source-code", func(r chi.Router) {
 				r.Post("/upload", sourceCodeHandler.UploadSourceCode)
+				r.Post("/import", sourceCodeHandler.ImportSourceCode)
 				r.Get("/status", sourceCodeHandler.GetUploadStatus)
 				r.Get("/health", sourceCodeHandler.GetSystemHealth)
 			})
@@ -543,12 +593,20 @@ func SetupRoutes(db *sqlx.DB,
 			// NEW: Enhanced submission workflow
 			r.Post("/topic/submit-for-review", topicHandlers.SubmitTopicForReview)
 			r.Post("/topic/{id}/comment", topicHandlers.AddComment)
+			r.Post("/topic/{id}/comments/read", topicHandlers.MarkCommentsRead)
+			r.Post("/topic/{id}/comment/{commentId}/resolve", topicHandlers.ResolveComment)
+			r.Post("/topic/{id}/comment/{commentId}/unresolve", topicHandlers.UnresolveComment)
+			r.Post("/topic/{id}/change-request", topicHandlers.CreateChangeRequest)
+			r.Post("/topic/change-request/{requestId}/withdraw", topicHandlers.WithdrawChangeRequest)
+			r.Get("/topic/{id}/form", topicHandlers.DownloadTopicForm)
+			r.Get("/topics/forms", topicHandlers.DownloadGroupTopicForms)
 
 			// Supervisor approval routes - NEW SECTION
 			r.Group(func(r chi.Router) {
 				r.Use(authMiddleware.RequireRole(auth.RoleSupervisor, auth.RoleAdmin))
 				r.Post("/topic/{id}/supervisor-approve", topicHandlers.SupervisorApproveTopic)
 				r.Post("/topic/{id}/supervisor-revision", topicHandlers.SupervisorRequestRevision)
+				r.Post("/topic/change-request/{requestId}/supervisor-decision", topicHandlers.SupervisorDecideChangeRequest)
 			})
 
 			// Department head/Admin final approval routes
@@ -556,6 +614,7 @@ func SetupRoutes(db *sqlx.DB,
 				r.Use(authMiddleware.RequireRole(auth.RoleDepartmentHead, auth.RoleAdmin))
 				r.Post("/topic/{id}/approve", topicHandlers.ApproveTopic)                          // Remove /api prefix
 				r.Post("/topic/{id}/department-revision", topicHandlers.DepartmentRequestRevision) // Remove /api prefix
+				r.Post("/topic/change-request/{requestId}/department-decision", topicHandlers.DepartmentDecideChangeRequest)
 
 			})
 		})
@@ -612,6 +671,7 @@ func SetupRoutes(db *sqlx.DB,
 			// Topic management routes
 			r.Get("/topics", topicHandlers.ShowDepartmentTopics)
 			r.Get("/topics/pending", topicHandlers.ShowPendingDepartmentTopics)
+			r.Post("/topics/bulk-review", topicHandlers.BulkReviewTopics)
 
 			// Topic approval routes with notification integration
 			r.Post("/topics/{id}/approve", func(w http.ResponseWriter, r *http.Request) {
@@ -668,6 +728,7 @@ func SetupRoutes(db *sqlx.DB,
 			r.Get("/{id}/compact-modal", studentListHandler.ReviewerReportModalHandler)
 			r.Post("/{id}/submit", studentListHandler.ReviewerReportSubmitHandler)
 			r.Post("/{id}/save-draft", studentListHandler.ReviewerReportSaveDraftHandler)
+			r.Post("/{id}/conflict-declaration", studentListHandler.SubmitConflictDeclaration)
 		})
 
 		// Admin routes - MERGED WITH IMPORT/EXPORT FUNCTIONALITY
@@ -678,11 +739,40 @@ func SetupRoutes(db *sqlx.DB,
 			r.Post("/reviewer-access/create", reviewerAccessHandler.CreateReviewerAccess)
 			r.Delete("/reviewer-access/{accessToken}", reviewerAccessHandler.DeactivateReviewerAccess)
 
+			r.Get("/reviewer-conflicts", reviewerConflictHandler.ShowFlaggedAssignments)
+			r.Post("/reviewer-conflicts/{id}/resolve", reviewerConflictHandler.ResolveConflict)
+
+			// Source code similarity between student submissions
+			if codeSimilarityHandler != nil {
+				r.Get("/code-similarity", codeSimilarityHandler.ShowRuns)
+				r.Post("/code-similarity", codeSimilarityHandler.StartRun)
+				r.Get("/code-similarity/{id}", codeSimilarityHandler.ShowRun)
+				r.Get("/code-similarity/{id}/pairs/{pairId}", codeSimilarityHandler.ShowPair)
+			}
+
+			r.Get("/reviewer-assignment", reviewerAssignmentHandler.ShowAssignmentPage)
+			r.Post("/reviewer-assignment/pool", reviewerAssignmentHandler.SavePoolMember)
+			r.Delete("/reviewer-assignment/pool/{id}", reviewerAssignmentHandler.RemovePoolMember)
+			r.Post("/reviewer-assignment/confirm", reviewerAssignmentHandler.ConfirmAssignments)
+
+			r.Get("/review-policy", reviewerAnswersHandler.ShowPolicies)
+			r.Post("/review-policy", reviewerAnswersHandler.SavePolicy)
+
+			// Windows in which commission and reviewer links open students' work
+			r.Get("/defense-periods", defensePeriodHandler.ShowPeriods)
+			r.Post("/defense-periods", defensePeriodHandler.CreatePeriod)
+			r.Delete("/defense-periods/{id}", defensePeriodHandler.DeletePeriod)
+
 			r.Get("/commission", commissionHandler.ShowManagementPage)
 			r.Post("/commission/create", commissionHandler.CreateAccess)
 			r.Delete("/commission/{accessCode}", commissionHandler.DeactivateAccess)
 			r.Get("/commission/list", commissionHandler.ListActiveAccess)
 
+			r.Get("/rubrics", gradingRubricHandler.ShowRubrics)
+			r.Post("/rubrics/create", gradingRubricHandler.CreateRubric)
+			r.Post("/rubrics/{id}", gradingRubricHandler.UpdateRubric)
+			r.Delete("/rubrics/{id}", gradingRubricHandler.DeactivateRubric)
+
 			r.Get("/dashboard", dashboardHandlers.DashboardHandler)
 
 			// Import/Export routes
@@ -812,9 +902,10 @@ func createCommissionRepositoryHandler(repositoryHandler *handlers.RepositoryHan
 
 		// Create fake authenticated user for commission member
 		fakeUser := &auth.AuthenticatedUser{
-			Email: "commission_" + accessCode,
-			Role:  auth.RoleCommissionMember,
-			Name:  "Commission Member",
+			Email:      "commission_" + accessCode,
+			Role:       auth.RoleCommissionMember,
+			Name:       "Commission Member",
+			Department: member.Department,
 		}
 
 		// Add to context
@@ -933,255 +1024,161 @@ func createCommissionDownloadHandler(repositoryHandler *handlers.RepositoryHandl
 	}
 }
 
-// PUBLIC ROUTES FOR DOCUMENTS
-
-// In routes.go, add these functions:
-
-// createPublicDocumentsHandler - fetches documents without authentication
-func createPublicDocumentsHandler(db *sqlx.DB) http.HandlerFunc {
+// createCommissionSubmissionHandler runs a submission history handler as the commission member of the access code
+func createCommissionSubmissionHandler(db *sqlx.DB, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		studentIDStr := chi.URLParam(r, "id")
-		studentID, err := strconv.Atoi(studentIDStr)
+		accessCode := chi.URLParam(r, "accessCode")
+
+		member, err := validateCommissionAccess(db, accessCode)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"error":     "Invalid student ID",
-				"documents": []interface{}{},
-			})
+			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		// Query documents from database
-		query := `
-            SELECT id, document_type, file_path, uploaded_date, 
-                   file_size, mime_type, original_filename
-            FROM documents 
-            WHERE student_record_id = ?
-            ORDER BY uploaded_date DESC
-        `
-
-		var documents []struct {
-			ID               int       `db:"id"`
-			DocumentType     string    `db:"document_type"`
-			FilePath         string    `db:"file_path"`
-			UploadedDate     time.Time `db:"uploaded_date"`
-			FileSize         *int64    `db:"file_size"`
-			MimeType         *string   `db:"mime_type"`
-			OriginalFilename *string   `db:"original_filename"`
+		fakeUser := &auth.AuthenticatedUser{
+			Email:      "commission_" + accessCode,
+			Role:       auth.RoleCommissionMember,
+			Name:       "Commission Member",
+			Department: member.Department,
 		}
 
-		err = db.Select(&documents, query, studentID)
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"error":     "Failed to fetch documents",
-				"documents": []interface{}{},
-			})
-			return
-		}
+		ctx := context.WithValue(r.Context(), auth.UserContextKey, fakeUser)
+		next(w, r.WithContext(ctx))
+	}
+}
 
-		// Transform for response
-		responseDocuments := make([]map[string]interface{}, 0)
-		for _, doc := range documents {
-			hasPreview := false
-			if doc.MimeType != nil && strings.HasPrefix(*doc.MimeType, "application/pdf") {
-				hasPreview = true
-			}
+// createReviewerSubmissionHandler runs a submission history handler as the reviewer owning the access token
+func createReviewerSubmissionHandler(db *sqlx.DB, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessToken := chi.URLParam(r, "accessToken")
 
-			docType := doc.DocumentType
-			if docType == "thesis" || docType == "thesis_pdf" {
-				docType = "thesis_pdf"
-			}
+		var reviewerAccess database.ReviewerAccessToken
+		query := `SELECT * FROM reviewer_access_tokens WHERE access_token = ? AND is_active = true`
+		if err := db.Get(&reviewerAccess, query, accessToken); err != nil {
+			http.Error(w, "Invalid access token", http.StatusUnauthorized)
+			return
+		}
+		if reviewerAccess.IsExpired() {
+			http.Error(w, "Access token has expired", http.StatusUnauthorized)
+			return
+		}
 
-			responseDocuments = append(responseDocuments, map[string]interface{}{
-				"id":         doc.ID,
-				"type":       docType,
-				"hasPreview": hasPreview,
-				"filename":   doc.OriginalFilename,
-				"size":       doc.FileSize,
-			})
+		// The handler checks the student's reviewer_email against this user
+		fakeUser := &auth.AuthenticatedUser{
+			Email: reviewerAccess.ReviewerEmail,
+			Role:  auth.RoleReviewer,
+			Name:  reviewerAccess.ReviewerName,
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"documents": responseDocuments,
-		})
+		ctx := context.WithValue(r.Context(), auth.UserContextKey, fakeUser)
+		next(w, r.WithContext(ctx))
 	}
 }
 
-// createPublicDocumentPreviewHandler - handles document preview without authentication
-func createPublicDocumentPreviewHandler(db *sqlx.DB) http.HandlerFunc {
+// Add this function to routes.go
+func createCommissionTopicRegistrationHandler(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		documentIDStr := chi.URLParam(r, "id")
-		documentID, err := strconv.Atoi(documentIDStr)
+		accessCode := chi.URLParam(r, "accessCode")
+		studentIDStr := chi.URLParam(r, "studentId")
+
+		// Validate student ID format
+		studentID, err := strconv.Atoi(studentIDStr)
 		if err != nil {
-			http.Error(w, "Invalid document ID", http.StatusBadRequest)
+			http.Error(w, "Invalid student ID", http.StatusBadRequest)
 			return
 		}
 
-		// Get document info
-		var doc struct {
-			ID               int     `db:"id"`
-			StudentRecordID  int     `db:"student_record_id"`
-			DocumentType     string  `db:"document_type"`
-			FilePath         string  `db:"file_path"`
-			MimeType         *string `db:"mime_type"`
-			OriginalFilename *string `db:"original_filename"`
-		}
-
-		query := `SELECT id, student_record_id, document_type, file_path, mime_type, original_filename 
-                  FROM documents WHERE id = ?`
-		err = db.Get(&doc, query, documentID)
+		// Validate commission access
+		member, err := validateCommissionAccess(db, accessCode)
 		if err != nil {
-			http.Error(w, "Document not found", http.StatusNotFound)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
-
-		// Check if document can be previewed
-		if doc.MimeType == nil || !strings.HasPrefix(*doc.MimeType, "application/pdf") {
-			http.Error(w, "Document cannot be previewed", http.StatusBadRequest)
+		if !handlers.CommissionCoversStudent(db, member, studentID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
 			return
 		}
 
-		// Read file
-		filePath := doc.FilePath
-		if !filepath.IsAbs(filePath) {
-			// Assuming uploads are in ./uploads directory
-			filePath = filepath.Join("./uploads", filePath)
-		}
-
-		file, err := os.Open(filePath)
-		if err != nil {
-			log.Printf("Error opening file %s: %v", filePath, err)
-			http.Error(w, "File not found", http.StatusNotFound)
+		// Documents open only within a defense period of the student
+		access := database.AccessInfo{Code: accessCode, Type: database.AccessTypeCommission}
+		if !handlers.EnforceAccessWindow(db, w, r, access, studentID, "document") {
 			return
 		}
-		defer file.Close()
 
-		// Get file info
-		fileInfo, err := file.Stat()
-		if err != nil {
-			http.Error(w, "Error reading file", http.StatusInternalServerError)
-			return
-		}
+		// Update access count
+		updateQuery := `UPDATE commission_members SET access_count = access_count + 1, last_accessed_at = ? WHERE id = ?`
+		db.Exec(updateQuery, time.Now().Unix(), member.ID)
 
-		// Set headers for PDF viewing
-		w.Header().Set("Content-Type", "application/pdf")
-		w.Header().Set("Content-Disposition", "inline; filename=\""+*doc.OriginalFilename+"\"")
-		w.Header().Set("Content-Length", strconv.FormatInt(fileInfo.Size(), 10))
+		// Create topic handler with database connection
+		topicHandlers := handlers.NewTopicHandlers(db)
 
-		// Copy file to response
-		_, err = io.Copy(w, file)
-		if err != nil {
-			log.Printf("Error serving file: %v", err)
+		// Create fake authenticated user for commission member
+		fakeUser := &auth.AuthenticatedUser{
+			Email:      "commission_" + accessCode,
+			Role:       auth.RoleCommissionMember,
+			Name:       "Commission Member",
+			Department: member.Department,
 		}
+
+		// Add to context
+		ctx := context.WithValue(r.Context(), auth.UserContextKey, fakeUser)
+
+		// Call the topic registration modal handler
+		topicHandlers.ShowTopicRegistrationModal(w, r.WithContext(ctx))
 	}
 }
 
-// createPublicDocumentDownloadHandler - handles document download without authentication
-func createPublicDocumentDownloadHandler(db *sqlx.DB) http.HandlerFunc {
+func createCommissionReportDocumentHandler(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		documentIDStr := chi.URLParam(r, "id")
-		documentID, err := strconv.Atoi(documentIDStr)
-		if err != nil {
-			http.Error(w, "Invalid document ID", http.StatusBadRequest)
-			return
-		}
-
-		// Get document info
-		var doc struct {
-			ID               int     `db:"id"`
-			StudentRecordID  int     `db:"student_record_id"`
-			DocumentType     string  `db:"document_type"`
-			FilePath         string  `db:"file_path"`
-			MimeType         *string `db:"mime_type"`
-			OriginalFilename *string `db:"original_filename"`
-		}
+		accessCode := chi.URLParam(r, "accessCode")
 
-		query := `SELECT id, student_record_id, document_type, file_path, mime_type, original_filename 
-                  FROM documents WHERE id = ?`
-		err = db.Get(&doc, query, documentID)
+		// Validate commission access
+		member, err := validateCommissionAccess(db, accessCode)
 		if err != nil {
-			http.Error(w, "Document not found", http.StatusNotFound)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		// Read file
-		filePath := doc.FilePath
-		if !filepath.IsAbs(filePath) {
-			// Assuming uploads are in ./uploads directory
-			filePath = filepath.Join("./uploads", filePath)
-		}
-
-		file, err := os.Open(filePath)
+		studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
 		if err != nil {
-			log.Printf("Error opening file %s: %v", filePath, err)
-			http.Error(w, "File not found", http.StatusNotFound)
+			http.Error(w, "Invalid student ID", http.StatusBadRequest)
 			return
 		}
-		defer file.Close()
-
-		// Get file info
-		fileInfo, err := file.Stat()
-		if err != nil {
-			http.Error(w, "Error reading file", http.StatusInternalServerError)
+		if !handlers.CommissionCoversStudent(db, member, studentID) {
+			http.Error(w, "Access denied", http.StatusForbidden)
 			return
 		}
-
-		// Determine content type
-		contentType := "application/octet-stream"
-		if doc.MimeType != nil {
-			contentType = *doc.MimeType
+		// Documents open only within a defense period of the student
+		access := database.AccessInfo{Code: accessCode, Type: database.AccessTypeCommission}
+		if !handlers.EnforceAccessWindow(db, w, r, access, studentID, "document") {
+			return
 		}
 
-		// Set download headers
-		filename := "document"
-		if doc.OriginalFilename != nil {
-			filename = *doc.OriginalFilename
+		// Create fake authenticated user for commission member
+		fakeUser := &auth.AuthenticatedUser{
+			Email:      "commission_" + accessCode,
+			Role:       auth.RoleCommissionMember,
+			Name:       "Commission Member",
+			Department: member.Department,
 		}
 
-		w.Header().Set("Content-Type", contentType)
-		w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
-		w.Header().Set("Content-Length", strconv.FormatInt(fileInfo.Size(), 10))
-
-		// Copy file to response
-		_, err = io.Copy(w, file)
-		if err != nil {
-			log.Printf("Error serving file: %v", err)
-		}
+		ctx := context.WithValue(r.Context(), auth.UserContextKey, fakeUser)
+		handlers.NewReportDocumentHandler(db).DownloadReportPDF(w, r.WithContext(ctx))
 	}
 }
 
-// Add this function to routes.go
-func createCommissionTopicRegistrationHandler(db *sqlx.DB) http.HandlerFunc {
+// createCommissionTopicFormHandler lets the commission download the topic registration form of its students
+func createCommissionTopicFormHandler(db *sqlx.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		accessCode := chi.URLParam(r, "accessCode")
-		studentIDStr := chi.URLParam(r, "studentId")
-
-		// Validate student ID format
-		_, err := strconv.Atoi(studentIDStr)
-		if err != nil {
-			http.Error(w, "Invalid student ID", http.StatusBadRequest)
-			return
-		}
 
-		// Validate commission access
 		member, err := validateCommissionAccess(db, accessCode)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		// Update access count
-		updateQuery := `UPDATE commission_members SET access_count = access_count + 1, last_accessed_at = ? WHERE id = ?`
-		db.Exec(updateQuery, time.Now().Unix(), member.ID)
-
-		// Create topic handler with database connection
-		topicHandlers := handlers.NewTopicHandlers(db)
-
-		// Create fake authenticated user for commission member
+		// The handler checks that the student is one of the code's students and the defense period
 		fakeUser := &auth.AuthenticatedUser{
 			Email:      "commission_" + accessCode,
 			Role:       auth.RoleCommissionMember,
@@ -1189,10 +1186,61 @@ func createCommissionTopicRegistrationHandler(db *sqlx.DB) http.HandlerFunc {
 			Department: member.Department,
 		}
 
-		// Add to context
 		ctx := context.WithValue(r.Context(), auth.UserContextKey, fakeUser)
+		handlers.NewTopicHandlers(db).DownloadTopicForm(w, r.WithContext(ctx))
+	}
+}
 
-		// Call the topic registration modal handler
-		topicHandlers.ShowTopicRegistrationModal(w, r.WithContext(ctx))
+// authorizeCommissionStudent validates the access code, that the student is one of the code's students and
+// that a defense period of the student is open, answering the request when access is refused
+func authorizeCommissionStudent(db *sqlx.DB, w http.ResponseWriter, r *http.Request, resource string) (int, bool) {
+	accessCode := chi.URLParam(r, "accessCode")
+	member, err := validateCommissionAccess(db, accessCode)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return 0, false
+	}
+
+	studentID, err := strconv.Atoi(chi.URLParam(r, "studentId"))
+	if err != nil {
+		http.Error(w, "Invalid student ID", http.StatusBadRequest)
+		return 0, false
+	}
+	if !handlers.CommissionCoversStudent(db, member, studentID) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return 0, false
+	}
+
+	access := database.AccessInfo{Code: accessCode, Type: database.AccessTypeCommission}
+	if !handlers.EnforceAccessWindow(db, w, r, access, studentID, resource) {
+		return 0, false
+	}
+	return studentID, true
+}
+
+// createCommissionDocumentsHandler lists a student's documents on the commission page
+func createCommissionDocumentsHandler(db *sqlx.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		studentID, ok := authorizeCommissionStudent(db, w, r, "document")
+		if !ok {
+			return
+		}
+		handlers.WriteStudentDocuments(db, w, studentID)
+	}
+}
+
+// createCommissionDocumentFileHandler previews or downloads one of the student's documents
+func createCommissionDocumentFileHandler(db *sqlx.DB, download bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		documentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid document ID", http.StatusBadRequest)
+			return
+		}
+		studentID, ok := authorizeCommissionStudent(db, w, r, "document")
+		if !ok {
+			return
+		}
+		handlers.ServeStudentDocument(db, w, r, studentID, documentID, download)
 	}
 }
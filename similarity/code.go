@@ -0,0 +1,282 @@
+// similarity/code.go - Winnowing fingerprints of source code for detecting code copied between submissions
+package similarity
+
+import (
+	"hash/fnv"
+	"path"
+	"strings"
+)
+
+// Winnowing parameters. Shared runs shorter than KGramSize normalized tokens are ignored as noise;
+// every shared run of at least KGramSize+WindowSize-1 tokens is guaranteed to be found.
+const (
+	KGramSize  = 12
+	WindowSize = 8
+)
+
+// CodeFingerprintVersion changes whenever tokenization or hashing changes; older stored fingerprints are recomputed
+const CodeFingerprintVersion = 1
+
+// MaxSourceFileSize skips generated or data files that happen to use a source extension
+const MaxSourceFileSize = 512 << 10
+
+// CodeFingerprint is one selected k-gram hash and the lines it spans
+type CodeFingerprint struct {
+	Hash      uint64 `json:"h"`
+	StartLine int    `json:"s"`
+	EndLine   int    `json:"e"`
+}
+
+// FileFingerprints are the fingerprints of one source file
+type FileFingerprints struct {
+	Path         string            `json:"path"`
+	Fingerprints []CodeFingerprint `json:"fp"`
+}
+
+// CodeFingerprints are the fingerprints of a whole submission
+type CodeFingerprints struct {
+	Version int                `json:"version"`
+	Files   []FileFingerprints `json:"files"`
+}
+
+// Count returns the number of fingerprints over all files
+func (c *CodeFingerprints) Count() int {
+	count := 0
+	for _, file := range c.Files {
+		count += len(file.Fingerprints)
+	}
+	return count
+}
+
+// SourceFile is a file handed to FingerprintCode
+type SourceFile struct {
+	Path    string
+	Content []byte
+}
+
+type commentStyle struct {
+	line       []string
+	blockStart string
+	blockEnd   string
+}
+
+var (
+	cComments    = commentStyle{line: []string{"//"}, blockStart: "/*", blockEnd: "*/"}
+	hashComments = commentStyle{line: []string{"#"}}
+	sqlComments  = commentStyle{line: []string{"--"}, blockStart: "/*", blockEnd: "*/"}
+)
+
+// sourceLanguages are the fingerprinted extensions. Markup, styles and data formats are left out:
+// they are dominated by framework boilerplate.
+var sourceLanguages = map[string]commentStyle{
+	".go": cComments, ".java": cComments, ".kt": cComments, ".scala": cComments,
+	".js": cComments, ".jsx": cComments, ".mjs": cComments, ".ts": cComments, ".tsx": cComments,
+	".vue": cComments, ".svelte": cComments,
+	".c": cComments, ".h": cComments, ".cpp": cComments, ".cc": cComments, ".hpp": cComments,
+	".cs": cComments, ".swift": cComments, ".dart": cComments, ".rs": cComments, ".m": cComments,
+	".php": {line: []string{"//", "#"}, blockStart: "/*", blockEnd: "*/"},
+	".py":  hashComments, ".rb": hashComments, ".r": hashComments, ".sh": hashComments,
+	".pl": hashComments, ".ex": hashComments, ".exs": hashComments,
+	".sql": sqlComments, ".lua": {line: []string{"--"}}, ".hs": {line: []string{"--"}},
+}
+
+// generatedSuffixes are bundles and generated sources that share code without being written by the student
+var generatedSuffixes = []string{".min.js", ".bundle.js", ".pb.go", "_pb2.py", ".g.dart", ".designer.cs", ".d.ts"}
+
+// keywords keep their identity after normalization; every other identifier becomes the same token,
+// so renaming variables does not hide copied code
+var keywords = toSet(
+	"if", "else", "elif", "for", "foreach", "while", "do", "switch", "case", "default", "break", "continue",
+	"return", "yield", "goto", "try", "catch", "except", "finally", "throw", "throws", "raise", "with",
+	"func", "function", "def", "fn", "lambda", "class", "struct", "interface", "enum", "trait", "impl",
+	"type", "var", "let", "const", "val", "static", "public", "private", "protected", "internal",
+	"abstract", "final", "override", "virtual", "async", "await", "new", "delete", "this", "self",
+	"super", "import", "from", "package", "using", "namespace", "include", "extends", "implements",
+	"in", "is", "not", "and", "or", "null", "nil", "none", "true", "false", "void", "select", "insert",
+	"update", "where", "join", "go", "defer", "chan", "map", "range", "end", "then",
+)
+
+func toSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// IsSourceFile reports whether a path is fingerprinted: a known source language that is not a generated bundle
+func IsSourceFile(filePath string) bool {
+	name := strings.ToLower(path.Base(filePath))
+	if _, ok := sourceLanguages[path.Ext(name)]; !ok {
+		return false
+	}
+	for _, suffix := range generatedSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// FingerprintCode tokenizes and winnows every source file; files that are not source code are skipped
+func FingerprintCode(files []SourceFile) *CodeFingerprints {
+	prints := &CodeFingerprints{Version: CodeFingerprintVersion}
+	for _, file := range files {
+		if !IsSourceFile(file.Path) || len(file.Content) > MaxSourceFileSize {
+			continue
+		}
+		style := sourceLanguages[strings.ToLower(path.Ext(file.Path))]
+		fingerprints := winnow(tokenize(string(file.Content), style))
+		if len(fingerprints) > 0 {
+			prints.Files = append(prints.Files, FileFingerprints{Path: file.Path, Fingerprints: fingerprints})
+		}
+	}
+	return prints
+}
+
+type token struct {
+	text string
+	line int
+}
+
+// tokenize drops comments and whitespace and normalizes identifiers, strings and numbers
+func tokenize(src string, style commentStyle) []token {
+	var tokens []token
+	line := 1
+	i := 0
+
+	// skipTo advances past the end marker, counting lines; it reports whether the marker was found
+	skipTo := func(end string, multiline bool) bool {
+		for i < len(src) {
+			if strings.HasPrefix(src[i:], end) {
+				i += len(end)
+				return true
+			}
+			if src[i] == '\\' && i+1 < len(src) && src[i+1] != '\n' {
+				i += 2
+				continue
+			}
+			if src[i] == '\n' {
+				if !multiline {
+					return false
+				}
+				line++
+			}
+			i++
+		}
+		return false
+	}
+
+scan:
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		}
+
+		for _, marker := range style.line {
+			if strings.HasPrefix(src[i:], marker) {
+				for i < len(src) && src[i] != '\n' {
+					i++
+				}
+				continue scan
+			}
+		}
+		if style.blockStart != "" && strings.HasPrefix(src[i:], style.blockStart) {
+			i += len(style.blockStart)
+			skipTo(style.blockEnd, true)
+			continue
+		}
+
+		start, startLine := i, line
+		switch {
+		case isIdentStart(c):
+			for i < len(src) && isIdentPart(src[i]) {
+				i++
+			}
+			word := strings.ToLower(src[start:i])
+			if !keywords[word] {
+				word = "v"
+			}
+			tokens = append(tokens, token{text: word, line: startLine})
+		case c >= '0' && c <= '9':
+			for i < len(src) && (isIdentPart(src[i]) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{text: "0", line: startLine})
+		case strings.HasPrefix(src[i:], `"""`) || strings.HasPrefix(src[i:], "'''"):
+			quote := src[i : i+3]
+			i += 3
+			skipTo(quote, true)
+			tokens = append(tokens, token{text: `"`, line: startLine})
+		case c == '"' || c == '\'' || c == '`':
+			i++
+			if !skipTo(string(c), c == '`') {
+				// An unterminated quote (such as a Rust lifetime) is plain punctuation
+				i, line = start+1, startLine
+				tokens = append(tokens, token{text: string(c), line: startLine})
+				continue
+			}
+			tokens = append(tokens, token{text: `"`, line: startLine})
+		default:
+			i++
+			tokens = append(tokens, token{text: string(c), line: startLine})
+		}
+	}
+	return tokens
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// winnow hashes every k-gram of tokens and keeps the rightmost minimum of every window
+func winnow(tokens []token) []CodeFingerprint {
+	if len(tokens) < KGramSize {
+		return nil
+	}
+
+	hashes := make([]uint64, len(tokens)-KGramSize+1)
+	for i := range hashes {
+		h := fnv.New64a()
+		for _, t := range tokens[i : i+KGramSize] {
+			h.Write([]byte(t.text))
+			h.Write([]byte{0})
+		}
+		hashes[i] = h.Sum64()
+	}
+
+	window := WindowSize
+	if window > len(hashes) {
+		window = len(hashes)
+	}
+	var fingerprints []CodeFingerprint
+	last := -1
+	for start := 0; start+window <= len(hashes); start++ {
+		min := start + window - 1
+		for j := min - 1; j >= start; j-- {
+			if hashes[j] < hashes[min] {
+				min = j
+			}
+		}
+		if min != last {
+			fingerprints = append(fingerprints, CodeFingerprint{
+				Hash:      hashes[min],
+				StartLine: tokens[min].line,
+				EndLine:   tokens[min+KGramSize-1].line,
+			})
+			last = min
+		}
+	}
+	return fingerprints
+}
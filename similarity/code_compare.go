@@ -0,0 +1,195 @@
+// similarity/code_compare.go - Pairwise comparison of code fingerprints and matched fragments
+package similarity
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// maxOccurrences bounds how often one hash is paired per side, so repeated code does not explode the matches
+	maxOccurrences = 4
+	// mergeGap is the number of lines two matched fingerprints may be apart and still form one fragment
+	mergeGap = 3
+	// minFragmentFingerprints drops fragments made of a single coincidental k-gram
+	minFragmentFingerprints = 2
+	// maxMatches is the number of fragments kept per pair
+	maxMatches = 100
+)
+
+// CodeMatch is a fragment of file A that matches a fragment of file B
+type CodeMatch struct {
+	PathA        string `json:"path_a"`
+	StartA       int    `json:"start_a"`
+	EndA         int    `json:"end_a"`
+	PathB        string `json:"path_b"`
+	StartB       int    `json:"start_b"`
+	EndB         int    `json:"end_b"`
+	Fingerprints int    `json:"fingerprints"`
+}
+
+// CodeComparison is the similarity of two submissions
+type CodeComparison struct {
+	Shared   int         // distinct fingerprints found in both submissions
+	PercentA float64     // share of A's fingerprints found in B
+	PercentB float64     // share of B's fingerprints found in A
+	Matches  []CodeMatch // largest fragments first
+}
+
+// Score ranks pairs: the larger share, so copying a small project into a larger one still stands out
+func (c *CodeComparison) Score() float64 {
+	return math.Max(c.PercentA, c.PercentB)
+}
+
+// CommonFingerprints returns the hashes found in more than maxShare of the submissions, and in at least
+// minSubmissions of them: framework scaffolding, course templates and other shared boilerplate
+func CommonFingerprints(submissions []*CodeFingerprints, maxShare float64, minSubmissions int) map[uint64]bool {
+	counts := make(map[uint64]int)
+	for _, submission := range submissions {
+		seen := make(map[uint64]bool)
+		for _, file := range submission.Files {
+			for _, fp := range file.Fingerprints {
+				if !seen[fp.Hash] {
+					seen[fp.Hash] = true
+					counts[fp.Hash]++
+				}
+			}
+		}
+	}
+
+	limit := int(math.Ceil(maxShare * float64(len(submissions))))
+	if limit < minSubmissions {
+		limit = minSubmissions
+	}
+	common := make(map[uint64]bool)
+	for hash, count := range counts {
+		if count > limit {
+			common[hash] = true
+		}
+	}
+	return common
+}
+
+type occurrence struct {
+	file int
+	fp   CodeFingerprint
+}
+
+func indexFingerprints(prints *CodeFingerprints, common map[uint64]bool) map[uint64][]occurrence {
+	index := make(map[uint64][]occurrence)
+	for i, file := range prints.Files {
+		for _, fp := range file.Fingerprints {
+			if common[fp.Hash] || len(index[fp.Hash]) >= maxOccurrences {
+				continue
+			}
+			index[fp.Hash] = append(index[fp.Hash], occurrence{file: i, fp: fp})
+		}
+	}
+	return index
+}
+
+// CompareCode compares two submissions, ignoring the common fingerprints
+func CompareCode(a, b *CodeFingerprints, common map[uint64]bool) *CodeComparison {
+	indexA := indexFingerprints(a, common)
+	indexB := indexFingerprints(b, common)
+
+	type point struct{ a, b occurrence }
+	byFiles := make(map[[2]int][]point)
+	shared := 0
+	for hash, occurrencesA := range indexA {
+		occurrencesB, ok := indexB[hash]
+		if !ok {
+			continue
+		}
+		shared++
+		for _, oa := range occurrencesA {
+			for _, ob := range occurrencesB {
+				key := [2]int{oa.file, ob.file}
+				byFiles[key] = append(byFiles[key], point{oa, ob})
+			}
+		}
+	}
+
+	comparison := &CodeComparison{Shared: shared}
+	if len(indexA) > 0 {
+		comparison.PercentA = roundPercent(float64(shared) / float64(len(indexA)) * 100)
+	}
+	if len(indexB) > 0 {
+		comparison.PercentB = roundPercent(float64(shared) / float64(len(indexB)) * 100)
+	}
+
+	for key, points := range byFiles {
+		sort.Slice(points, func(i, j int) bool {
+			if points[i].a.fp.StartLine != points[j].a.fp.StartLine {
+				return points[i].a.fp.StartLine < points[j].a.fp.StartLine
+			}
+			return points[i].b.fp.StartLine < points[j].b.fp.StartLine
+		})
+
+		var fragments []CodeMatch
+		for _, p := range points {
+			merged := false
+			for i := len(fragments) - 1; i >= 0; i-- {
+				f := &fragments[i]
+				if p.a.fp.StartLine > f.EndA+mergeGap {
+					break
+				}
+				if p.b.fp.StartLine < f.StartB-mergeGap || p.b.fp.StartLine > f.EndB+mergeGap {
+					continue
+				}
+				f.EndA = maxInt(f.EndA, p.a.fp.EndLine)
+				f.StartB = minInt(f.StartB, p.b.fp.StartLine)
+				f.EndB = maxInt(f.EndB, p.b.fp.EndLine)
+				f.Fingerprints++
+				merged = true
+				break
+			}
+			if !merged {
+				fragments = append(fragments, CodeMatch{
+					PathA: a.Files[key[0]].Path, StartA: p.a.fp.StartLine, EndA: p.a.fp.EndLine,
+					PathB: b.Files[key[1]].Path, StartB: p.b.fp.StartLine, EndB: p.b.fp.EndLine,
+					Fingerprints: 1,
+				})
+			}
+		}
+
+		for _, f := range fragments {
+			if f.Fingerprints >= minFragmentFingerprints {
+				comparison.Matches = append(comparison.Matches, f)
+			}
+		}
+	}
+
+	sort.Slice(comparison.Matches, func(i, j int) bool {
+		mi, mj := comparison.Matches[i], comparison.Matches[j]
+		if mi.Fingerprints != mj.Fingerprints {
+			return mi.Fingerprints > mj.Fingerprints
+		}
+		if mi.PathA != mj.PathA {
+			return mi.PathA < mj.PathA
+		}
+		return mi.StartA < mj.StartA
+	})
+	if len(comparison.Matches) > maxMatches {
+		comparison.Matches = comparison.Matches[:maxMatches]
+	}
+	return comparison
+}
+
+func roundPercent(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
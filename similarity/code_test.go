@@ -0,0 +1,156 @@
+package similarity
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const originalSource = `package store
+
+// Inventory keeps item counts
+type Inventory struct {
+	items map[string]int
+}
+
+func (inv *Inventory) Add(name string, count int) error {
+	if count <= 0 {
+		return fmt.Errorf("invalid count %d", count)
+	}
+	inv.items[name] += count
+	return nil
+}
+
+func (inv *Inventory) Remove(name string, count int) error {
+	current, ok := inv.items[name]
+	if !ok || current < count {
+		return fmt.Errorf("not enough %s", name)
+	}
+	inv.items[name] = current - count
+	return nil
+}
+`
+
+// copiedSource renames identifiers, rewrites comments and strings and shifts the code down
+const copiedSource = `package warehouse
+
+/* Stock of products,
+   written from scratch */
+type Stock struct {
+	products map[string]int
+}
+
+func (s *Stock) Put(product string, amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("bad amount: %d", amount)
+	}
+	s.products[product] += amount
+	return nil
+}
+
+func (s *Stock) Take(product string, amount int) error {
+	have, found := s.products[product]
+	if !found || have < amount {
+		return fmt.Errorf("missing %s", product)
+	}
+	s.products[product] = have - amount
+	return nil
+}
+`
+
+const unrelatedSource = `def fibonacci(n):
+    """Return the n-th Fibonacci number."""
+    a, b = 0, 1
+    for _ in range(n):
+        a, b = b, a + b
+    return a
+
+if __name__ == "__main__":
+    print([fibonacci(i) for i in range(10)])
+`
+
+func TestCompareCodeDetectsRenamedCopy(t *testing.T) {
+	original := FingerprintCode([]SourceFile{{Path: "store/inventory.go", Content: []byte(originalSource)}})
+	copied := FingerprintCode([]SourceFile{{Path: "warehouse/stock.go", Content: []byte(copiedSource)}})
+	unrelated := FingerprintCode([]SourceFile{{Path: "fib.py", Content: []byte(unrelatedSource)}})
+
+	if original.Count() == 0 {
+		t.Fatal("no fingerprints for the original source")
+	}
+
+	comparison := CompareCode(original, copied, nil)
+	if comparison.Score() < 90 {
+		t.Errorf("Score() of renamed copy = %.2f, want >= 90", comparison.Score())
+	}
+	if len(comparison.Matches) == 0 {
+		t.Fatal("no matched fragments for renamed copy")
+	}
+	match := comparison.Matches[0]
+	if match.PathA != "store/inventory.go" || match.PathB != "warehouse/stock.go" {
+		t.Errorf("match paths = %s, %s", match.PathA, match.PathB)
+	}
+	if match.StartA < 1 || match.EndA > 24 || match.StartB < 1 || match.EndB > 25 || match.StartA > match.EndA {
+		t.Errorf("match lines out of range: %+v", match)
+	}
+
+	if comparison := CompareCode(original, unrelated, nil); comparison.Shared != 0 || comparison.Score() != 0 {
+		t.Errorf("unrelated code compared as %+v", comparison)
+	}
+}
+
+func TestCommonFingerprintsRemoveBoilerplate(t *testing.T) {
+	var submissions []*CodeFingerprints
+	for i := 0; i < 5; i++ {
+		// Every submission contains the course template plus its own code
+		own := fmt.Sprintf("package main\n\nfunc solve%d(x int) int {\n\treturn x*%d + %s\n}\n", i, i, strings.Repeat("x-", i+1)+"0")
+		submissions = append(submissions, FingerprintCode([]SourceFile{
+			{Path: "template.go", Content: []byte(originalSource)},
+			{Path: "solution.go", Content: []byte(own)},
+		}))
+	}
+
+	common := CommonFingerprints(submissions, 0.5, 2)
+	if len(common) == 0 {
+		t.Fatal("template fingerprints were not recognized as common")
+	}
+	if comparison := CompareCode(submissions[0], submissions[1], common); comparison.Score() > 50 {
+		t.Errorf("Score() with boilerplate removed = %.2f, want <= 50", comparison.Score())
+	}
+	if comparison := CompareCode(submissions[0], submissions[1], nil); comparison.Score() < 50 {
+		t.Errorf("Score() with boilerplate = %.2f, want > 50", comparison.Score())
+	}
+}
+
+func TestTokenizeNormalizes(t *testing.T) {
+	a := tokenize("x := compute(42, \"hello\") // note\n", cComments)
+	b := tokenize("total := compute(7, \"bye\")\n/* other */", cComments)
+	if len(a) != len(b) {
+		t.Fatalf("token counts differ: %v vs %v", a, b)
+	}
+	for i := range a {
+		if a[i].text != b[i].text {
+			t.Errorf("token %d = %q vs %q", i, a[i].text, b[i].text)
+		}
+	}
+
+	lines := tokenize("a = 1\n'''doc\nstring'''\nreturn a\n", hashComments)
+	if last := lines[len(lines)-1]; last.text != "v" || last.line != 4 {
+		t.Errorf("last token = %+v, want identifier on line 4", last)
+	}
+}
+
+func TestIsSourceFile(t *testing.T) {
+	tests := map[string]bool{
+		"main.go":              true,
+		"src/App.TSX":          true,
+		"static/jquery.min.js": false,
+		"api/service.pb.go":    false,
+		"index.html":           false,
+		"README.md":            false,
+	}
+	for name, want := range tests {
+		if got := IsSourceFile(name); got != want {
+			t.Errorf("IsSourceFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// similarity/pdf.go - Minimal text extraction from PDF content streams
+package similarity
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+)
+
+// maxStreamSize bounds a single inflated content stream
+const maxStreamSize = 16 << 20
+
+var errNoPDFText = errors.New("could not read text from the PDF; upload the HTML or CSV export instead")
+
+// extractPDFText reads literal strings shown by text operators in every content stream.
+// Reports that embed text only as images or CID-encoded glyphs are not supported.
+func extractPDFText(data []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("%PDF")) {
+		return "", errors.New("file is not a PDF document")
+	}
+
+	var text strings.Builder
+	rest := data
+	for {
+		start := bytes.Index(rest, []byte("stream"))
+		if start < 0 {
+			break
+		}
+		body := rest[start+len("stream"):]
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		dictionary := rest[:start]
+		if i := bytes.LastIndex(dictionary, []byte("<<")); i >= 0 {
+			dictionary = dictionary[i:]
+		}
+		content := bytes.TrimLeft(body[:end], "\r\n")
+		rest = body[end+len("endstream"):]
+
+		if bytes.Contains(dictionary, []byte("/FlateDecode")) {
+			inflated, err := inflate(content)
+			if err != nil {
+				continue
+			}
+			content = inflated
+		} else if bytes.Contains(dictionary, []byte("/Filter")) {
+			// Images and other encodings carry no readable text
+			continue
+		}
+		appendContentText(&text, content)
+	}
+
+	if strings.TrimSpace(text.String()) == "" {
+		return "", errNoPDFText
+	}
+	return text.String(), nil
+}
+
+func inflate(content []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	// Truncated streams are common; keep whatever was inflated
+	inflated, err := io.ReadAll(io.LimitReader(reader, maxStreamSize))
+	if len(inflated) == 0 && err != nil {
+		return nil, err
+	}
+	return inflated, nil
+}
+
+// appendContentText walks a content stream and writes shown strings, one text line per output line
+func appendContentText(text *strings.Builder, content []byte) {
+	inText := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '(' && inText:
+			value, next := readLiteralString(content, i)
+			text.WriteString(value)
+			i = next
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case isOperatorStart(content, i, "BT"):
+			inText = true
+		case isOperatorStart(content, i, "ET"):
+			inText = false
+			text.WriteByte('\n')
+		case inText && (isOperatorStart(content, i, "Td") || isOperatorStart(content, i, "TD") ||
+			isOperatorStart(content, i, "T*") || isOperatorStart(content, i, "Tm") || c == '\''):
+			text.WriteByte('\n')
+		case inText && isOperatorStart(content, i, "Tj"):
+			// A space keeps consecutive strings from merging into one word
+			text.WriteByte(' ')
+		}
+	}
+}
+
+// isOperatorStart reports whether a two-character operator stands alone at position i
+func isOperatorStart(content []byte, i int, op string) bool {
+	if i+len(op) > len(content) || string(content[i:i+len(op)]) != op {
+		return false
+	}
+	if i > 0 && !isDelimiter(content[i-1]) {
+		return false
+	}
+	end := i + len(op)
+	return end == len(content) || isDelimiter(content[end])
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case ' ', '\n', '\r', '\t', '\f', 0, '(', ')', '[', ']', '<', '>', '/':
+		return true
+	}
+	return false
+}
+
+// readLiteralString decodes a (...) string starting at position i and returns the index of its closing parenthesis
+func readLiteralString(content []byte, i int) (string, int) {
+	var b bytes.Buffer
+	depth := 0
+	for j := i; j < len(content); j++ {
+		c := content[j]
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				b.WriteByte(c)
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return decodePDFString(b.Bytes()), j
+			}
+			b.WriteByte(c)
+		case '\\':
+			if j+1 >= len(content) {
+				continue
+			}
+			j++
+			switch e := content[j]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r', 't', 'b', 'f':
+				b.WriteByte(' ')
+			case '\r', '\n':
+				// Line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					value := int(e - '0')
+					for k := 0; k < 2 && j+1 < len(content) && content[j+1] >= '0' && content[j+1] <= '7'; k++ {
+						j++
+						value = value*8 + int(content[j]-'0')
+					}
+					b.WriteByte(byte(value))
+				} else {
+					b.WriteByte(e)
+				}
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return decodePDFString(b.Bytes()), len(content)
+}
+
+// decodePDFString converts UTF-16BE strings (with BOM) and treats the rest as Latin-1 compatible text
+func decodePDFString(raw []byte) string {
+	if len(raw) >= 2 && raw[0] == 0xFE && raw[1] == 0xFF {
+		runes := make([]rune, 0, (len(raw)-2)/2)
+		for i := 2; i+1 < len(raw); i += 2 {
+			runes = append(runes, rune(raw[i])<<8|rune(raw[i+1]))
+		}
+		return string(runes)
+	}
+	runes := make([]rune, len(raw))
+	for i, c := range raw {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
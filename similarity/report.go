@@ -0,0 +1,176 @@
+// similarity/report.go - Parsing of similarity (plagiarism) check reports into supervisor report fields
+package similarity
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Supervisor report fields filled from a similarity report
+const (
+	FieldOtherMatch = "other_match"
+	FieldOneMatch   = "one_match"
+	FieldOwnMatch   = "own_match"
+	FieldJoinMatch  = "join_match"
+)
+
+// Fields lists the supported fields in form order
+var Fields = []string{FieldOtherMatch, FieldOneMatch, FieldOwnMatch, FieldJoinMatch}
+
+// Tolerance is the largest difference between a typed and a parsed value that still counts as equal
+const Tolerance = 0.05
+
+// MaxFileSize limits accepted report files
+const MaxFileSize = 20 << 20
+
+// ErrNoValues is returned when the file contains none of the known similarity labels
+var ErrNoValues = errors.New("no similarity percentages found in the report")
+
+// Report holds the percentages found in a similarity report
+type Report struct {
+	Format string
+	Values map[string]float64
+}
+
+// Value returns the parsed percentage of a field and whether it was present
+func (r *Report) Value(field string) (float64, bool) {
+	v, ok := r.Values[field]
+	return v, ok
+}
+
+// Mismatches returns the fields whose typed value differs from the parsed one.
+// Fields missing from the report are not compared.
+func (r *Report) Mismatches(typed map[string]float64) []string {
+	var fields []string
+	for _, field := range Fields {
+		parsed, ok := r.Values[field]
+		if !ok {
+			continue
+		}
+		if math.Abs(typed[field]-parsed) > Tolerance {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Labels used by the common similarity report exports (Lithuanian and English).
+// More specific labels are checked first so "bendra sutaptis su vienu šaltiniu" is not read as the total.
+var fieldLabels = []struct {
+	field  string
+	labels []string
+}{
+	{FieldOneMatch, []string{"su vienu šaltiniu", "vieno šaltinio", "didžiausia sutaptis", "single source", "one source", "largest match", FieldOneMatch}},
+	{FieldOwnMatch, []string{"savi ankstesni", "savo ankstesni", "ankstesniais darbais", "own previous", "own works", "self-plagiarism", "self plagiarism", FieldOwnMatch}},
+	{FieldJoinMatch, []string{"bendraautori", "bendri autoriai", "bendrų autorių", "bendro darbo", "joint work", "co-author", "coauthor", FieldJoinMatch}},
+	{FieldOtherMatch, []string{"bendra sutaptis", "bendras sutapimas", "bendroji sutaptis", "sutaptis su kitais darbais", "overall similarity", "total similarity", "similarity index", "similarity score", FieldOtherMatch}},
+}
+
+var (
+	percentPattern = regexp.MustCompile(`(\d{1,3}(?:[.,]\d+)?)\s*%`)
+	cellPattern    = regexp.MustCompile(`^[\s"]*[;,:\t][\s"]*(\d{1,3}(?:[.,]\d+)?)[\s"]*(?:[;\t]|$)`)
+	tagPattern     = regexp.MustCompile(`(?s)<(script|style)[^>]*>.*?</(script|style)>|<[^>]+>`)
+)
+
+// Parse reads a similarity report export (PDF, HTML, CSV or plain text)
+func Parse(filename string, data []byte) (*Report, error) {
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("report file is larger than %d MB", MaxFileSize>>20)
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	var text string
+	switch format {
+	case "pdf":
+		extracted, err := extractPDFText(data)
+		if err != nil {
+			return nil, err
+		}
+		text = extracted
+	case "html", "htm":
+		text = html.UnescapeString(tagPattern.ReplaceAllString(string(data), "\n"))
+	case "csv", "txt", "tsv":
+		text = string(data)
+	default:
+		return nil, fmt.Errorf("unsupported report format %q (use PDF, HTML, CSV or TXT)", format)
+	}
+
+	values := parseText(text)
+	if len(values) == 0 {
+		return nil, ErrNoValues
+	}
+	return &Report{Format: format, Values: values}, nil
+}
+
+// parseText finds the first percentage after each known label
+func parseText(text string) map[string]float64 {
+	text = strings.NewReplacer("\r", "", "\u00a0", " ").Replace(text)
+	lines := strings.Split(text, "\n")
+	values := make(map[string]float64)
+
+	for i, line := range lines {
+		lower := strings.ToLower(line)
+		for _, entry := range fieldLabels {
+			if _, found := values[entry.field]; found {
+				continue
+			}
+			pos := labelIndex(lower, entry.labels)
+			if pos < 0 {
+				continue
+			}
+
+			if v, ok := valueAfterLabel(lower[pos:]); ok {
+				values[entry.field] = v
+			} else if v, ok := valueOnFollowingLines(lines, i); ok {
+				values[entry.field] = v
+			}
+			// One line describes one field
+			break
+		}
+	}
+	return values
+}
+
+func labelIndex(line string, labels []string) int {
+	for _, label := range labels {
+		if pos := strings.Index(line, label); pos >= 0 {
+			return pos + len(label)
+		}
+	}
+	return -1
+}
+
+// valueAfterLabel accepts a percentage or, in tabular exports, a bare number in the next cell
+func valueAfterLabel(rest string) (float64, bool) {
+	if m := percentPattern.FindStringSubmatch(rest); m != nil {
+		return parsePercent(m[1])
+	}
+	if m := cellPattern.FindStringSubmatch(rest); m != nil {
+		return parsePercent(m[1])
+	}
+	return 0, false
+}
+
+// valueOnFollowingLines handles layouts where the label and the value are on separate lines
+func valueOnFollowingLines(lines []string, i int) (float64, bool) {
+	for j := i + 1; j < len(lines) && j <= i+2; j++ {
+		if m := percentPattern.FindStringSubmatch(lines[j]); m != nil {
+			return parsePercent(m[1])
+		}
+	}
+	return 0, false
+}
+
+func parsePercent(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", "."), 64)
+	if err != nil || v < 0 || v > 100 {
+		return 0, false
+	}
+	return math.Round(v*10) / 10, true
+}
@@ -0,0 +1,103 @@
+package similarity
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+)
+
+func assertValues(t *testing.T, report *Report, want map[string]float64) {
+	t.Helper()
+	for field, value := range want {
+		got, ok := report.Value(field)
+		if !ok {
+			t.Errorf("%s not found", field)
+			continue
+		}
+		if got != value {
+			t.Errorf("%s = %v, want %v", field, got, value)
+		}
+	}
+}
+
+func TestParseTextExport(t *testing.T) {
+	text := "Sutapties patikros ataskaita\n" +
+		"Bendra sutaptis: 12,5 %\n" +
+		"Didžiausia sutaptis su vienu šaltiniu: 3 %\n" +
+		"Sutaptis su savo ankstesniais darbais: 0,0 %\n" +
+		"Sutaptis su bendraautorių darbais: 1.2%\n"
+
+	report, err := Parse("ataskaita.txt", []byte(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, report, map[string]float64{
+		FieldOtherMatch: 12.5,
+		FieldOneMatch:   3,
+		FieldOwnMatch:   0,
+		FieldJoinMatch:  1.2,
+	})
+}
+
+func TestParseCSVWithoutPercentSigns(t *testing.T) {
+	csv := "Metric;Value\nOverall similarity;17,4\nLargest match with a single source;6\n"
+
+	report, err := Parse("export.csv", []byte(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, report, map[string]float64{FieldOtherMatch: 17.4, FieldOneMatch: 6})
+	if _, ok := report.Value(FieldJoinMatch); ok {
+		t.Error("join_match should be missing")
+	}
+}
+
+func TestParseHTMLValueOnNextLine(t *testing.T) {
+	page := `<html><style>td{color:red}</style><table>
+<tr><td>Similarity index</td><td>21&nbsp;%</td></tr>
+<tr><td>Own previous works</td><td>4.5 %</td></tr>
+</table></html>`
+
+	report, err := Parse("report.html", []byte(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, report, map[string]float64{FieldOtherMatch: 21, FieldOwnMatch: 4.5})
+}
+
+func TestParsePDFContentStream(t *testing.T) {
+	content := "BT /F1 12 Tf 72 700 Td (Overall similarity: 9.8 %) Tj ET\n" +
+		"BT 72 680 Td [(Single source: ) -20 (2.1 %)] TJ ET\n"
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	writer.Write([]byte(content))
+	writer.Close()
+
+	pdf := fmt.Sprintf("%%PDF-1.4\n4 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream\nendobj\n%%%%EOF",
+		compressed.Len(), compressed.String())
+
+	report, err := Parse("report.pdf", []byte(pdf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, report, map[string]float64{FieldOtherMatch: 9.8, FieldOneMatch: 2.1})
+}
+
+func TestParseRejectsUnknownContent(t *testing.T) {
+	if _, err := Parse("notes.txt", []byte("nothing to see here")); err != ErrNoValues {
+		t.Errorf("err = %v, want ErrNoValues", err)
+	}
+	if _, err := Parse("report.docx", []byte("x")); err == nil {
+		t.Error("expected unsupported format error")
+	}
+}
+
+func TestMismatches(t *testing.T) {
+	report := &Report{Values: map[string]float64{FieldOtherMatch: 12.5, FieldOneMatch: 3}}
+
+	got := report.Mismatches(map[string]float64{FieldOtherMatch: 12.5, FieldOneMatch: 4, FieldOwnMatch: 7})
+	if len(got) != 1 || got[0] != FieldOneMatch {
+		t.Errorf("Mismatches = %v, want [one_match]", got)
+	}
+}
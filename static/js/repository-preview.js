@@ -198,21 +198,16 @@ function setupUploadForm() {
             const result = await response.json();
 
             if (result.success) {
-                // Simulate progress for better UX
-                let progress = 0;
-                const interval = setInterval(() => {
-                    progress += 10;
-                    progressBar.style.width = progress + '%';
-
-                    if (progress >= 100) {
-                        clearInterval(interval);
-                        statusText.textContent = 'Upload completed successfully!';
-                        statusText.className = 'text-sm text-green-600';
-                        setTimeout(() => {
-                            window.location.reload();
-                        }, 1500);
-                    }
-                }, 200);
+                await waitForSourceUpload(result.submission_id, (status) => {
+                    progressBar.style.width = status.progress + '%';
+                    statusText.textContent = describeUploadStatus(status);
+                });
+                progressBar.style.width = '100%';
+                statusText.textContent = 'Upload completed successfully!';
+                statusText.className = 'text-sm text-green-600';
+                setTimeout(() => {
+                    window.location.reload();
+                }, 1500);
             } else {
                 throw new Error(result.error || 'Upload failed');
             }
@@ -225,4 +220,43 @@ function setupUploadForm() {
             progressContainer.classList.add('hidden');
         }
     });
-}
\ No newline at end of file
+}
+// Polls the upload job until it is done; rejects with the job error when it failed
+async function waitForSourceUpload(submissionId, onProgress) {
+    while (true) {
+        const response = await fetch(`/api/source-code/status?id=${encodeURIComponent(submissionId)}`);
+        const status = await response.json();
+        if (!response.ok) {
+            throw new Error(status.error || 'Could not check upload status');
+        }
+        if (onProgress) {
+            onProgress(status);
+        }
+        if (status.status === 'done') {
+            return status;
+        }
+        if (status.status === 'failed') {
+            throw new Error((status.result && status.result.error) || status.last_error || 'Upload failed');
+        }
+        await new Promise(resolve => setTimeout(resolve, 3000));
+    }
+}
+
+function describeUploadStatus(status) {
+    if (status.status === 'queued') {
+        if (status.stage === 'retry_scheduled') {
+            return `Retrying soon (attempt ${status.attempts + 1} of ${status.max_attempts})...`;
+        }
+        return `Queued, position ${status.queue_position}...`;
+    }
+    const stages = {
+        starting: 'Starting...',
+        importing: 'Cloning repository...',
+        extracting: 'Extracting archive...',
+        validating: 'Validating files...',
+        repository: 'Preparing repository...',
+        pushing: 'Pushing to repository...',
+        saving: 'Saving submission...'
+    };
+    return stages[status.stage] || 'Processing...';
+}
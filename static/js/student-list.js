@@ -917,6 +917,8 @@ function getDocumentType(type) {
         'FINAL_THESIS.PDF': { icon: '📄', label: 'Darbas' },
         'thesis': { icon: '📄', label: 'Thesis' },
         'presentation': { icon: '📊', label: 'Presentation' },
+        'supervisor_report_pdf': { icon: '📝', label: 'Vadovo atsiliepimas' },
+        'reviewer_report_pdf': { icon: '🧾', label: 'Recenzija' },
         'default': { icon: '📎', label: 'Document' }
     };
     return documentTypes[type] || documentTypes['default'];
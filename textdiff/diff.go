@@ -0,0 +1,245 @@
+// textdiff/diff.go - Word and sentence level text diffs for version comparison
+package textdiff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Op describes what happened to a segment between two texts
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+// Segment is a run of text with the same diff operation
+type Segment struct {
+	Op   Op
+	Text string
+}
+
+// Granularity controls how texts are split before diffing
+type Granularity int
+
+const (
+	Words Granularity = iota
+	Sentences
+	Lines
+)
+
+// maxCells bounds the LCS table; longer texts fall back to sentence granularity
+const maxCells = 4_000_000
+
+// Diff compares two texts word by word, falling back to sentences for very long texts
+func Diff(oldText, newText string) []Segment {
+	oldTokens := tokenize(oldText, Words)
+	newTokens := tokenize(newText, Words)
+	if len(oldTokens)*len(newTokens) > maxCells {
+		return DiffWith(oldText, newText, Sentences)
+	}
+	return diffTokens(oldTokens, newTokens)
+}
+
+// DiffWith compares two texts using the given granularity
+func DiffWith(oldText, newText string, granularity Granularity) []Segment {
+	return diffTokens(tokenize(oldText, granularity), tokenize(newText, granularity))
+}
+
+// HasChanges reports whether any segment was inserted or deleted
+func HasChanges(segments []Segment) bool {
+	for _, s := range segments {
+		if s.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// OldSide returns the segments making up the old text (equal and deleted)
+func OldSide(segments []Segment) []Segment {
+	return side(segments, Delete)
+}
+
+// NewSide returns the segments making up the new text (equal and inserted)
+func NewSide(segments []Segment) []Segment {
+	return side(segments, Insert)
+}
+
+// Stats counts inserted and deleted words in a diff
+func Stats(segments []Segment) (inserted, deleted int) {
+	for _, s := range segments {
+		n := len(strings.Fields(s.Text))
+		switch s.Op {
+		case Insert:
+			inserted += n
+		case Delete:
+			deleted += n
+		}
+	}
+	return inserted, deleted
+}
+
+func side(segments []Segment, keep Op) []Segment {
+	var out []Segment
+	for _, s := range segments {
+		if s.Op == Equal || s.Op == keep {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// tokenize splits text keeping whitespace and punctuation as separate tokens,
+// so joining all tokens reproduces the original text exactly
+func tokenize(text string, granularity Granularity) []string {
+	if text == "" {
+		return nil
+	}
+	switch granularity {
+	case Sentences:
+		return splitSentences(text)
+	case Lines:
+		return strings.SplitAfter(strings.TrimSuffix(text, "\n"), "\n")
+	}
+
+	var tokens []string
+	var current strings.Builder
+	currentKind := -1
+	for _, r := range text {
+		kind := runeKind(r)
+		// Punctuation is always its own token
+		if kind != currentKind || kind == kindPunct {
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+			currentKind = kind
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+const (
+	kindWord = iota
+	kindSpace
+	kindPunct
+)
+
+func runeKind(r rune) int {
+	switch {
+	case unicode.IsSpace(r):
+		return kindSpace
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-':
+		return kindWord
+	default:
+		return kindPunct
+	}
+}
+
+// splitSentences splits after sentence terminators and line breaks
+func splitSentences(text string) []string {
+	var tokens []string
+	start := 0
+	runes := []rune(text)
+	for i, r := range runes {
+		end := false
+		switch r {
+		case '\n':
+			end = true
+		case '.', '!', '?', ';':
+			end = i+1 == len(runes) || unicode.IsSpace(runes[i+1])
+		}
+		if end {
+			tokens = append(tokens, string(runes[start:i+1]))
+			start = i + 1
+		}
+	}
+	if start < len(runes) {
+		tokens = append(tokens, string(runes[start:]))
+	}
+	return tokens
+}
+
+// diffTokens computes an LCS based diff and merges adjacent segments of the same op
+func diffTokens(a, b []string) []Segment {
+	// Trim common prefix and suffix to keep the table small
+	prefix, suffix := commonAffixes(a, b)
+
+	var segments []Segment
+	add := func(op Op, text string) {
+		if text == "" {
+			return
+		}
+		if n := len(segments); n > 0 && segments[n-1].Op == op {
+			segments[n-1].Text += text
+			return
+		}
+		segments = append(segments, Segment{Op: op, Text: text})
+	}
+
+	add(Equal, strings.Join(a[:prefix], ""))
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	n, m := len(midA), len(midB)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case midA[i] == midB[j]:
+			add(Equal, midA[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(Delete, midA[i])
+			i++
+		default:
+			add(Insert, midB[j])
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		add(Delete, midA[i])
+	}
+	for ; j < m; j++ {
+		add(Insert, midB[j])
+	}
+
+	add(Equal, strings.Join(a[len(a)-suffix:], ""))
+	return segments
+}
+
+// commonAffixes returns the number of equal leading and trailing tokens
+func commonAffixes(a, b []string) (prefix, suffix int) {
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	return prefix, suffix
+}
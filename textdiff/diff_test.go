@@ -0,0 +1,74 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func join(segments []Segment) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+func TestDiffReconstructsBothSides(t *testing.T) {
+	cases := []struct{ old, new string }{
+		{"", ""},
+		{"", "naujas tekstas"},
+		{"senas tekstas", ""},
+		{"Sukurti sistemą studentams.", "Sukurti mobilią sistemą dėstytojams."},
+		{"1. Analizė\n2. Projektavimas\n3. Testavimas", "1. Analizė\n2. Realizacija\n3. Testavimas\n4. Diegimas"},
+	}
+
+	for _, c := range cases {
+		segments := Diff(c.old, c.new)
+		if got := join(OldSide(segments)); got != c.old {
+			t.Errorf("old side mismatch: got %q, want %q", got, c.old)
+		}
+		if got := join(NewSide(segments)); got != c.new {
+			t.Errorf("new side mismatch: got %q, want %q", got, c.new)
+		}
+		if HasChanges(segments) != (c.old != c.new) {
+			t.Errorf("HasChanges(%q, %q) = %v", c.old, c.new, HasChanges(segments))
+		}
+	}
+}
+
+func TestDiffWordLevel(t *testing.T) {
+	segments := Diff("Create a web system", "Create a mobile system")
+
+	want := []Segment{
+		{Equal, "Create a "},
+		{Delete, "web"},
+		{Insert, "mobile"},
+		{Equal, " system"},
+	}
+	if len(segments) != len(want) {
+		t.Fatalf("got %d segments %+v, want %+v", len(segments), segments, want)
+	}
+	for i := range want {
+		if segments[i] != want[i] {
+			t.Errorf("segment %d: got %+v, want %+v", i, segments[i], want[i])
+		}
+	}
+
+	inserted, deleted := Stats(segments)
+	if inserted != 1 || deleted != 1 {
+		t.Errorf("Stats = (%d, %d), want (1, 1)", inserted, deleted)
+	}
+}
+
+func TestDiffSentenceLevel(t *testing.T) {
+	segments := DiffWith("First task. Second task. Third task.", "First task. Changed task. Third task.", Sentences)
+
+	for _, s := range segments {
+		if s.Op == Delete && s.Text != " Second task." {
+			t.Errorf("unexpected deleted segment %q", s.Text)
+		}
+		if s.Op == Insert && s.Text != " Changed task." {
+			t.Errorf("unexpected inserted segment %q", s.Text)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// textdiff/field.go - Field level diff model used by comparison views
+package textdiff
+
+// FieldDiff holds the diff of a single named field between two versions
+type FieldDiff struct {
+	Key      string
+	OldValue string
+	NewValue string
+	Segments []Segment
+}
+
+// NewFieldDiff diffs a field's old and new value
+func NewFieldDiff(key, oldValue, newValue string) FieldDiff {
+	return FieldDiff{
+		Key:      key,
+		OldValue: oldValue,
+		NewValue: newValue,
+		Segments: Diff(oldValue, newValue),
+	}
+}
+
+// Changed reports whether the field differs between the versions
+func (f FieldDiff) Changed() bool {
+	return f.OldValue != f.NewValue
+}
+
+// OldSegments returns the old side for side-by-side rendering
+func (f FieldDiff) OldSegments() []Segment {
+	return OldSide(f.Segments)
+}
+
+// NewSegments returns the new side for side-by-side rendering
+func (f FieldDiff) NewSegments() []Segment {
+	return NewSide(f.Segments)
+}
+
+// Stats returns inserted and deleted word counts for the field
+func (f FieldDiff) Stats() (inserted, deleted int) {
+	return Stats(f.Segments)
+}
@@ -0,0 +1,57 @@
+// textdiff/lines.go - Line level diffs for comparing source files
+package textdiff
+
+import "strings"
+
+// Line is one line of a line diff. OldNumber is 0 for inserted lines and NewNumber is 0 for deleted lines.
+type Line struct {
+	Op        Op
+	OldNumber int
+	NewNumber int
+	Text      string
+}
+
+// DiffLines compares two texts line by line. It returns false when the changed region is too
+// large to diff, so callers can fall back to showing the files separately.
+func DiffLines(oldText, newText string) ([]Line, bool) {
+	a := tokenize(oldText, Lines)
+	b := tokenize(newText, Lines)
+	prefix, suffix := commonAffixes(a, b)
+	if (len(a)-prefix-suffix)*(len(b)-prefix-suffix) > maxCells {
+		return nil, false
+	}
+
+	var lines []Line
+	oldNumber, newNumber := 1, 1
+	for _, segment := range diffTokens(a, b) {
+		for _, text := range strings.SplitAfter(segment.Text, "\n") {
+			if text == "" {
+				continue
+			}
+			line := Line{Op: segment.Op, Text: strings.TrimSuffix(text, "\n")}
+			if segment.Op != Insert {
+				line.OldNumber = oldNumber
+				oldNumber++
+			}
+			if segment.Op != Delete {
+				line.NewNumber = newNumber
+				newNumber++
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines, true
+}
+
+// LineStats counts inserted and deleted lines
+func LineStats(lines []Line) (inserted, deleted int) {
+	for _, line := range lines {
+		switch line.Op {
+		case Insert:
+			inserted++
+		case Delete:
+			deleted++
+		}
+	}
+	return inserted, deleted
+}
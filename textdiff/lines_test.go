@@ -0,0 +1,45 @@
+package textdiff
+
+import "testing"
+
+func TestDiffLines(t *testing.T) {
+	oldText := "package main\n\nfunc main() {\n\tprintln(\"a\")\n}\n"
+	newText := "package main\n\nfunc main() {\n\tprintln(\"b\")\n\tprintln(\"c\")\n}\n"
+
+	lines, ok := DiffLines(oldText, newText)
+	if !ok {
+		t.Fatal("DiffLines() reported the diff as too large")
+	}
+	want := []Line{
+		{Op: Equal, OldNumber: 1, NewNumber: 1, Text: "package main"},
+		{Op: Equal, OldNumber: 2, NewNumber: 2, Text: ""},
+		{Op: Equal, OldNumber: 3, NewNumber: 3, Text: "func main() {"},
+		{Op: Delete, OldNumber: 4, Text: "\tprintln(\"a\")"},
+		{Op: Insert, NewNumber: 4, Text: "\tprintln(\"b\")"},
+		{Op: Insert, NewNumber: 5, Text: "\tprintln(\"c\")"},
+		{Op: Equal, OldNumber: 5, NewNumber: 6, Text: "}"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("DiffLines() = %+v", lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+
+	if inserted, deleted := LineStats(lines); inserted != 2 || deleted != 1 {
+		t.Errorf("LineStats() = %d, %d, want 2, 1", inserted, deleted)
+	}
+}
+
+func TestDiffLinesAddedAndRemovedFiles(t *testing.T) {
+	lines, _ := DiffLines("", "a\nb")
+	if len(lines) != 2 || lines[0].Op != Insert || lines[1].NewNumber != 2 {
+		t.Errorf("DiffLines() of a new file = %+v", lines)
+	}
+	lines, _ = DiffLines("a\nb\n", "")
+	if len(lines) != 2 || lines[0].Op != Delete || lines[1].OldNumber != 2 {
+		t.Errorf("DiffLines() of a removed file = %+v", lines)
+	}
+}
@@ -1,6 +1,12 @@
 package types
 
-import "time"
+import (
+	"time"
+
+	"FinalProjectManagementApp/database"
+	"FinalProjectManagementApp/similarity"
+	"FinalProjectManagementApp/textdiff"
+)
 
 type RepositoryContents struct {
 	Files   []RepositoryFile `json:"files"`
@@ -47,3 +53,59 @@ type FileContent struct {
 	DownloadURL string `json:"download_url"`
 	Error       string `json:"error,omitempty"`
 }
+
+// SubmissionSnapshot is one uploaded version of a student's source code, kept on its own branch
+type SubmissionSnapshot struct {
+	Branch           string               `json:"branch"`
+	SubmittedAt      time.Time            `json:"submitted_at"`
+	CommitSHA        string               `json:"commit_sha"`
+	FilesCount       int                  `json:"files_count"` // 0 when unknown (uploads made before history tracking)
+	Filter           *database.FilterInfo `json:"filter_info,omitempty"`
+	OriginalFilename string               `json:"original_filename,omitempty"`
+	UploadedBy       string               `json:"uploaded_by,omitempty"`
+	IsLatest         bool                 `json:"is_latest"`
+}
+
+// SubmissionComparison lists the files that differ between two submissions
+type SubmissionComparison struct {
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+	Changes  []SubmissionFileChange `json:"changes"`
+	Added    int                    `json:"added"`
+	Removed  int                    `json:"removed"`
+	Modified int                    `json:"modified"`
+}
+
+type SubmissionFileChange struct {
+	Path    string `json:"path"`
+	Status  string `json:"status"` // added, removed or modified
+	OldSize int64  `json:"old_size"`
+	NewSize int64  `json:"new_size"`
+}
+
+// SubmissionFileDiff is the line diff of one file between two submissions
+type SubmissionFileDiff struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Path     string          `json:"path"`
+	Lines    []textdiff.Line `json:"lines"`
+	Inserted int             `json:"inserted"`
+	Deleted  int             `json:"deleted"`
+	Binary   bool            `json:"binary"`
+	TooLarge bool            `json:"too_large"`
+}
+
+// CodeFragment is a matched fragment of a suspicious pair with the source lines of both submissions
+type CodeFragment struct {
+	Match  similarity.CodeMatch
+	LinesA []CodeLine
+	LinesB []CodeLine
+	Error  string
+}
+
+// CodeLine is one line of a fragment; context lines around the match have Matched unset
+type CodeLine struct {
+	Number  int
+	Text    string
+	Matched bool
+}